@@ -76,7 +76,7 @@ func init() {
 
 	i18nCmd.Flags().StringVarP(&i18nTargetPath, "target", "t", "", "i18n target path, eg: -t ./i18n/target")
 
-	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd} {
+	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd, searchIndexCmd} {
 		rootCmd.AddCommand(cmd)
 	}
 }
@@ -285,6 +285,27 @@ To run answer, use:
 		},
 	}
 
+	// searchIndexCmd used to rebuild the native full-text search index
+	searchIndexCmd = &cobra.Command{
+		Use:   "search-index",
+		Short: "rebuild the full-text search index",
+		Long:  `Rebuild the built-in full-text search index with all questions and answers`,
+		Run: func(_ *cobra.Command, _ []string) {
+			cli.FormatAllPath(dataDirPath)
+
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			if err = migrations.RebuildSearchIndexWithConfig(c.Data.Database); err != nil {
+				fmt.Println("rebuild search index failed: ", err.Error())
+				return
+			}
+			fmt.Println("rebuild search index successfully")
+		},
+	}
+
 	// i18nCmd used to merge i18n files
 	i18nCmd = &cobra.Command{
 		Use:   "i18n",
@@ -88,11 +88,15 @@ func runApp() {
 	}
 }
 
-func newApplication(serverConf *conf.Server, server *gin.Engine, manager *cron.ScheduledTaskManager) *pacman.Application {
+func newApplication(serverConf *conf.Server, server *gin.Engine, manager *cron.ScheduledTaskManager) (
+	*pacman.Application, error) {
+	if err := server.SetTrustedProxies(serverConf.HTTP.TrustedProxies); err != nil {
+		return nil, err
+	}
 	manager.Run()
 	return pacman.NewApp(
 		pacman.WithName(Name),
 		pacman.WithVersion(Version),
 		pacman.WithServer(http.NewServer(server, serverConf.HTTP.Addr)),
-	)
+	), nil
 }
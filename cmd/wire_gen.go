@@ -39,17 +39,23 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/activity"
 	"github.com/apache/incubator-answer/internal/repo/activity_common"
 	"github.com/apache/incubator-answer/internal/repo/answer"
+	"github.com/apache/incubator-answer/internal/repo/audit_log"
 	"github.com/apache/incubator-answer/internal/repo/auth"
 	"github.com/apache/incubator-answer/internal/repo/badge"
 	"github.com/apache/incubator-answer/internal/repo/badge_award"
 	"github.com/apache/incubator-answer/internal/repo/badge_group"
+	"github.com/apache/incubator-answer/internal/repo/bounty"
 	"github.com/apache/incubator-answer/internal/repo/captcha"
 	"github.com/apache/incubator-answer/internal/repo/collection"
 	"github.com/apache/incubator-answer/internal/repo/comment"
 	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/draft"
+	"github.com/apache/incubator-answer/internal/repo/email_log"
+	"github.com/apache/incubator-answer/internal/repo/event_outbox"
 	"github.com/apache/incubator-answer/internal/repo/export"
 	"github.com/apache/incubator-answer/internal/repo/limit"
 	"github.com/apache/incubator-answer/internal/repo/meta"
+	"github.com/apache/incubator-answer/internal/repo/moderator_message"
 	notification2 "github.com/apache/incubator-answer/internal/repo/notification"
 	"github.com/apache/incubator-answer/internal/repo/plugin_config"
 	"github.com/apache/incubator-answer/internal/repo/question"
@@ -59,22 +65,27 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/review"
 	"github.com/apache/incubator-answer/internal/repo/revision"
 	"github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/apache/incubator-answer/internal/repo/saved_search"
 	"github.com/apache/incubator-answer/internal/repo/search_common"
 	"github.com/apache/incubator-answer/internal/repo/site_info"
 	"github.com/apache/incubator-answer/internal/repo/tag"
 	"github.com/apache/incubator-answer/internal/repo/tag_common"
 	"github.com/apache/incubator-answer/internal/repo/unique"
 	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/repo/user_access_token"
 	"github.com/apache/incubator-answer/internal/repo/user_external_login"
 	"github.com/apache/incubator-answer/internal/repo/user_notification_config"
+	"github.com/apache/incubator-answer/internal/repo/webhook"
 	"github.com/apache/incubator-answer/internal/router"
 	"github.com/apache/incubator-answer/internal/service/action"
 	activity2 "github.com/apache/incubator-answer/internal/service/activity"
 	activity_common2 "github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	"github.com/apache/incubator-answer/internal/service/answer_common"
+	audit_log2 "github.com/apache/incubator-answer/internal/service/audit_log"
 	auth2 "github.com/apache/incubator-answer/internal/service/auth"
 	badge2 "github.com/apache/incubator-answer/internal/service/badge"
+	bounty2 "github.com/apache/incubator-answer/internal/service/bounty"
 	collection2 "github.com/apache/incubator-answer/internal/service/collection"
 	"github.com/apache/incubator-answer/internal/service/collection_common"
 	comment2 "github.com/apache/incubator-answer/internal/service/comment"
@@ -82,11 +93,13 @@ import (
 	config2 "github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/content"
 	"github.com/apache/incubator-answer/internal/service/dashboard"
+	draft2 "github.com/apache/incubator-answer/internal/service/draft"
 	"github.com/apache/incubator-answer/internal/service/event_queue"
 	export2 "github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/follow"
 	meta2 "github.com/apache/incubator-answer/internal/service/meta"
 	"github.com/apache/incubator-answer/internal/service/meta_common"
+	moderator_message2 "github.com/apache/incubator-answer/internal/service/moderator_message"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
 	"github.com/apache/incubator-answer/internal/service/notification_common"
@@ -100,17 +113,22 @@ import (
 	review2 "github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	role2 "github.com/apache/incubator-answer/internal/service/role"
+	saved_search2 "github.com/apache/incubator-answer/internal/service/saved_search"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
 	"github.com/apache/incubator-answer/internal/service/service_config"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	tag2 "github.com/apache/incubator-answer/internal/service/tag"
 	tag_common2 "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
 	"github.com/apache/incubator-answer/internal/service/uploader"
+	user_access_token2 "github.com/apache/incubator-answer/internal/service/user_access_token"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
 	"github.com/apache/incubator-answer/internal/service/user_common"
 	user_external_login2 "github.com/apache/incubator-answer/internal/service/user_external_login"
 	user_notification_config2 "github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
+	webhook2 "github.com/apache/incubator-answer/internal/service/webhook"
 	"github.com/segmentfault/pacman"
 	"github.com/segmentfault/pacman/log"
 )
@@ -150,18 +168,22 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	userRankRepo := rank.NewUserRankRepo(dataData, configService)
 	userActiveActivityRepo := activity.NewUserActiveActivityRepo(dataData, activityRepo, userRankRepo, configService)
 	emailRepo := export.NewEmailRepo(dataData)
-	emailService := export2.NewEmailService(configService, emailRepo, siteInfoCommonService)
+	emailLogRepo := email_log.NewEmailLogRepo(dataData)
+	emailService := export2.NewEmailService(configService, emailRepo, emailLogRepo, siteInfoCommonService, serviceConf)
 	userRoleRelRepo := role.NewUserRoleRelRepo(dataData)
 	roleRepo := role.NewRoleRepo(dataData)
-	roleService := role2.NewRoleService(roleRepo)
+	powerRepo := role.NewPowerRepo(dataData)
+	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
+	roleService := role2.NewRoleService(roleRepo, powerRepo, rolePowerRelRepo, userRoleRelRepo)
 	userRoleRelService := role2.NewUserRoleRelService(userRoleRelRepo, roleService)
 	userCommon := usercommon.NewUserCommon(userRepo, userRoleRelService, authService, siteInfoCommonService)
 	userExternalLoginRepo := user_external_login.NewUserExternalLoginRepo(dataData)
 	userNotificationConfigRepo := user_notification_config.NewUserNotificationConfigRepo(dataData)
 	userNotificationConfigService := user_notification_config2.NewUserNotificationConfigService(userRepo, userNotificationConfigRepo)
 	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService)
-	questionRepo := question.NewQuestionRepo(dataData, uniqueIDRepo)
-	answerRepo := answer.NewAnswerRepo(dataData, uniqueIDRepo, userRankRepo, activityRepo)
+	searchIndexRepo := search_common.NewSearchIndexRepo(dataData)
+	questionRepo := question.NewQuestionRepo(dataData, uniqueIDRepo, searchIndexRepo)
+	answerRepo := answer.NewAnswerRepo(dataData, uniqueIDRepo, userRankRepo, activityRepo, searchIndexRepo)
 	voteRepo := activity_common.NewVoteRepo(dataData, activityRepo)
 	followRepo := activity_common.NewFollowRepo(dataData, uniqueIDRepo, activityRepo)
 	tagCommonRepo := tag_common.NewTagCommonRepo(dataData, uniqueIDRepo)
@@ -177,34 +199,51 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	metaRepo := meta.NewMetaRepo(dataData)
 	metaCommonService := metacommon.NewMetaCommonService(metaRepo)
 	questionCommon := questioncommon.NewQuestionCommon(questionRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData)
-	eventQueueService := event_queue.NewEventQueueService()
-	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventQueueService)
+	eventOutboxRepo := event_outbox.NewEventOutboxRepo(dataData)
+	eventQueueService := event_queue.NewEventQueueService(serviceConf, eventOutboxRepo)
+	userAccessTokenRepo := user_access_token.NewUserAccessTokenRepo(dataData)
+	userAccessTokenService := user_access_token2.NewUserAccessTokenService(userAccessTokenRepo, userRepo, userRoleRelService)
+	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventQueueService, userAccessTokenService)
 	captchaRepo := captcha.NewCaptchaRepo(dataData)
 	captchaService := action.NewCaptchaService(captchaRepo)
-	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService)
+	userSuspensionRepo := user.NewUserSuspensionRepo(dataData)
+	reasonRepo := reason.NewReasonRepo(configService)
+	userSuspensionService := user_suspension.NewUserSuspensionService(userSuspensionRepo, userRepo, reasonRepo, authService, userRoleRelService, userCommon, emailService, siteInfoCommonService)
+	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService, userSuspensionService)
 	commentRepo := comment.NewCommentRepo(dataData, uniqueIDRepo)
 	commentCommonRepo := comment.NewCommentCommonRepo(dataData, uniqueIDRepo)
 	objService := object_info.NewObjService(answerRepo, questionRepo, commentCommonRepo, tagCommonRepo, tagCommonService)
 	notificationQueueService := notice_queue.NewNotificationQueueService()
 	externalNotificationQueueService := notice_queue.NewNewQuestionNotificationQueueService()
-	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService, eventQueueService)
-	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
+	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService, eventQueueService, revisionService)
 	rolePowerRelService := role2.NewRolePowerRelService(rolePowerRelRepo, userRoleRelService)
-	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, configService)
+	tagModeratorRepo := tag.NewTagModeratorRepo(dataData)
+	tagModeratorService := tag_moderator.NewTagModeratorService(tagModeratorRepo, objService, tagCommonService, userCommon)
+	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, configService, tagModeratorService)
 	limitRepo := limit.NewRateLimitRepo(dataData)
 	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limitRepo)
-	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware)
 	reportRepo := report.NewReportRepo(dataData, uniqueIDRepo)
 	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService)
 	answerActivityRepo := activity.NewAnswerActivityRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
 	answerActivityService := activity2.NewAnswerActivityService(answerActivityRepo, configService)
-	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService)
+	notificationRepo := notification2.NewNotificationRepo(dataData)
+	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService, notificationRepo, questionRepo)
 	reviewRepo := review.NewReviewRepo(dataData)
-	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService)
-	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService)
-	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService, eventQueueService)
+	reviewTaskRepo := review.NewReviewTaskRepo(dataData)
+	auditLogRepo := audit_log.NewAuditLogRepo(dataData)
+	auditLogService := audit_log2.NewAuditLogService(auditLogRepo, userCommon)
+	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService, tagModeratorService, reviewTaskRepo, auditLogService)
+	bountyRepo := bounty.NewBountyRepo(dataData, userRankRepo)
+	draftRepo := draft.NewDraftRepo(dataData)
+	draftService := draft2.NewDraftService(draftRepo)
+	questionLinkRepo := question.NewQuestionLinkRepo(dataData)
+	questionMergeRepo := activity.NewQuestionMergeRepo(dataData, activityRepo, userRankRepo)
+	questionCloseVoteRepo := question.NewQuestionCloseVoteRepo(dataData)
+	bountyService := bounty2.NewBountyService(bountyRepo, questionRepo, answerRepo, activityRepo, userCommon, rankService, notificationQueueService)
+	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, bountyRepo, bountyService, questionLinkRepo, questionMergeRepo, draftService, auditLogService, questionCloseVoteRepo)
+	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService, eventQueueService, draftService, auditLogService)
 	reportHandle := report_handle.NewReportHandle(questionService, answerService, commentService)
-	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, eventQueueService)
+	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, eventQueueService, auditLogService)
 	reportController := controller.NewReportController(reportService, rankService, captchaService)
 	contentVoteRepo := activity.NewVoteRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
 	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService, eventQueueService)
@@ -217,26 +256,29 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	collectionService := collection2.NewCollectionService(collectionRepo, collectionGroupRepo, questionCommon)
 	collectionController := controller.NewCollectionController(collectionService)
 	questionController := controller.NewQuestionController(questionService, answerService, rankService, siteInfoCommonService, captchaService, rateLimitMiddleware)
-	answerController := controller.NewAnswerController(answerService, rankService, captchaService, siteInfoCommonService, rateLimitMiddleware)
+	postConvertRepo := activity.NewPostConvertRepo(dataData, activityRepo)
+	postConvertService := content.NewPostConvertService(postConvertRepo, commentRepo, commentService, answerRepo, answerService, questionRepo, questionCommon, userCommon, revisionService, notificationQueueService, activityQueueService, eventQueueService, auditLogService)
+	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware, postConvertService)
+	answerController := controller.NewAnswerController(answerService, rankService, captchaService, siteInfoCommonService, rateLimitMiddleware, postConvertService)
 	searchParser := search_parser.NewSearchParser(tagCommonService, userCommon)
 	searchRepo := search_common.NewSearchRepo(dataData, uniqueIDRepo, userCommon, tagCommonService)
 	searchService := content.NewSearchService(searchParser, searchRepo)
 	searchController := controller.NewSearchController(searchService, captchaService)
 	reviewActivityRepo := activity.NewReviewActivityRepo(dataData, activityRepo, userRankRepo, configService)
-	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo)
+	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo, questionService, auditLogService)
 	revisionController := controller.NewRevisionController(contentRevisionService, rankService)
 	rankController := controller.NewRankController(rankService)
 	userAdminRepo := user.NewUserAdminRepo(dataData, authRepo)
-	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo)
-	userAdminController := controller_admin.NewUserAdminController(userAdminService)
-	reasonRepo := reason.NewReasonRepo(configService)
+	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo, userSuspensionService, auditLogService, userAccessTokenService)
+	moderatorMessageRepo := moderator_message.NewModeratorMessageRepo(dataData)
+	moderatorMessageService := moderator_message2.NewModeratorMessageService(moderatorMessageRepo, userRepo, userCommon, reasonRepo, objService, notificationQueueService, emailService, siteInfoCommonService, auditLogService)
+	userAdminController := controller_admin.NewUserAdminController(userAdminService, userSuspensionService, moderatorMessageService)
 	reasonService := reason2.NewReasonService(reasonRepo)
 	reasonController := controller.NewReasonController(reasonService)
 	themeController := controller_admin.NewThemeController()
 	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon)
-	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService)
+	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService, auditLogService)
 	controllerSiteInfoController := controller.NewSiteInfoController(siteInfoCommonService)
-	notificationRepo := notification2.NewNotificationRepo(dataData)
 	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, notificationQueueService, userExternalLoginRepo, siteInfoCommonService)
 	badgeRepo := badge.NewBadgeRepo(dataData, uniqueIDRepo)
 	notificationService := notification.NewNotificationService(dataData, notificationRepo, notificationCommon, revisionService, userRepo, reportRepo, reviewService, badgeRepo)
@@ -250,11 +292,11 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	commentCommonService := comment_common.NewCommentCommonService(commentCommonRepo)
 	activityService := activity2.NewActivityService(activityActivityRepo, userCommon, activityCommon, tagCommonService, objService, commentCommonService, revisionService, metaCommonService, configService)
 	activityController := controller.NewActivityController(activityService)
-	roleController := controller_admin.NewRoleController(roleService)
+	roleController := controller_admin.NewRoleController(roleService, auditLogService)
 	pluginConfigRepo := plugin_config.NewPluginConfigRepo(dataData)
 	pluginUserConfigRepo := plugin_config.NewPluginUserConfigRepo(dataData)
 	pluginCommonService := plugin_common.NewPluginCommonService(pluginConfigRepo, pluginUserConfigRepo, configService, dataData)
-	pluginController := controller_admin.NewPluginController(pluginCommonService)
+	pluginController := controller_admin.NewPluginController(pluginCommonService, auditLogService)
 	permissionController := controller.NewPermissionController(rankService)
 	userPluginController := controller.NewUserPluginController(pluginCommonService)
 	reviewController := controller.NewReviewController(reviewService, rankService, captchaService)
@@ -265,15 +307,28 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	eventRuleRepo := badge.NewEventRuleRepo(dataData)
 	badgeAwardService := badge2.NewBadgeAwardService(badgeAwardRepo, badgeRepo, userCommon, objService, notificationQueueService)
 	badgeEventService := badge2.NewBadgeEventService(dataData, eventQueueService, badgeRepo, eventRuleRepo, badgeAwardService)
-	badgeService := badge2.NewBadgeService(badgeRepo, badgeGroupRepo, badgeAwardRepo, badgeEventService, siteInfoCommonService)
+	badgeService := badge2.NewBadgeService(badgeRepo, badgeGroupRepo, badgeAwardRepo, badgeEventService, siteInfoCommonService, auditLogService)
 	badgeController := controller.NewBadgeController(badgeService, badgeAwardService)
 	controller_adminBadgeController := controller_admin.NewBadgeController(badgeService)
-	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController)
+	webhookRepo := webhook.NewWebhookRepo(dataData)
+	webhookService := webhook2.NewWebhookService(webhookRepo, siteInfoCommonService, eventQueueService)
+	webhookController := controller_admin.NewWebhookController(webhookService, auditLogService)
+	tagModeratorController := controller_admin.NewTagModeratorController(tagModeratorService, auditLogService)
+	userAccessTokenController := controller.NewUserAccessTokenController(userAccessTokenService)
+	savedSearchRepo := saved_search.NewSavedSearchRepo(dataData)
+	savedSearchService := saved_search2.NewSavedSearchService(savedSearchRepo, searchService, userRepo, emailService, notificationQueueService)
+	savedSearchController := controller.NewSavedSearchController(savedSearchService)
+	bountyController := controller.NewBountyController(bountyService)
+	moderatorMessageController := controller.NewModeratorMessageController(moderatorMessageService, rankService)
+	draftController := controller.NewDraftController(draftService)
+	auditLogController := controller_admin.NewAuditLogController(auditLogService)
+	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController, webhookController, userAccessTokenController, savedSearchController, bountyController, draftController, tagModeratorController, moderatorMessageController, auditLogController)
 	swaggerRouter := router.NewSwaggerRouter(swaggerConf)
 	uiRouter := router.NewUIRouter(controllerSiteInfoController, siteInfoCommonService)
-	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService)
+	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService, userAccessTokenService)
 	avatarMiddleware := middleware.NewAvatarMiddleware(serviceConf, uploaderService)
 	shortIDMiddleware := middleware.NewShortIDMiddleware(siteInfoCommonService)
+	moderatorMessageMiddleware := middleware.NewModeratorMessageMiddleware(moderatorMessageService)
 	templateRenderController := templaterender.NewTemplateRenderController(questionService, userService, tagService, answerService, commentService, siteInfoCommonService, questionRepo)
 	templateController := controller.NewTemplateController(templateRenderController, siteInfoCommonService, eventQueueService, userService)
 	templateRouter := router.NewTemplateRouter(templateController, templateRenderController, siteInfoController, authUserMiddleware)
@@ -284,9 +339,14 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	embedController := controller.NewEmbedController()
 	renderController := controller.NewRenderController()
 	pluginAPIRouter := router.NewPluginAPIRouter(connectorController, userCenterController, captchaController, embedController, renderController)
-	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, templateRouter, pluginAPIRouter, uiConf)
-	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService)
-	application := newApplication(serverConf, ginEngine, scheduledTaskManager)
+	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, moderatorMessageMiddleware, templateRouter, pluginAPIRouter, uiConf)
+	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, savedSearchService, externalNotificationService, bountyService, draftService, userSuspensionService)
+	application, err := newApplication(serverConf, ginEngine, scheduledTaskManager)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	return application, func() {
 		cleanup2()
 		cleanup()
@@ -56,6 +56,9 @@ const (
 	ActQuestionUnPin     ActivityTypeKey = "question.unpin"
 	ActQuestionHide      ActivityTypeKey = "question.hide"
 	ActQuestionShow      ActivityTypeKey = "question.show"
+	ActQuestionMerged    ActivityTypeKey = "question.merged"
+	ActQuestionWiki      ActivityTypeKey = "question.wiki"
+	ActQuestionUnWiki    ActivityTypeKey = "question.unwiki"
 )
 
 const (
@@ -68,6 +71,11 @@ const (
 	ActAnswerRollback  ActivityTypeKey = "answer.rollback"
 	ActAnswerDeleted   ActivityTypeKey = "answer.deleted"
 	ActAnswerUndeleted ActivityTypeKey = "answer.undeleted"
+	ActAnswerWiki      ActivityTypeKey = "answer.wiki"
+	ActAnswerUnWiki    ActivityTypeKey = "answer.unwiki"
+
+	ActAnswerConvertedFromComment ActivityTypeKey = "answer.converted_from_comment"
+	ActAnswerConvertedToComment   ActivityTypeKey = "answer.converted_to_comment"
 )
 
 const (
@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package constant
+
+// the object types only used in the audit log
+const (
+	AuditLogObjectTypeSiteInfo = "site_info"
+	AuditLogObjectTypePlugin   = "plugin"
+	AuditLogObjectTypeRole     = "role"
+	AuditLogObjectTypeWebhook  = "webhook"
+)
+
+const (
+	AuditLogActionQuestionStatusUpdate = "question.status.update"
+	AuditLogActionAnswerStatusUpdate   = "answer.status.update"
+	AuditLogActionUserAdd              = "user.add"
+	AuditLogActionUserStatusUpdate     = "user.status.update"
+	AuditLogActionUserRoleUpdate       = "user.role.update"
+	AuditLogActionUserPasswordUpdate   = "user.password.update"
+	AuditLogActionUserProfileUpdate    = "user.profile.update"
+	AuditLogActionModeratorMessageSend = "user.moderator_message.send"
+	AuditLogActionTagModeratorAdd      = "tag.moderator.add"
+	AuditLogActionTagModeratorRemove   = "tag.moderator.remove"
+	AuditLogActionBadgeStatusUpdate    = "badge.status.update"
+	AuditLogActionRoleAdd              = "role.add"
+	AuditLogActionRoleUpdate           = "role.update"
+	AuditLogActionRoleRemove           = "role.remove"
+	AuditLogActionRolePowerUpdate      = "role.power.update"
+	AuditLogActionWebhookAdd           = "webhook.add"
+	AuditLogActionWebhookUpdate        = "webhook.update"
+	AuditLogActionWebhookRemove        = "webhook.remove"
+	AuditLogActionPluginStatusUpdate   = "plugin.status.update"
+	AuditLogActionPluginConfigUpdate   = "plugin.config.update"
+	AuditLogActionSiteInfoUpdate       = "site_info.update"
+	AuditLogActionSMTPConfigUpdate     = "site_info.smtp.update"
+	AuditLogActionPrivilegesUpdate     = "site_info.privileges.update"
+	AuditLogActionReviewTaskComplete   = "review_task.complete"
+
+	AuditLogActionQuestionClose            = "question.close"
+	AuditLogActionQuestionReopen           = "question.reopen"
+	AuditLogActionQuestionPin              = "question.pin"
+	AuditLogActionQuestionUnPin            = "question.unpin"
+	AuditLogActionQuestionHide             = "question.hide"
+	AuditLogActionQuestionShow             = "question.show"
+	AuditLogActionQuestionWiki             = "question.wiki"
+	AuditLogActionQuestionUnWiki           = "question.unwiki"
+	AuditLogActionQuestionMerge            = "question.merge"
+	AuditLogActionQuestionCloseVoteDismiss = "question.close_vote.dismiss"
+	AuditLogActionAnswerWiki               = "answer.wiki"
+	AuditLogActionAnswerUnWiki             = "answer.unwiki"
+	AuditLogActionAnswerConvertToComment   = "answer.convert_to_comment"
+	AuditLogActionCommentConvertToAnswer   = "comment.convert_to_answer"
+	AuditLogActionPendingPostReview        = "pending_post.review"
+	AuditLogActionReportReview             = "report.review"
+	AuditLogActionRevisionReview           = "revision.review"
+)
+
+// AuditLogActions all the actions recorded in the audit log, used for filtering
+var AuditLogActions = []string{
+	AuditLogActionQuestionStatusUpdate,
+	AuditLogActionAnswerStatusUpdate,
+	AuditLogActionUserAdd,
+	AuditLogActionUserStatusUpdate,
+	AuditLogActionUserRoleUpdate,
+	AuditLogActionUserPasswordUpdate,
+	AuditLogActionUserProfileUpdate,
+	AuditLogActionModeratorMessageSend,
+	AuditLogActionTagModeratorAdd,
+	AuditLogActionTagModeratorRemove,
+	AuditLogActionBadgeStatusUpdate,
+	AuditLogActionRoleAdd,
+	AuditLogActionRoleUpdate,
+	AuditLogActionRoleRemove,
+	AuditLogActionRolePowerUpdate,
+	AuditLogActionWebhookAdd,
+	AuditLogActionWebhookUpdate,
+	AuditLogActionWebhookRemove,
+	AuditLogActionPluginStatusUpdate,
+	AuditLogActionPluginConfigUpdate,
+	AuditLogActionSiteInfoUpdate,
+	AuditLogActionSMTPConfigUpdate,
+	AuditLogActionPrivilegesUpdate,
+	AuditLogActionReviewTaskComplete,
+	AuditLogActionQuestionClose,
+	AuditLogActionQuestionReopen,
+	AuditLogActionQuestionPin,
+	AuditLogActionQuestionUnPin,
+	AuditLogActionQuestionHide,
+	AuditLogActionQuestionShow,
+	AuditLogActionQuestionWiki,
+	AuditLogActionQuestionUnWiki,
+	AuditLogActionQuestionMerge,
+	AuditLogActionQuestionCloseVoteDismiss,
+	AuditLogActionAnswerWiki,
+	AuditLogActionAnswerUnWiki,
+	AuditLogActionAnswerConvertToComment,
+	AuditLogActionCommentConvertToAnswer,
+	AuditLogActionPendingPostReview,
+	AuditLogActionReportReview,
+	AuditLogActionRevisionReview,
+}
@@ -22,4 +22,5 @@ package constant
 const (
 	AcceptLanguageFlag = "Accept-Language"
 	ShortIDFlag        = "Short-ID-Enabled"
+	ClientIPFlag       = "Client-IP"
 )
@@ -43,4 +43,19 @@ const (
 
 	EmailTplKeyNewQuestionTitle = "email_tpl.new_question.title"
 	EmailTplKeyNewQuestionBody  = "email_tpl.new_question.body"
+
+	EmailTplKeySavedSearchDigestTitle = "email_tpl.saved_search_digest.title"
+	EmailTplKeySavedSearchDigestBody  = "email_tpl.saved_search_digest.body"
+
+	EmailTplKeyDigestTitle      = "email_tpl.digest.title"
+	EmailTplKeyDigestBody       = "email_tpl.digest.body"
+	EmailTplKeyDigestNothingNew = "email_tpl.digest.nothing_new"
+
+	EmailTplKeyUserSuspendedTitle   = "email_tpl.user_suspended.title"
+	EmailTplKeyUserSuspendedBody    = "email_tpl.user_suspended.body"
+	EmailTplKeyUserSuspendedUntil   = "email_tpl.user_suspended.until"
+	EmailTplKeyUserSuspendedForever = "email_tpl.user_suspended.forever"
+
+	EmailTplKeyModeratorMessageTitle = "email_tpl.moderator_message.title"
+	EmailTplKeyModeratorMessageBody  = "email_tpl.moderator_message.body"
 )
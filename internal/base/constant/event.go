@@ -73,3 +73,37 @@ const (
 	EventCommentVote   EventType = eventComment + "." + eventVote
 	EventCommentFlag   EventType = eventComment + "." + eventFlag
 )
+
+// EventTypeList all event types that can be subscribed
+var EventTypeList = []EventType{
+	EventUserUpdate,
+	EventUserShare,
+	EventQuestionCreate,
+	EventQuestionUpdate,
+	EventQuestionDelete,
+	EventQuestionVote,
+	EventQuestionAccept,
+	EventQuestionFlag,
+	EventQuestionReact,
+	EventAnswerCreate,
+	EventAnswerUpdate,
+	EventAnswerDelete,
+	EventAnswerVote,
+	EventAnswerFlag,
+	EventAnswerReact,
+	EventCommentCreate,
+	EventCommentUpdate,
+	EventCommentDelete,
+	EventCommentVote,
+	EventCommentFlag,
+}
+
+// IsValidEventType check if the event type is defined
+func IsValidEventType(eventType string) bool {
+	for _, e := range EventTypeList {
+		if string(e) == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -54,10 +54,20 @@ const (
 	NotificationYourAnswerWasDeleted = "notification.action.your_answer_was_deleted"
 	// NotificationYourCommentWasDeleted your comment was deleted
 	NotificationYourCommentWasDeleted = "notification.action.your_comment_was_deleted"
+	// NotificationYourCommentWasConverted your comment was converted to an answer
+	NotificationYourCommentWasConverted = "notification.action.your_comment_was_converted"
+	// NotificationYourAnswerWasConverted your answer was converted to a comment
+	NotificationYourAnswerWasConverted = "notification.action.your_answer_was_converted"
 	// NotificationInvitedYouToAnswer invited you to answer
 	NotificationInvitedYouToAnswer = "notification.action.invited_you_to_answer"
 	// NotificationEarnedBadge earned badge
 	NotificationEarnedBadge = "notification.action.earned_badge"
+	// NotificationSavedSearchNewMatch new question matches the saved search
+	NotificationSavedSearchNewMatch = "notification.action.saved_search_new_match"
+	// NotificationBountyAwarded bounty awarded to your answer
+	NotificationBountyAwarded = "notification.action.bounty_awarded"
+	// NotificationModeratorMessage received a private message from the moderators
+	NotificationModeratorMessage = "notification.action.moderator_message"
 )
 
 type NotificationChannelKey string
@@ -67,12 +77,22 @@ const (
 	InboxSource                          NotificationSource = "inbox"
 	AllNewQuestionSource                 NotificationSource = "all_new_question"
 	AllNewQuestionForFollowingTagsSource NotificationSource = "all_new_question_for_following_tags"
+	DigestSource                         NotificationSource = "digest"
 )
 
 const (
 	EmailChannel NotificationChannelKey = "email"
 )
 
+// NotificationFrequency how often the email notifications are delivered
+type NotificationFrequency string
+
+const (
+	NotificationFrequencyImmediate NotificationFrequency = "immediate"
+	NotificationFrequencyDaily     NotificationFrequency = "daily"
+	NotificationFrequencyWeekly    NotificationFrequency = "weekly"
+)
+
 const (
 	NotificationTypeInbox            = "inbox"
 	NotificationTypeAchievement      = "achievement"
@@ -81,23 +101,28 @@ const (
 
 var (
 	NotificationMsgTypeMapping = map[string]int{
-		NotificationUpdateQuestion:         1,
-		NotificationAnswerTheQuestion:      1,
-		NotificationUpVotedTheQuestion:     2,
-		NotificationDownVotedTheQuestion:   2,
-		NotificationUpdateAnswer:           1,
-		NotificationAcceptAnswer:           1,
-		NotificationUpVotedTheAnswer:       2,
-		NotificationDownVotedTheAnswer:     2,
-		NotificationCommentQuestion:        1,
-		NotificationCommentAnswer:          1,
-		NotificationUpVotedTheComment:      2,
-		NotificationReplyToYou:             1,
-		NotificationMentionYou:             1,
-		NotificationYourQuestionIsClosed:   1,
-		NotificationYourQuestionWasDeleted: 1,
-		NotificationYourAnswerWasDeleted:   1,
-		NotificationYourCommentWasDeleted:  1,
-		NotificationInvitedYouToAnswer:     3,
+		NotificationUpdateQuestion:          1,
+		NotificationAnswerTheQuestion:       1,
+		NotificationUpVotedTheQuestion:      2,
+		NotificationDownVotedTheQuestion:    2,
+		NotificationUpdateAnswer:            1,
+		NotificationAcceptAnswer:            1,
+		NotificationUpVotedTheAnswer:        2,
+		NotificationDownVotedTheAnswer:      2,
+		NotificationCommentQuestion:         1,
+		NotificationCommentAnswer:           1,
+		NotificationUpVotedTheComment:       2,
+		NotificationReplyToYou:              1,
+		NotificationMentionYou:              1,
+		NotificationYourQuestionIsClosed:    1,
+		NotificationYourQuestionWasDeleted:  1,
+		NotificationYourAnswerWasDeleted:    1,
+		NotificationYourCommentWasDeleted:   1,
+		NotificationYourCommentWasConverted: 1,
+		NotificationYourAnswerWasConverted:  1,
+		NotificationInvitedYouToAnswer:      3,
+		NotificationSavedSearchNewMatch:     1,
+		NotificationBountyAwarded:           1,
+		NotificationModeratorMessage:        1,
 	}
 )
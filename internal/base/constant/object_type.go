@@ -29,6 +29,8 @@ const (
 	ReportObjectType     = "report"
 	BadgeObjectType      = "badge"
 	BadgeAwardObjectType = "badge_award"
+	// ModeratorMessageObjectType only used as the object of the notification
+	ModeratorMessageObjectType = "moderator_message"
 )
 
 var (
@@ -61,6 +61,12 @@ const (
 	RankQuestionCloseKey             = "rank.question.close"
 	RankQuestionReopenKey            = "rank.question.reopen"
 	RankTagUseReservedTagKey         = "rank.tag.use_reserved_tag"
+	RankQuestionBountyKey            = "rank.question.bounty"
+	RankQuestionWikiEditKey          = "rank.question.wiki_edit"
+	RankAnswerWikiEditKey            = "rank.answer.wiki_edit"
+	RankReviewFirstPostKey           = "rank.review.first_post"
+	RankReviewLateAnswerKey          = "rank.review.late_answer"
+	RankReviewLowQualityKey          = "rank.review.low_quality"
 )
 
 var (
@@ -80,12 +86,18 @@ var (
 		{Label: reason.RankTagEditLabel, Key: RankTagEditKey},
 		{Label: reason.RankQuestionEditLabel, Key: RankQuestionEditKey},
 		{Label: reason.RankAnswerEditLabel, Key: RankAnswerEditKey},
+		{Label: reason.RankQuestionWikiEditLabel, Key: RankQuestionWikiEditKey},
+		{Label: reason.RankAnswerWikiEditLabel, Key: RankAnswerWikiEditKey},
 		{Label: reason.RankQuestionEditWithoutReviewLabel, Key: RankQuestionEditWithoutReviewKey},
 		{Label: reason.RankAnswerEditWithoutReviewLabel, Key: RankAnswerEditWithoutReviewKey},
 		{Label: reason.RankQuestionAuditLabel, Key: RankQuestionAuditKey},
 		{Label: reason.RankAnswerAuditLabel, Key: RankAnswerAuditKey},
 		{Label: reason.RankTagAuditLabel, Key: RankTagAuditKey},
+		{Label: reason.RankReviewFirstPostLabel, Key: RankReviewFirstPostKey},
+		{Label: reason.RankReviewLateAnswerLabel, Key: RankReviewLateAnswerKey},
+		{Label: reason.RankReviewLowQualityLabel, Key: RankReviewLowQualityKey},
 		{Label: reason.RankTagEditWithoutReviewLabel, Key: RankTagEditWithoutReviewKey},
 		{Label: reason.RankTagSynonymLabel, Key: RankTagSynonymKey},
+		{Label: reason.RankQuestionBountyLabel, Key: RankQuestionBountyKey},
 	}
 )
@@ -24,4 +24,8 @@ const (
 	QuestionsTitleTrKey       = "question.questions_title"
 	TagsListTitleTrKey        = "tag.tags_title"
 	TagHasNoDescription       = "tag.no_description"
+	FeedTagTitleTrKey         = "feed.tag_title"
+	FeedQuestionTitleTrKey    = "feed.question_title"
+	FeedAnswerTitleTrKey      = "feed.answer_title"
+	FeedUserTitleTrKey        = "feed.user_title"
 )
@@ -27,6 +27,10 @@ const (
 	FlaggedPost       ReviewingType = "flagged_post"
 	FlaggedUser       ReviewingType = "flagged_user"
 	SuggestedPostEdit ReviewingType = "suggested_post_edit"
+	CloseReopenVote   ReviewingType = "close_reopen_vote"
+	FirstPost         ReviewingType = "first_post"
+	LateAnswer        ReviewingType = "late_answer"
+	LowQualityPost    ReviewingType = "low_quality"
 )
 
 const (
@@ -41,4 +45,8 @@ const (
 	ReviewQueuedPostLabel        = "review.queued_post"
 	ReviewFlaggedPostLabel       = "review.flagged_post"
 	ReviewSuggestedPostEditLabel = "review.suggested_post_edit"
+	ReviewCloseReopenVoteLabel   = "review.close_reopen_vote"
+	ReviewFirstPostLabel         = "review.first_post"
+	ReviewLateAnswerLabel        = "review.late_answer"
+	ReviewLowQualityPostLabel    = "review.low_quality"
 )
@@ -23,26 +23,47 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/service/bounty"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/draft"
+	"github.com/apache/incubator-answer/internal/service/notification"
+	"github.com/apache/incubator-answer/internal/service/saved_search"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
 	"github.com/robfig/cron/v3"
 	"github.com/segmentfault/pacman/log"
 )
 
 // ScheduledTaskManager scheduled task manager
 type ScheduledTaskManager struct {
-	siteInfoService siteinfo_common.SiteInfoCommonService
-	questionService *content.QuestionService
+	siteInfoService       siteinfo_common.SiteInfoCommonService
+	questionService       *content.QuestionService
+	savedSearchService    *saved_search.SavedSearchService
+	notificationService   *notification.ExternalNotificationService
+	bountyService         *bounty.BountyService
+	draftService          *draft.DraftService
+	userSuspensionService *user_suspension.UserSuspensionService
 }
 
 // NewScheduledTaskManager new scheduled task manager
 func NewScheduledTaskManager(
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	questionService *content.QuestionService,
+	savedSearchService *saved_search.SavedSearchService,
+	notificationService *notification.ExternalNotificationService,
+	bountyService *bounty.BountyService,
+	draftService *draft.DraftService,
+	userSuspensionService *user_suspension.UserSuspensionService,
 ) *ScheduledTaskManager {
 	manager := &ScheduledTaskManager{
-		siteInfoService: siteInfoService,
-		questionService: questionService,
+		siteInfoService:       siteInfoService,
+		questionService:       questionService,
+		savedSearchService:    savedSearchService,
+		notificationService:   notificationService,
+		bountyService:         bountyService,
+		draftService:          draftService,
+		userSuspensionService: userSuspensionService,
 	}
 	return manager
 }
@@ -69,5 +90,68 @@ func (s *ScheduledTaskManager) Run() {
 		log.Error(err)
 	}
 
+	_, err = c.AddFunc("30 */1 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("saved search notification cron execution")
+		s.savedSearchService.NotifyNewMatchesCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("0 8 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("daily digest cron execution")
+		s.notificationService.SendDigestCron(ctx, constant.NotificationFrequencyDaily)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("0 8 * * 1", func() {
+		ctx := context.Background()
+		fmt.Println("weekly digest cron execution")
+		s.notificationService.SendDigestCron(ctx, constant.NotificationFrequencyWeekly)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("15 */1 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("expire bounties cron execution")
+		s.bountyService.ExpireBountiesCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("0 3 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("clear expired drafts cron execution")
+		s.draftService.ClearExpiredDraftsCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("*/5 * * * *", func() {
+		ctx := context.Background()
+		fmt.Println("lift expired user suspensions cron execution")
+		s.userSuspensionService.LiftExpiredSuspensionsCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("45 */1 * * *", func() {
+		ctx := context.Background()
+		fmt.Println("expire close votes cron execution")
+		s.questionService.ExpireCloseVotesCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
 	c.Start()
 }
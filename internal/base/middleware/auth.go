@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/user_access_token"
 	"github.com/apache/incubator-answer/ui"
 	"github.com/gin-gonic/gin"
 
@@ -38,22 +39,58 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
-var ctxUUIDKey = "ctxUuidKey"
+var (
+	ctxUUIDKey        = "ctxUuidKey"
+	ctxAccessTokenKey = "ctxAccessTokenKey"
+)
 
 // AuthUserMiddleware auth user middleware
 type AuthUserMiddleware struct {
-	authService           *auth.AuthService
-	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	authService            *auth.AuthService
+	siteInfoCommonService  siteinfo_common.SiteInfoCommonService
+	userAccessTokenService *user_access_token.UserAccessTokenService
 }
 
 // NewAuthUserMiddleware new auth user middleware
 func NewAuthUserMiddleware(
 	authService *auth.AuthService,
-	siteInfoCommonService siteinfo_common.SiteInfoCommonService) *AuthUserMiddleware {
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	userAccessTokenService *user_access_token.UserAccessTokenService) *AuthUserMiddleware {
 	return &AuthUserMiddleware{
-		authService:           authService,
-		siteInfoCommonService: siteInfoCommonService,
+		authService:            authService,
+		siteInfoCommonService:  siteInfoCommonService,
+		userAccessTokenService: userAccessTokenService,
+	}
+}
+
+// getUserCacheInfo get user info by the session token or the personal access token.
+// The personal access token is only accepted from the Authorization header,
+// scopeDenied will be true if the scopes of the token do not allow the request.
+func (am *AuthUserMiddleware) getUserCacheInfo(ctx *gin.Context, token string, adminAPI bool) (
+	userInfo *entity.UserCacheInfo, scopeDenied bool, err error) {
+	if !user_access_token.IsUserAccessToken(token) {
+		if adminAPI {
+			userInfo, err = am.authService.GetAdminUserCacheInfo(ctx, token)
+		} else {
+			userInfo, err = am.authService.GetUserCacheInfo(ctx, token)
+		}
+		return userInfo, false, err
 	}
+	if len(ctx.GetHeader("Authorization")) == 0 {
+		return nil, false, nil
+	}
+	userInfo, scopes, err := am.userAccessTokenService.GetUserCacheInfo(ctx, token)
+	if err != nil || userInfo == nil {
+		return nil, false, err
+	}
+	if adminAPI && userInfo.RoleID != role.RoleAdminID {
+		return nil, false, nil
+	}
+	if !user_access_token.ScopeAllowed(scopes, ctx.Request.Method, adminAPI) {
+		return nil, true, nil
+	}
+	ctx.Set(ctxAccessTokenKey, true)
+	return userInfo, false, nil
 }
 
 // Auth get token and auth user, set user info to context if user is already login
@@ -64,7 +101,7 @@ func (am *AuthUserMiddleware) Auth() gin.HandlerFunc {
 			ctx.Next()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
+		userInfo, _, err := am.getUserCacheInfo(ctx, token, false)
 		if err != nil {
 			ctx.Next()
 			return
@@ -116,7 +153,12 @@ func (am *AuthUserMiddleware) MustAuthWithoutAccountAvailable() gin.HandlerFunc
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
+		userInfo, scopeDenied, err := am.getUserCacheInfo(ctx, token, false)
+		if scopeDenied {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.UserAccessTokenScopeNotAllowed), nil)
+			ctx.Abort()
+			return
+		}
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
 			ctx.Abort()
@@ -141,7 +183,12 @@ func (am *AuthUserMiddleware) MustAuthAndAccountAvailable() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
+		userInfo, scopeDenied, err := am.getUserCacheInfo(ctx, token, false)
+		if scopeDenied {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.UserAccessTokenScopeNotAllowed), nil)
+			ctx.Abort()
+			return
+		}
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
 			ctx.Abort()
@@ -177,7 +224,12 @@ func (am *AuthUserMiddleware) AdminAuth() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetAdminUserCacheInfo(ctx, token)
+		userInfo, scopeDenied, err := am.getUserCacheInfo(ctx, token, true)
+		if scopeDenied {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.UserAccessTokenScopeNotAllowed), nil)
+			ctx.Abort()
+			return
+		}
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Forbidden(reason.UnauthorizedError), nil)
 			ctx.Abort()
@@ -232,6 +284,11 @@ func GetLoginUserIDFromContext(ctx *gin.Context) (userID string) {
 	return userInfo.UserID
 }
 
+// IsUserAccessTokenRequest check whether the request is authorized by a personal access token
+func IsUserAccessTokenRequest(ctx *gin.Context) bool {
+	return ctx.GetBool(ctxAccessTokenKey)
+}
+
 // GetIsAdminFromContext get user is admin from context
 func GetIsAdminFromContext(ctx *gin.Context) (isAdmin bool) {
 	userInfo := GetUserInfoFromContext(ctx)
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/moderator_message"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// ModeratorMessageMiddleware moderator message middleware
+type ModeratorMessageMiddleware struct {
+	moderatorMessageService *moderator_message.ModeratorMessageService
+}
+
+// NewModeratorMessageMiddleware new moderator message middleware
+func NewModeratorMessageMiddleware(
+	moderatorMessageService *moderator_message.ModeratorMessageService) *ModeratorMessageMiddleware {
+	return &ModeratorMessageMiddleware{
+		moderatorMessageService: moderatorMessageService,
+	}
+}
+
+// MustAcknowledgeModeratorMessage reject the request while the login user has moderator messages
+// not acknowledged yet. It should be used for the requests that post content, such as add question,
+// add answer, comment and vote.
+func (mm *ModeratorMessageMiddleware) MustAcknowledgeModeratorMessage() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := GetLoginUserIDFromContext(ctx)
+		if len(userID) == 0 {
+			ctx.Next()
+			return
+		}
+		exist, err := mm.moderatorMessageService.HasUnacknowledgedModeratorMessage(ctx, userID)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			ctx.Abort()
+			return
+		}
+		if exist {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.ModeratorMessageUnacknowledged),
+				&schema.ForbiddenResp{Type: schema.ForbiddenReasonTypeModeratorMessage})
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -47,4 +47,10 @@ const (
 	RankTagAuditLabel                  = "privilege.rank_tag_audit_label"
 	RankTagEditWithoutReviewLabel      = "privilege.rank_tag_edit_without_review_label"
 	RankTagSynonymLabel                = "privilege.rank_tag_synonym_label"
+	RankQuestionBountyLabel            = "privilege.rank_question_bounty_label"
+	RankQuestionWikiEditLabel          = "privilege.rank_question_wiki_edit_label"
+	RankAnswerWikiEditLabel            = "privilege.rank_answer_wiki_edit_label"
+	RankReviewFirstPostLabel           = "privilege.rank_review_first_post_label"
+	RankReviewLateAnswerLabel          = "privilege.rank_review_late_answer_label"
+	RankReviewLowQualityLabel          = "privilege.rank_review_low_quality_label"
 )
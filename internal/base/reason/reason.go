@@ -40,17 +40,23 @@ const (
 	EmailOrPasswordWrong             = "error.object.email_or_password_incorrect"
 	CommentNotFound                  = "error.comment.not_found"
 	CommentCannotEditAfterDeadline   = "error.comment.cannot_edit_after_deadline"
+	CommentCannotConvert             = "error.comment.cannot_convert"
 	QuestionNotFound                 = "error.question.not_found"
 	QuestionCannotDeleted            = "error.question.cannot_deleted"
 	QuestionCannotClose              = "error.question.cannot_close"
 	QuestionCannotUpdate             = "error.question.cannot_update"
 	QuestionAlreadyDeleted           = "error.question.already_deleted"
 	QuestionUnderReview              = "error.question.under_review"
+	QuestionDuplicateInvalid         = "error.question.duplicate_invalid"
+	QuestionMergeInvalid             = "error.question.merge_invalid"
+	QuestionCloseVoteInvalid         = "error.question.close_vote_invalid"
+	QuestionCloseVoteAlreadyCast     = "error.question.close_vote_already_cast"
 	AnswerNotFound                   = "error.answer.not_found"
 	AnswerCannotDeleted              = "error.answer.cannot_deleted"
 	AnswerCannotUpdate               = "error.answer.cannot_update"
 	AnswerCannotAddByClosedQuestion  = "error.answer.question_closed_cannot_add"
 	AnswerRestrictAnswer             = "error.answer.restrict_answer"
+	AnswerCannotConvert              = "error.answer.cannot_convert"
 	CommentEditWithoutPermission     = "error.comment.edit_without_permission"
 	DisallowVote                     = "error.object.disallow_vote"
 	DisallowFollow                   = "error.object.disallow_follow"
@@ -92,6 +98,7 @@ const (
 	RecommendTagEnter                = "error.tag.recommend_tag_enter"
 	RevisionReviewUnderway           = "error.revision.review_underway"
 	RevisionNoPermission             = "error.revision.no_permission"
+	RevisionConflict                 = "error.revision.conflict"
 	UserCannotUpdateYourRole         = "error.user.cannot_update_your_role"
 	TagCannotSetSynonymAsItself      = "error.tag.cannot_set_synonym_as_itself"
 	NotAllowedRegistration           = "error.user.not_allowed_registration"
@@ -108,6 +115,32 @@ const (
 	MetaObjectNotFound               = "error.meta.object_not_found"
 	BadgeObjectNotFound              = "error.badge.object_not_found"
 	StatusInvalid                    = "error.common.status_invalid"
+	WebhookNotFound                  = "error.webhook.not_found"
+	WebhookEventTypeInvalid          = "error.webhook.event_type_invalid"
+	UserAccessTokenNotFound          = "error.user_access_token.not_found"
+	UserAccessTokenScopeNotAllowed   = "error.user_access_token.scope_not_allowed"
+	SavedSearchNotFound              = "error.saved_search.not_found"
+	SavedSearchTooMany               = "error.saved_search.too_many"
+	EmailLogNotFound                 = "error.email_log.not_found"
+	BountyNotFound                   = "error.bounty.not_found"
+	BountyAlreadyExist               = "error.bounty.already_exist"
+	BountyAmountInvalid              = "error.bounty.amount_invalid"
+	BountyRankNotEnough              = "error.bounty.rank_not_enough"
+	BountyCannotAwardYourself        = "error.bounty.cannot_award_yourself"
+	BountyAnswerNotEligible          = "error.bounty.answer_not_eligible"
+	BountyQuestionNotOpen            = "error.bounty.question_not_open"
+	DraftNotFound                    = "error.draft.not_found"
+	DraftTooMany                     = "error.draft.too_many"
+	DraftQuestionRequired            = "error.draft.question_required"
+	RoleNotFound                     = "error.role.not_found"
+	RoleNameDuplicate                = "error.role.name_duplicate"
+	RoleBuiltInCannotModify          = "error.role.built_in_cannot_modify"
+	RoleInUse                        = "error.role.in_use"
+	RolePowerNotFound                = "error.role.power_not_found"
+	UserSuspendReasonInvalid         = "error.user.suspend_reason_invalid"
+	ModeratorMessageNotFound         = "error.moderator_message.not_found"
+	ModeratorMessageTemplateInvalid  = "error.moderator_message.template_invalid"
+	ModeratorMessageUnacknowledged   = "error.moderator_message.unacknowledged"
 )
 
 // user external login reasons
@@ -22,6 +22,9 @@ package server
 // HTTP http config
 type HTTP struct {
 	Addr string `json:"addr" mapstructure:"addr"`
+	// the client ip is only read from the X-Forwarded-For and X-Real-IP headers sent by these proxies,
+	// such as the reverse proxy in front of the site, no proxy is trusted by default
+	TrustedProxies []string `json:"trusted_proxies" mapstructure:"trusted_proxies" yaml:"trusted_proxies"`
 }
 
 // UI ui config
@@ -40,6 +40,7 @@ func NewHTTPServer(debug bool,
 	authUserMiddleware *middleware.AuthUserMiddleware,
 	avatarMiddleware *middleware.AvatarMiddleware,
 	shortIDMiddleware *middleware.ShortIDMiddleware,
+	moderatorMessageMiddleware *middleware.ModeratorMessageMiddleware,
 	templateRouter *router.TemplateRouter,
 	pluginAPIRouter *router.PluginAPIRouter,
 	uiConf *UI,
@@ -51,7 +52,8 @@ func NewHTTPServer(debug bool,
 		gin.SetMode(gin.ReleaseMode)
 	}
 	r := gin.New()
-	r.Use(brotli.Brotli(brotli.DefaultCompression), middleware.ExtractAndSetAcceptLanguage, shortIDMiddleware.SetShortIDFlag())
+	r.Use(brotli.Brotli(brotli.DefaultCompression), middleware.ExtractAndSetAcceptLanguage, middleware.ExtractAndSetClientIP,
+		shortIDMiddleware.SetShortIDFlag())
 	r.GET("/healthz", func(ctx *gin.Context) { ctx.String(200, "OK") })
 
 	html, _ := fs.Sub(ui.Template, "template")
@@ -83,7 +85,7 @@ func NewHTTPServer(debug bool,
 	// register api that must be authenticated
 	authV1 := r.Group("/answer/api/v1")
 	authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable())
-	answerRouter.RegisterAnswerAPIRouter(authV1)
+	answerRouter.RegisterAnswerAPIRouter(moderatorMessageMiddleware, authV1)
 
 	adminauthV1 := r.Group("/answer/admin/api")
 	adminauthV1.Use(authUserMiddleware.AdminAuth())
@@ -47,6 +47,7 @@ type AnswerController struct {
 	actionService         *action.CaptchaService
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
 	rateLimitMiddleware   *middleware.RateLimitMiddleware
+	postConvertService    *content.PostConvertService
 }
 
 // NewAnswerController new controller
@@ -56,6 +57,7 @@ func NewAnswerController(
 	actionService *action.CaptchaService,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	postConvertService *content.PostConvertService,
 ) *AnswerController {
 	return &AnswerController{
 		answerService:         answerService,
@@ -63,6 +65,7 @@ func NewAnswerController(
 		actionService:         actionService,
 		siteInfoCommonService: siteInfoCommonService,
 		rateLimitMiddleware:   rateLimitMiddleware,
+		postConvertService:    postConvertService,
 	}
 }
 
@@ -97,9 +100,9 @@ func (ac *AnswerController) RemoveAnswer(ctx *gin.Context) {
 	}
 
 	objectOwner := ac.rankService.CheckOperationObjectOwner(ctx, req.UserID, req.ID)
-	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := ac.rankService.CheckOperationPermissionsForObject(ctx, req.UserID, []string{
 		permission.AnswerDelete,
-	})
+	}, req.ID)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -135,9 +138,9 @@ func (ac *AnswerController) RecoverAnswer(ctx *gin.Context) {
 	req.AnswerID = uid.DeShortID(req.AnswerID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 
-	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := ac.rankService.CheckOperationPermissionsForObject(ctx, req.UserID, []string{
 		permission.AnswerUnDelete,
-	})
+	}, req.AnswerID)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -313,11 +316,12 @@ func (ac *AnswerController) Update(ctx *gin.Context) {
 	}
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 
-	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := ac.rankService.CheckOperationPermissionsForObject(ctx, req.UserID, []string{
 		permission.AnswerEdit,
 		permission.AnswerEditWithoutReview,
 		permission.LinkUrlLimit,
-	})
+		permission.AnswerWikiEdit,
+	}, req.ID)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -338,13 +342,23 @@ func (ac *AnswerController) Update(ctx *gin.Context) {
 	}
 
 	objectOwner := ac.rankService.CheckOperationObjectOwner(ctx, req.UserID, req.ID)
-	req.CanEdit = canList[0] || objectOwner
+	req.CanEdit = canList[0] || objectOwner || (canList[3] && ac.rankService.CheckOperationObjectWiki(ctx, req.ID))
 	req.NoNeedReview = canList[1] || objectOwner
 	if !req.CanEdit {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
 
+	conflict, err := ac.answerService.CheckEditConflict(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if conflict != nil {
+		handler.HandleResponse(ctx, errors.Conflict(reason.RevisionConflict), conflict)
+		return
+	}
+
 	_, err = ac.answerService.Update(ctx, req)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -383,11 +397,14 @@ func (ac *AnswerController) AnswerList(ctx *gin.Context) {
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 	req.QuestionID = uid.DeShortID(req.QuestionID)
 
-	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := ac.rankService.CheckOperationPermissionsForObject(ctx, req.UserID, []string{
 		permission.AnswerEdit,
 		permission.AnswerDelete,
 		permission.AnswerUnDelete,
-	})
+		permission.AnswerWiki,
+		permission.AnswerWikiEdit,
+		permission.AnswerConvert,
+	}, req.QuestionID)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -395,6 +412,9 @@ func (ac *AnswerController) AnswerList(ctx *gin.Context) {
 	req.CanEdit = canList[0]
 	req.CanDelete = canList[1]
 	req.CanRecover = canList[2]
+	req.CanWiki = canList[3]
+	req.CanWikiEdit = canList[4]
+	req.CanConvert = canList[5]
 
 	list, count, err := ac.answerService.SearchList(ctx, req)
 	if err != nil {
@@ -440,6 +460,70 @@ func (ac *AnswerController) Accepted(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// OperationAnswer make the answer community wiki or not
+// @Summary make the answer community wiki or not
+// @Description Operation answer \n operation [wiki unwiki]
+// @Tags api-answer
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body schema.OperationAnswerReq  true "OperationAnswerReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/answer/operation [put]
+func (ac *AnswerController) OperationAnswer(ctx *gin.Context) {
+	req := &schema.OperationAnswerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.ID = uid.DeShortID(req.ID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := ac.rankService.CheckOperationPermission(ctx, req.UserID, permission.AnswerWiki, req.ID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	err = ac.answerService.OperationAnswer(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ConvertAnswerToComment convert answer to comment
+// @Summary convert the answer into a comment on the question
+// @Description convert the answer without comments into a comment on the question, the author, created time and up votes are kept
+// @Tags api-answer
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param data body schema.ConvertAnswerToCommentReq  true "ConvertAnswerToCommentReq"
+// @Success 200 {object} handler.RespBody{data=schema.ConvertAnswerToCommentResp}
+// @Router /answer/api/v1/answer/convert [post]
+func (ac *AnswerController) ConvertAnswerToComment(ctx *gin.Context) {
+	req := &schema.ConvertAnswerToCommentReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.AnswerID = uid.DeShortID(req.AnswerID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := ac.rankService.CheckOperationPermission(ctx, req.UserID, permission.AnswerConvert, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	resp, err := ac.postConvertService.ConvertAnswerToComment(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // AdminUpdateAnswerStatus update answer status
 // @Summary update answer status
 // @Description update answer status
@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/bounty"
+	"github.com/gin-gonic/gin"
+)
+
+// BountyController question bounty controller
+type BountyController struct {
+	bountyService *bounty.BountyService
+}
+
+// NewBountyController new controller
+func NewBountyController(bountyService *bounty.BountyService) *BountyController {
+	return &BountyController{bountyService: bountyService}
+}
+
+// GetBounty get the active bounty of the question
+// @Summary get the active bounty of the question
+// @Description get the active bounty of the question
+// @Tags Question
+// @Produce json
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=schema.GetBountyResp}
+// @Router /answer/api/v1/question/bounty [get]
+func (bc *BountyController) GetBounty(ctx *gin.Context) {
+	req := &schema.GetBountyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := bc.bountyService.GetBounty(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddBounty offer a bounty on the question
+// @Summary offer a bounty on the question
+// @Description offer a bounty on the question, the reputation of the user will be escrowed until the bounty is finished
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddBountyReq true "bounty"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/bounty [post]
+func (bc *BountyController) AddBounty(ctx *gin.Context) {
+	req := &schema.AddBountyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := bc.bountyService.AddBounty(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AwardBounty award the bounty to an answer
+// @Summary award the bounty to an answer
+// @Description only the user who offered the bounty can award it
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AwardBountyReq true "bounty"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/bounty/award [put]
+func (bc *BountyController) AwardBounty(ctx *gin.Context) {
+	req := &schema.AwardBountyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := bc.bountyService.AwardBounty(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/comment"
+	"github.com/apache/incubator-answer/internal/service/content"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -43,6 +44,7 @@ type CommentController struct {
 	rankService         *rank.RankService
 	actionService       *action.CaptchaService
 	rateLimitMiddleware *middleware.RateLimitMiddleware
+	postConvertService  *content.PostConvertService
 }
 
 // NewCommentController new controller
@@ -51,12 +53,14 @@ func NewCommentController(
 	rankService *rank.RankService,
 	actionService *action.CaptchaService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	postConvertService *content.PostConvertService,
 ) *CommentController {
 	return &CommentController{
 		commentService:      commentService,
 		rankService:         rankService,
 		actionService:       actionService,
 		rateLimitMiddleware: rateLimitMiddleware,
+		postConvertService:  postConvertService,
 	}
 }
 
@@ -225,6 +229,36 @@ func (cc *CommentController) UpdateComment(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// ConvertCommentToAnswer convert comment to answer
+// @Summary convert the comment into an answer of the question
+// @Description convert the comment on the question or its answers into an answer, the author, created time and up votes are kept
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ConvertCommentToAnswerReq true "comment"
+// @Success 200 {object} handler.RespBody{data=schema.ConvertCommentToAnswerResp}
+// @Router /answer/api/v1/comment/convert [post]
+func (cc *CommentController) ConvertCommentToAnswer(ctx *gin.Context) {
+	req := &schema.ConvertCommentToAnswerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := cc.rankService.CheckOperationPermission(ctx, req.UserID, permission.CommentConvert, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	resp, err := cc.postConvertService.ConvertCommentToAnswer(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetCommentWithPage get comment page
 // @Summary get comment page
 // @Description get comment page
@@ -234,6 +268,8 @@ func (cc *CommentController) UpdateComment(ctx *gin.Context) {
 // @Param page_size query int false "page size"
 // @Param object_id query string true "object id"
 // @Param query_cond query string false "query condition" Enums(vote)
+// @Param mode query string false "response mode, the replies are nested in the children of the comment in tree mode" Enums(flat, tree)
+// @Param max_depth query int false "the max depth of the comment tree, default is 3"
 // @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetCommentResp}}
 // @Router /answer/api/v1/comment/page [get]
 func (cc *CommentController) GetCommentWithPage(ctx *gin.Context) {
@@ -246,6 +282,7 @@ func (cc *CommentController) GetCommentWithPage(ctx *gin.Context) {
 	canList, err := cc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
 		permission.CommentEdit,
 		permission.CommentDelete,
+		permission.CommentConvert,
 	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -253,6 +290,7 @@ func (cc *CommentController) GetCommentWithPage(ctx *gin.Context) {
 	}
 	req.CanEdit = canList[0]
 	req.CanDelete = canList[1]
+	req.CanConvert = canList[2]
 
 	resp, err := cc.commentService.GetCommentWithPage(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
@@ -53,4 +53,9 @@ var ProviderSetController = wire.NewSet(
 	NewEmbedController,
 	NewBadgeController,
 	NewRenderController,
+	NewUserAccessTokenController,
+	NewSavedSearchController,
+	NewDraftController,
+	NewBountyController,
+	NewModeratorMessageController,
 )
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/draft"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+)
+
+// DraftController draft controller
+type DraftController struct {
+	draftService *draft.DraftService
+}
+
+// NewDraftController new controller
+func NewDraftController(
+	draftService *draft.DraftService,
+) *DraftController {
+	return &DraftController{draftService: draftService}
+}
+
+// GetDraftList get drafts of the current user
+// @Summary get drafts of the current user
+// @Description get drafts of the current user, the latest updated first
+// @Tags Draft
+// @Produce json
+// @Security ApiKeyAuth
+// @Param object_type query string false "object type" Enums(question, answer)
+// @Success 200 {object} handler.RespBody{data=[]schema.DraftInfo}
+// @Router /answer/api/v1/user/drafts [get]
+func (dc *DraftController) GetDraftList(ctx *gin.Context) {
+	req := &schema.GetDraftListReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := dc.draftService.GetDraftList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetDraft get the draft
+// @Summary get the draft
+// @Description get the draft by id, or the answer draft of the question
+// @Tags Draft
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query int false "draft id"
+// @Param question_id query string false "question id"
+// @Success 200 {object} handler.RespBody{data=schema.DraftInfo}
+// @Router /answer/api/v1/user/draft [get]
+func (dc *DraftController) GetDraft(ctx *gin.Context) {
+	req := &schema.GetDraftReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if len(req.QuestionID) > 0 {
+		req.QuestionID = uid.DeShortID(req.QuestionID)
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := dc.draftService.GetDraft(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// SaveDraft autosave the draft
+// @Summary autosave the draft
+// @Description autosave the draft of question or answer, create a new one if the id is empty
+// @Tags Draft
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SaveDraftReq true "draft"
+// @Success 200 {object} handler.RespBody{data=schema.SaveDraftResp}
+// @Router /answer/api/v1/user/draft [put]
+func (dc *DraftController) SaveDraft(ctx *gin.Context) {
+	req := &schema.SaveDraftReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if len(req.QuestionID) > 0 {
+		req.QuestionID = uid.DeShortID(req.QuestionID)
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := dc.draftService.SaveDraft(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveDraft remove the draft
+// @Summary remove the draft
+// @Description remove the draft
+// @Tags Draft
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveDraftReq true "draft"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/draft [delete]
+func (dc *DraftController) RemoveDraft(ctx *gin.Context) {
+	req := &schema.RemoveDraftReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := dc.draftService.RemoveDraft(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
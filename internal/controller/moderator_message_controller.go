@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/moderator_message"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// ModeratorMessageController moderator message controller
+type ModeratorMessageController struct {
+	moderatorMessageService *moderator_message.ModeratorMessageService
+	rankService             *rank.RankService
+}
+
+// NewModeratorMessageController new controller
+func NewModeratorMessageController(
+	moderatorMessageService *moderator_message.ModeratorMessageService,
+	rankService *rank.RankService,
+) *ModeratorMessageController {
+	return &ModeratorMessageController{
+		moderatorMessageService: moderatorMessageService,
+		rankService:             rankService,
+	}
+}
+
+// AddModeratorMessage send a private moderator message to the user
+// @Summary send a private moderator message to the user
+// @Description send a private moderator message to the user, the user will be notified in app and by email
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddModeratorMessageReq true "moderator message"
+// @Success 200 {object} handler.RespBody{data=schema.AddModeratorMessageResp}
+// @Router /answer/api/v1/moderator-message [post]
+func (mc *ModeratorMessageController) AddModeratorMessage(ctx *gin.Context) {
+	req := &schema.AddModeratorMessageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := mc.rankService.CheckOperationPermission(ctx, req.LoginUserID, permission.UserModeratorMessage, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	resp, err := mc.moderatorMessageService.AddModeratorMessage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetUserModeratorMessageList get the moderator messages received by the login user
+// @Summary get the moderator messages received by the login user
+// @Description get the moderator messages received by the login user, the latest first
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Param unacknowledged query bool false "only show the messages which are not acknowledged"
+// @Success 200 {object} handler.RespBody{data=[]schema.ModeratorMessageResp}
+// @Router /answer/api/v1/user/moderator-messages [get]
+func (mc *ModeratorMessageController) GetUserModeratorMessageList(ctx *gin.Context) {
+	req := &schema.GetUserModeratorMessageListReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := mc.moderatorMessageService.GetUserModeratorMessageList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AcknowledgeModeratorMessage acknowledge the moderator message
+// @Summary acknowledge the moderator message
+// @Description acknowledge the moderator message received by the login user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AcknowledgeModeratorMessageReq true "moderator message"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/moderator-message/acknowledge [put]
+func (mc *ModeratorMessageController) AcknowledgeModeratorMessage(ctx *gin.Context) {
+	req := &schema.AcknowledgeModeratorMessageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := mc.moderatorMessageService.AcknowledgeModeratorMessage(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
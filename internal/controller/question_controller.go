@@ -119,7 +119,7 @@ func (qc *QuestionController) RemoveQuestion(ctx *gin.Context) {
 
 // OperationQuestion Operation question
 // @Summary Operation question
-// @Description Operation question \n operation [pin unpin hide show]
+// @Description Operation question \n operation [pin unpin hide show wiki unwiki]
 // @Tags Question
 // @Accept json
 // @Produce json
@@ -154,6 +154,17 @@ func (qc *QuestionController) OperationQuestion(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	if req.Operation == schema.QuestionOperationWiki || req.Operation == schema.QuestionOperationUnWiki {
+		req.CanWiki, err = qc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionWiki, req.ID)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
+		if !req.CanWiki {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+			return
+		}
+	}
 	err = qc.questionService.OperationQuestion(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
@@ -166,7 +177,7 @@ func (qc *QuestionController) OperationQuestion(ctx *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param data body schema.CloseQuestionReq true "question"
-// @Success 200 {object} handler.RespBody
+// @Success 200 {object} handler.RespBody{data=schema.QuestionCloseVoteResp}
 // @Router  /answer/api/v1/question/status [put]
 func (qc *QuestionController) CloseQuestion(ctx *gin.Context) {
 	req := &schema.CloseQuestionReq{}
@@ -175,18 +186,21 @@ func (qc *QuestionController) CloseQuestion(ctx *gin.Context) {
 	}
 	req.ID = uid.DeShortID(req.ID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	can, err := qc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionClose, "")
+	canList, err := qc.rankService.CheckOperationPermissionsForObject(ctx, req.UserID, []string{
+		permission.QuestionClose,
+	}, req.ID)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	if !can {
+	if !canList[0] {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	req.BindingVote = qc.rankService.CheckModeratorPermissionForObject(ctx, req.UserID, permission.QuestionClose, req.ID)
 
-	err = qc.questionService.CloseQuestion(ctx, req)
-	handler.HandleResponse(ctx, err, nil)
+	resp, err := qc.questionService.VoteToCloseQuestion(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
 }
 
 // ReopenQuestion reopen question
@@ -197,7 +211,7 @@ func (qc *QuestionController) CloseQuestion(ctx *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param data body schema.ReopenQuestionReq true "question"
-// @Success 200 {object} handler.RespBody
+// @Success 200 {object} handler.RespBody{data=schema.QuestionCloseVoteResp}
 // @Router /answer/api/v1/question/reopen [put]
 func (qc *QuestionController) ReopenQuestion(ctx *gin.Context) {
 	req := &schema.ReopenQuestionReq{}
@@ -206,7 +220,124 @@ func (qc *QuestionController) ReopenQuestion(ctx *gin.Context) {
 	}
 	req.QuestionID = uid.DeShortID(req.QuestionID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	can, err := qc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionReopen, "")
+	canList, err := qc.rankService.CheckOperationPermissionsForObject(ctx, req.UserID, []string{
+		permission.QuestionReopen,
+	}, req.QuestionID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !canList[0] {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+	req.BindingVote = qc.rankService.CheckModeratorPermissionForObject(ctx, req.UserID, permission.QuestionReopen,
+		req.QuestionID)
+
+	resp, err := qc.questionService.VoteToReopenQuestion(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetCloseVoteQueuePage get the questions with the pending votes to close or reopen
+// @Summary get the questions with the pending votes to close or reopen
+// @Description get the questions with the pending votes to close or reopen, the latest voted first
+// @Tags Question
+// @Produce json
+// @Security ApiKeyAuth
+// @Param vote_type query string true "vote type" Enums(close, reopen)
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetCloseVoteQueueResp}}
+// @Router /answer/api/v1/question/close-votes/page [get]
+func (qc *QuestionController) GetCloseVoteQueuePage(ctx *gin.Context) {
+	req := &schema.GetCloseVoteQueuePageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	action := permission.QuestionClose
+	if req.VoteType == schema.CloseVoteTypeReopen {
+		action = permission.QuestionReopen
+	}
+	canList, err := qc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{action})
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !canList[0] {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	resp, err := qc.questionService.GetCloseVoteQueuePage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// DismissCloseVotes dismiss the pending votes of the question
+// @Summary dismiss the pending votes of the question
+// @Description the moderator keeps the question status and dismisses the pending votes to close or reopen it
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.DismissCloseVotesReq true "close votes"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/close-votes/dismiss [put]
+func (qc *QuestionController) DismissCloseVotes(ctx *gin.Context) {
+	req := &schema.DismissCloseVotesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	action := permission.QuestionClose
+	if req.VoteType == schema.CloseVoteTypeReopen {
+		action = permission.QuestionReopen
+	}
+	if !qc.rankService.CheckModeratorPermissionForObject(ctx, req.UserID, action, req.QuestionID) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	err := qc.questionService.DismissCloseVotes(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetQuestionLinks get the linked questions
+// @Summary get the duplicate and merged questions linked with the question
+// @Description get the duplicate and merged questions linked with the question
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=[]schema.QuestionLinkInfo}
+// @Router /answer/api/v1/question/links [get]
+func (qc *QuestionController) GetQuestionLinks(ctx *gin.Context) {
+	req := &schema.GetQuestionLinksReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := qc.questionService.GetQuestionLinks(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// MergeQuestion merge question
+// @Summary merge the source question into the target question
+// @Description move answers, comments, votes and followers to the target question and close the source question
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.MergeQuestionReq true "merge question"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/merge [post]
+func (qc *QuestionController) MergeQuestion(ctx *gin.Context) {
+	req := &schema.MergeQuestionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := qc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionMerge, "")
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -216,7 +347,7 @@ func (qc *QuestionController) ReopenQuestion(ctx *gin.Context) {
 		return
 	}
 
-	err = qc.questionService.ReopenQuestion(ctx, req)
+	err = qc.questionService.MergeQuestion(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -235,7 +366,7 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	id = uid.DeShortID(id)
 	userID := middleware.GetLoginUserIDFromContext(ctx)
 	req := schema.QuestionPermission{}
-	canList, err := qc.rankService.CheckOperationPermissions(ctx, userID, []string{
+	canList, err := qc.rankService.CheckOperationPermissionsForObject(ctx, userID, []string{
 		permission.QuestionEdit,
 		permission.QuestionDelete,
 		permission.QuestionClose,
@@ -246,7 +377,9 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 		permission.QuestionShow,
 		permission.AnswerInviteSomeoneToAnswer,
 		permission.QuestionUnDelete,
-	})
+		permission.QuestionWiki,
+		permission.QuestionWikiEdit,
+	}, id)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -263,6 +396,8 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	req.CanShow = canList[7]
 	req.CanInviteOtherToAnswer = canList[8]
 	req.CanRecover = canList[9]
+	req.CanWiki = canList[10]
+	req.CanWikiEdit = canList[11]
 
 	info, err := qc.questionService.GetQuestionAndAddPV(ctx, id, userID, req)
 	if err != nil {
@@ -633,6 +768,7 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 		permission.TagUseReservedTag,
 		permission.TagAdd,
 		permission.LinkUrlLimit,
+		permission.QuestionWikiEdit,
 	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -653,9 +789,12 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 	}
 
 	objectOwner := qc.rankService.CheckOperationObjectOwner(ctx, req.UserID, req.ID)
-	req.CanEdit = canList[0] || objectOwner
-	req.CanDelete = canList[1]
-	req.NoNeedReview = canList[2] || objectOwner
+	// the tag moderators edit the question without review
+	tagModerator := qc.rankService.CheckTagModeratorPermission(ctx, req.UserID, permission.QuestionEdit, req.ID)
+	req.CanEdit = canList[0] || objectOwner || tagModerator ||
+		(canList[6] && qc.rankService.CheckOperationObjectWiki(ctx, req.ID))
+	req.CanDelete = canList[1] || tagModerator
+	req.NoNeedReview = canList[2] || objectOwner || tagModerator
 	req.CanUseReservedTag = canList[3]
 	req.CanAddTag = canList[4]
 	if !req.CanEdit {
@@ -686,6 +825,16 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 		return
 	}
 
+	conflict, err := qc.questionService.CheckEditConflict(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if conflict != nil {
+		handler.HandleResponse(ctx, errors.Conflict(reason.RevisionConflict), conflict)
+		return
+	}
+
 	resp, err := qc.questionService.UpdateQuestion(ctx, req)
 	if err != nil {
 		handler.HandleResponse(ctx, err, resp)
@@ -720,9 +869,9 @@ func (qc *QuestionController) QuestionRecover(ctx *gin.Context) {
 	req.QuestionID = uid.DeShortID(req.QuestionID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 
-	canList, err := qc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, err := qc.rankService.CheckOperationPermissionsForObject(ctx, req.UserID, []string{
 		permission.QuestionUnDelete,
-	})
+	}, req.QuestionID)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -25,6 +25,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
+	"github.com/apache/incubator-answer/internal/service/permission"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/plugin"
@@ -102,11 +103,99 @@ func (rc *ReviewController) UpdateReview(ctx *gin.Context) {
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
-	if !req.IsAdmin {
-		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+
+	err := rc.reviewService.UpdateReview(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// reviewTaskQueuePowers the power required to review the posts in the queue
+var reviewTaskQueuePowers = map[string]string{
+	schema.ReviewTaskQueueFirstPost:  permission.ReviewFirstPost,
+	schema.ReviewTaskQueueLateAnswer: permission.ReviewLateAnswer,
+	schema.ReviewTaskQueueLowQuality: permission.ReviewLowQuality,
+}
+
+// GetReviewTaskPage get the pending review tasks of the queue
+// @Summary get the pending review tasks of the queue
+// @Description get the pending review tasks of the first posts, late answers or low quality queue
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param queue query string true "queue" Enums(first_post, late_answer, low_quality)
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetReviewTaskPageResp}}
+// @Router /answer/api/v1/review/tasks/page [get]
+func (rc *ReviewController) GetReviewTaskPage(ctx *gin.Context) {
+	req := &schema.GetReviewTaskPageReq{}
+	if handler.BindAndCheck(ctx, req) {
 		return
 	}
 
-	err := rc.reviewService.UpdateReview(ctx, req)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := rc.rankService.CheckOperationPermission(ctx, req.UserID, reviewTaskQueuePowers[req.Queue], "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	resp, err := rc.reviewService.GetReviewTaskPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateReviewTask complete the review task
+// @Summary complete the review task
+// @Description complete the review task with the review reason as the outcome
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateReviewTaskReq true "review task"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/review/task [put]
+func (rc *ReviewController) UpdateReviewTask(ctx *gin.Context) {
+	req := &schema.UpdateReviewTaskReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := rc.rankService.CheckOperationPermission(ctx, req.UserID, reviewTaskQueuePowers[req.Queue], "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	err = rc.reviewService.UpdateReviewTask(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// AdminGetReviewTaskStats get the statistics of the review queues
+// @Summary get the statistics of the review queues
+// @Description get the outcomes of the review queues and the most active reviewers in the time range
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param start_time query int false "start time, unix timestamp"
+// @Param end_time query int false "end time, unix timestamp"
+// @Success 200 {object} handler.RespBody{data=schema.GetReviewTaskStatsResp}
+// @Router /answer/admin/api/review/tasks/stats [get]
+func (rc *ReviewController) AdminGetReviewTaskStats(ctx *gin.Context) {
+	req := &schema.GetReviewTaskStatsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := rc.reviewService.GetReviewTaskStats(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
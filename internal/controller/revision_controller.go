@@ -67,6 +67,10 @@ func (rc *RevisionController) GetRevisionList(ctx *gin.Context) {
 		return
 	}
 	objectID = uid.DeShortID(objectID)
+	if !rc.canViewRevisions(ctx, objectID) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
 	req := &schema.GetRevisionListReq{
 		ObjectID: objectID,
 	}
@@ -81,6 +85,73 @@ func (rc *RevisionController) GetRevisionList(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, list)
 }
 
+// GetContributors godoc
+// @Summary get the contributors of the post
+// @Description get the users who edited the post, aggregated from the revision history
+// @Tags Revision
+// @Produce json
+// @Param object_id query string true "object id"
+// @Success 200 {object} handler.RespBody{data=[]schema.GetContributorsResp}
+// @Router /answer/api/v1/revisions/contributors [get]
+func (rc *RevisionController) GetContributors(ctx *gin.Context) {
+	req := &schema.GetContributorsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	if !rc.canViewRevisions(ctx, req.ObjectID) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	canViewDeleted, err := rc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionReopen, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanViewDeleted = canViewDeleted
+
+	resp, err := rc.revisionListService.GetContributors(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// canViewRevisions the edit history of comments is only visible to the moderators
+func (rc *RevisionController) canViewRevisions(ctx *gin.Context, objectID string) bool {
+	objectType, _ := obj.GetObjectTypeStrByObjectID(objectID)
+	if objectType != constant.CommentObjectType {
+		return true
+	}
+	return middleware.GetUserIsAdminModerator(ctx)
+}
+
+// GetRevisionDiff godoc
+// @Summary get the diff between two revisions
+// @Description get the word level and line level diff between two revisions of the same question, answer, tag or comment,
+// @Description the title, tags and content are compared separately and rendered as both json operations and html
+// @Tags Revision
+// @Produce json
+// @Param source_id query string true "the old revision id"
+// @Param target_id query string true "the new revision id"
+// @Success 200 {object} handler.RespBody{data=schema.GetRevisionDiffResp}
+// @Router /answer/api/v1/revisions/diff [get]
+func (rc *RevisionController) GetRevisionDiff(ctx *gin.Context) {
+	req := &schema.GetRevisionDiffReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.CanReview = middleware.GetUserIsAdminModerator(ctx)
+	canViewDeleted, err := rc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionReopen, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanViewDeleted = canViewDeleted
+
+	resp, err := rc.revisionListService.GetRevisionDiff(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetUnreviewedRevisionList godoc
 // @Summary get unreviewed revision list
 // @Description get unreviewed revision list
@@ -163,14 +234,14 @@ func (rc *RevisionController) CheckCanUpdateRevision(ctx *gin.Context) {
 	}
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 
-	action := ""
+	action, wikiAction := "", ""
 	req.ID = uid.DeShortID(req.ID)
 	objectTypeStr, _ := obj.GetObjectTypeStrByObjectID(req.ID)
 	switch objectTypeStr {
 	case constant.QuestionObjectType:
-		action = permission.QuestionEdit
+		action, wikiAction = permission.QuestionEdit, permission.QuestionWikiEdit
 	case constant.AnswerObjectType:
-		action = permission.AnswerEdit
+		action, wikiAction = permission.AnswerEdit, permission.AnswerWikiEdit
 	case constant.TagObjectType:
 		action = permission.TagEdit
 	default:
@@ -183,6 +254,13 @@ func (rc *RevisionController) CheckCanUpdateRevision(ctx *gin.Context) {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
+	if !can && len(wikiAction) > 0 && rc.rankService.CheckOperationObjectWiki(ctx, req.ID) {
+		can, err = rc.rankService.CheckOperationPermission(ctx, req.UserID, wikiAction, "")
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
+	}
 	if !can {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
@@ -207,6 +285,11 @@ func (rc *RevisionController) GetReviewingType(ctx *gin.Context) {
 		permission.QuestionAudit,
 		permission.AnswerAudit,
 		permission.TagAudit,
+		permission.QuestionClose,
+		permission.QuestionReopen,
+		permission.ReviewFirstPost,
+		permission.ReviewLateAnswer,
+		permission.ReviewLowQuality,
 	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -215,6 +298,17 @@ func (rc *RevisionController) GetReviewingType(ctx *gin.Context) {
 	req.CanReviewQuestion = canList[0]
 	req.CanReviewAnswer = canList[1]
 	req.CanReviewTag = canList[2]
+	req.CanCloseQuestion = canList[3]
+	req.CanReopenQuestion = canList[4]
+	for idx, queue := range []string{
+		schema.ReviewTaskQueueFirstPost,
+		schema.ReviewTaskQueueLateAnswer,
+		schema.ReviewTaskQueueLowQuality,
+	} {
+		if canList[5+idx] {
+			req.ReviewTaskQueues = append(req.ReviewTaskQueues, queue)
+		}
+	}
 	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
 
 	resp, err := rc.revisionListService.GetReviewingType(ctx, req)
@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/saved_search"
+	"github.com/gin-gonic/gin"
+)
+
+// SavedSearchController saved search controller
+type SavedSearchController struct {
+	savedSearchService *saved_search.SavedSearchService
+}
+
+// NewSavedSearchController new controller
+func NewSavedSearchController(
+	savedSearchService *saved_search.SavedSearchService,
+) *SavedSearchController {
+	return &SavedSearchController{savedSearchService: savedSearchService}
+}
+
+// GetSavedSearchList get saved searches of the current user
+// @Summary get saved searches of the current user
+// @Description get saved searches of the current user
+// @Tags Search
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.SavedSearchInfo}
+// @Router /answer/api/v1/user/saved-searches [get]
+func (sc *SavedSearchController) GetSavedSearchList(ctx *gin.Context) {
+	req := &schema.GetSavedSearchListReq{}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := sc.savedSearchService.GetSavedSearchList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddSavedSearch save a search query
+// @Summary save a search query
+// @Description save a search query, the new matched questions will be notified
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddSavedSearchReq true "saved search"
+// @Success 200 {object} handler.RespBody{data=schema.SavedSearchInfo}
+// @Router /answer/api/v1/user/saved-search [post]
+func (sc *SavedSearchController) AddSavedSearch(ctx *gin.Context) {
+	req := &schema.AddSavedSearchReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := sc.savedSearchService.AddSavedSearch(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSavedSearch update the saved search
+// @Summary update the saved search
+// @Description update the saved search
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateSavedSearchReq true "saved search"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/saved-search [put]
+func (sc *SavedSearchController) UpdateSavedSearch(ctx *gin.Context) {
+	req := &schema.UpdateSavedSearchReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := sc.savedSearchService.UpdateSavedSearch(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveSavedSearch remove the saved search
+// @Summary remove the saved search
+// @Description remove the saved search
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveSavedSearchReq true "saved search"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/saved-search [delete]
+func (sc *SavedSearchController) RemoveSavedSearch(ctx *gin.Context) {
+	req := &schema.RemoveSavedSearchReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := sc.savedSearchService.RemoveSavedSearch(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
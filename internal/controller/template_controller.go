@@ -41,6 +41,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/display"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/obj"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -314,6 +315,18 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	}
 
 	siteInfo := tc.SiteInfo(ctx)
+	// the question has been merged into another question, move to the target permanently
+	duplicates := make([]*schema.QuestionLinkInfo, 0)
+	for _, link := range detail.LinkedQuestions {
+		switch link.Relation {
+		case schema.QuestionRelationMergedInto:
+			mergedURL := display.QuestionURL(siteInfo.SiteSeo.Permalink, siteInfo.General.SiteUrl, link.ID, link.Title)
+			ctx.Redirect(http.StatusMovedPermanently, mergedURL)
+			return
+		case schema.QuestionRelationDuplicateOf:
+			duplicates = append(duplicates, link)
+		}
+	}
 	jump, jumpurl := tc.QuestionInfoeRdirect(ctx, siteInfo, correctTitle)
 	if jump {
 		ctx.Redirect(http.StatusFound, jumpurl)
@@ -403,12 +416,13 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	siteInfo.Keywords = strings.Replace(strings.Trim(fmt.Sprint(tags), "[]"), " ", ",", -1)
 	siteInfo.Title = fmt.Sprintf("%s - %s", detail.Title, siteInfo.General.Name)
 	tc.html(ctx, http.StatusOK, "question-detail.html", siteInfo, gin.H{
-		"id":       id,
-		"answerid": answerid,
-		"detail":   detail,
-		"answers":  answers,
-		"comments": comments,
-		"noindex":  detail.Show == entity.QuestionHide,
+		"id":         id,
+		"answerid":   answerid,
+		"detail":     detail,
+		"answers":    answers,
+		"comments":   comments,
+		"duplicates": duplicates,
+		"noindex":    detail.Show == entity.QuestionHide,
 	})
 }
 
@@ -620,6 +634,66 @@ func (tc *TemplateController) SitemapPage(ctx *gin.Context) {
 	}
 }
 
+// QuestionsFeed site-wide questions feed
+func (tc *TemplateController) QuestionsFeed(ctx *gin.Context) {
+	req := &schema.FeedReq{}
+	if tc.checkPrivateMode(ctx) || handler.BindAndCheck(ctx, req) {
+		tc.Page404(ctx)
+		return
+	}
+	feed, err := tc.templateRenderController.QuestionsFeed(ctx, req)
+	if err != nil {
+		tc.Page404(ctx)
+		return
+	}
+	tc.templateRenderController.Feed(ctx, req.Format, feed)
+}
+
+// TagFeed questions feed of the tag
+func (tc *TemplateController) TagFeed(ctx *gin.Context) {
+	req := &schema.FeedReq{}
+	if tc.checkPrivateMode(ctx) || handler.BindAndCheck(ctx, req) {
+		tc.Page404(ctx)
+		return
+	}
+	feed, err := tc.templateRenderController.TagFeed(ctx, ctx.Param("tag"), req)
+	if err != nil {
+		tc.Page404(ctx)
+		return
+	}
+	tc.templateRenderController.Feed(ctx, req.Format, feed)
+}
+
+// QuestionFeed answers feed of the question
+func (tc *TemplateController) QuestionFeed(ctx *gin.Context) {
+	req := &schema.FeedReq{}
+	if tc.checkPrivateMode(ctx) || handler.BindAndCheck(ctx, req) {
+		tc.Page404(ctx)
+		return
+	}
+	feed, err := tc.templateRenderController.QuestionFeed(ctx, ctx.Param("id"))
+	if err != nil {
+		tc.Page404(ctx)
+		return
+	}
+	tc.templateRenderController.Feed(ctx, req.Format, feed)
+}
+
+// UserFeed activity feed of the user
+func (tc *TemplateController) UserFeed(ctx *gin.Context) {
+	req := &schema.FeedReq{}
+	if tc.checkPrivateMode(ctx) || handler.BindAndCheck(ctx, req) {
+		tc.Page404(ctx)
+		return
+	}
+	feed, err := tc.templateRenderController.UserFeed(ctx, ctx.Param("username"))
+	if err != nil {
+		tc.Page404(ctx)
+		return
+	}
+	tc.templateRenderController.Feed(ctx, req.Format, feed)
+}
+
 func (tc *TemplateController) checkPrivateMode(ctx *gin.Context) bool {
 	resp, err := tc.siteInfoService.GetSiteLogin(ctx)
 	if err != nil {
@@ -0,0 +1,333 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package templaterender
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// QuestionsFeed newest or active questions of the whole site
+func (t *TemplateRenderController) QuestionsFeed(ctx *gin.Context, req *schema.FeedReq) (
+	feed *schema.FeedInfo, err error) {
+	general, seo, err := t.getFeedSiteInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.OrderCond) == 0 {
+		req.OrderCond = schema.QuestionOrderCondNewest
+	}
+	questions, _, err := t.questionService.GetQuestionPage(ctx, &schema.QuestionPageReq{
+		Page:      1,
+		PageSize:  schema.FeedMaxSize,
+		OrderCond: req.OrderCond,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	feed = &schema.FeedInfo{
+		Title: fmt.Sprintf("%s - %s",
+			translator.Tr(handler.GetLang(ctx), constant.QuestionsTitleTrKey), general.Name),
+		Description: general.Description,
+		Author:      general.Name,
+		Link:        general.SiteUrl + "/questions",
+		SelfLink:    feedSelfLink(ctx, general.SiteUrl+"/feed"),
+		Items:       questionFeedItems(seo.Permalink, general.SiteUrl, questions),
+	}
+	return formatFeed(feed), nil
+}
+
+// TagFeed newest or active questions of the tag
+func (t *TemplateRenderController) TagFeed(ctx *gin.Context, tagName string, req *schema.FeedReq) (
+	feed *schema.FeedInfo, err error) {
+	general, seo, err := t.getFeedSiteInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tagInfo, err := t.tagService.GetTagInfo(ctx, &schema.GetTagInfoReq{Name: tagName})
+	if err != nil {
+		return nil, err
+	}
+	if len(req.OrderCond) == 0 {
+		req.OrderCond = schema.QuestionOrderCondNewest
+	}
+	questions, _, err := t.questionService.GetQuestionPage(ctx, &schema.QuestionPageReq{
+		Page:      1,
+		PageSize:  schema.FeedMaxSize,
+		OrderCond: req.OrderCond,
+		Tag:       tagInfo.SlugName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	link := general.SiteUrl + "/tags/" + url.PathEscape(tagInfo.SlugName)
+	feed = &schema.FeedInfo{
+		Title: fmt.Sprintf("%s - %s", translator.TrWithData(handler.GetLang(ctx), constant.FeedTagTitleTrKey,
+			map[string]string{"TagName": tagInfo.DisplayName}), general.Name),
+		Description: tagInfo.Excerpt,
+		Author:      general.Name,
+		Link:        link,
+		SelfLink:    feedSelfLink(ctx, link+"/feed"),
+		Items:       questionFeedItems(seo.Permalink, general.SiteUrl, questions),
+	}
+	return formatFeed(feed), nil
+}
+
+// QuestionFeed new answers of the question
+func (t *TemplateRenderController) QuestionFeed(ctx *gin.Context, questionID string) (
+	feed *schema.FeedInfo, err error) {
+	general, seo, err := t.getFeedSiteInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	question, err := t.questionService.GetQuestion(ctx, questionID, "", schema.QuestionPermission{})
+	if err != nil {
+		return nil, err
+	}
+	if question.Show == entity.QuestionHide {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
+	answers, _, err := t.answerService.SearchList(ctx, &schema.AnswerListReq{
+		QuestionID: question.ID,
+		Order:      entity.AnswerSearchOrderByTime,
+		Page:       1,
+		PageSize:   schema.FeedMaxSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lang := handler.GetLang(ctx)
+	answerTitle := translator.TrWithData(lang, constant.FeedAnswerTitleTrKey,
+		map[string]string{"QuestionTitle": question.Title})
+	items := make([]*schema.FeedItem, 0, len(answers))
+	for _, answer := range answers {
+		link := display.AnswerURL(seo.Permalink, general.SiteUrl, question.ID, question.Title, answer.ID)
+		item := &schema.FeedItem{
+			ID:        link,
+			Title:     answerTitle,
+			Link:      link,
+			Summary:   answer.HTML,
+			Published: time.Unix(answer.CreateTime, 0),
+			Updated:   time.Unix(answer.UpdateTime, 0),
+		}
+		if answer.UserInfo != nil {
+			item.Author = answer.UserInfo.DisplayName
+		}
+		items = append(items, item)
+	}
+
+	link := display.QuestionURL(seo.Permalink, general.SiteUrl, question.ID, question.Title)
+	feed = &schema.FeedInfo{
+		Title: fmt.Sprintf("%s - %s", translator.TrWithData(lang, constant.FeedQuestionTitleTrKey,
+			map[string]string{"QuestionTitle": question.Title}), general.Name),
+		Description: question.Description,
+		Author:      general.Name,
+		Link:        link,
+		SelfLink:    feedSelfLink(ctx, fmt.Sprintf("%s/questions/%s/feed", general.SiteUrl, questionID)),
+		Items:       items,
+	}
+	return formatFeed(feed), nil
+}
+
+// UserFeed recent questions and answers of the user
+func (t *TemplateRenderController) UserFeed(ctx *gin.Context, username string) (
+	feed *schema.FeedInfo, err error) {
+	general, seo, err := t.getFeedSiteInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userInfo, err := t.userService.GetOtherUserInfoByUsername(ctx,
+		&schema.GetOtherUserInfoByUsernameReq{Username: username})
+	if err != nil {
+		return nil, err
+	}
+	questions, _, err := t.questionService.GetQuestionPage(ctx, &schema.QuestionPageReq{
+		Page:             1,
+		PageSize:         schema.FeedMaxSize,
+		OrderCond:        schema.QuestionOrderCondNewest,
+		UserIDBeSearched: userInfo.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := questionFeedItems(seo.Permalink, general.SiteUrl, questions)
+	for _, item := range items {
+		item.Author = userInfo.DisplayName
+	}
+
+	answerPage, err := t.questionService.PersonalAnswerPage(ctx, &schema.PersonalAnswerPageReq{
+		Page:      1,
+		PageSize:  schema.FeedMaxSize,
+		OrderCond: schema.QuestionOrderCondNewest,
+		Username:  userInfo.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	answers, _ := answerPage.List.([]*schema.UserAnswerInfo)
+	questionIDs := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		questionIDs = append(questionIDs, uid.DeShortID(answer.QuestionID))
+	}
+	// answers of the hidden, pending or deleted questions should not be shown
+	visibleQuestions := make(map[string]bool, len(questionIDs))
+	if len(questionIDs) > 0 {
+		questionList, err := t.questionRepo.FindByID(ctx, questionIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, question := range questionList {
+			visibleQuestions[uid.DeShortID(question.ID)] = question.Show == entity.QuestionShow &&
+				(question.Status == entity.QuestionStatusAvailable || question.Status == entity.QuestionStatusClosed)
+		}
+	}
+	lang := handler.GetLang(ctx)
+	for _, answer := range answers {
+		if !visibleQuestions[uid.DeShortID(answer.QuestionID)] {
+			continue
+		}
+		link := display.AnswerURL(seo.Permalink, general.SiteUrl,
+			answer.QuestionID, answer.QuestionInfo.Title, answer.AnswerID)
+		items = append(items, &schema.FeedItem{
+			ID: link,
+			Title: translator.TrWithData(lang, constant.FeedAnswerTitleTrKey,
+				map[string]string{"QuestionTitle": answer.QuestionInfo.Title}),
+			Link:      link,
+			Author:    userInfo.DisplayName,
+			Published: time.Unix(int64(answer.CreateTime), 0),
+			Updated:   time.Unix(int64(answer.UpdateTime), 0),
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Published.After(items[j].Published)
+	})
+	if len(items) > schema.FeedMaxSize {
+		items = items[:schema.FeedMaxSize]
+	}
+
+	link := display.UserURL(general.SiteUrl, userInfo.Username)
+	feed = &schema.FeedInfo{
+		Title: fmt.Sprintf("%s - %s", translator.TrWithData(lang, constant.FeedUserTitleTrKey,
+			map[string]string{"DisplayName": userInfo.DisplayName}), general.Name),
+		Description: htmltext.FetchExcerpt(userInfo.BioHTML, "...", 240),
+		Author:      userInfo.DisplayName,
+		Link:        link,
+		SelfLink:    feedSelfLink(ctx, link+"/feed"),
+		Items:       items,
+	}
+	return formatFeed(feed), nil
+}
+
+// Feed render feed as atom or rss
+func (t *TemplateRenderController) Feed(ctx *gin.Context, format string, feed *schema.FeedInfo) {
+	tpl, contentType := "feed-atom.xml", "application/atom+xml; charset=utf-8"
+	if format == schema.FeedFormatRSS {
+		tpl, contentType = "feed-rss.xml", "application/rss+xml; charset=utf-8"
+	}
+	ctx.Header("Content-Type", contentType)
+	ctx.HTML(
+		http.StatusOK, tpl, gin.H{
+			"xmlHeader": template.HTML(`<?xml version="1.0" encoding="UTF-8"?>`),
+			"feed":      feed,
+		},
+	)
+}
+
+func (t *TemplateRenderController) getFeedSiteInfo(ctx *gin.Context) (
+	general *schema.SiteGeneralResp, seo *schema.SiteSeoResp, err error) {
+	general, err = t.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	seo, err = t.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return general, seo, nil
+}
+
+func questionFeedItems(permalink int, siteUrl string, questions []*schema.QuestionPageResp) (
+	items []*schema.FeedItem) {
+	items = make([]*schema.FeedItem, 0, len(questions))
+	for _, question := range questions {
+		link := display.QuestionURL(permalink, siteUrl, question.ID, question.Title)
+		item := &schema.FeedItem{
+			ID:        link,
+			Title:     question.Title,
+			Link:      link,
+			Summary:   question.Description,
+			Published: time.Unix(question.CreatedAt, 0),
+			Updated:   time.Unix(question.OperatedAt, 0),
+		}
+		// the operator is the author only when the question is just asked
+		if question.Operator != nil && question.OperationType == schema.QuestionPageRespOperationTypeAsked {
+			item.Author = question.Operator.DisplayName
+		}
+		for _, tag := range question.Tags {
+			item.Categories = append(item.Categories, tag.DisplayName)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// formatFeed fill the update time of the feed and its items
+func formatFeed(feed *schema.FeedInfo) *schema.FeedInfo {
+	feed.ID = feed.SelfLink
+	for _, item := range feed.Items {
+		if item.Updated.Before(item.Published) {
+			item.Updated = item.Published
+		}
+		if item.Updated.After(feed.Updated) {
+			feed.Updated = item.Updated
+		}
+	}
+	if feed.Updated.IsZero() {
+		feed.Updated = time.Now()
+	}
+	return feed
+}
+
+// feedSelfLink link of the feed itself with the query parameters
+func feedSelfLink(ctx *gin.Context, link string) string {
+	if len(ctx.Request.URL.RawQuery) > 0 {
+		return link + "?" + ctx.Request.URL.RawQuery
+	}
+	return link
+}
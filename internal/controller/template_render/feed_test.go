@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package templaterender
+
+import (
+	"encoding/xml"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/ui"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type atomFeed struct {
+	Title   string `xml:"title"`
+	Updated string `xml:"updated"`
+	Links   []struct {
+		Rel  string `xml:"rel,attr"`
+		Href string `xml:"href,attr"`
+	} `xml:"link"`
+	Entries []struct {
+		ID         string `xml:"id"`
+		Title      string `xml:"title"`
+		Author     string `xml:"author>name"`
+		Summary    string `xml:"summary"`
+		Categories []struct {
+			Term string `xml:"term,attr"`
+		} `xml:"category"`
+	} `xml:"entry"`
+}
+
+type rssFeed struct {
+	Channel struct {
+		Title string `xml:"title"`
+		// the atom:link of the feed itself is also matched
+		Links       []string `xml:"link"`
+		Description string   `xml:"description"`
+		Items       []struct {
+			GUID        string   `xml:"guid"`
+			Title       string   `xml:"title"`
+			Creator     string   `xml:"creator"`
+			Description string   `xml:"description"`
+			Categories  []string `xml:"category"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+func newTestFeed() *schema.FeedInfo {
+	published := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return formatFeed(&schema.FeedInfo{
+		Title:    `Questions - <Answer> & "Co"`,
+		Author:   "Answer",
+		Link:     "https://example.com/questions",
+		SelfLink: "https://example.com/feed?format=atom&order=newest",
+		Items: []*schema.FeedItem{
+			{
+				ID:         "https://example.com/questions/1",
+				Title:      `How to use <script>alert(1)</script> & ']]>'?`,
+				Link:       "https://example.com/questions/1",
+				Author:     "<b>author</b>",
+				Summary:    `<p>hello &amp; <a href="https://example.com">world</a></p>`,
+				Categories: []string{"go & <xml>"},
+				Published:  published,
+				Updated:    published.Add(-time.Hour),
+			},
+		},
+	})
+}
+
+func renderTestFeed(t *testing.T, format string, feed *schema.FeedInfo) *httptest.ResponseRecorder {
+	html, err := fs.Sub(ui.Template, "template")
+	assert.NoError(t, err)
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	ctx, engine := gin.CreateTestContext(w)
+	engine.SetHTMLTemplate(template.Must(template.New("").ParseFS(html, "feed-*.xml")))
+	(&TemplateRenderController{}).Feed(ctx, format, feed)
+	assert.Equal(t, http.StatusOK, w.Code)
+	return w
+}
+
+func TestTemplateRenderController_FeedAtom(t *testing.T) {
+	feed := newTestFeed()
+	w := renderTestFeed(t, schema.FeedFormatAtom, feed)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.NotContains(t, w.Body.String(), "<script>")
+
+	got := &atomFeed{}
+	assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), got))
+	assert.Equal(t, feed.Title, got.Title)
+	assert.Equal(t, "2024-01-02T03:04:05Z", got.Updated)
+	assert.Equal(t, 2, len(got.Links))
+	assert.Equal(t, feed.SelfLink, got.Links[1].Href)
+	assert.Equal(t, 1, len(got.Entries))
+	entry := got.Entries[0]
+	assert.Equal(t, feed.Items[0].Title, entry.Title)
+	assert.Equal(t, feed.Items[0].Author, entry.Author)
+	// the html summary is escaped as text, so the reader gets the original html
+	assert.Equal(t, feed.Items[0].Summary, entry.Summary)
+	assert.Equal(t, 1, len(entry.Categories))
+	assert.Equal(t, "go & <xml>", entry.Categories[0].Term)
+}
+
+func TestTemplateRenderController_FeedRSS(t *testing.T) {
+	feed := newTestFeed()
+	w := renderTestFeed(t, schema.FeedFormatRSS, feed)
+	assert.Equal(t, "application/rss+xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.NotContains(t, w.Body.String(), "<script>")
+
+	got := &rssFeed{}
+	assert.NoError(t, xml.Unmarshal(w.Body.Bytes(), got))
+	assert.Equal(t, feed.Title, got.Channel.Title)
+	assert.Equal(t, []string{feed.Link, ""}, got.Channel.Links)
+	// the title is used when there is no description
+	assert.Equal(t, feed.Title, got.Channel.Description)
+	assert.Equal(t, 1, len(got.Channel.Items))
+	item := got.Channel.Items[0]
+	assert.Equal(t, feed.Items[0].ID, item.GUID)
+	assert.Equal(t, feed.Items[0].Title, item.Title)
+	assert.Equal(t, feed.Items[0].Author, item.Creator)
+	assert.Equal(t, feed.Items[0].Summary, item.Description)
+	assert.Equal(t, []string{"go & <xml>"}, item.Categories)
+}
+
+func Test_formatFeed(t *testing.T) {
+	feed := newTestFeed()
+	// the update time of the item is never before the published time
+	assert.Equal(t, feed.Items[0].Published, feed.Items[0].Updated)
+	assert.Equal(t, feed.Items[0].Published, feed.Updated)
+	assert.Equal(t, feed.SelfLink, feed.ID)
+
+	empty := formatFeed(&schema.FeedInfo{SelfLink: "https://example.com/feed"})
+	assert.False(t, empty.Updated.IsZero())
+}
+
+func Test_questionFeedItems(t *testing.T) {
+	questions := []*schema.QuestionPageResp{
+		{
+			ID:            "10010000000000001",
+			Title:         "hello world",
+			Description:   "excerpt",
+			CreatedAt:     100,
+			OperatedAt:    200,
+			OperationType: schema.QuestionPageRespOperationTypeAsked,
+			Operator:      &schema.QuestionPageRespOperator{DisplayName: "asker"},
+			Tags:          []*schema.TagResp{{DisplayName: "go"}},
+		},
+		{
+			ID:            "10010000000000002",
+			Title:         "answered",
+			CreatedAt:     100,
+			OperatedAt:    300,
+			OperationType: schema.QuestionPageRespOperationTypeAnswered,
+			Operator:      &schema.QuestionPageRespOperator{DisplayName: "answerer"},
+		},
+	}
+	items := questionFeedItems(1, "https://example.com", questions)
+	assert.Equal(t, 2, len(items))
+	assert.Equal(t, items[0].Link, items[0].ID)
+	assert.Equal(t, "excerpt", items[0].Summary)
+	assert.Equal(t, "asker", items[0].Author)
+	assert.Equal(t, []string{"go"}, items[0].Categories)
+	assert.Equal(t, time.Unix(200, 0), items[0].Updated)
+	// the operator of the answered question is not the author
+	assert.Empty(t, items[1].Author)
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/user_access_token"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// UserAccessTokenController personal access token controller
+type UserAccessTokenController struct {
+	userAccessTokenService *user_access_token.UserAccessTokenService
+}
+
+// NewUserAccessTokenController new controller
+func NewUserAccessTokenController(
+	userAccessTokenService *user_access_token.UserAccessTokenService,
+) *UserAccessTokenController {
+	return &UserAccessTokenController{userAccessTokenService: userAccessTokenService}
+}
+
+// GetUserAccessTokenList get personal access tokens of the current user
+// @Summary get personal access tokens of the current user
+// @Description get personal access tokens of the current user
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.UserAccessTokenInfo}
+// @Router /answer/api/v1/user/access-tokens [get]
+func (uc *UserAccessTokenController) GetUserAccessTokenList(ctx *gin.Context) {
+	req := &schema.GetUserAccessTokenListReq{}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userAccessTokenService.GetUserAccessTokenList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddUserAccessToken create a personal access token
+// @Summary create a personal access token
+// @Description create a personal access token, the token is only returned once
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddUserAccessTokenReq true "token"
+// @Success 200 {object} handler.RespBody{data=schema.AddUserAccessTokenResp}
+// @Router /answer/api/v1/user/access-token [post]
+func (uc *UserAccessTokenController) AddUserAccessToken(ctx *gin.Context) {
+	req := &schema.AddUserAccessTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	// personal access token can not be used to create new tokens
+	if middleware.IsUserAccessTokenRequest(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.UserAccessTokenScopeNotAllowed), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = middleware.GetIsAdminFromContext(ctx)
+	resp, err := uc.userAccessTokenService.AddUserAccessToken(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevokeUserAccessToken revoke a personal access token
+// @Summary revoke a personal access token
+// @Description revoke a personal access token
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RevokeUserAccessTokenReq true "token"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/access-token [delete]
+func (uc *UserAccessTokenController) RevokeUserAccessToken(ctx *gin.Context) {
+	req := &schema.RevokeUserAccessTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := uc.userAccessTokenService.RevokeUserAccessToken(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -50,6 +51,7 @@ type UserController struct {
 	emailService                  *export.EmailService
 	siteInfoCommonService         siteinfo_common.SiteInfoCommonService
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
+	userSuspensionService         *user_suspension.UserSuspensionService
 }
 
 // NewUserController new controller
@@ -60,6 +62,7 @@ func NewUserController(
 	emailService *export.EmailService,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
+	userSuspensionService *user_suspension.UserSuspensionService,
 ) *UserController {
 	return &UserController{
 		authService:                   authService,
@@ -68,6 +71,7 @@ func NewUserController(
 		emailService:                  emailService,
 		siteInfoCommonService:         siteInfoCommonService,
 		userNotificationConfigService: userNotificationConfigService,
+		userSuspensionService:         userSuspensionService,
 	}
 }
 
@@ -88,7 +92,12 @@ func (uc *UserController) GetUserInfoByUserID(ctx *gin.Context) {
 	}
 
 	// if user is no login return null in data
-	userInfo, _ := uc.authService.GetUserCacheInfo(ctx, token)
+	var userInfo *entity.UserCacheInfo
+	if middleware.IsUserAccessTokenRequest(ctx) {
+		userInfo = middleware.GetUserInfoFromContext(ctx)
+	} else {
+		userInfo, _ = uc.authService.GetUserCacheInfo(ctx, token)
+	}
 	if userInfo == nil {
 		handler.HandleResponse(ctx, nil, nil)
 		return
@@ -223,6 +232,20 @@ func (uc *UserController) UseRePassWord(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// GetCurrentUserSuspension get the active suspension of the login user
+// @Summary get the active suspension of the login user, data is null if the user is not suspended
+// @Description get the active suspension of the login user, data is null if the user is not suspended
+// @Tags User
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.UserSuspensionResp}
+// @Router /answer/api/v1/user/suspension [get]
+func (uc *UserController) GetCurrentUserSuspension(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userSuspensionService.GetCurrentUserSuspension(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // UserLogout user logout
 // @Summary user logout
 // @Description user logout
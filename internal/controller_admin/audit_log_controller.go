@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogController audit log controller
+type AuditLogController struct {
+	auditLogService *audit_log.AuditLogService
+}
+
+// NewAuditLogController new controller
+func NewAuditLogController(auditLogService *audit_log.AuditLogService) *AuditLogController {
+	return &AuditLogController{auditLogService: auditLogService}
+}
+
+// GetAuditLogPage get audit log page
+// @Summary get audit log page
+// @Description get the operations of the admins and moderators, the latest first
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param operator_user_id query string false "operator user id"
+// @Param action query string false "action"
+// @Param object_type query string false "object type"
+// @Param object_id query string false "object id"
+// @Param start_time query int false "start time, unix timestamp"
+// @Param end_time query int false "end time, unix timestamp"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetAuditLogResp}}
+// @Router /answer/admin/api/audit-logs/page [get]
+func (ac *AuditLogController) GetAuditLogPage(ctx *gin.Context) {
+	req := &schema.GetAuditLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ac.auditLogService.GetAuditLogPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetAuditLogActions get all the actions recorded in the audit log
+// @Summary get all the actions recorded in the audit log
+// @Description get all the actions recorded in the audit log
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.GetAuditLogActionsResp}
+// @Router /answer/admin/api/audit-log/actions [get]
+func (ac *AuditLogController) GetAuditLogActions(ctx *gin.Context) {
+	resp, err := ac.auditLogService.GetAuditLogActions(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ExportAuditLog export audit log as csv
+// @Summary export audit log as csv
+// @Description export the audit logs matching the conditions as csv file
+// @Tags admin
+// @Produce text/csv
+// @Security ApiKeyAuth
+// @Param operator_user_id query string false "operator user id"
+// @Param action query string false "action"
+// @Param object_type query string false "object type"
+// @Param object_id query string false "object id"
+// @Param start_time query int false "start time, unix timestamp"
+// @Param end_time query int false "end time, unix timestamp"
+// @Success 200 {file} file
+// @Router /answer/admin/api/audit-logs/export [get]
+func (ac *AuditLogController) ExportAuditLog(ctx *gin.Context) {
+	req := &schema.ExportAuditLogReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	data, err := ac.auditLogService.ExportAuditLog(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Header("Content-Disposition", "attachment; filename=audit_log.csv")
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
@@ -21,6 +21,7 @@ package controller_admin
 
 import (
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/badge"
@@ -80,6 +81,7 @@ func (b *BadgeController) UpdateBadgeStatus(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 
 	err := b.badgeService.UpdateStatus(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
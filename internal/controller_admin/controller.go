@@ -29,4 +29,7 @@ var ProviderSetController = wire.NewSet(
 	NewRoleController,
 	NewPluginController,
 	NewBadgeController,
+	NewWebhookController,
+	NewTagModeratorController,
+	NewAuditLogController,
 )
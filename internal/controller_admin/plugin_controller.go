@@ -22,8 +22,11 @@ package controller_admin
 import (
 	"encoding/json"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/plugin_common"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
@@ -32,11 +35,18 @@ import (
 // PluginController role controller
 type PluginController struct {
 	pluginCommonService *plugin_common.PluginCommonService
+	auditLogService     *audit_log.AuditLogService
 }
 
 // NewPluginController new controller
-func NewPluginController(pluginCommonService *plugin_common.PluginCommonService) *PluginController {
-	return &PluginController{pluginCommonService: pluginCommonService}
+func NewPluginController(
+	pluginCommonService *plugin_common.PluginCommonService,
+	auditLogService *audit_log.AuditLogService,
+) *PluginController {
+	return &PluginController{
+		pluginCommonService: pluginCommonService,
+		auditLogService:     auditLogService,
+	}
 }
 
 // GetAllPluginStatus get all plugins status
@@ -149,8 +159,19 @@ func (pc *PluginController) UpdatePluginStatus(ctx *gin.Context) {
 		return
 	}
 
+	previousEnabled := plugin.StatusManager.IsEnabled(req.PluginSlugName)
 	plugin.StatusManager.Enable(req.PluginSlugName, req.Enabled)
 	err := pc.pluginCommonService.UpdatePluginStatus(ctx)
+	if err == nil {
+		pc.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+			OperatorUserID: middleware.GetLoginUserIDFromContext(ctx),
+			Action:         constant.AuditLogActionPluginStatusUpdate,
+			ObjectType:     constant.AuditLogObjectTypePlugin,
+			ObjectID:       req.PluginSlugName,
+			Before:         map[string]bool{"enabled": previousEnabled},
+			After:          map[string]bool{"enabled": req.Enabled},
+		})
+	}
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -209,8 +230,17 @@ func (pc *PluginController) UpdatePluginConfig(ctx *gin.Context) {
 	}
 
 	configFields, _ := json.Marshal(req.ConfigFields)
+	before, after := make(map[string]any), make(map[string]any)
 	err := plugin.CallConfig(func(fn plugin.Config) error {
 		if fn.Info().SlugName == req.PluginSlugName {
+			// the secret values such as passwords are not recorded in the audit log
+			for _, field := range fn.ConfigFields() {
+				if field.UIOptions.InputType == plugin.InputTypePassword {
+					before[field.Name], after[field.Name] = schema.AuditLogRedactedValue, schema.AuditLogRedactedValue
+					continue
+				}
+				before[field.Name], after[field.Name] = field.Value, req.ConfigFields[field.Name]
+			}
 			return fn.ConfigReceiver(configFields)
 		}
 		return nil
@@ -221,5 +251,15 @@ func (pc *PluginController) UpdatePluginConfig(ctx *gin.Context) {
 	}
 
 	err = pc.pluginCommonService.UpdatePluginConfig(ctx, req)
+	if err == nil {
+		pc.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+			OperatorUserID: middleware.GetLoginUserIDFromContext(ctx),
+			Action:         constant.AuditLogActionPluginConfigUpdate,
+			ObjectType:     constant.AuditLogObjectTypePlugin,
+			ObjectID:       req.PluginSlugName,
+			Before:         before,
+			After:          after,
+		})
+	}
 	handler.HandleResponse(ctx, err, nil)
 }
@@ -20,20 +20,32 @@
 package controller_admin
 
 import (
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	service "github.com/apache/incubator-answer/internal/service/role"
 	"github.com/gin-gonic/gin"
 )
 
 // RoleController role controller
 type RoleController struct {
-	roleService *service.RoleService
+	roleService     *service.RoleService
+	auditLogService *audit_log.AuditLogService
 }
 
 // NewRoleController new controller
-func NewRoleController(roleService *service.RoleService) *RoleController {
-	return &RoleController{roleService: roleService}
+func NewRoleController(
+	roleService *service.RoleService,
+	auditLogService *audit_log.AuditLogService,
+) *RoleController {
+	return &RoleController{
+		roleService:     roleService,
+		auditLogService: auditLogService,
+	}
 }
 
 // GetRoleList get role list
@@ -51,3 +63,144 @@ func (rc *RoleController) GetRoleList(ctx *gin.Context) {
 	resp, err := rc.roleService.GetRoleList(ctx)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// AddRole add custom role
+// @Summary add custom role
+// @Description add custom role with powers
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddRoleReq true "role"
+// @Success 200 {object} handler.RespBody{data=schema.AddRoleResp}
+// @Router /answer/admin/api/role [post]
+func (rc *RoleController) AddRole(ctx *gin.Context) {
+	req := &schema.AddRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := rc.roleService.AddRole(ctx, req)
+	if err == nil {
+		rc.addRoleAuditLog(ctx, constant.AuditLogActionRoleAdd, resp.ID, nil, req)
+	}
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateRole update custom role
+// @Summary update custom role
+// @Description update the name and description of custom role
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [put]
+func (rc *RoleController) UpdateRole(ctx *gin.Context) {
+	req := &schema.UpdateRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	before := rc.getRoleAuditSnapshot(ctx, req.RoleID)
+	err := rc.roleService.UpdateRole(ctx, req)
+	if err == nil {
+		rc.addRoleAuditLog(ctx, constant.AuditLogActionRoleUpdate, req.RoleID, before, req)
+	}
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveRole remove custom role
+// @Summary remove custom role
+// @Description remove custom role which is not assigned to any user
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [delete]
+func (rc *RoleController) RemoveRole(ctx *gin.Context) {
+	req := &schema.RemoveRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	before := rc.getRoleAuditSnapshot(ctx, req.RoleID)
+	err := rc.roleService.RemoveRole(ctx, req)
+	if err == nil {
+		rc.addRoleAuditLog(ctx, constant.AuditLogActionRoleRemove, req.RoleID, before, nil)
+	}
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetRolePowerList get role power list
+// @Summary get role power list
+// @Description get the power types of the role
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param role_id query int true "role id"
+// @Success 200 {object} handler.RespBody{data=schema.GetRolePowerListResp}
+// @Router /answer/admin/api/role/powers [get]
+func (rc *RoleController) GetRolePowerList(ctx *gin.Context) {
+	req := &schema.GetRolePowerListReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := rc.roleService.GetRolePowerList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateRolePowerList update role power list
+// @Summary update role power list
+// @Description replace the power types of custom role
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateRolePowerListReq true "role powers"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role/powers [put]
+func (rc *RoleController) UpdateRolePowerList(ctx *gin.Context) {
+	req := &schema.UpdateRolePowerListReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	before, _ := rc.roleService.GetRolePowerList(ctx, &schema.GetRolePowerListReq{RoleID: req.RoleID})
+	err := rc.roleService.UpdateRolePowerList(ctx, req)
+	if err == nil {
+		rc.addRoleAuditLog(ctx, constant.AuditLogActionRolePowerUpdate, req.RoleID, before, req)
+	}
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetPowerList get power list
+// @Summary get power list
+// @Description get all powers that can be assigned to roles
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetPowerResp}
+// @Router /answer/admin/api/powers [get]
+func (rc *RoleController) GetPowerList(ctx *gin.Context) {
+	resp, err := rc.roleService.GetPowerList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+func (rc *RoleController) addRoleAuditLog(ctx *gin.Context, action string, roleID int, before, after any) {
+	rc.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: middleware.GetLoginUserIDFromContext(ctx),
+		Action:         action,
+		ObjectType:     constant.AuditLogObjectTypeRole,
+		ObjectID:       fmt.Sprintf("%d", roleID),
+		Before:         before,
+		After:          after,
+	})
+}
+
+func (rc *RoleController) getRoleAuditSnapshot(ctx *gin.Context, roleID int) map[string]any {
+	role, exist, err := rc.roleService.GetRole(ctx, roleID)
+	if err != nil || !exist {
+		return nil
+	}
+	return map[string]any{"name": role.Name, "description": role.Description}
+}
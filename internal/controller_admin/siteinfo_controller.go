@@ -23,9 +23,11 @@ import (
 	"html"
 	"net/http"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
 	"github.com/gin-gonic/gin"
 )
@@ -33,12 +35,17 @@ import (
 // SiteInfoController site info controller
 type SiteInfoController struct {
 	siteInfoService *siteinfo.SiteInfoService
+	auditLogService *audit_log.AuditLogService
 }
 
 // NewSiteInfoController new site info controller
-func NewSiteInfoController(siteInfoService *siteinfo.SiteInfoService) *SiteInfoController {
+func NewSiteInfoController(
+	siteInfoService *siteinfo.SiteInfoService,
+	auditLogService *audit_log.AuditLogService,
+) *SiteInfoController {
 	return &SiteInfoController{
 		siteInfoService: siteInfoService,
+		auditLogService: auditLogService,
 	}
 }
 
@@ -172,6 +179,19 @@ func (sc *SiteInfoController) GetSiteUsers(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// GetSiteReview get site review queues config
+// @Summary get site review queues config
+// @Description get site review queues config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteReviewResp}
+// @Router /answer/admin/api/siteinfo/review [get]
+func (sc *SiteInfoController) GetSiteReview(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteReview(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetRobots get site robots information
 // @Summary get site robots information
 // @Description get site robots information
@@ -219,7 +239,9 @@ func (sc *SiteInfoController) UpdateSeo(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, &req) {
 		return
 	}
+	before, _ := sc.siteInfoService.GetSeo(ctx)
 	err := sc.siteInfoService.SaveSeo(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSiteInfoUpdate, constant.SiteTypeSeo, before, req, err)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -237,7 +259,9 @@ func (sc *SiteInfoController) UpdateGeneral(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, &req) {
 		return
 	}
+	before, _ := sc.siteInfoService.GetSiteGeneral(ctx)
 	err := sc.siteInfoService.SaveSiteGeneral(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSiteInfoUpdate, constant.SiteTypeGeneral, before, req, err)
 	req.Name = html.UnescapeString(req.Name)
 	handler.HandleResponse(ctx, err, req)
 }
@@ -256,7 +280,9 @@ func (sc *SiteInfoController) UpdateInterface(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, &req) {
 		return
 	}
+	before, _ := sc.siteInfoService.GetSiteInterface(ctx)
 	err := sc.siteInfoService.SaveSiteInterface(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSiteInfoUpdate, constant.SiteTypeInterface, before, req, err)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -274,7 +300,9 @@ func (sc *SiteInfoController) UpdateBranding(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	before, _ := sc.siteInfoService.GetSiteBranding(ctx)
 	err := sc.siteInfoService.SaveSiteBranding(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSiteInfoUpdate, constant.SiteTypeBranding, before, req, err)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -294,7 +322,9 @@ func (sc *SiteInfoController) UpdateSiteWrite(ctx *gin.Context) {
 	}
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 
+	before, _ := sc.siteInfoService.GetSiteWrite(ctx)
 	resp, err := sc.siteInfoService.SaveSiteWrite(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSiteInfoUpdate, constant.SiteTypeWrite, before, req, err)
 	handler.HandleResponse(ctx, err, resp)
 }
 
@@ -312,7 +342,9 @@ func (sc *SiteInfoController) UpdateSiteLegal(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	before, _ := sc.siteInfoService.GetSiteLegal(ctx)
 	err := sc.siteInfoService.SaveSiteLegal(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSiteInfoUpdate, constant.SiteTypeLegal, before, req, err)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -330,7 +362,9 @@ func (sc *SiteInfoController) UpdateSiteLogin(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	before, _ := sc.siteInfoService.GetSiteLogin(ctx)
 	err := sc.siteInfoService.SaveSiteLogin(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSiteInfoUpdate, constant.SiteTypeLogin, before, req, err)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -348,7 +382,9 @@ func (sc *SiteInfoController) UpdateSiteCustomCssHTML(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	before, _ := sc.siteInfoService.GetSiteCustomCssHTML(ctx)
 	err := sc.siteInfoService.SaveSiteCustomCssHTML(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSiteInfoUpdate, constant.SiteTypeCustomCssHTML, before, req, err)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -366,7 +402,9 @@ func (sc *SiteInfoController) SaveSiteTheme(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	before, _ := sc.siteInfoService.GetSiteTheme(ctx)
 	err := sc.siteInfoService.SaveSiteTheme(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSiteInfoUpdate, constant.SiteTypeTheme, before, req, err)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -384,7 +422,29 @@ func (sc *SiteInfoController) UpdateSiteUsers(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	before, _ := sc.siteInfoService.GetSiteUsers(ctx)
 	err := sc.siteInfoService.SaveSiteUsers(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSiteInfoUpdate, constant.SiteTypeUsers, before, req, err)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateSiteReview update site config about review queues
+// @Summary update site info config about review queues
+// @Description update site info config about review queues
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteReviewReq true "review queues info"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/review [put]
+func (sc *SiteInfoController) UpdateSiteReview(ctx *gin.Context) {
+	req := &schema.SiteReviewReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	before, _ := sc.siteInfoService.GetSiteReview(ctx)
+	err := sc.siteInfoService.SaveSiteReview(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSiteInfoUpdate, constant.SiteTypeReview, before, req, err)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -415,7 +475,52 @@ func (sc *SiteInfoController) UpdateSMTPConfig(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	before, _ := sc.siteInfoService.GetSMTPConfig(ctx)
 	err := sc.siteInfoService.UpdateSMTPConfig(ctx, req)
+	after := *req
+	if len(after.SMTPPassword) > 0 {
+		after.SMTPPassword = schema.AuditLogRedactedValue
+	}
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionSMTPConfigUpdate, constant.EmailConfigKey, before, after, err)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetEmailLogPage get email log page
+// @Summary get email log page
+// @Description get the outgoing emails with their delivery status
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param status query string false "status" Enums(pending, sending, sent, failed)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{records=[]schema.GetEmailLogResp}}
+// @Router /answer/admin/api/setting/smtp/logs [get]
+func (sc *SiteInfoController) GetEmailLogPage(ctx *gin.Context) {
+	req := &schema.GetEmailLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := sc.siteInfoService.GetEmailLogPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RetryEmailLog retry failed email
+// @Summary retry failed email
+// @Description send the failed email again
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RetryEmailLogReq true "email log"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/smtp/log/retry [put]
+func (sc *SiteInfoController) RetryEmailLog(ctx *gin.Context) {
+	req := &schema.RetryEmailLogReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.RetryEmailLog(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -446,6 +551,27 @@ func (sc *SiteInfoController) UpdatePrivilegesConfig(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	var before any
+	if privilegesConfig, _ := sc.siteInfoService.GetPrivilegesConfig(ctx); privilegesConfig != nil {
+		before = map[string]any{"level": privilegesConfig.SelectedLevel}
+	}
 	err := sc.siteInfoService.UpdatePrivilegesConfig(ctx, req)
+	sc.addSiteInfoAuditLog(ctx, constant.AuditLogActionPrivilegesUpdate, constant.SiteTypePrivileges, before, req, err)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// addSiteInfoAuditLog record the site configuration updated successfully
+func (sc *SiteInfoController) addSiteInfoAuditLog(ctx *gin.Context, action, siteType string,
+	before, after any, err error) {
+	if err != nil {
+		return
+	}
+	sc.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: middleware.GetLoginUserIDFromContext(ctx),
+		Action:         action,
+		ObjectType:     constant.AuditLogObjectTypeSiteInfo,
+		ObjectID:       siteType,
+		Before:         before,
+		After:          after,
+	})
+}
@@ -25,7 +25,9 @@ import (
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/moderator_message"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -33,12 +35,22 @@ import (
 
 // UserAdminController user controller
 type UserAdminController struct {
-	userService *user_admin.UserAdminService
+	userService             *user_admin.UserAdminService
+	userSuspensionService   *user_suspension.UserSuspensionService
+	moderatorMessageService *moderator_message.ModeratorMessageService
 }
 
 // NewUserAdminController new controller
-func NewUserAdminController(userService *user_admin.UserAdminService) *UserAdminController {
-	return &UserAdminController{userService: userService}
+func NewUserAdminController(
+	userService *user_admin.UserAdminService,
+	userSuspensionService *user_suspension.UserSuspensionService,
+	moderatorMessageService *moderator_message.ModeratorMessageService,
+) *UserAdminController {
+	return &UserAdminController{
+		userService:             userService,
+		userSuspensionService:   userSuspensionService,
+		moderatorMessageService: moderatorMessageService,
+	}
 }
 
 // UpdateUserStatus update user
@@ -67,6 +79,44 @@ func (uc *UserAdminController) UpdateUserStatus(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// GetUserSuspensionList get the suspension history of the user
+// @Summary get the suspension history of the user
+// @Description get the suspension history of the user, the latest first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param user_id query string true "user id"
+// @Success 200 {object} handler.RespBody{data=[]schema.UserSuspensionResp}
+// @Router /answer/admin/api/user/suspensions [get]
+func (uc *UserAdminController) GetUserSuspensionList(ctx *gin.Context) {
+	req := &schema.GetUserSuspensionListReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := uc.userSuspensionService.GetUserSuspensionList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetModeratorMessageList get the moderator messages sent to the user
+// @Summary get the moderator messages sent to the user
+// @Description get the moderator messages sent to the user, the latest first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param user_id query string true "user id"
+// @Success 200 {object} handler.RespBody{data=[]schema.ModeratorMessageResp}
+// @Router /answer/admin/api/user/moderator-messages [get]
+func (uc *UserAdminController) GetModeratorMessageList(ctx *gin.Context) {
+	req := &schema.GetModeratorMessageListReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := uc.moderatorMessageService.GetModeratorMessageList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // UpdateUserRole update user role
 // @Summary update user role
 // @Description update user role
@@ -89,6 +139,28 @@ func (uc *UserAdminController) UpdateUserRole(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// UpdateUsersRole update the role of users in bulk
+// @Summary update the role of users in bulk
+// @Description update the role of users in bulk
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateUsersRoleReq true "users"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/users/role [put]
+func (uc *UserAdminController) UpdateUsersRole(ctx *gin.Context) {
+	req := &schema.UpdateUsersRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userService.UpdateUsersRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // AddUser add user
 // @Summary add user
 // @Description add user
@@ -127,6 +199,8 @@ func (uc *UserAdminController) AddUsers(ctx *gin.Context) {
 		return
 	}
 
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+
 	resp, err := uc.userService.AddUsers(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
 }
@@ -169,6 +243,7 @@ func (uc *UserAdminController) EditUserProfile(ctx *gin.Context) {
 		return
 	}
 
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
 	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
 	if !req.IsAdmin {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
@@ -0,0 +1,198 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/apache/incubator-answer/internal/service/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookController webhook controller
+type WebhookController struct {
+	webhookService  *webhook.WebhookService
+	auditLogService *audit_log.AuditLogService
+}
+
+// NewWebhookController new controller
+func NewWebhookController(
+	webhookService *webhook.WebhookService,
+	auditLogService *audit_log.AuditLogService,
+) *WebhookController {
+	return &WebhookController{
+		webhookService:  webhookService,
+		auditLogService: auditLogService,
+	}
+}
+
+// GetWebhookList get webhook list
+// @Summary get webhook list
+// @Description get webhook list
+// @Tags AdminWebhook
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.WebhookInfo}
+// @Router /answer/admin/api/webhooks [get]
+func (wc *WebhookController) GetWebhookList(ctx *gin.Context) {
+	resp, err := wc.webhookService.GetWebhookList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetWebhookEventTypes get all event types that can be subscribed
+// @Summary get all event types that can be subscribed
+// @Description get all event types that can be subscribed
+// @Tags AdminWebhook
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]string}
+// @Router /answer/admin/api/webhook/events [get]
+func (wc *WebhookController) GetWebhookEventTypes(ctx *gin.Context) {
+	handler.HandleResponse(ctx, nil, wc.webhookService.GetWebhookEventTypes(ctx))
+}
+
+// AddWebhook add webhook
+// @Summary add webhook
+// @Description add webhook, the secret will be generated if it is empty
+// @Tags AdminWebhook
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddWebhookReq true "webhook"
+// @Success 200 {object} handler.RespBody{data=schema.AddWebhookResp}
+// @Router /answer/admin/api/webhook [post]
+func (wc *WebhookController) AddWebhook(ctx *gin.Context) {
+	req := &schema.AddWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := wc.webhookService.AddWebhook(ctx, req)
+	if err == nil {
+		after := *req
+		after.Secret = schema.AuditLogRedactedValue
+		wc.addWebhookAuditLog(ctx, constant.AuditLogActionWebhookAdd, resp.ID, nil, after)
+	}
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateWebhook update webhook
+// @Summary update webhook
+// @Description update webhook, the secret will not be changed if it is empty
+// @Tags AdminWebhook
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateWebhookReq true "webhook"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/webhook [put]
+func (wc *WebhookController) UpdateWebhook(ctx *gin.Context) {
+	req := &schema.UpdateWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	before := wc.getWebhookInfo(ctx, req.ID)
+	err := wc.webhookService.UpdateWebhook(ctx, req)
+	if err == nil {
+		after := *req
+		if len(after.Secret) > 0 {
+			after.Secret = schema.AuditLogRedactedValue
+		}
+		wc.addWebhookAuditLog(ctx, constant.AuditLogActionWebhookUpdate, req.ID, before, after)
+	}
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// DeleteWebhook delete webhook
+// @Summary delete webhook
+// @Description delete webhook and its delivery records
+// @Tags AdminWebhook
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.DeleteWebhookReq true "webhook"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/webhook [delete]
+func (wc *WebhookController) DeleteWebhook(ctx *gin.Context) {
+	req := &schema.DeleteWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	before := wc.getWebhookInfo(ctx, req.ID)
+	err := wc.webhookService.DeleteWebhook(ctx, req)
+	if err == nil {
+		wc.addWebhookAuditLog(ctx, constant.AuditLogActionWebhookRemove, req.ID, before, nil)
+	}
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetWebhookDeliveryPage get recent deliveries of the webhook
+// @Summary get recent deliveries of the webhook
+// @Description get recent deliveries of the webhook, the newest first
+// @Tags AdminWebhook
+// @Produce json
+// @Security ApiKeyAuth
+// @Param webhook_id query int true "webhook id"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetWebhookDeliveryResp}}
+// @Router /answer/admin/api/webhook/deliveries [get]
+func (wc *WebhookController) GetWebhookDeliveryPage(ctx *gin.Context) {
+	req := &schema.GetWebhookDeliveryPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, total, err := wc.webhookService.GetWebhookDeliveryPage(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	handler.HandleResponse(ctx, nil, pager.NewPageModel(total, resp))
+}
+
+// getWebhookInfo get the webhook info with the masked secret for the audit log
+func (wc *WebhookController) getWebhookInfo(ctx *gin.Context, webhookID int) *schema.WebhookInfo {
+	webhooks, err := wc.webhookService.GetWebhookList(ctx)
+	if err != nil {
+		return nil
+	}
+	for _, info := range webhooks {
+		if info.ID == webhookID {
+			return info
+		}
+	}
+	return nil
+}
+
+func (wc *WebhookController) addWebhookAuditLog(ctx *gin.Context, action string, webhookID int, before, after any) {
+	wc.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: middleware.GetLoginUserIDFromContext(ctx),
+		Action:         action,
+		ObjectType:     constant.AuditLogObjectTypeWebhook,
+		ObjectID:       fmt.Sprintf("%d", webhookID),
+		Before:         before,
+		After:          after,
+	})
+}
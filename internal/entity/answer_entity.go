@@ -38,6 +38,12 @@ var AdminAnswerSearchStatus = map[string]int{
 	"pending":   AnswerStatusPending,
 }
 
+var AdminAnswerSearchStatusIntToString = map[int]string{
+	AnswerStatusAvailable: "available",
+	AnswerStatusDeleted:   "deleted",
+	AnswerStatusPending:   "pending",
+}
+
 // Answer answer
 type Answer struct {
 	ID             string    `xorm:"not null pk autoincr BIGINT(20) id"`
@@ -53,6 +59,7 @@ type Answer struct {
 	CommentCount   int       `xorm:"not null default 0 INT(11) comment_count"`
 	VoteCount      int       `xorm:"not null default 0 INT(11) vote_count"`
 	RevisionID     string    `xorm:"not null default 0 BIGINT(20) revision_id"`
+	Wiki           bool      `xorm:"not null default false BOOL wiki"`
 }
 
 type AnswerSearch struct {
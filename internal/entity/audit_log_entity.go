@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// AuditLog the operation of the admin or moderator, it is append-only
+type AuditLog struct {
+	ID             int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt      time.Time `xorm:"created not null default CURRENT_TIMESTAMP index TIMESTAMP created_at"`
+	OperatorUserID string    `xorm:"not null default 0 index BIGINT(20) operator_user_id"`
+	Action         string    `xorm:"not null default '' index VARCHAR(100) action"`
+	ObjectType     string    `xorm:"not null default '' VARCHAR(100) object_type"`
+	ObjectID       string    `xorm:"not null default '' index VARCHAR(100) object_id"`
+	BeforeData     string    `xorm:"MEDIUMTEXT before_data"`
+	AfterData      string    `xorm:"MEDIUMTEXT after_data"`
+	IP             string    `xorm:"not null default '' VARCHAR(100) ip"`
+}
+
+// TableName audit log table name
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	DraftObjectTypeQuestion = "question"
+	DraftObjectTypeAnswer   = "answer"
+)
+
+// Draft the unpublished question or answer autosaved for the user
+type Draft struct {
+	ID         int       `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at index"`
+	UserID     string    `xorm:"not null default 0 index BIGINT(20) user_id"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(20) object_type"`
+	QuestionID string    `xorm:"not null default 0 BIGINT(20) question_id"`
+	Title      string    `xorm:"not null default '' VARCHAR(150) title"`
+	Content    string    `xorm:"MEDIUMTEXT content"`
+	Tags       string    `xorm:"TEXT tags"`
+	// AnswerContent the answer written together with the question draft
+	AnswerContent string `xorm:"MEDIUMTEXT answer_content"`
+}
+
+// TableName draft table name
+func (Draft) TableName() string {
+	return "draft"
+}
@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package entity
+
+import "time"
+
+const (
+	EmailLogStatusPending = 1
+	EmailLogStatusSending = 2
+	EmailLogStatusSent    = 3
+	EmailLogStatusFailed  = 4
+)
+
+// EmailLog email log, it keeps the outgoing emails until they are delivered
+type EmailLog struct {
+	ID          int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	ToEmail     string    `xorm:"not null default '' VARCHAR(256) to_email"`
+	Subject     string    `xorm:"not null default '' VARCHAR(512) subject"`
+	Body        string    `xorm:"not null MEDIUMTEXT body"`
+	Transport   string    `xorm:"not null default '' VARCHAR(64) transport"`
+	Status      int       `xorm:"not null default 1 index INT(11) status"`
+	Attempts    int       `xorm:"not null default 0 INT(11) attempts"`
+	NextRetryAt time.Time `xorm:"not null default CURRENT_TIMESTAMP index TIMESTAMP next_retry_at"`
+	LastError   string    `xorm:"TEXT last_error"`
+}
+
+// TableName email log table name
+func (EmailLog) TableName() string {
+	return "email_log"
+}
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	EventOutboxStatusPending    = 1
+	EventOutboxStatusProcessing = 2
+	EventOutboxStatusDone       = 3
+	EventOutboxStatusDead       = 4
+)
+
+// EventOutbox event outbox, it keeps the events that are waiting to be handled
+type EventOutbox struct {
+	ID           int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt    time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt    time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	EventType    string    `xorm:"not null default '' VARCHAR(64) event_type"`
+	Payload      string    `xorm:"not null MEDIUMTEXT payload"`
+	Status       int       `xorm:"not null default 1 index INT(11) status"`
+	Attempts     int       `xorm:"not null default 0 INT(11) attempts"`
+	NextRetryAt  time.Time `xorm:"not null default CURRENT_TIMESTAMP index TIMESTAMP next_retry_at"`
+	LastError    string    `xorm:"TEXT last_error"`
+	DoneHandlers string    `xorm:"TEXT done_handlers"`
+}
+
+// TableName event outbox table name
+func (EventOutbox) TableName() string {
+	return "event_outbox"
+}
+
+// GetDoneHandlers get the names of handlers which have handled the event successfully
+func (e *EventOutbox) GetDoneHandlers() []string {
+	if len(e.DoneHandlers) == 0 {
+		return nil
+	}
+	return strings.Split(e.DoneHandlers, ",")
+}
+
+// SetDoneHandlers set the names of handlers which have handled the event successfully, they are separated by comma
+func (e *EventOutbox) SetDoneHandlers(names []string) {
+	e.DoneHandlers = strings.Join(names, ",")
+}
@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	ModeratorMessageStatusUnacknowledged = 1
+	ModeratorMessageStatusAcknowledged   = 2
+)
+
+// ModeratorMessage the private message from the staff to the user, the user must acknowledge it
+type ModeratorMessage struct {
+	ID             int       `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt      time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	UserID         string    `xorm:"not null default 0 index BIGINT(20) user_id"`
+	OperatorUserID string    `xorm:"not null default 0 BIGINT(20) operator_user_id"`
+	ObjectID       string    `xorm:"not null default 0 BIGINT(20) object_id"`
+	TemplateKey    string    `xorm:"not null default '' VARCHAR(100) template_key"`
+	Content        string    `xorm:"not null MEDIUMTEXT content"`
+	Status         int       `xorm:"not null default 1 TINYINT(4) status"`
+	AcknowledgedAt time.Time `xorm:"TIMESTAMP acknowledged_at"`
+}
+
+// TableName moderator message table name
+func (ModeratorMessage) TableName() string {
+	return "moderator_message"
+}
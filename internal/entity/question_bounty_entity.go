@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	QuestionBountyStatusActive  = 1
+	QuestionBountyStatusAwarded = 2
+	QuestionBountyStatusExpired = 3
+	QuestionBountyStatusClosed  = 4
+)
+
+// QuestionBounty the reputation escrowed by a user to attract answers to a question
+type QuestionBounty struct {
+	ID              int       `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt       time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt       time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	QuestionID      string    `xorm:"not null default 0 index BIGINT(20) question_id"`
+	UserID          string    `xorm:"not null default 0 index BIGINT(20) user_id"`
+	Amount          int       `xorm:"not null default 0 INT(11) amount"`
+	Status          int       `xorm:"not null default 1 index TINYINT(4) status"`
+	ExpiresAt       time.Time `xorm:"not null default CURRENT_TIMESTAMP index TIMESTAMP expires_at"`
+	AwardedAnswerID string    `xorm:"not null default 0 BIGINT(20) awarded_answer_id"`
+	AwardedUserID   string    `xorm:"not null default 0 BIGINT(20) awarded_user_id"`
+	AwardedAmount   int       `xorm:"not null default 0 INT(11) awarded_amount"`
+}
+
+// TableName question bounty table name
+func (QuestionBounty) TableName() string {
+	return "question_bounty"
+}
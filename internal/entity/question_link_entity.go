@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	QuestionLinkTypeDuplicate = 1
+	QuestionLinkTypeMerged    = 2
+
+	QuestionLinkStatusAvailable = 1
+	QuestionLinkStatusDeleted   = 10
+)
+
+// QuestionLink the relation from a question to another question, such as closed as duplicate or merged into
+type QuestionLink struct {
+	ID             int       `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt      time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	FromQuestionID string    `xorm:"not null default 0 index BIGINT(20) from_question_id"`
+	ToQuestionID   string    `xorm:"not null default 0 index BIGINT(20) to_question_id"`
+	LinkType       int       `xorm:"not null default 1 TINYINT(4) link_type"`
+	Status         int       `xorm:"not null default 1 TINYINT(4) status"`
+	UserID         string    `xorm:"not null default 0 BIGINT(20) user_id"`
+}
+
+// TableName question link table name
+func (QuestionLink) TableName() string {
+	return "question_link"
+}
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	ReviewTaskQueueFirstPost  = 1
+	ReviewTaskQueueLateAnswer = 2
+	ReviewTaskQueueLowQuality = 3
+
+	ReviewTaskStatusPending   = 1
+	ReviewTaskStatusCompleted = 2
+	// the post is deleted or hidden before it is reviewed
+	ReviewTaskStatusInvalid = 3
+)
+
+// ReviewTask the post waiting to be reviewed in the review queue
+type ReviewTask struct {
+	ID         int       `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created not null default CURRENT_TIMESTAMP index TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	QueueType  int       `xorm:"not null default 0 index TINYINT(4) queue_type"`
+	ObjectID   string    `xorm:"not null default 0 index BIGINT(20) object_id"`
+	ObjectType int       `xorm:"not null default 0 INT(11) object_type"`
+	// the author of the post
+	UserID string `xorm:"not null default 0 BIGINT(20) user_id"`
+	// the matched low quality heuristics, separated by commas
+	Reason string `xorm:"not null default '' VARCHAR(255) reason"`
+	Status int    `xorm:"not null default 1 index TINYINT(4) status"`
+	// the reviewer and the review reason key which is chosen as the outcome
+	ReviewerUserID string    `xorm:"not null default 0 index BIGINT(20) reviewer_user_id"`
+	Outcome        string    `xorm:"not null default '' VARCHAR(100) outcome"`
+	ReviewedAt     time.Time `xorm:"TIMESTAMP reviewed_at"`
+}
+
+// TableName review task table name
+func (ReviewTask) TableName() string {
+	return "review_task"
+}
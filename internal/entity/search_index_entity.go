@@ -0,0 +1,33 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+// SearchIndex native full-text search index of questions and answers.
+// In SQLite, it is a FTS5 virtual table and the object id is stored as rowid.
+type SearchIndex struct {
+	ObjectID string `xorm:"not null pk BIGINT(20) object_id"`
+	Title    string `xorm:"not null default '' VARCHAR(150) title"`
+	Content  string `xorm:"not null MEDIUMTEXT content"`
+}
+
+// TableName search index table name
+func (SearchIndex) TableName() string {
+	return "search_index"
+}
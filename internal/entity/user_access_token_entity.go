@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	UserAccessTokenStatusAvailable = 1
+	UserAccessTokenStatusRevoked   = 2
+)
+
+// UserAccessToken personal access token, only the hash of the token is saved
+type UserAccessToken struct {
+	ID          int       `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	UserID      string    `xorm:"not null default 0 index BIGINT(20) user_id"`
+	Name        string    `xorm:"not null default '' VARCHAR(100) name"`
+	TokenHash   string    `xorm:"not null default '' unique VARCHAR(64) token_hash"`
+	TokenPrefix string    `xorm:"not null default '' VARCHAR(16) token_prefix"`
+	Scopes      string    `xorm:"not null default '' VARCHAR(100) scopes"`
+	Status      int       `xorm:"not null default 1 INT(11) status"`
+	ExpiredAt   time.Time `xorm:"TIMESTAMP expired_at"`
+	LastUsedAt  time.Time `xorm:"TIMESTAMP last_used_at"`
+}
+
+// TableName user access token table name
+func (UserAccessToken) TableName() string {
+	return "user_access_token"
+}
@@ -42,6 +42,7 @@ type User struct {
 	CreatedAt      time.Time `xorm:"created TIMESTAMP created_at"`
 	UpdatedAt      time.Time `xorm:"updated TIMESTAMP updated_at"`
 	SuspendedAt    time.Time `xorm:"TIMESTAMP suspended_at"`
+	SuspendedUntil time.Time `xorm:"TIMESTAMP suspended_until"`
 	DeletedAt      time.Time `xorm:"TIMESTAMP deleted_at"`
 	LastLoginDate  time.Time `xorm:"TIMESTAMP last_login_date"`
 	Username       string    `xorm:"not null default '' VARCHAR(50) UNIQUE username"`
@@ -30,6 +30,8 @@ type UserNotificationConfig struct {
 	Source    string    `xorm:"not null default '' INDEX UNIQUE(uk_us) VARCHAR(64) source"`
 	Channels  string    `xorm:"not null TEXT channels"`
 	Enabled   bool      `xorm:"not null default false BOOL enabled"`
+	// DigestSentAt the time when the last digest is sent, only used by the digest source
+	DigestSentAt time.Time `xorm:"TIMESTAMP digest_sent_at"`
 }
 
 // TableName notification table name
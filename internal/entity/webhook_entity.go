@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// Webhook webhook
+type Webhook struct {
+	ID          int       `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	Name        string    `xorm:"not null default '' VARCHAR(100) name"`
+	URL         string    `xorm:"not null default '' VARCHAR(1024) url"`
+	Secret      string    `xorm:"not null default '' VARCHAR(255) secret"`
+	Events      string    `xorm:"not null TEXT events"`
+	Active      bool      `xorm:"not null default false BOOL active"`
+	Description string    `xorm:"not null default '' VARCHAR(500) description"`
+}
+
+// TableName webhook table name
+func (Webhook) TableName() string {
+	return "webhook"
+}
+
+// WebhookDelivery webhook delivery, every attempt will be recorded
+type WebhookDelivery struct {
+	ID           int       `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt    time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	WebhookID    int       `xorm:"not null index BIGINT(20) webhook_id"`
+	DeliveryID   string    `xorm:"not null default '' index VARCHAR(64) delivery_id"`
+	EventType    string    `xorm:"not null default '' VARCHAR(64) event_type"`
+	Attempt      int       `xorm:"not null default 1 INT(11) attempt"`
+	Success      bool      `xorm:"not null default false BOOL success"`
+	StatusCode   int       `xorm:"not null default 0 INT(11) status_code"`
+	Duration     int64     `xorm:"not null default 0 BIGINT(20) duration"`
+	RequestBody  string    `xorm:"not null MEDIUMTEXT request_body"`
+	ResponseBody string    `xorm:"TEXT response_body"`
+	Error        string    `xorm:"TEXT error"`
+}
+
+// TableName webhook delivery table name
+func (WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
+
+const (
+	WebhookTaskStatusPending    = 1
+	WebhookTaskStatusProcessing = 2
+	WebhookTaskStatusDone       = 3
+	WebhookTaskStatusDead       = 4
+)
+
+// WebhookTask webhook task, it keeps the deliveries that are waiting to be sent or retried
+type WebhookTask struct {
+	ID          int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	WebhookID   int       `xorm:"not null index BIGINT(20) webhook_id"`
+	DeliveryID  string    `xorm:"not null default '' VARCHAR(64) delivery_id"`
+	EventType   string    `xorm:"not null default '' VARCHAR(64) event_type"`
+	Payload     string    `xorm:"not null MEDIUMTEXT payload"`
+	Status      int       `xorm:"not null default 1 index INT(11) status"`
+	Attempts    int       `xorm:"not null default 0 INT(11) attempts"`
+	NextRetryAt time.Time `xorm:"not null default CURRENT_TIMESTAMP index TIMESTAMP next_retry_at"`
+}
+
+// TableName webhook task table name
+func (WebhookTask) TableName() string {
+	return "webhook_task"
+}
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
+)
+
+// addPowersToRoles add or update the powers, then grant them to the roles if not granted yet
+func addPowersToRoles(ctx context.Context, x *xorm.Engine, powers []*entity.Power, roleIDs ...int) error {
+	for _, power := range powers {
+		exist, err := x.Context(ctx).Get(&entity.Power{ID: power.ID})
+		if err != nil {
+			return fmt.Errorf("get power failed: %w", err)
+		}
+		if exist {
+			_, err = x.Context(ctx).ID(power.ID).Update(power)
+		} else {
+			_, err = x.Context(ctx).Insert(power)
+		}
+		if err != nil {
+			return fmt.Errorf("add power failed: %w", err)
+		}
+	}
+
+	for _, roleID := range roleIDs {
+		for _, power := range powers {
+			rel := &entity.RolePowerRel{RoleID: roleID, PowerType: power.PowerType}
+			exist, err := x.Context(ctx).Get(&entity.RolePowerRel{RoleID: rel.RoleID, PowerType: rel.PowerType})
+			if err != nil {
+				return fmt.Errorf("get role power rel failed: %w", err)
+			}
+			if exist {
+				continue
+			}
+			if _, err = x.Context(ctx).Insert(rel); err != nil {
+				return fmt.Errorf("add role power rel failed: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// addDefaultConfigs insert the configs which do not exist,
+// the existing configs are kept because they may have been changed by the admin
+func addDefaultConfigs(ctx context.Context, x *xorm.Engine, configs []*entity.Config) error {
+	for _, c := range configs {
+		exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+		if err != nil {
+			return fmt.Errorf("get config failed: %w", err)
+		}
+		if exist {
+			continue
+		}
+		if _, err = x.Context(ctx).Insert(&entity.Config{ID: c.ID, Key: c.Key, Value: c.Value}); err != nil {
+			log.Errorf("insert %+v config failed: %s", c, err)
+			return fmt.Errorf("add config failed: %w", err)
+		}
+	}
+	return nil
+}
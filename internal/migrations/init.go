@@ -80,6 +80,7 @@ func (m *Mentor) InitDB() error {
 	m.do("init site info write", m.initSiteInfoWrite)
 	m.do("init default content", m.initDefaultContent)
 	m.do("init default badges", m.initDefaultBadges)
+	m.do("init search index", m.initSearchIndex)
 	return m.err
 }
 
@@ -104,6 +105,10 @@ func (m *Mentor) syncTable() {
 	m.err = m.engine.Context(m.ctx).Sync(tables...)
 }
 
+func (m *Mentor) initSearchIndex() {
+	m.err = InitSearchIndex(m.ctx, m.engine)
+}
+
 func (m *Mentor) initVersionTable() {
 	_, m.err = m.engine.Context(m.ctx).Insert(&entity.Version{ID: 1, VersionNumber: ExpectedVersion()})
 }
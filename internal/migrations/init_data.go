@@ -72,6 +72,22 @@ var (
 		&entity.Badge{},
 		&entity.BadgeGroup{},
 		&entity.BadgeAward{},
+		&entity.EventOutbox{},
+		&entity.Webhook{},
+		&entity.WebhookDelivery{},
+		&entity.WebhookTask{},
+		&entity.UserAccessToken{},
+		&entity.SavedSearch{},
+		&entity.EmailLog{},
+		&entity.QuestionBounty{},
+		&entity.QuestionLink{},
+		&entity.Draft{},
+		&entity.TagModerator{},
+		&entity.UserSuspension{},
+		&entity.ModeratorMessage{},
+		&entity.AuditLog{},
+		&entity.QuestionCloseVote{},
+		&entity.ReviewTask{},
 	}
 
 	roles = []*entity.Role{
@@ -122,6 +138,15 @@ var (
 		{ID: 39, Name: "recover answer", PowerType: permission.AnswerUnDelete, Description: "recover deleted answer"},
 		{ID: 40, Name: "recover question", PowerType: permission.QuestionUnDelete, Description: "recover deleted question"},
 		{ID: 41, Name: "recover tag", PowerType: permission.TagUnDelete, Description: "recover deleted tag"},
+		{ID: 42, Name: "merge question", PowerType: permission.QuestionMerge, Description: "merge question"},
+		{ID: 43, Name: "question wiki", PowerType: permission.QuestionWiki, Description: "make question community wiki"},
+		{ID: 44, Name: "answer wiki", PowerType: permission.AnswerWiki, Description: "make answer community wiki"},
+		{ID: 45, Name: "convert comment", PowerType: permission.CommentConvert, Description: "convert comment to answer"},
+		{ID: 46, Name: "convert answer", PowerType: permission.AnswerConvert, Description: "convert answer to comment"},
+		{ID: 47, Name: "moderator message", PowerType: permission.UserModeratorMessage, Description: "send private moderator message to user"},
+		{ID: 48, Name: "review first posts", PowerType: permission.ReviewFirstPost, Description: "review the first posts of new users"},
+		{ID: 49, Name: "review late answers", PowerType: permission.ReviewLateAnswer, Description: "review the answers to old questions"},
+		{ID: 50, Name: "review low quality posts", PowerType: permission.ReviewLowQuality, Description: "review the low quality posts"},
 	}
 
 	rolePowerRels = []*entity.RolePowerRel{
@@ -166,6 +191,15 @@ var (
 		{RoleID: 2, PowerType: permission.AnswerInviteSomeoneToAnswer},
 		{RoleID: 2, PowerType: permission.AnswerUnDelete},
 		{RoleID: 2, PowerType: permission.QuestionUnDelete},
+		{RoleID: 2, PowerType: permission.QuestionMerge},
+		{RoleID: 2, PowerType: permission.QuestionWiki},
+		{RoleID: 2, PowerType: permission.AnswerWiki},
+		{RoleID: 2, PowerType: permission.CommentConvert},
+		{RoleID: 2, PowerType: permission.AnswerConvert},
+		{RoleID: 2, PowerType: permission.UserModeratorMessage},
+		{RoleID: 2, PowerType: permission.ReviewFirstPost},
+		{RoleID: 2, PowerType: permission.ReviewLateAnswer},
+		{RoleID: 2, PowerType: permission.ReviewLowQuality},
 		{RoleID: 2, PowerType: permission.TagUnDelete},
 
 		{RoleID: 3, PowerType: permission.QuestionAdd},
@@ -208,6 +242,15 @@ var (
 		{RoleID: 3, PowerType: permission.AnswerInviteSomeoneToAnswer},
 		{RoleID: 3, PowerType: permission.AnswerUnDelete},
 		{RoleID: 3, PowerType: permission.QuestionUnDelete},
+		{RoleID: 3, PowerType: permission.QuestionMerge},
+		{RoleID: 3, PowerType: permission.QuestionWiki},
+		{RoleID: 3, PowerType: permission.AnswerWiki},
+		{RoleID: 3, PowerType: permission.CommentConvert},
+		{RoleID: 3, PowerType: permission.AnswerConvert},
+		{RoleID: 3, PowerType: permission.UserModeratorMessage},
+		{RoleID: 3, PowerType: permission.ReviewFirstPost},
+		{RoleID: 3, PowerType: permission.ReviewLateAnswer},
+		{RoleID: 3, PowerType: permission.ReviewLowQuality},
 		{RoleID: 3, PowerType: permission.TagUnDelete},
 	}
 
@@ -346,6 +389,38 @@ var (
 		{ID: 128, Key: "rank.answer.undeleted", Value: `-1`},
 		{ID: 129, Key: "rank.question.undeleted", Value: `-1`},
 		{ID: 130, Key: "rank.tag.undeleted", Value: `-1`},
+		{ID: 131, Key: "question.bounty_offer", Value: `0`},
+		{ID: 132, Key: "answer.bounty_awarded", Value: `0`},
+		{ID: 133, Key: "question.bounty_refund", Value: `0`},
+		{ID: 134, Key: "rank.question.bounty", Value: `75`},
+		{ID: 135, Key: "rank.question.merge", Value: `-1`},
+		{ID: 136, Key: "question.merged", Value: `0`},
+		{ID: 137, Key: "rank.question.wiki", Value: `-1`},
+		{ID: 138, Key: "rank.answer.wiki", Value: `-1`},
+		{ID: 139, Key: "rank.question.wiki_edit", Value: `100`},
+		{ID: 140, Key: "rank.answer.wiki_edit", Value: `100`},
+		{ID: 141, Key: "question.wiki", Value: `0`},
+		{ID: 142, Key: "question.unwiki", Value: `0`},
+		{ID: 143, Key: "answer.wiki", Value: `0`},
+		{ID: 144, Key: "answer.unwiki", Value: `0`},
+		{ID: 145, Key: "rank.comment.convert", Value: `-1`},
+		{ID: 146, Key: "rank.answer.convert", Value: `-1`},
+		{ID: 147, Key: "answer.converted_from_comment", Value: `0`},
+		{ID: 148, Key: "answer.converted_to_comment", Value: `0`},
+		{ID: 149, Key: "reason.suspend_spam", Value: `{"name":"spam","description":"This user posts advertisements or vandalizes the site."}`},
+		{ID: 150, Key: "reason.suspend_rude_or_abusive", Value: `{"name":"rude or abusive","description":"This user is rude or abusive to other members of the community."}`},
+		{ID: 151, Key: "reason.suspend_low_quality", Value: `{"name":"low quality contributions","description":"This user keeps posting low quality content despite earlier warnings."}`},
+		{ID: 152, Key: "reason.suspend_something", Value: `{"name":"something else","description":"This user is suspended for another reason not listed above.","content_type":"textarea"}`},
+		{ID: 153, Key: "user.suspend.reasons", Value: `["reason.suspend_spam","reason.suspend_rude_or_abusive","reason.suspend_low_quality","reason.suspend_something"]`},
+		{ID: 154, Key: "reason.message_low_quality", Value: `{"name":"low quality posts","description":"Several of your recent posts have been of low quality. Please review the community guidelines and take more care with future posts."}`},
+		{ID: 155, Key: "reason.message_rude_or_abusive", Value: `{"name":"rude or abusive","description":"Some of your recent posts or comments were rude or abusive. Please be respectful to other members of the community."}`},
+		{ID: 156, Key: "reason.message_voting_fraud", Value: `{"name":"voting irregularities","description":"We have detected irregular voting patterns on your account. Please vote on the content itself rather than on specific users."}`},
+		{ID: 157, Key: "reason.message_something", Value: `{"name":"something else","description":"","content_type":"textarea"}`},
+		{ID: 158, Key: "user.moderator_message.reasons", Value: `["reason.message_low_quality","reason.message_rude_or_abusive","reason.message_voting_fraud","reason.message_something"]`},
+		{ID: 159, Key: "rank.user.moderator_message", Value: `-1`},
+		{ID: 160, Key: "rank.review.first_post", Value: `500`},
+		{ID: 161, Key: "rank.review.late_answer", Value: `500`},
+		{ID: 162, Key: "rank.review.low_quality", Value: `2000`},
 	}
 
 	defaultBadgeGroupTable = []*entity.BadgeGroup{
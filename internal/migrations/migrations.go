@@ -98,6 +98,26 @@ var migrations = []Migration{
 	NewMigration("v1.3.0", "add review", addReview, false),
 	NewMigration("v1.3.6", "add hot score to question table", addQuestionHotScore, true),
 	NewMigration("v1.4.0", "add badge/badge_group/badge_award table", addBadges, true),
+	NewMigration("v1.4.1", "add event outbox table", addEventOutbox, false),
+	NewMigration("v1.4.2", "add webhook tables", addWebhook, false),
+	NewMigration("v1.4.3", "add user access token table", addUserAccessToken, false),
+	NewMigration("v1.4.4", "add native full-text search index", addSearchIndex, false),
+	NewMigration("v1.4.5", "add saved search table", addSavedSearch, false),
+	NewMigration("v1.4.6", "add email log table", addEmailLog, false),
+	NewMigration("v1.4.7", "add question bounty table", addQuestionBounty, true),
+	NewMigration("v1.4.8", "add question link table", addQuestionLink, true),
+	NewMigration("v1.4.9", "add community wiki mode", addCommunityWiki, true),
+	NewMigration("v1.4.10", "add draft table", addDraft, true),
+	NewMigration("v1.4.11", "add post convert permission", addPostConvert, true),
+	NewMigration("v1.4.12", "add tag moderator table", addTagModerator, true),
+	NewMigration("v1.4.13", "add user suspension table", addUserSuspension, true),
+	NewMigration("v1.4.14", "add moderator message table", addModeratorMessage, true),
+	NewMigration("v1.4.15", "add audit log table", addAuditLog, true),
+	NewMigration("v1.4.16", "add question close vote table", addQuestionCloseVote, true),
+	NewMigration("v1.4.17", "add review task table", addReviewTask, true),
+	NewMigration("v1.4.18", "add webhook task table", addWebhookTask, false),
+	NewMigration("v1.4.19", "add done handlers to event outbox", addEventOutboxDoneHandlers, false),
+	NewMigration("v1.4.20", "add digest sent time to user notification config", addUserNotificationConfigDigestSentAt, false),
 }
 
 func GetMigrations() []Migration {
@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// RebuildSearchIndexWithConfig create the native full-text search index if not exist and rebuild it
+func RebuildSearchIndexWithConfig(dbConf *data.Database) error {
+	engine, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	return InitSearchIndex(context.Background(), engine)
+}
+
+// InitSearchIndex create the native full-text search index and fill it with existing questions and answers
+func InitSearchIndex(ctx context.Context, x *xorm.Engine) (err error) {
+	switch x.Dialect().URI().DBType {
+	case schemas.SQLITE:
+		_, err = x.Context(ctx).Exec(
+			`CREATE VIRTUAL TABLE IF NOT EXISTS "search_index" USING fts5("title", "content", tokenize = 'unicode61')`)
+		if err != nil {
+			return fmt.Errorf("create search index table failed: %w", err)
+		}
+	case schemas.POSTGRES:
+		if err = x.Context(ctx).Sync(new(entity.SearchIndex)); err != nil {
+			return fmt.Errorf("sync search index table failed: %w", err)
+		}
+		_, err = x.Context(ctx).Exec(`CREATE INDEX IF NOT EXISTS "IDX_search_index_fulltext" ON "search_index" ` +
+			`USING GIN ((setweight(to_tsvector('simple', "title"), 'A') || setweight(to_tsvector('simple', "content"), 'B')))`)
+		if err != nil {
+			return fmt.Errorf("create search index failed: %w", err)
+		}
+	case schemas.MYSQL:
+		if err = x.Context(ctx).Sync(new(entity.SearchIndex)); err != nil {
+			return fmt.Errorf("sync search index table failed: %w", err)
+		}
+		count, err := x.Context(ctx).SQL("SELECT COUNT(*) FROM `information_schema`.`statistics` "+
+			"WHERE `table_schema` = DATABASE() AND `table_name` = ? AND `index_name` = ?",
+			entity.SearchIndex{}.TableName(), "IDX_search_index_fulltext").Count()
+		if err != nil {
+			return fmt.Errorf("check search index failed: %w", err)
+		}
+		if count == 0 {
+			_, err = x.Context(ctx).Exec(
+				"ALTER TABLE `search_index` ADD FULLTEXT INDEX `IDX_search_index_fulltext` (`title`, `content`)")
+			if err != nil {
+				return fmt.Errorf("create search index failed: %w", err)
+			}
+		}
+	default:
+		return nil
+	}
+	return RebuildSearchIndex(ctx, x)
+}
+
+// RebuildSearchIndex clear the native full-text search index and fill it with all questions and answers
+func RebuildSearchIndex(ctx context.Context, x *xorm.Engine) (err error) {
+	idColumn := "object_id"
+	if x.Dialect().URI().DBType == schemas.SQLITE {
+		idColumn = "rowid"
+	}
+	sqlList := []string{
+		"DELETE FROM search_index",
+		fmt.Sprintf("INSERT INTO search_index (%s, title, content) SELECT id, title, original_text FROM question", idColumn),
+		fmt.Sprintf("INSERT INTO search_index (%s, title, content) SELECT id, '', original_text FROM answer", idColumn),
+	}
+	_, err = x.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		for _, sql := range sqlList {
+			if _, err := session.Exec(sql); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("rebuild search index failed: %w", err)
+	}
+	return nil
+}
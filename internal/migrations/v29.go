@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+func addQuestionBounty(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.QuestionBounty)); err != nil {
+		return fmt.Errorf("sync question bounty table failed: %w", err)
+	}
+
+	defaultConfigTable := []*entity.Config{
+		{ID: 131, Key: "question.bounty_offer", Value: `0`},
+		{ID: 132, Key: "answer.bounty_awarded", Value: `0`},
+		{ID: 133, Key: "question.bounty_refund", Value: `0`},
+		{ID: 134, Key: "rank.question.bounty", Value: `75`},
+	}
+	return addDefaultConfigs(ctx, x, defaultConfigTable)
+}
@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"xorm.io/xorm"
+)
+
+func addQuestionLink(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.QuestionLink)); err != nil {
+		return fmt.Errorf("sync question link table failed: %w", err)
+	}
+
+	powers := []*entity.Power{
+		{ID: 42, Name: "merge question", PowerType: permission.QuestionMerge, Description: "merge question"},
+	}
+	if err := addPowersToRoles(ctx, x, powers, 2, 3); err != nil {
+		return err
+	}
+
+	defaultConfigTable := []*entity.Config{
+		{ID: 135, Key: "rank.question.merge", Value: `-1`},
+		{ID: 136, Key: "question.merged", Value: `0`},
+	}
+	return addDefaultConfigs(ctx, x, defaultConfigTable)
+}
@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"xorm.io/xorm"
+)
+
+func addCommunityWiki(ctx context.Context, x *xorm.Engine) error {
+	type Question struct {
+		Wiki bool `xorm:"not null default false BOOL wiki"`
+	}
+	type Answer struct {
+		Wiki bool `xorm:"not null default false BOOL wiki"`
+	}
+	if err := x.Context(ctx).Sync(new(Question), new(Answer)); err != nil {
+		return fmt.Errorf("sync question and answer table failed: %w", err)
+	}
+
+	powers := []*entity.Power{
+		{ID: 43, Name: "question wiki", PowerType: permission.QuestionWiki, Description: "make question community wiki"},
+		{ID: 44, Name: "answer wiki", PowerType: permission.AnswerWiki, Description: "make answer community wiki"},
+	}
+	if err := addPowersToRoles(ctx, x, powers, 2, 3); err != nil {
+		return err
+	}
+
+	defaultConfigTable := []*entity.Config{
+		{ID: 137, Key: "rank.question.wiki", Value: `-1`},
+		{ID: 138, Key: "rank.answer.wiki", Value: `-1`},
+		{ID: 139, Key: "rank.question.wiki_edit", Value: `100`},
+		{ID: 140, Key: "rank.answer.wiki_edit", Value: `100`},
+		{ID: 141, Key: "question.wiki", Value: `0`},
+		{ID: 142, Key: "question.unwiki", Value: `0`},
+		{ID: 143, Key: "answer.wiki", Value: `0`},
+		{ID: 144, Key: "answer.unwiki", Value: `0`},
+	}
+	return addDefaultConfigs(ctx, x, defaultConfigTable)
+}
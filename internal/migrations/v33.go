@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"xorm.io/xorm"
+)
+
+func addPostConvert(ctx context.Context, x *xorm.Engine) error {
+	powers := []*entity.Power{
+		{ID: 45, Name: "convert comment", PowerType: permission.CommentConvert, Description: "convert comment to answer"},
+		{ID: 46, Name: "convert answer", PowerType: permission.AnswerConvert, Description: "convert answer to comment"},
+	}
+	if err := addPowersToRoles(ctx, x, powers, 2, 3); err != nil {
+		return err
+	}
+
+	defaultConfigTable := []*entity.Config{
+		{ID: 145, Key: "rank.comment.convert", Value: `-1`},
+		{ID: 146, Key: "rank.answer.convert", Value: `-1`},
+		{ID: 147, Key: "answer.converted_from_comment", Value: `0`},
+		{ID: 148, Key: "answer.converted_to_comment", Value: `0`},
+	}
+	return addDefaultConfigs(ctx, x, defaultConfigTable)
+}
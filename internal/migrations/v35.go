@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+func addUserSuspension(ctx context.Context, x *xorm.Engine) error {
+	type User struct {
+		SuspendedUntil time.Time `xorm:"TIMESTAMP suspended_until"`
+	}
+	type UserSuspension struct {
+		ID             int       `xorm:"not null pk autoincr BIGINT(20) id"`
+		CreatedAt      time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		UpdatedAt      time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+		UserID         string    `xorm:"not null default 0 index BIGINT(20) user_id"`
+		OperatorUserID string    `xorm:"not null default 0 BIGINT(20) operator_user_id"`
+		ReasonKey      string    `xorm:"not null default '' VARCHAR(100) reason_key"`
+		Message        string    `xorm:"not null TEXT message"`
+		Duration       int       `xorm:"not null default 0 INT(11) duration"`
+		SuspendedUntil time.Time `xorm:"index TIMESTAMP suspended_until"`
+		Status         int       `xorm:"not null default 1 index TINYINT(4) status"`
+		LiftedAt       time.Time `xorm:"TIMESTAMP lifted_at"`
+	}
+	if err := x.Context(ctx).Sync(new(User), new(UserSuspension)); err != nil {
+		return fmt.Errorf("sync user suspension table failed: %w", err)
+	}
+
+	defaultConfigTable := []*entity.Config{
+		{ID: 149, Key: "reason.suspend_spam", Value: `{"name":"spam","description":"This user posts advertisements or vandalizes the site."}`},
+		{ID: 150, Key: "reason.suspend_rude_or_abusive", Value: `{"name":"rude or abusive","description":"This user is rude or abusive to other members of the community."}`},
+		{ID: 151, Key: "reason.suspend_low_quality", Value: `{"name":"low quality contributions","description":"This user keeps posting low quality content despite earlier warnings."}`},
+		{ID: 152, Key: "reason.suspend_something", Value: `{"name":"something else","description":"This user is suspended for another reason not listed above.","content_type":"textarea"}`},
+		{ID: 153, Key: "user.suspend.reasons", Value: `["reason.suspend_spam","reason.suspend_rude_or_abusive","reason.suspend_low_quality","reason.suspend_something"]`},
+	}
+	return addDefaultConfigs(ctx, x, defaultConfigTable)
+}
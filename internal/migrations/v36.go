@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"xorm.io/xorm"
+)
+
+func addModeratorMessage(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.ModeratorMessage)); err != nil {
+		return fmt.Errorf("sync moderator message table failed: %w", err)
+	}
+
+	powers := []*entity.Power{
+		{ID: 47, Name: "moderator message", PowerType: permission.UserModeratorMessage,
+			Description: "send private moderator message to user"},
+	}
+	if err := addPowersToRoles(ctx, x, powers, 2, 3); err != nil {
+		return err
+	}
+
+	defaultConfigTable := []*entity.Config{
+		{ID: 154, Key: "reason.message_low_quality", Value: `{"name":"low quality posts","description":"Several of your recent posts have been of low quality. Please review the community guidelines and take more care with future posts."}`},
+		{ID: 155, Key: "reason.message_rude_or_abusive", Value: `{"name":"rude or abusive","description":"Some of your recent posts or comments were rude or abusive. Please be respectful to other members of the community."}`},
+		{ID: 156, Key: "reason.message_voting_fraud", Value: `{"name":"voting irregularities","description":"We have detected irregular voting patterns on your account. Please vote on the content itself rather than on specific users."}`},
+		{ID: 157, Key: "reason.message_something", Value: `{"name":"something else","description":"","content_type":"textarea"}`},
+		{ID: 158, Key: "user.moderator_message.reasons", Value: `["reason.message_low_quality","reason.message_rude_or_abusive","reason.message_voting_fraud","reason.message_something"]`},
+		{ID: 159, Key: "rank.user.moderator_message", Value: `-1`},
+	}
+	return addDefaultConfigs(ctx, x, defaultConfigTable)
+}
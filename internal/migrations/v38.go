@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+func addQuestionCloseVote(ctx context.Context, x *xorm.Engine) error {
+	type QuestionCloseVote struct {
+		ID           int       `xorm:"not null pk autoincr BIGINT(20) id"`
+		CreatedAt    time.Time `xorm:"created not null default CURRENT_TIMESTAMP index TIMESTAMP created_at"`
+		UpdatedAt    time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+		QuestionID   string    `xorm:"not null default 0 unique(question_user_vote) index BIGINT(20) question_id"`
+		UserID       string    `xorm:"not null default 0 unique(question_user_vote) BIGINT(20) user_id"`
+		VoteType     int       `xorm:"not null default 1 unique(question_user_vote) TINYINT(4) vote_type"`
+		CloseType    int       `xorm:"not null default 0 INT(11) close_type"`
+		CloseMsg     string    `xorm:"not null default '' VARCHAR(500) close_msg"`
+		DuplicateIDs string    `xorm:"not null default '' VARCHAR(255) duplicate_ids"`
+		Status       int       `xorm:"not null default 1 index TINYINT(4) status"`
+	}
+	if err := x.Context(ctx).Sync(new(QuestionCloseVote)); err != nil {
+		return fmt.Errorf("sync question close vote table failed: %w", err)
+	}
+	return nil
+}
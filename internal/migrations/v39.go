@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"xorm.io/xorm"
+)
+
+func addReviewTask(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.ReviewTask)); err != nil {
+		return fmt.Errorf("sync review task table failed: %w", err)
+	}
+
+	powers := []*entity.Power{
+		{ID: 48, Name: "review first posts", PowerType: permission.ReviewFirstPost, Description: "review the first posts of new users"},
+		{ID: 49, Name: "review late answers", PowerType: permission.ReviewLateAnswer, Description: "review the answers to old questions"},
+		{ID: 50, Name: "review low quality posts", PowerType: permission.ReviewLowQuality, Description: "review the low quality posts"},
+	}
+	if err := addPowersToRoles(ctx, x, powers, 2, 3); err != nil {
+		return err
+	}
+
+	defaultConfigTable := []*entity.Config{
+		{ID: 160, Key: "rank.review.first_post", Value: `500`},
+		{ID: 161, Key: "rank.review.late_answer", Value: `500`},
+		{ID: 162, Key: "rank.review.low_quality", Value: `2000`},
+	}
+	return addDefaultConfigs(ctx, x, defaultConfigTable)
+}
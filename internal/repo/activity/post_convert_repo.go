@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package activity
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/activity_common"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// PostConvertRepo convert post repository
+type PostConvertRepo struct {
+	data         *data.Data
+	activityRepo activity_common.ActivityRepo
+}
+
+// NewPostConvertRepo new repository
+func NewPostConvertRepo(
+	data *data.Data,
+	activityRepo activity_common.ActivityRepo,
+) content.PostConvertRepo {
+	return &PostConvertRepo{
+		data:         data,
+		activityRepo: activityRepo,
+	}
+}
+
+// ConvertCommentToAnswer keep the created time of the comment and carry the up votes over to the answer converted from it.
+// The up votes are kept without reputation, because the comment votes never earned any reputation.
+func (pr *PostConvertRepo) ConvertCommentToAnswer(ctx context.Context, comment *entity.Comment, answerID string) (err error) {
+	commentVoteUp, err := pr.activityRepo.GetActivityTypeByConfigKey(ctx, activity_type.CommentVoteUp)
+	if err != nil {
+		return err
+	}
+	answerVoteUp, err := pr.activityRepo.GetActivityTypeByConfigKey(ctx, activity_type.AnswerVoteUp)
+	if err != nil {
+		return err
+	}
+
+	_, err = pr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		voteCount, err := pr.copyVotes(session, comment.ID, answerID, commentVoteUp, answerVoteUp)
+		if err != nil {
+			return nil, err
+		}
+		// the created column is skipped when updating with the struct, so the map is used here
+		_, err = session.Table(entity.Answer{}.TableName()).Where("id = ?", answerID).NoAutoTime().Update(map[string]any{
+			"created_at": comment.CreatedAt,
+			"updated_at": comment.UpdatedAt,
+			"vote_count": voteCount,
+		})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// ConvertAnswerToComment keep the created time of the answer and carry the up votes over to the comment converted from it.
+// The down votes are dropped because the comment can not be voted down,
+// and the reputation earned by the answer is kept just like the answer is deleted.
+func (pr *PostConvertRepo) ConvertAnswerToComment(ctx context.Context, answer *entity.Answer, commentID string) (err error) {
+	answerVoteUp, err := pr.activityRepo.GetActivityTypeByConfigKey(ctx, activity_type.AnswerVoteUp)
+	if err != nil {
+		return err
+	}
+	commentVoteUp, err := pr.activityRepo.GetActivityTypeByConfigKey(ctx, activity_type.CommentVoteUp)
+	if err != nil {
+		return err
+	}
+
+	_, err = pr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		voteCount, err := pr.copyVotes(session, answer.ID, commentID, answerVoteUp, commentVoteUp)
+		if err != nil {
+			return nil, err
+		}
+		updatedAt := answer.UpdatedAt
+		if updatedAt.IsZero() {
+			updatedAt = answer.CreatedAt
+		}
+		_, err = session.Table(&entity.Comment{}).Where("id = ?", commentID).NoAutoTime().Update(map[string]any{
+			"created_at": answer.CreatedAt,
+			"updated_at": updatedAt,
+			"vote_count": voteCount,
+		})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// copyVotes copy the available votes of the voters from one object to another, return the amount of the votes.
+// The votes of the original object are kept, so that the history of the deleted object is still complete.
+func (pr *PostConvertRepo) copyVotes(session *xorm.Session, fromObjectID, toObjectID string,
+	fromActivityType, toActivityType int) (count int, err error) {
+	votes := make([]*entity.Activity, 0)
+	err = session.Where("object_id = ?", fromObjectID).
+		And("activity_type = ?", fromActivityType).
+		And("cancelled = ?", entity.ActivityAvailable).
+		Find(&votes)
+	if err != nil {
+		return 0, err
+	}
+	for _, vote := range votes {
+		_, err = session.NoAutoTime().Insert(&entity.Activity{
+			CreatedAt:        vote.CreatedAt,
+			UpdatedAt:        vote.UpdatedAt,
+			UserID:           vote.UserID,
+			TriggerUserID:    vote.TriggerUserID,
+			ObjectID:         toObjectID,
+			OriginalObjectID: toObjectID,
+			ActivityType:     toActivityType,
+			Cancelled:        entity.ActivityAvailable,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(votes), nil
+}
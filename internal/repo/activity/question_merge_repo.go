@@ -0,0 +1,475 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package activity
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_common"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// QuestionMergeRepo merge question repository
+type QuestionMergeRepo struct {
+	data         *data.Data
+	activityRepo activity_common.ActivityRepo
+	userRankRepo rank.UserRankRepo
+}
+
+// NewQuestionMergeRepo new repository
+func NewQuestionMergeRepo(
+	data *data.Data,
+	activityRepo activity_common.ActivityRepo,
+	userRankRepo rank.UserRankRepo,
+) content.QuestionMergeRepo {
+	return &QuestionMergeRepo{
+		data:         data,
+		activityRepo: activityRepo,
+		userRankRepo: userRankRepo,
+	}
+}
+
+// MergeQuestion move answers, comments, votes, followers and collections from the source question to the target question.
+// The votes, followers and collections of the user who already did the same thing on the target question are kept in the source question.
+// The source question is closed with the close meta and linked to the target question in the same transaction.
+func (qr *QuestionMergeRepo) MergeQuestion(ctx context.Context, sourceQuestionID, targetQuestionID,
+	closeMeta, userID string) (err error) {
+	activityTypes := make(map[string]int)
+	for _, action := range []string{constant.ActVoteUp, constant.ActVoteDown,
+		constant.ActVotedUp, constant.ActVotedDown, constant.ActFollow} {
+		activityTypes[action], err = qr.activityRepo.GetActivityTypeByObjectType(ctx, constant.QuestionObjectType, action)
+		if err != nil {
+			return err
+		}
+	}
+	for _, configKey := range []string{activity_type.AnswerAccept, activity_type.AnswerAccepted} {
+		activityTypes[configKey], err = qr.activityRepo.GetActivityTypeByConfigKey(ctx, configKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		if err = qr.moveAnswers(ctx, session, sourceQuestionID, targetQuestionID, activityTypes); err != nil {
+			return nil, err
+		}
+		if err = qr.moveComments(session, sourceQuestionID, targetQuestionID); err != nil {
+			return nil, err
+		}
+		if err = qr.moveVotes(ctx, session, sourceQuestionID, targetQuestionID, activityTypes); err != nil {
+			return nil, err
+		}
+		if err = qr.moveFollows(session, sourceQuestionID, targetQuestionID, activityTypes[constant.ActFollow]); err != nil {
+			return nil, err
+		}
+		if err = qr.moveCollections(session, sourceQuestionID, targetQuestionID); err != nil {
+			return nil, err
+		}
+		for _, questionID := range []string{sourceQuestionID, targetQuestionID} {
+			if err = qr.updateQuestionCounts(session, questionID, activityTypes); err != nil {
+				return nil, err
+			}
+		}
+		if err = qr.closeSource(session, sourceQuestionID, closeMeta); err != nil {
+			return nil, err
+		}
+		if err = qr.linkQuestions(session, sourceQuestionID, targetQuestionID, userID); err != nil {
+			return nil, err
+		}
+		_, err = session.ID(targetQuestionID).Cols("post_update_time").
+			Update(&entity.Question{PostUpdateTime: time.Now()})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// closeSource close the source question as duplicate of the target question
+func (qr *QuestionMergeRepo) closeSource(session *xorm.Session, sourceQuestionID, closeMeta string) (err error) {
+	_, err = session.ID(sourceQuestionID).Cols("status").Update(&entity.Question{Status: entity.QuestionStatusClosed})
+	if err != nil {
+		return err
+	}
+	_, err = session.Insert(&entity.Meta{
+		ObjectID: sourceQuestionID,
+		Key:      entity.QuestionCloseReasonKey,
+		Value:    closeMeta,
+	})
+	return err
+}
+
+// linkQuestions replace all links from the source question with the merged link to the target question
+func (qr *QuestionMergeRepo) linkQuestions(session *xorm.Session, sourceQuestionID, targetQuestionID, userID string) (
+	err error) {
+	_, err = session.Where("from_question_id = ?", sourceQuestionID).
+		And("status = ?", entity.QuestionLinkStatusAvailable).
+		Cols("status").Update(&entity.QuestionLink{Status: entity.QuestionLinkStatusDeleted})
+	if err != nil {
+		return err
+	}
+	link := &entity.QuestionLink{
+		FromQuestionID: sourceQuestionID,
+		ToQuestionID:   targetQuestionID,
+		LinkType:       entity.QuestionLinkTypeMerged,
+		UserID:         userID,
+		Status:         entity.QuestionLinkStatusAvailable,
+	}
+	exist := &entity.QuestionLink{}
+	has, err := session.Where(builder.Eq{
+		"from_question_id": link.FromQuestionID,
+		"to_question_id":   link.ToQuestionID,
+		"link_type":        link.LinkType,
+	}).Get(exist)
+	if err != nil {
+		return err
+	}
+	if has {
+		_, err = session.ID(exist.ID).Cols("status", "user_id").Update(link)
+	} else {
+		_, err = session.Insert(link)
+	}
+	return err
+}
+
+// moveAnswers move all answers to the target question,
+// the accepted answer of the source question is kept only if the target question has no accepted answer,
+// otherwise it is not accepted any more and the rank of the accept activities is rolled back.
+func (qr *QuestionMergeRepo) moveAnswers(ctx context.Context, session *xorm.Session,
+	sourceQuestionID, targetQuestionID string, activityTypes map[string]int) (err error) {
+	target := &entity.Question{}
+	if _, err = session.ID(targetQuestionID).Cols("accepted_answer_id").Get(target); err != nil {
+		return err
+	}
+	source := &entity.Question{}
+	if _, err = session.ID(sourceQuestionID).Cols("accepted_answer_id").Get(source); err != nil {
+		return err
+	}
+	if len(source.AcceptedAnswerID) > 0 && source.AcceptedAnswerID != "0" {
+		if len(target.AcceptedAnswerID) > 0 && target.AcceptedAnswerID != "0" {
+			_, err = session.Where("question_id = ?", sourceQuestionID).
+				And("adopted = ?", schema.AnswerAcceptedEnable).
+				Cols("adopted").Update(&entity.Answer{Accepted: schema.AnswerAcceptedFailed})
+			if err != nil {
+				return err
+			}
+			accepts := make([]*entity.Activity, 0)
+			err = session.Where(builder.Eq{"object_id": source.AcceptedAnswerID, "cancelled": entity.ActivityAvailable}).
+				In("activity_type", activityTypes[activity_type.AnswerAccept], activityTypes[activity_type.AnswerAccepted]).
+				Find(&accepts)
+			if err != nil {
+				return err
+			}
+			if err = qr.cancelActivities(ctx, session, accepts); err != nil {
+				return err
+			}
+		} else {
+			_, err = session.ID(targetQuestionID).Cols("accepted_answer_id").
+				Update(&entity.Question{AcceptedAnswerID: source.AcceptedAnswerID})
+			if err != nil {
+				return err
+			}
+			// the accept activity refers to the question which accepts the answer
+			_, err = session.Where(builder.Eq{"object_id": source.AcceptedAnswerID, "original_object_id": sourceQuestionID}).
+				Cols("original_object_id").Update(&entity.Activity{OriginalObjectID: targetQuestionID})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	_, err = session.Where("question_id = ?", sourceQuestionID).Cols("question_id").
+		Update(&entity.Answer{QuestionID: targetQuestionID})
+	return err
+}
+
+// moveComments move the comments of the question and its answers to the target question
+func (qr *QuestionMergeRepo) moveComments(session *xorm.Session, sourceQuestionID, targetQuestionID string) (err error) {
+	_, err = session.Where("object_id = ?", sourceQuestionID).Cols("object_id").
+		Update(&entity.Comment{ObjectID: targetQuestionID})
+	if err != nil {
+		return err
+	}
+	_, err = session.Where("question_id = ?", sourceQuestionID).Cols("question_id").
+		Update(&entity.Comment{QuestionID: targetQuestionID})
+	return err
+}
+
+// moveVotes move the votes to the target question. The voted activities of the source question author are cancelled,
+// and the same voted activities are added to the target question author, so the rank is moved between the authors.
+func (qr *QuestionMergeRepo) moveVotes(ctx context.Context, session *xorm.Session,
+	sourceQuestionID, targetQuestionID string, activityTypes map[string]int) (err error) {
+	voteTypes := []int{activityTypes[constant.ActVoteUp], activityTypes[constant.ActVoteDown]}
+	votedTypes := []int{activityTypes[constant.ActVotedUp], activityTypes[constant.ActVotedDown]}
+
+	target := &entity.Question{}
+	if _, err = session.ID(targetQuestionID).Cols("user_id").Get(target); err != nil {
+		return err
+	}
+	voters, err := qr.getActivityUserIDs(session, targetQuestionID, voteTypes)
+	if err != nil {
+		return err
+	}
+	votes := make([]*entity.Activity, 0)
+	if err = session.Where("object_id = ?", sourceQuestionID).In("activity_type", voteTypes).Find(&votes); err != nil {
+		return err
+	}
+	for _, vote := range votes {
+		if voters[vote.UserID] {
+			continue
+		}
+		err = qr.removeCancelledActivities(session, builder.Eq{"object_id": targetQuestionID,
+			"user_id": vote.UserID, "activity_type": vote.ActivityType})
+		if err != nil {
+			return err
+		}
+		_, err = session.ID(vote.ID).Cols("object_id", "original_object_id").
+			Update(&entity.Activity{ObjectID: targetQuestionID, OriginalObjectID: targetQuestionID})
+		if err != nil {
+			return err
+		}
+
+		voted := make([]*entity.Activity, 0)
+		err = session.Where(builder.Eq{"object_id": sourceQuestionID, "trigger_user_id": vote.UserID,
+			"cancelled": entity.ActivityAvailable}).In("activity_type", votedTypes).Find(&voted)
+		if err != nil {
+			return err
+		}
+		if err = qr.cancelActivities(ctx, session, voted); err != nil {
+			return err
+		}
+		// the author can not get rank from the own vote
+		if target.UserID == vote.UserID {
+			continue
+		}
+		for _, act := range voted {
+			err = qr.removeCancelledActivities(session, builder.Eq{"object_id": targetQuestionID,
+				"user_id": target.UserID, "trigger_user_id": act.TriggerUserID, "activity_type": act.ActivityType})
+			if err != nil {
+				return err
+			}
+			if err = qr.addActivity(ctx, session, &entity.Activity{
+				UserID:           target.UserID,
+				TriggerUserID:    act.TriggerUserID,
+				ObjectID:         targetQuestionID,
+				OriginalObjectID: targetQuestionID,
+				ActivityType:     act.ActivityType,
+				Rank:             act.Rank,
+				HasRank:          act.HasRank,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// cancelActivities cancel the activities and roll back the rank that users get from them
+func (qr *QuestionMergeRepo) cancelActivities(ctx context.Context, session *xorm.Session,
+	activities []*entity.Activity) (err error) {
+	for _, act := range activities {
+		_, err = session.ID(act.ID).Cols("cancelled", "cancelled_at").
+			Update(&entity.Activity{Cancelled: entity.ActivityCancelled, CancelledAt: time.Now()})
+		if err != nil {
+			return err
+		}
+		if err = qr.changeUserRank(ctx, session, act.UserID, -act.Rank); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addActivity add the activity and change the rank of user
+func (qr *QuestionMergeRepo) addActivity(ctx context.Context, session *xorm.Session, act *entity.Activity) (err error) {
+	act.Cancelled = entity.ActivityAvailable
+	if _, err = session.Insert(act); err != nil {
+		return err
+	}
+	return qr.changeUserRank(ctx, session, act.UserID, act.Rank)
+}
+
+func (qr *QuestionMergeRepo) changeUserRank(ctx context.Context, session *xorm.Session, userID string,
+	deltaRank int) (err error) {
+	if deltaRank == 0 {
+		return nil
+	}
+	user := &entity.User{}
+	exist, err := session.ID(userID).ForUpdate().Get(user)
+	if err != nil || !exist {
+		return err
+	}
+	return qr.userRankRepo.ChangeUserRank(ctx, session, userID, user.Rank, deltaRank)
+}
+
+// moveFollows move the followers who don't follow the target question
+func (qr *QuestionMergeRepo) moveFollows(session *xorm.Session, sourceQuestionID, targetQuestionID string,
+	followType int) (err error) {
+	followers, err := qr.getActivityUserIDs(session, targetQuestionID, []int{followType})
+	if err != nil {
+		return err
+	}
+	follows := make([]*entity.Activity, 0)
+	if err = session.Where("object_id = ?", sourceQuestionID).And("activity_type = ?", followType).Find(&follows); err != nil {
+		return err
+	}
+	for _, follow := range follows {
+		if followers[follow.UserID] {
+			continue
+		}
+		err = qr.removeCancelledActivities(session, builder.Eq{"object_id": targetQuestionID,
+			"user_id": follow.UserID, "activity_type": followType})
+		if err != nil {
+			return err
+		}
+		_, err = session.ID(follow.ID).Cols("object_id", "original_object_id").
+			Update(&entity.Activity{ObjectID: targetQuestionID, OriginalObjectID: targetQuestionID})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveCollections move the collections of the users who don't collect the target question
+func (qr *QuestionMergeRepo) moveCollections(session *xorm.Session, sourceQuestionID, targetQuestionID string) (err error) {
+	targetCollections := make([]*entity.Collection, 0)
+	if err = session.Where("object_id = ?", targetQuestionID).Cols("user_id").Find(&targetCollections); err != nil {
+		return err
+	}
+	collectors := make(map[string]bool, len(targetCollections))
+	for _, collection := range targetCollections {
+		collectors[collection.UserID] = true
+	}
+	collections := make([]*entity.Collection, 0)
+	if err = session.Where("object_id = ?", sourceQuestionID).Find(&collections); err != nil {
+		return err
+	}
+	for _, collection := range collections {
+		if collectors[collection.UserID] {
+			continue
+		}
+		if _, err = session.ID(collection.ID).Cols("object_id").Update(&entity.Collection{ObjectID: targetQuestionID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getActivityUserIDs get the users who have the activities on the object, the cancelled activities are ignored
+func (qr *QuestionMergeRepo) getActivityUserIDs(session *xorm.Session, objectID string, activityTypes []int) (
+	userIDs map[string]bool, err error) {
+	activities := make([]*entity.Activity, 0)
+	err = session.Where("object_id = ?", objectID).In("activity_type", activityTypes).
+		And("cancelled = ?", entity.ActivityAvailable).Cols("user_id").Find(&activities)
+	if err != nil {
+		return nil, err
+	}
+	userIDs = make(map[string]bool, len(activities))
+	for _, act := range activities {
+		userIDs[act.UserID] = true
+	}
+	return userIDs, nil
+}
+
+// removeCancelledActivities remove the cancelled activities of the target question, so the user still has
+// only one activity of the type after the activity of the source question is moved to the target
+func (qr *QuestionMergeRepo) removeCancelledActivities(session *xorm.Session, cond builder.Cond) (err error) {
+	_, err = session.Where(builder.Eq{"cancelled": entity.ActivityCancelled}.And(cond)).Delete(&entity.Activity{})
+	return err
+}
+
+// updateQuestionCounts recount the answers, votes, followers and collections of the question
+func (qr *QuestionMergeRepo) updateQuestionCounts(session *xorm.Session, questionID string,
+	activityTypes map[string]int) (err error) {
+	question := &entity.Question{}
+	question.AnswerCount, err = qr.count(session.Where("question_id = ?", questionID).
+		And("status = ?", entity.AnswerStatusAvailable), &entity.Answer{})
+	if err != nil {
+		return err
+	}
+	lastAnswer := &entity.Answer{}
+	exist, err := session.Where("question_id = ?", questionID).And("status = ?", entity.AnswerStatusAvailable).
+		Desc("created_at").Cols("id").Get(lastAnswer)
+	if err != nil {
+		return err
+	}
+	question.LastAnswerID = "0"
+	if exist {
+		question.LastAnswerID = lastAnswer.ID
+	}
+	acceptedAnswer := &entity.Answer{}
+	exist, err = session.Where("question_id = ?", questionID).And("adopted = ?", schema.AnswerAcceptedEnable).
+		Cols("id").Get(acceptedAnswer)
+	if err != nil {
+		return err
+	}
+	question.AcceptedAnswerID = "0"
+	if exist {
+		question.AcceptedAnswerID = acceptedAnswer.ID
+	}
+
+	voteUp, err := qr.countActivity(session, questionID, activityTypes[constant.ActVoteUp])
+	if err != nil {
+		return err
+	}
+	voteDown, err := qr.countActivity(session, questionID, activityTypes[constant.ActVoteDown])
+	if err != nil {
+		return err
+	}
+	question.VoteCount = voteUp - voteDown
+	question.FollowCount, err = qr.countActivity(session, questionID, activityTypes[constant.ActFollow])
+	if err != nil {
+		return err
+	}
+	question.CollectionCount, err = qr.count(session.Where("object_id = ?", questionID), &entity.Collection{})
+	if err != nil {
+		return err
+	}
+	_, err = session.ID(questionID).Cols("answer_count", "last_answer_id", "accepted_answer_id",
+		"vote_count", "follow_count", "collection_count").Update(question)
+	return err
+}
+
+func (qr *QuestionMergeRepo) countActivity(session *xorm.Session, objectID string, activityType int) (int, error) {
+	return qr.count(session.Where(builder.Eq{
+		"object_id":     objectID,
+		"activity_type": activityType,
+		"cancelled":     entity.ActivityAvailable,
+	}), &entity.Activity{})
+}
+
+func (qr *QuestionMergeRepo) count(session *xorm.Session, bean any) (int, error) {
+	count, err := session.Count(bean)
+	return int(count), err
+}
@@ -33,19 +33,22 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
 	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/internal/service/search_common"
 	"github.com/apache/incubator-answer/internal/service/unique"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
 )
 
 // answerRepo answer repository
 type answerRepo struct {
-	data         *data.Data
-	uniqueIDRepo unique.UniqueIDRepo
-	userRankRepo rank.UserRankRepo
-	activityRepo activity_common.ActivityRepo
+	data            *data.Data
+	uniqueIDRepo    unique.UniqueIDRepo
+	userRankRepo    rank.UserRankRepo
+	activityRepo    activity_common.ActivityRepo
+	searchIndexRepo search_common.SearchIndexRepo
 }
 
 // NewAnswerRepo new repository
@@ -54,12 +57,14 @@ func NewAnswerRepo(
 	uniqueIDRepo unique.UniqueIDRepo,
 	userRankRepo rank.UserRankRepo,
 	activityRepo activity_common.ActivityRepo,
+	searchIndexRepo search_common.SearchIndexRepo,
 ) answercommon.AnswerRepo {
 	return &answerRepo{
-		data:         data,
-		uniqueIDRepo: uniqueIDRepo,
-		userRankRepo: userRankRepo,
-		activityRepo: activityRepo,
+		data:            data,
+		uniqueIDRepo:    uniqueIDRepo,
+		userRankRepo:    userRankRepo,
+		activityRepo:    activityRepo,
+		searchIndexRepo: searchIndexRepo,
 	}
 }
 
@@ -71,8 +76,13 @@ func (ar *answerRepo) AddAnswer(ctx context.Context, answer *entity.Answer) (err
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	answer.ID = ID
-	_, err = ar.data.DB.Context(ctx).Insert(answer)
-
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Insert(answer); err != nil {
+			return nil, err
+		}
+		return nil, ar.searchIndexRepo.UpdateSearchIndex(ctx, session, answer.ID, "", answer.OriginalText)
+	})
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -148,9 +158,20 @@ func (ar *answerRepo) RemoveAllUserAnswer(ctx context.Context, userID string) (e
 func (ar *answerRepo) UpdateAnswer(ctx context.Context, answer *entity.Answer, cols []string) (err error) {
 	answer.ID = uid.DeShortID(answer.ID)
 	answer.QuestionID = uid.DeShortID(answer.QuestionID)
-	_, err = ar.data.DB.Context(ctx).ID(answer.ID).Cols(cols...).Update(answer)
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.ID(answer.ID).Cols(cols...).Update(answer); err != nil {
+			return nil, err
+		}
+		for _, col := range cols {
+			if col == "original_text" {
+				return nil, ar.searchIndexRepo.UpdateSearchIndex(ctx, session, answer.ID, "", answer.OriginalText)
+			}
+		}
+		return nil, nil
+	})
 	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	_ = ar.updateSearch(ctx, answer.ID)
 	return err
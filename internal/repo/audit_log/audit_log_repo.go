@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package audit_log
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// auditLogRepo audit log repository, the audit logs can only be added
+type auditLogRepo struct {
+	data *data.Data
+}
+
+// NewAuditLogRepo new repository
+func NewAuditLogRepo(data *data.Data) audit_log.AuditLogRepo {
+	return &auditLogRepo{
+		data: data,
+	}
+}
+
+// AddAuditLog add audit log
+func (ar *auditLogRepo) AddAuditLog(ctx context.Context, auditLog *entity.AuditLog) (err error) {
+	_, err = ar.data.DB.Context(ctx).Insert(auditLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAuditLogPage get audit log page, the latest first
+func (ar *auditLogRepo) GetAuditLogPage(ctx context.Context, page, pageSize int, cond *schema.AuditLogCondition) (
+	auditLogs []*entity.AuditLog, total int64, err error) {
+	auditLogs = make([]*entity.AuditLog, 0)
+	session := ar.data.DB.Context(ctx).Where(ar.buildCondition(cond)).Desc("id")
+	total, err = pager.Help(page, pageSize, &auditLogs, &entity.AuditLog{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAuditLogList get the audit logs whose id is less than beforeID, the latest first.
+// If beforeID is 0, get from the latest one.
+func (ar *auditLogRepo) GetAuditLogList(ctx context.Context, cond *schema.AuditLogCondition, beforeID int64,
+	limit int) (auditLogs []*entity.AuditLog, err error) {
+	auditLogs = make([]*entity.AuditLog, 0)
+	session := ar.data.DB.Context(ctx).Where(ar.buildCondition(cond))
+	if beforeID > 0 {
+		session.And(builder.Lt{"id": beforeID})
+	}
+	err = session.Desc("id").Limit(limit).Find(&auditLogs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func (ar *auditLogRepo) buildCondition(cond *schema.AuditLogCondition) builder.Cond {
+	c := builder.NewCond()
+	if len(cond.OperatorUserID) > 0 {
+		c = c.And(builder.Eq{"operator_user_id": cond.OperatorUserID})
+	}
+	if len(cond.Action) > 0 {
+		c = c.And(builder.Eq{"action": cond.Action})
+	}
+	if len(cond.ObjectType) > 0 {
+		c = c.And(builder.Eq{"object_type": cond.ObjectType})
+	}
+	if len(cond.ObjectID) > 0 {
+		c = c.And(builder.Eq{"object_id": cond.ObjectID})
+	}
+	if cond.StartTime > 0 {
+		c = c.And(builder.Gte{"created_at": time.Unix(cond.StartTime, 0)})
+	}
+	if cond.EndTime > 0 {
+		c = c.And(builder.Lt{"created_at": time.Unix(cond.EndTime, 0)})
+	}
+	return c
+}
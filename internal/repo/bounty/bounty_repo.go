@@ -0,0 +1,174 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package bounty
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/bounty"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// bountyRepo question bounty repository
+type bountyRepo struct {
+	data         *data.Data
+	userRankRepo rank.UserRankRepo
+}
+
+// NewBountyRepo new repository
+func NewBountyRepo(data *data.Data, userRankRepo rank.UserRankRepo) bounty.BountyRepo {
+	return &bountyRepo{
+		data:         data,
+		userRankRepo: userRankRepo,
+	}
+}
+
+// AddBounty escrow the reputation of the sponsor and add an active bounty to the question.
+// The activity records the negative rank change of the sponsor.
+func (br *bountyRepo) AddBounty(ctx context.Context, bounty *entity.QuestionBounty, act *entity.Activity) (err error) {
+	_, err = br.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		user := &entity.User{}
+		exist, err := session.ID(bounty.UserID).ForUpdate().Get(user)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if !exist {
+			return nil, errors.BadRequest(reason.UserNotFound)
+		}
+		// the rank of user can not be less than 1, so the user must keep at least 1 after the amount is escrowed
+		if user.Rank-bounty.Amount < 1 {
+			return nil, errors.BadRequest(reason.BountyRankNotEnough)
+		}
+
+		exist, err = session.Where("question_id = ?", bounty.QuestionID).
+			And("status = ?", entity.QuestionBountyStatusActive).ForUpdate().Exist(&entity.QuestionBounty{})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if exist {
+			return nil, errors.BadRequest(reason.BountyAlreadyExist)
+		}
+
+		if _, err = session.Insert(bounty); err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if err = br.saveActivity(ctx, session, user, act); err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// FinishBounty change the active bounty to awarded or expired status,
+// the activity records the rank that the receiver gets from this bounty.
+// If the bounty is already finished, return not found error.
+func (br *bountyRepo) FinishBounty(ctx context.Context, bounty *entity.QuestionBounty, act *entity.Activity) (err error) {
+	_, err = br.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		user := &entity.User{}
+		exist, err := session.ID(act.UserID).ForUpdate().Get(user)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if !exist {
+			return nil, errors.BadRequest(reason.UserNotFound)
+		}
+
+		affected, err := session.ID(bounty.ID).Where("status = ?", entity.QuestionBountyStatusActive).
+			Cols("status", "awarded_answer_id", "awarded_user_id", "awarded_amount").Update(bounty)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if affected == 0 {
+			return nil, errors.BadRequest(reason.BountyNotFound)
+		}
+
+		if err = br.saveActivity(ctx, session, user, act); err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func (br *bountyRepo) saveActivity(ctx context.Context, session *xorm.Session,
+	user *entity.User, act *entity.Activity) (err error) {
+	if act.Rank != 0 {
+		act.HasRank = 1
+	}
+	act.Cancelled = entity.ActivityAvailable
+	if _, err = session.Insert(act); err != nil {
+		return err
+	}
+	return br.userRankRepo.ChangeUserRank(ctx, session, user.ID, user.Rank, act.Rank)
+}
+
+// GetActiveBounty get the active bounty of the question
+func (br *bountyRepo) GetActiveBounty(ctx context.Context, questionID string) (
+	bounty *entity.QuestionBounty, exist bool, err error) {
+	bounty = &entity.QuestionBounty{}
+	exist, err = br.data.DB.Context(ctx).Where("question_id = ?", questionID).
+		And("status = ?", entity.QuestionBountyStatusActive).Get(bounty)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetActiveBountyAmounts get the amount of active bounties, the key is the question id
+func (br *bountyRepo) GetActiveBountyAmounts(ctx context.Context, questionIDs []string) (
+	amounts map[string]int, err error) {
+	amounts = make(map[string]int, len(questionIDs))
+	if len(questionIDs) == 0 {
+		return amounts, nil
+	}
+	bounties := make([]*entity.QuestionBounty, 0)
+	err = br.data.DB.Context(ctx).In("question_id", questionIDs).
+		And("status = ?", entity.QuestionBountyStatusActive).Find(&bounties)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, b := range bounties {
+		amounts[b.QuestionID] = b.Amount
+	}
+	return amounts, nil
+}
+
+// GetExpiredBounties get the active bounties which are expired before the time
+func (br *bountyRepo) GetExpiredBounties(ctx context.Context, expiredAt time.Time, limit int) (
+	bounties []*entity.QuestionBounty, err error) {
+	bounties = make([]*entity.QuestionBounty, 0)
+	err = br.data.DB.Context(ctx).Where("status = ?", entity.QuestionBountyStatusActive).
+		And("expires_at <= ?", expiredAt).Asc("expires_at").Limit(limit).Find(&bounties)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
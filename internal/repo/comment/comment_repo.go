@@ -32,6 +32,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	"github.com/apache/incubator-answer/internal/service/unique"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
 )
 
 // commentRepo comment repository
@@ -141,6 +142,49 @@ func (cr *commentRepo) GetCommentPage(ctx context.Context, commentQuery *comment
 	return
 }
 
+// GetRootCommentPage get the root comments page of the object,
+// the replies whose replied comment is not available (e.g. deleted) are also regarded as root comments
+func (cr *commentRepo) GetRootCommentPage(ctx context.Context, commentQuery *comment.CommentQuery) (
+	commentList []*entity.Comment, total int64, err error,
+) {
+	commentList = make([]*entity.Comment, 0)
+	session := cr.data.DB.Context(ctx)
+	session.OrderBy(commentQuery.GetOrderBy())
+	session.Where("status = ?", entity.CommentStatusAvailable)
+	session.Where(builder.Or(
+		builder.IsNull{"reply_comment_id"},
+		builder.Eq{"reply_comment_id": 0},
+		builder.Expr("reply_comment_id = id"),
+		builder.NotIn("reply_comment_id", builder.Select("id").From((&entity.Comment{}).TableName()).
+			Where(builder.Eq{"object_id": commentQuery.ObjectID, "status": entity.CommentStatusAvailable})),
+	))
+
+	cond := &entity.Comment{ObjectID: commentQuery.ObjectID}
+	total, err = pager.Help(commentQuery.Page, commentQuery.PageSize, &commentList, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetReplyCommentList get the available replies of the comments of the object
+func (cr *commentRepo) GetReplyCommentList(ctx context.Context, commentQuery *comment.CommentQuery,
+	replyCommentIDs []string) (commentList []*entity.Comment, err error) {
+	commentList = make([]*entity.Comment, 0)
+	if len(replyCommentIDs) == 0 {
+		return commentList, nil
+	}
+	session := cr.data.DB.Context(ctx)
+	session.OrderBy(commentQuery.GetOrderBy())
+	session.Where("status = ?", entity.CommentStatusAvailable)
+	session.In("reply_comment_id", replyCommentIDs)
+	err = session.Find(&commentList, &entity.Comment{ObjectID: commentQuery.ObjectID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // RemoveAllUserComment remove all user comment
 func (cr *commentRepo) RemoveAllUserComment(ctx context.Context, userID string) (err error) {
 	session := cr.data.DB.Context(ctx).Where("user_id = ?", userID)
@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package draft
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/draft"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// draftRepo draft repository
+type draftRepo struct {
+	data *data.Data
+}
+
+// NewDraftRepo new repository
+func NewDraftRepo(data *data.Data) draft.DraftRepo {
+	return &draftRepo{
+		data: data,
+	}
+}
+
+// AddDraft add draft
+func (dr *draftRepo) AddDraft(ctx context.Context, draft *entity.Draft) (err error) {
+	_, err = dr.data.DB.Context(ctx).Insert(draft)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateDraft update the draft of the user
+func (dr *draftRepo) UpdateDraft(ctx context.Context, draft *entity.Draft) (affected int64, err error) {
+	affected, err = dr.data.DB.Context(ctx).ID(draft.ID).
+		Where("user_id = ? AND object_type = ?", draft.UserID, draft.ObjectType).
+		Cols("question_id", "title", "content", "tags", "answer_content").Update(draft)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDraft get the draft of the user
+func (dr *draftRepo) GetDraft(ctx context.Context, userID string, id int) (
+	draft *entity.Draft, exist bool, err error) {
+	draft = &entity.Draft{}
+	exist, err = dr.data.DB.Context(ctx).ID(id).Where("user_id = ?", userID).Get(draft)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnswerDraft get the answer draft of the user for the question
+func (dr *draftRepo) GetAnswerDraft(ctx context.Context, userID, questionID string) (
+	draft *entity.Draft, exist bool, err error) {
+	draft = &entity.Draft{}
+	exist, err = dr.data.DB.Context(ctx).
+		Where("user_id = ? AND object_type = ? AND question_id = ?", userID, entity.DraftObjectTypeAnswer, questionID).
+		Desc("updated_at").Get(draft)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDraftList get drafts of the user, the latest updated first
+func (dr *draftRepo) GetDraftList(ctx context.Context, userID, objectType string) (
+	drafts []*entity.Draft, err error) {
+	drafts = make([]*entity.Draft, 0)
+	session := dr.data.DB.Context(ctx).Where("user_id = ?", userID)
+	if len(objectType) > 0 {
+		session.And("object_type = ?", objectType)
+	}
+	err = session.Desc("updated_at").Find(&drafts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountDraft count drafts of the user
+func (dr *draftRepo) CountDraft(ctx context.Context, userID string) (count int64, err error) {
+	count, err = dr.data.DB.Context(ctx).Where("user_id = ?", userID).Count(&entity.Draft{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveDraft remove the draft of the user
+func (dr *draftRepo) RemoveDraft(ctx context.Context, userID string, id int) (affected int64, err error) {
+	affected, err = dr.data.DB.Context(ctx).ID(id).Where("user_id = ?", userID).Delete(&entity.Draft{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveExpiredDrafts remove the drafts which are not updated since the expired time
+func (dr *draftRepo) RemoveExpiredDrafts(ctx context.Context, expiredAt time.Time) (affected int64, err error) {
+	affected, err = dr.data.DB.Context(ctx).Where("updated_at < ?", expiredAt).Delete(&entity.Draft{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,141 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package email_log
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// emailLogRepo email log repository
+type emailLogRepo struct {
+	data *data.Data
+}
+
+// NewEmailLogRepo new repository
+func NewEmailLogRepo(data *data.Data) export.EmailLogRepo {
+	return &emailLogRepo{
+		data: data,
+	}
+}
+
+// AddEmailLog add email log
+func (er *emailLogRepo) AddEmailLog(ctx context.Context, emailLog *entity.EmailLog) (err error) {
+	_, err = er.data.DB.Context(ctx).Insert(emailLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAvailableEmailLogs get the emails that should be sent now, including the sending emails whose lease has expired
+func (er *emailLogRepo) GetAvailableEmailLogs(ctx context.Context, now time.Time, limit int) (
+	emailLogs []*entity.EmailLog, err error) {
+	emailLogs = make([]*entity.EmailLog, 0)
+	err = er.data.DB.Context(ctx).
+		In("status", entity.EmailLogStatusPending, entity.EmailLogStatusSending).
+		And(builder.Lte{"next_retry_at": now}).
+		Asc("id").Limit(limit).Find(&emailLogs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClaimEmailLog lock the email until leaseUntil. The attempts is used as a version,
+// so only one worker can claim the email at the same time.
+func (er *emailLogRepo) ClaimEmailLog(ctx context.Context, emailLog *entity.EmailLog, leaseUntil time.Time) (
+	claimed bool, err error) {
+	affected, err := er.data.DB.Context(ctx).ID(emailLog.ID).
+		Where(builder.Eq{"attempts": emailLog.Attempts}).
+		In("status", entity.EmailLogStatusPending, entity.EmailLogStatusSending).
+		Cols("status", "attempts", "next_retry_at").
+		Update(&entity.EmailLog{
+			Status:      entity.EmailLogStatusSending,
+			Attempts:    emailLog.Attempts + 1,
+			NextRetryAt: leaseUntil,
+		})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	emailLog.Status = entity.EmailLogStatusSending
+	emailLog.Attempts++
+	emailLog.NextRetryAt = leaseUntil
+	return true, nil
+}
+
+// UpdateEmailLogResult update the email status and body after it is delivered
+func (er *emailLogRepo) UpdateEmailLogResult(ctx context.Context, emailLog *entity.EmailLog) (err error) {
+	_, err = er.data.DB.Context(ctx).ID(emailLog.ID).
+		Cols("status", "transport", "next_retry_at", "last_error", "body").Update(emailLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RetryEmailLog reset the failed email to pending, so it will be sent again
+func (er *emailLogRepo) RetryEmailLog(ctx context.Context, id int64) (affected int64, err error) {
+	affected, err = er.data.DB.Context(ctx).ID(id).
+		Where(builder.Eq{"status": entity.EmailLogStatusFailed}).
+		Cols("status", "attempts", "next_retry_at").
+		Update(&entity.EmailLog{
+			Status:      entity.EmailLogStatusPending,
+			Attempts:    0,
+			NextRetryAt: time.Now(),
+		})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetEmailLogPage get email log page, all status if status is zero
+func (er *emailLogRepo) GetEmailLogPage(ctx context.Context, page, pageSize, status int) (
+	emailLogs []*entity.EmailLog, total int64, err error) {
+	emailLogs = make([]*entity.EmailLog, 0)
+	session := er.data.DB.Context(ctx).Omit("body").Desc("id")
+	cond := &entity.EmailLog{Status: status}
+	total, err = pager.Help(page, pageSize, &emailLogs, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveEmailLogsBefore remove the email logs with the status which are updated before the time
+func (er *emailLogRepo) RemoveEmailLogsBefore(ctx context.Context, status int, before time.Time) (err error) {
+	_, err = er.data.DB.Context(ctx).Where(builder.Eq{"status": status}).
+		And(builder.Lt{"updated_at": before}).Delete(&entity.EmailLog{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
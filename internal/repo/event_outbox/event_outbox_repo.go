@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// eventOutboxRepo event outbox repository
+type eventOutboxRepo struct {
+	data *data.Data
+}
+
+// NewEventOutboxRepo new repository
+func NewEventOutboxRepo(data *data.Data) event_queue.EventOutboxRepo {
+	return &eventOutboxRepo{
+		data: data,
+	}
+}
+
+// AddEvent add event to outbox
+func (er *eventOutboxRepo) AddEvent(ctx context.Context, event *entity.EventOutbox) (err error) {
+	_, err = er.data.DB.Context(ctx).Insert(event)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAvailableEvents get the events that should be handled now, including the processing events whose lease has expired
+func (er *eventOutboxRepo) GetAvailableEvents(ctx context.Context, now time.Time, limit int) (
+	events []*entity.EventOutbox, err error) {
+	events = make([]*entity.EventOutbox, 0)
+	err = er.data.DB.Context(ctx).
+		In("status", entity.EventOutboxStatusPending, entity.EventOutboxStatusProcessing).
+		And(builder.Lte{"next_retry_at": now}).
+		Asc("id").Limit(limit).Find(&events)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClaimEvent lock the event until leaseUntil. The attempts is used as a version,
+// so only one worker can claim the event at the same time.
+func (er *eventOutboxRepo) ClaimEvent(ctx context.Context, event *entity.EventOutbox, leaseUntil time.Time) (
+	claimed bool, err error) {
+	affected, err := er.data.DB.Context(ctx).ID(event.ID).
+		Where(builder.Eq{"attempts": event.Attempts}).
+		In("status", entity.EventOutboxStatusPending, entity.EventOutboxStatusProcessing).
+		Cols("status", "attempts", "next_retry_at").
+		Update(&entity.EventOutbox{
+			Status:      entity.EventOutboxStatusProcessing,
+			Attempts:    event.Attempts + 1,
+			NextRetryAt: leaseUntil,
+		})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	event.Status = entity.EventOutboxStatusProcessing
+	event.Attempts++
+	event.NextRetryAt = leaseUntil
+	return true, nil
+}
+
+// UpdateEventResult update the event status after it is handled
+func (er *eventOutboxRepo) UpdateEventResult(ctx context.Context, event *entity.EventOutbox) (err error) {
+	_, err = er.data.DB.Context(ctx).ID(event.ID).
+		Cols("status", "next_retry_at", "last_error", "done_handlers").Update(event)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveEventsBefore remove the events with the status which are updated before the time
+func (er *eventOutboxRepo) RemoveEventsBefore(ctx context.Context, status int, before time.Time) (err error) {
+	_, err = er.data.DB.Context(ctx).Where(builder.Eq{"status": status}).
+		And(builder.Lt{"updated_at": before}).Delete(&entity.EventOutbox{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
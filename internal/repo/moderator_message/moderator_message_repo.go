@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package moderator_message
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/moderator_message"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// moderatorMessageRepo moderator message repository
+type moderatorMessageRepo struct {
+	data *data.Data
+}
+
+// NewModeratorMessageRepo new repository
+func NewModeratorMessageRepo(data *data.Data) moderator_message.ModeratorMessageRepo {
+	return &moderatorMessageRepo{
+		data: data,
+	}
+}
+
+// AddModeratorMessage add moderator message
+func (mr *moderatorMessageRepo) AddModeratorMessage(ctx context.Context, message *entity.ModeratorMessage) (err error) {
+	_, err = mr.data.DB.Context(ctx).Insert(message)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetModeratorMessage get moderator message by id
+func (mr *moderatorMessageRepo) GetModeratorMessage(ctx context.Context, id int) (
+	message *entity.ModeratorMessage, exist bool, err error) {
+	message = &entity.ModeratorMessage{}
+	exist, err = mr.data.DB.Context(ctx).ID(id).Get(message)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetModeratorMessageList get the messages received by the user, the latest first
+func (mr *moderatorMessageRepo) GetModeratorMessageList(ctx context.Context, userID string, unacknowledgedOnly bool) (
+	messages []*entity.ModeratorMessage, err error) {
+	messages = make([]*entity.ModeratorMessage, 0)
+	session := mr.data.DB.Context(ctx).Where("user_id = ?", userID)
+	if unacknowledgedOnly {
+		session.And("status = ?", entity.ModeratorMessageStatusUnacknowledged)
+	}
+	err = session.Desc("id").Find(&messages)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// HasUnacknowledgedModeratorMessage check whether the user has any message not acknowledged yet
+func (mr *moderatorMessageRepo) HasUnacknowledgedModeratorMessage(ctx context.Context, userID string) (
+	exist bool, err error) {
+	exist, err = mr.data.DB.Context(ctx).Where("user_id = ?", userID).
+		And("status = ?", entity.ModeratorMessageStatusUnacknowledged).
+		Exist(&entity.ModeratorMessage{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AcknowledgeModeratorMessage mark the message of the user as acknowledged
+func (mr *moderatorMessageRepo) AcknowledgeModeratorMessage(ctx context.Context, id int, userID string) (err error) {
+	_, err = mr.data.DB.Context(ctx).ID(id).Where("user_id = ?", userID).
+		And("status = ?", entity.ModeratorMessageStatusUnacknowledged).
+		Cols("status", "acknowledged_at").
+		Update(&entity.ModeratorMessage{Status: entity.ModeratorMessageStatusAcknowledged, AcknowledgedAt: time.Now()})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
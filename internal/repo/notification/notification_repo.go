@@ -137,3 +137,21 @@ func (nr *notificationRepo) CountNotificationByUser(ctx context.Context, cond *e
 	}
 	return count, err
 }
+
+// GetUnreadInboxNotifications get the latest unread inbox notifications of user created in [createdFrom, createdTo)
+func (nr *notificationRepo) GetUnreadInboxNotifications(ctx context.Context, userID string,
+	createdFrom, createdTo time.Time, limit int) (notificationList []*entity.Notification, err error) {
+	notificationList = make([]*entity.Notification, 0)
+	err = nr.data.DB.Context(ctx).
+		Where("user_id = ?", userID).
+		And("type = ?", schema.NotificationTypeInbox).
+		And("is_read = ?", schema.NotificationNotRead).
+		And("status = ?", schema.NotificationStatusNormal).
+		And("created_at >= ?", createdFrom).
+		And("created_at < ?", createdTo).
+		Desc("created_at").Limit(limit).Find(&notificationList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -24,17 +24,23 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/activity"
 	"github.com/apache/incubator-answer/internal/repo/activity_common"
 	"github.com/apache/incubator-answer/internal/repo/answer"
+	"github.com/apache/incubator-answer/internal/repo/audit_log"
 	"github.com/apache/incubator-answer/internal/repo/auth"
 	"github.com/apache/incubator-answer/internal/repo/badge"
 	"github.com/apache/incubator-answer/internal/repo/badge_award"
 	"github.com/apache/incubator-answer/internal/repo/badge_group"
+	"github.com/apache/incubator-answer/internal/repo/bounty"
 	"github.com/apache/incubator-answer/internal/repo/captcha"
 	"github.com/apache/incubator-answer/internal/repo/collection"
 	"github.com/apache/incubator-answer/internal/repo/comment"
 	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/draft"
+	"github.com/apache/incubator-answer/internal/repo/email_log"
+	"github.com/apache/incubator-answer/internal/repo/event_outbox"
 	"github.com/apache/incubator-answer/internal/repo/export"
 	"github.com/apache/incubator-answer/internal/repo/limit"
 	"github.com/apache/incubator-answer/internal/repo/meta"
+	"github.com/apache/incubator-answer/internal/repo/moderator_message"
 	"github.com/apache/incubator-answer/internal/repo/notification"
 	"github.com/apache/incubator-answer/internal/repo/plugin_config"
 	"github.com/apache/incubator-answer/internal/repo/question"
@@ -44,14 +50,17 @@ import (
 	"github.com/apache/incubator-answer/internal/repo/review"
 	"github.com/apache/incubator-answer/internal/repo/revision"
 	"github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/apache/incubator-answer/internal/repo/saved_search"
 	"github.com/apache/incubator-answer/internal/repo/search_common"
 	"github.com/apache/incubator-answer/internal/repo/site_info"
 	"github.com/apache/incubator-answer/internal/repo/tag"
 	"github.com/apache/incubator-answer/internal/repo/tag_common"
 	"github.com/apache/incubator-answer/internal/repo/unique"
 	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/repo/user_access_token"
 	"github.com/apache/incubator-answer/internal/repo/user_external_login"
 	"github.com/apache/incubator-answer/internal/repo/user_notification_config"
+	"github.com/apache/incubator-answer/internal/repo/webhook"
 	"github.com/google/wire"
 )
 
@@ -72,6 +81,8 @@ var ProviderSetRepo = wire.NewSet(
 	user.NewUserAdminRepo,
 	rank.NewUserRankRepo,
 	question.NewQuestionRepo,
+	question.NewQuestionLinkRepo,
+	question.NewQuestionCloseVoteRepo,
 	answer.NewAnswerRepo,
 	activity_common.NewActivityRepo,
 	activity.NewVoteRepo,
@@ -80,6 +91,8 @@ var ProviderSetRepo = wire.NewSet(
 	activity.NewUserActiveActivityRepo,
 	activity.NewActivityRepo,
 	activity.NewReviewActivityRepo,
+	activity.NewQuestionMergeRepo,
+	activity.NewPostConvertRepo,
 	tag.NewTagRepo,
 	tag_common.NewTagCommonRepo,
 	tag.NewTagRelRepo,
@@ -88,6 +101,7 @@ var ProviderSetRepo = wire.NewSet(
 	auth.NewAuthRepo,
 	revision.NewRevisionRepo,
 	search_common.NewSearchRepo,
+	search_common.NewSearchIndexRepo,
 	meta.NewMetaRepo,
 	export.NewEmailRepo,
 	reason.NewReasonRepo,
@@ -103,8 +117,20 @@ var ProviderSetRepo = wire.NewSet(
 	limit.NewRateLimitRepo,
 	plugin_config.NewPluginUserConfigRepo,
 	review.NewReviewRepo,
+	review.NewReviewTaskRepo,
 	badge.NewBadgeRepo,
 	badge.NewEventRuleRepo,
 	badge_group.NewBadgeGroupRepo,
 	badge_award.NewBadgeAwardRepo,
+	event_outbox.NewEventOutboxRepo,
+	webhook.NewWebhookRepo,
+	user_access_token.NewUserAccessTokenRepo,
+	saved_search.NewSavedSearchRepo,
+	bounty.NewBountyRepo,
+	email_log.NewEmailLogRepo,
+	draft.NewDraftRepo,
+	tag.NewTagModeratorRepo,
+	user.NewUserSuspensionRepo,
+	moderator_message.NewModeratorMessageRepo,
+	audit_log.NewAuditLogRepo,
 )
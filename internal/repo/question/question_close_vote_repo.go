@@ -0,0 +1,168 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// questionCloseVoteRepo question close vote repository
+type questionCloseVoteRepo struct {
+	data *data.Data
+}
+
+// NewQuestionCloseVoteRepo new repository
+func NewQuestionCloseVoteRepo(data *data.Data) content.QuestionCloseVoteRepo {
+	return &questionCloseVoteRepo{
+		data: data,
+	}
+}
+
+// AddCloseVote add close vote, the user can only have one vote of each type on the question,
+// so the previous vote which is already resolved or stale is replaced
+func (qr *questionCloseVoteRepo) AddCloseVote(ctx context.Context, vote *entity.QuestionCloseVote) (err error) {
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.Where(builder.Eq{
+			"question_id": vote.QuestionID,
+			"user_id":     vote.UserID,
+			"vote_type":   vote.VoteType,
+		}).Delete(&entity.QuestionCloseVote{})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(vote)
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPendingCloseVote get the pending vote of the user which is cast after the since time
+func (qr *questionCloseVoteRepo) GetPendingCloseVote(ctx context.Context, questionID, userID string, voteType int,
+	since time.Time) (vote *entity.QuestionCloseVote, exist bool, err error) {
+	vote = &entity.QuestionCloseVote{}
+	exist, err = qr.data.DB.Context(ctx).Where(qr.pendingCond(voteType, since)).
+		And(builder.Eq{"question_id": questionID, "user_id": userID}).Get(vote)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPendingCloseVotes get the pending votes of the questions which are cast after the since time, the earliest first
+func (qr *questionCloseVoteRepo) GetPendingCloseVotes(ctx context.Context, questionIDs []string, voteType int,
+	since time.Time) (votes []*entity.QuestionCloseVote, err error) {
+	votes = make([]*entity.QuestionCloseVote, 0)
+	if len(questionIDs) == 0 {
+		return votes, nil
+	}
+	err = qr.data.DB.Context(ctx).Where(qr.pendingCond(voteType, since)).
+		And(builder.In("question_id", questionIDs)).Asc("id").Find(&votes)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPendingCloseVoteQuestionPage get the questions with the pending votes which are cast after the since time,
+// the latest voted first
+func (qr *questionCloseVoteRepo) GetPendingCloseVoteQuestionPage(ctx context.Context, page, pageSize, voteType int,
+	since time.Time) (questionIDs []string, total int64, err error) {
+	questionIDs = make([]string, 0)
+	total, err = qr.CountPendingCloseVoteQuestions(ctx, voteType, since)
+	if err != nil || total == 0 {
+		return questionIDs, total, err
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	rows := make([]*struct {
+		QuestionID string `xorm:"question_id"`
+		LastVoteID int    `xorm:"last_vote_id"`
+	}, 0)
+	err = qr.data.DB.Context(ctx).Table(entity.QuestionCloseVote{}.TableName()).
+		Select("question_id, MAX(id) AS last_vote_id").Where(qr.pendingCond(voteType, since)).
+		GroupBy("question_id").Desc("last_vote_id").Limit(pageSize, (page-1)*pageSize).Find(&rows)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, row := range rows {
+		questionIDs = append(questionIDs, row.QuestionID)
+	}
+	return questionIDs, total, nil
+}
+
+// CountPendingCloseVoteQuestions count the questions with the pending votes which are cast after the since time
+func (qr *questionCloseVoteRepo) CountPendingCloseVoteQuestions(ctx context.Context, voteType int, since time.Time) (
+	count int64, err error) {
+	_, err = qr.data.DB.Context(ctx).Table(entity.QuestionCloseVote{}.TableName()).
+		Select("COUNT(DISTINCT question_id)").Where(qr.pendingCond(voteType, since)).Get(&count)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateCloseVoteStatus update the status of the pending votes of the question
+func (qr *questionCloseVoteRepo) UpdateCloseVoteStatus(ctx context.Context, questionID string, voteTypes []int,
+	status int) (err error) {
+	_, err = qr.data.DB.Context(ctx).Where(builder.Eq{
+		"question_id": questionID,
+		"status":      entity.QuestionCloseVoteStatusPending,
+	}).And(builder.In("vote_type", voteTypes)).Cols("status").Update(&entity.QuestionCloseVote{Status: status})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ExpireCloseVotes expire the pending votes which are cast before the time
+func (qr *questionCloseVoteRepo) ExpireCloseVotes(ctx context.Context, before time.Time) (err error) {
+	_, err = qr.data.DB.Context(ctx).Where(builder.Eq{"status": entity.QuestionCloseVoteStatusPending}).
+		And(builder.Lt{"created_at": before}).
+		Cols("status").Update(&entity.QuestionCloseVote{Status: entity.QuestionCloseVoteStatusExpired})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func (qr *questionCloseVoteRepo) pendingCond(voteType int, since time.Time) builder.Cond {
+	return builder.Eq{
+		"vote_type": voteType,
+		"status":    entity.QuestionCloseVoteStatusPending,
+	}.And(builder.Gte{"created_at": since})
+}
@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// questionLinkRepo question link repository
+type questionLinkRepo struct {
+	data *data.Data
+}
+
+// NewQuestionLinkRepo new repository
+func NewQuestionLinkRepo(data *data.Data) questioncommon.QuestionLinkRepo {
+	return &questionLinkRepo{
+		data: data,
+	}
+}
+
+// AddQuestionLinks add question links, if the link already exists, it will be available again
+func (qr *questionLinkRepo) AddQuestionLinks(ctx context.Context, links []*entity.QuestionLink) (err error) {
+	for _, link := range links {
+		link.Status = entity.QuestionLinkStatusAvailable
+		exist := &entity.QuestionLink{}
+		has, err := qr.data.DB.Context(ctx).Where(builder.Eq{
+			"from_question_id": link.FromQuestionID,
+			"to_question_id":   link.ToQuestionID,
+			"link_type":        link.LinkType,
+		}).Get(exist)
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if has {
+			_, err = qr.data.DB.Context(ctx).ID(exist.ID).Cols("status", "user_id").Update(link)
+		} else {
+			_, err = qr.data.DB.Context(ctx).Insert(link)
+		}
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	return nil
+}
+
+// RemoveDuplicateLinks remove the duplicate links from the question, the merged link is kept
+// because the answers of the question have been moved to the target question
+func (qr *questionLinkRepo) RemoveDuplicateLinks(ctx context.Context, fromQuestionID string) (err error) {
+	_, err = qr.data.DB.Context(ctx).Where("from_question_id = ?", fromQuestionID).
+		And("link_type = ?", entity.QuestionLinkTypeDuplicate).
+		And("status = ?", entity.QuestionLinkStatusAvailable).
+		Cols("status").Update(&entity.QuestionLink{Status: entity.QuestionLinkStatusDeleted})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionLinks get all available links from or to the question
+func (qr *questionLinkRepo) GetQuestionLinks(ctx context.Context, questionID string) (
+	links []*entity.QuestionLink, err error) {
+	links = make([]*entity.QuestionLink, 0)
+	err = qr.data.DB.Context(ctx).
+		Where(builder.Or(builder.Eq{"from_question_id": questionID}, builder.Eq{"to_question_id": questionID})).
+		And("status = ?", entity.QuestionLinkStatusAvailable).
+		Asc("id").Find(&links)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
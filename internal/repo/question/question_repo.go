@@ -35,6 +35,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/search_common"
 	"github.com/apache/incubator-answer/internal/service/unique"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -47,18 +48,21 @@ import (
 
 // questionRepo question repository
 type questionRepo struct {
-	data         *data.Data
-	uniqueIDRepo unique.UniqueIDRepo
+	data            *data.Data
+	uniqueIDRepo    unique.UniqueIDRepo
+	searchIndexRepo search_common.SearchIndexRepo
 }
 
 // NewQuestionRepo new repository
 func NewQuestionRepo(
 	data *data.Data,
 	uniqueIDRepo unique.UniqueIDRepo,
+	searchIndexRepo search_common.SearchIndexRepo,
 ) questioncommon.QuestionRepo {
 	return &questionRepo{
-		data:         data,
-		uniqueIDRepo: uniqueIDRepo,
+		data:            data,
+		uniqueIDRepo:    uniqueIDRepo,
+		searchIndexRepo: searchIndexRepo,
 	}
 }
 
@@ -68,7 +72,13 @@ func (qr *questionRepo) AddQuestion(ctx context.Context, question *entity.Questi
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	_, err = qr.data.DB.Context(ctx).Insert(question)
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Insert(question); err != nil {
+			return nil, err
+		}
+		return nil, qr.searchIndexRepo.UpdateSearchIndex(ctx, session, question.ID, question.Title, question.OriginalText)
+	})
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -91,7 +101,18 @@ func (qr *questionRepo) RemoveQuestion(ctx context.Context, id string) (err erro
 // UpdateQuestion update question
 func (qr *questionRepo) UpdateQuestion(ctx context.Context, question *entity.Question, Cols []string) (err error) {
 	question.ID = uid.DeShortID(question.ID)
-	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols(Cols...).Update(question)
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("id =?", question.ID).Cols(Cols...).Update(question); err != nil {
+			return nil, err
+		}
+		for _, col := range Cols {
+			if col == "title" || col == "original_text" {
+				return nil, qr.searchIndexRepo.UpdateSearchIndex(ctx, session, question.ID, question.Title, question.OriginalText)
+			}
+		}
+		return nil, nil
+	})
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -179,7 +200,7 @@ func (qr *questionRepo) RecoverQuestion(ctx context.Context, questionID string)
 
 func (qr *questionRepo) UpdateQuestionOperation(ctx context.Context, question *entity.Question) (err error) {
 	question.ID = uid.DeShortID(question.ID)
-	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("pin", "show").Update(question)
+	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("pin", "show", "wiki").Update(question)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -387,6 +408,10 @@ func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
 	case "unanswered":
 		session.Where("question.answer_count = 0")
 		session.OrderBy("question.pin desc,question.created_at DESC")
+	case "bounty":
+		session.Join("INNER", "question_bounty", "question.id = question_bounty.question_id")
+		session.And("question_bounty.status = ?", entity.QuestionBountyStatusActive)
+		session.OrderBy("question_bounty.amount DESC, question_bounty.expires_at ASC")
 	}
 
 	total, err = pager.Help(page, pageSize, &questionList, &entity.Question{}, session)
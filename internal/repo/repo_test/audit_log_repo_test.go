@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/audit_log"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_auditLogRepo_GetAuditLogPage(t *testing.T) {
+	auditLogRepo := audit_log.NewAuditLogRepo(testDataSource)
+	operatorUserID := "1201"
+
+	auditLogs := []*entity.AuditLog{
+		{OperatorUserID: operatorUserID, Action: constant.AuditLogActionQuestionStatusUpdate,
+			ObjectType: constant.QuestionObjectType, ObjectID: "10010000000001201",
+			BeforeData: `{"status":"available"}`, AfterData: `{"status":"closed"}`, IP: "127.0.0.1"},
+		{OperatorUserID: operatorUserID, Action: constant.AuditLogActionUserRoleUpdate,
+			ObjectType: constant.UserObjectType, ObjectID: "1202",
+			BeforeData: `{"role_id":1}`, AfterData: `{"role_id":3}`, IP: "127.0.0.1"},
+		{OperatorUserID: "1203", Action: constant.AuditLogActionUserRoleUpdate,
+			ObjectType: constant.UserObjectType, ObjectID: "1202",
+			BeforeData: `{"role_id":3}`, AfterData: `{"role_id":2}`, IP: "127.0.0.2"},
+	}
+	for _, auditLog := range auditLogs {
+		err := auditLogRepo.AddAuditLog(context.TODO(), auditLog)
+		assert.NoError(t, err)
+		assert.NotZero(t, auditLog.ID)
+	}
+
+	got, total, err := auditLogRepo.GetAuditLogPage(context.TODO(), 1, 10,
+		&schema.AuditLogCondition{OperatorUserID: operatorUserID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, auditLogs[1].ID, got[0].ID)
+
+	got, total, err = auditLogRepo.GetAuditLogPage(context.TODO(), 1, 10,
+		&schema.AuditLogCondition{Action: constant.AuditLogActionUserRoleUpdate, ObjectID: "1202"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, auditLogs[2].ID, got[0].ID)
+	assert.Equal(t, "127.0.0.2", got[0].IP)
+}
+
+func Test_auditLogRepo_GetAuditLogList(t *testing.T) {
+	auditLogRepo := audit_log.NewAuditLogRepo(testDataSource)
+	cond := &schema.AuditLogCondition{ObjectType: constant.AuditLogObjectTypeWebhook, ObjectID: "1301"}
+
+	for _, action := range []string{constant.AuditLogActionWebhookAdd,
+		constant.AuditLogActionWebhookUpdate, constant.AuditLogActionWebhookRemove} {
+		err := auditLogRepo.AddAuditLog(context.TODO(), &entity.AuditLog{OperatorUserID: "1",
+			Action: action, ObjectType: cond.ObjectType, ObjectID: cond.ObjectID})
+		assert.NoError(t, err)
+	}
+
+	first, err := auditLogRepo.GetAuditLogList(context.TODO(), cond, 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(first))
+	assert.Equal(t, constant.AuditLogActionWebhookRemove, first[0].Action)
+
+	second, err := auditLogRepo.GetAuditLogList(context.TODO(), cond, first[1].ID, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(second))
+	assert.Equal(t, constant.AuditLogActionWebhookAdd, second[0].Action)
+}
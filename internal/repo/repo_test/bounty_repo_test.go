@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/bounty"
+	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/question"
+	"github.com/apache/incubator-answer/internal/repo/rank"
+	"github.com/apache/incubator-answer/internal/repo/search_common"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/repo/user"
+	config2 "github.com/apache/incubator-answer/internal/service/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_bountyRepo_AddAndFinishBounty(t *testing.T) {
+	var (
+		uniqueIDRepo  = unique.NewUniqueIDRepo(testDataSource)
+		questionRepo  = question.NewQuestionRepo(testDataSource, uniqueIDRepo, search_common.NewSearchIndexRepo(testDataSource))
+		userRepo      = user.NewUserRepo(testDataSource)
+		configService = config2.NewConfigService(config.NewConfigRepo(testDataSource))
+		bountyRepo    = bounty.NewBountyRepo(testDataSource, rank.NewUserRankRepo(testDataSource, configService))
+	)
+
+	sponsor := &entity.User{
+		Username:    "bounty_sponsor",
+		Pass:        "bounty_sponsor",
+		EMail:       "bounty_sponsor@example.com",
+		MailStatus:  entity.EmailStatusAvailable,
+		Status:      entity.UserStatusAvailable,
+		DisplayName: "bounty_sponsor",
+		Rank:        200,
+	}
+	err := userRepo.AddUser(context.TODO(), sponsor)
+	assert.NoError(t, err)
+	questionInfo := &entity.Question{
+		UserID:       sponsor.ID,
+		Title:        "Question with bounty",
+		OriginalText: "A hard question",
+		ParsedText:   "A hard question",
+		Status:       entity.QuestionStatusAvailable,
+		Show:         entity.QuestionShow,
+	}
+	err = questionRepo.AddQuestion(context.TODO(), questionInfo)
+	assert.NoError(t, err)
+
+	// the sponsor doesn't have enough reputation
+	err = bountyRepo.AddBounty(context.TODO(), &entity.QuestionBounty{
+		QuestionID: questionInfo.ID,
+		UserID:     sponsor.ID,
+		Amount:     500,
+		Status:     entity.QuestionBountyStatusActive,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}, &entity.Activity{UserID: sponsor.ID, ObjectID: questionInfo.ID, Rank: -500})
+	assert.Error(t, err)
+
+	// the sponsor must keep at least 1 reputation after the bounty is escrowed
+	err = bountyRepo.AddBounty(context.TODO(), &entity.QuestionBounty{
+		QuestionID: questionInfo.ID,
+		UserID:     sponsor.ID,
+		Amount:     200,
+		Status:     entity.QuestionBountyStatusActive,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}, &entity.Activity{UserID: sponsor.ID, ObjectID: questionInfo.ID, Rank: -200})
+	assert.Error(t, err)
+
+	questionBounty := &entity.QuestionBounty{
+		QuestionID: questionInfo.ID,
+		UserID:     sponsor.ID,
+		Amount:     100,
+		Status:     entity.QuestionBountyStatusActive,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	err = bountyRepo.AddBounty(context.TODO(), questionBounty,
+		&entity.Activity{UserID: sponsor.ID, ObjectID: questionInfo.ID, Rank: -100})
+	assert.NoError(t, err)
+	got, exist, err := userRepo.GetByUserID(context.TODO(), sponsor.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 100, got.Rank)
+
+	// only one active bounty for each question
+	err = bountyRepo.AddBounty(context.TODO(), &entity.QuestionBounty{
+		QuestionID: questionInfo.ID,
+		UserID:     sponsor.ID,
+		Amount:     50,
+		Status:     entity.QuestionBountyStatusActive,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}, &entity.Activity{UserID: sponsor.ID, ObjectID: questionInfo.ID, Rank: -50})
+	assert.Error(t, err)
+
+	amounts, err := bountyRepo.GetActiveBountyAmounts(context.TODO(), []string{questionInfo.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 100, amounts[questionInfo.ID])
+
+	questions, total, err := questionRepo.GetQuestionPage(context.TODO(), 1, 10,
+		nil, "", "bounty", 0, false, false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, questionInfo.ID, questions[0].ID)
+
+	expired, err := bountyRepo.GetExpiredBounties(context.TODO(), time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(expired))
+	expired, err = bountyRepo.GetExpiredBounties(context.TODO(), time.Now().Add(2*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(expired))
+
+	// refund the bounty to the sponsor
+	expired[0].Status = entity.QuestionBountyStatusExpired
+	err = bountyRepo.FinishBounty(context.TODO(), expired[0],
+		&entity.Activity{UserID: sponsor.ID, ObjectID: questionInfo.ID, Rank: 100})
+	assert.NoError(t, err)
+	got, _, err = userRepo.GetByUserID(context.TODO(), sponsor.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, got.Rank)
+
+	// the bounty can not be finished twice
+	err = bountyRepo.FinishBounty(context.TODO(), expired[0],
+		&entity.Activity{UserID: sponsor.ID, ObjectID: questionInfo.ID, Rank: 100})
+	assert.Error(t, err)
+	_, exist, err = bountyRepo.GetActiveBounty(context.TODO(), questionInfo.ID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
@@ -75,6 +75,53 @@ func Test_commentRepo_GetCommentPage(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_commentRepo_GetRootCommentPage(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	commentRepo := comment.NewCommentRepo(testDataSource, uniqueIDRepo)
+	testCommentEntity := buildCommentEntity()
+	err := commentRepo.AddComment(context.TODO(), testCommentEntity)
+	assert.NoError(t, err)
+	replyCommentEntity := buildCommentEntity()
+	replyCommentEntity.SetReplyCommentID(testCommentEntity.ID)
+	err = commentRepo.AddComment(context.TODO(), replyCommentEntity)
+	assert.NoError(t, err)
+	deepReplyCommentEntity := buildCommentEntity()
+	deepReplyCommentEntity.SetReplyCommentID(replyCommentEntity.ID)
+	err = commentRepo.AddComment(context.TODO(), deepReplyCommentEntity)
+	assert.NoError(t, err)
+
+	query := &commentService.CommentQuery{
+		PageCond: pager.PageCond{Page: 1, PageSize: 10},
+		ObjectID: testCommentEntity.ObjectID,
+	}
+	resp, total, err := commentRepo.GetRootCommentPage(context.TODO(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, testCommentEntity.ID, resp[0].ID)
+
+	resp, err = commentRepo.GetReplyCommentList(context.TODO(), query, []string{testCommentEntity.ID})
+	assert.NoError(t, err)
+	assert.Len(t, resp, 1)
+	assert.Equal(t, replyCommentEntity.ID, resp[0].ID)
+	assert.Equal(t, testCommentEntity.ID, resp[0].GetReplyCommentID())
+
+	// the reply of the removed comment becomes a root comment
+	err = commentRepo.RemoveComment(context.TODO(), replyCommentEntity.ID)
+	assert.NoError(t, err)
+	resp, total, err = commentRepo.GetRootCommentPage(context.TODO(), query)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.ElementsMatch(t, []string{testCommentEntity.ID, deepReplyCommentEntity.ID}, []string{resp[0].ID, resp[1].ID})
+	resp, err = commentRepo.GetReplyCommentList(context.TODO(), query, []string{testCommentEntity.ID})
+	assert.NoError(t, err)
+	assert.Empty(t, resp)
+
+	err = commentRepo.RemoveComment(context.TODO(), deepReplyCommentEntity.ID)
+	assert.NoError(t, err)
+	err = commentRepo.RemoveComment(context.TODO(), testCommentEntity.ID)
+	assert.NoError(t, err)
+}
+
 func Test_commentRepo_UpdateComment(t *testing.T) {
 	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
 	commentRepo := comment.NewCommentRepo(testDataSource, uniqueIDRepo)
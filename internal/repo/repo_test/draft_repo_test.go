@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/draft"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_draftRepo_GetAnswerDraft(t *testing.T) {
+	draftRepo := draft.NewDraftRepo(testDataSource)
+	questionDraft := &entity.Draft{
+		UserID:     "1",
+		ObjectType: entity.DraftObjectTypeQuestion,
+		Title:      "how to install answer",
+		Content:    "draft content",
+		Tags:       `[{"slug_name":"go"}]`,
+	}
+	err := draftRepo.AddDraft(context.TODO(), questionDraft)
+	assert.NoError(t, err)
+	answerDraft := &entity.Draft{
+		UserID:     "1",
+		ObjectType: entity.DraftObjectTypeAnswer,
+		QuestionID: "10010000000000001",
+		Content:    "answer draft content",
+	}
+	err = draftRepo.AddDraft(context.TODO(), answerDraft)
+	assert.NoError(t, err)
+
+	count, err := draftRepo.CountDraft(context.TODO(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	drafts, err := draftRepo.GetDraftList(context.TODO(), "1", entity.DraftObjectTypeQuestion)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(drafts))
+	assert.Equal(t, questionDraft.ID, drafts[0].ID)
+
+	gotDraft, exist, err := draftRepo.GetAnswerDraft(context.TODO(), "1", "10010000000000001")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, answerDraft.ID, gotDraft.ID)
+
+	_, exist, err = draftRepo.GetAnswerDraft(context.TODO(), "2", "10010000000000001")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	// the draft belongs to other user can not be updated
+	answerDraft.UserID, answerDraft.Content = "2", "updated answer draft content"
+	affected, err := draftRepo.UpdateDraft(context.TODO(), answerDraft)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), affected)
+
+	answerDraft.UserID = "1"
+	affected, err = draftRepo.UpdateDraft(context.TODO(), answerDraft)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+	gotDraft, exist, err = draftRepo.GetDraft(context.TODO(), "1", answerDraft.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "updated answer draft content", gotDraft.Content)
+
+	affected, err = draftRepo.RemoveDraft(context.TODO(), "1", answerDraft.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	affected, err = draftRepo.RemoveExpiredDrafts(context.TODO(), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+}
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/email_log"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_emailLogRepo_Deliver(t *testing.T) {
+	emailLogRepo := email_log.NewEmailLogRepo(testDataSource)
+	emailLog := &entity.EmailLog{
+		ToEmail:     "test@example.com",
+		Subject:     "test",
+		Body:        "<p>test</p>",
+		Status:      entity.EmailLogStatusPending,
+		NextRetryAt: time.Now().Add(-time.Minute),
+	}
+	err := emailLogRepo.AddEmailLog(context.TODO(), emailLog)
+	assert.NoError(t, err)
+
+	emailLogs, err := emailLogRepo.GetAvailableEmailLogs(context.TODO(), time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(emailLogs))
+	stale := *emailLogs[0]
+
+	claimed, err := emailLogRepo.ClaimEmailLog(context.TODO(), emailLogs[0], time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+
+	// the email has been claimed by others, so it can not be claimed again
+	claimed, err = emailLogRepo.ClaimEmailLog(context.TODO(), &stale, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+
+	emailLogs[0].Status = entity.EmailLogStatusFailed
+	emailLogs[0].Transport = "smtp"
+	emailLogs[0].LastError = "connection refused"
+	err = emailLogRepo.UpdateEmailLogResult(context.TODO(), emailLogs[0])
+	assert.NoError(t, err)
+
+	page, total, err := emailLogRepo.GetEmailLogPage(context.TODO(), 1, 10, entity.EmailLogStatusFailed)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "connection refused", page[0].LastError)
+	assert.Empty(t, page[0].Body)
+
+	affected, err := emailLogRepo.RetryEmailLog(context.TODO(), emailLog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+	emailLogs, err = emailLogRepo.GetAvailableEmailLogs(context.TODO(), time.Now().Add(time.Second), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(emailLogs))
+	assert.Equal(t, 0, emailLogs[0].Attempts)
+	// the body of the failed email is kept for retrying
+	assert.Equal(t, "<p>test</p>", emailLogs[0].Body)
+
+	// only failed email can be retried
+	affected, err = emailLogRepo.RetryEmailLog(context.TODO(), emailLog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), affected)
+
+	err = emailLogRepo.RemoveEmailLogsBefore(context.TODO(), entity.EmailLogStatusPending, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+}
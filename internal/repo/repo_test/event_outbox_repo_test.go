@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/event_outbox"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_eventOutboxRepo_ClaimEvent(t *testing.T) {
+	eventOutboxRepo := event_outbox.NewEventOutboxRepo(testDataSource)
+	event := &entity.EventOutbox{
+		EventType:   "question.create",
+		Payload:     `{"event_type":"question.create"}`,
+		Status:      entity.EventOutboxStatusPending,
+		NextRetryAt: time.Now().Add(-time.Minute),
+	}
+	err := eventOutboxRepo.AddEvent(context.TODO(), event)
+	assert.NoError(t, err)
+
+	events, err := eventOutboxRepo.GetAvailableEvents(context.TODO(), time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(events))
+	stale := *events[0]
+
+	claimed, err := eventOutboxRepo.ClaimEvent(context.TODO(), events[0], time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, 1, events[0].Attempts)
+
+	// the event has been claimed by others, so it can not be claimed again
+	claimed, err = eventOutboxRepo.ClaimEvent(context.TODO(), &stale, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+
+	events[0].Status = entity.EventOutboxStatusDone
+	err = eventOutboxRepo.UpdateEventResult(context.TODO(), events[0])
+	assert.NoError(t, err)
+
+	events, err = eventOutboxRepo.GetAvailableEvents(context.TODO(), time.Now().Add(time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(events))
+
+	err = eventOutboxRepo.RemoveEventsBefore(context.TODO(), entity.EventOutboxStatusDone, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/moderator_message"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_moderatorMessageRepo_AcknowledgeModeratorMessage(t *testing.T) {
+	var (
+		moderatorMessageRepo = moderator_message.NewModeratorMessageRepo(testDataSource)
+		userID               = "1101"
+	)
+
+	first := &entity.ModeratorMessage{UserID: userID, OperatorUserID: "1", TemplateKey: "reason.message_low_quality",
+		Content: "Please take more care with your posts.", Status: entity.ModeratorMessageStatusUnacknowledged}
+	err := moderatorMessageRepo.AddModeratorMessage(context.TODO(), first)
+	assert.NoError(t, err)
+	second := &entity.ModeratorMessage{UserID: userID, OperatorUserID: "1", ObjectID: "10010000000000001",
+		Content: "Please be respectful to others.", Status: entity.ModeratorMessageStatusUnacknowledged}
+	err = moderatorMessageRepo.AddModeratorMessage(context.TODO(), second)
+	assert.NoError(t, err)
+
+	messages, err := moderatorMessageRepo.GetModeratorMessageList(context.TODO(), userID, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(messages))
+	assert.Equal(t, second.ID, messages[0].ID)
+
+	// only the receiver can acknowledge the message
+	err = moderatorMessageRepo.AcknowledgeModeratorMessage(context.TODO(), first.ID, "1102")
+	assert.NoError(t, err)
+	got, exist, err := moderatorMessageRepo.GetModeratorMessage(context.TODO(), first.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, entity.ModeratorMessageStatusUnacknowledged, got.Status)
+
+	err = moderatorMessageRepo.AcknowledgeModeratorMessage(context.TODO(), first.ID, userID)
+	assert.NoError(t, err)
+	got, _, err = moderatorMessageRepo.GetModeratorMessage(context.TODO(), first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ModeratorMessageStatusAcknowledged, got.Status)
+	assert.False(t, got.AcknowledgedAt.IsZero())
+
+	messages, err = moderatorMessageRepo.GetModeratorMessageList(context.TODO(), userID, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(messages))
+	messages, err = moderatorMessageRepo.GetModeratorMessageList(context.TODO(), userID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(messages))
+}
+
+func Test_moderatorMessageRepo_HasUnacknowledgedModeratorMessage(t *testing.T) {
+	var (
+		moderatorMessageRepo = moderator_message.NewModeratorMessageRepo(testDataSource)
+		userID               = "1103"
+	)
+
+	exist, err := moderatorMessageRepo.HasUnacknowledgedModeratorMessage(context.TODO(), userID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	message := &entity.ModeratorMessage{UserID: userID, OperatorUserID: "1", TemplateKey: "reason.message_low_quality",
+		Content: "Please take more care with your posts.", Status: entity.ModeratorMessageStatusUnacknowledged}
+	err = moderatorMessageRepo.AddModeratorMessage(context.TODO(), message)
+	assert.NoError(t, err)
+	exist, err = moderatorMessageRepo.HasUnacknowledgedModeratorMessage(context.TODO(), userID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	err = moderatorMessageRepo.AcknowledgeModeratorMessage(context.TODO(), message.ID, userID)
+	assert.NoError(t, err)
+	exist, err = moderatorMessageRepo.HasUnacknowledgedModeratorMessage(context.TODO(), userID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
@@ -22,6 +22,7 @@ package repo_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/repo/notification"
@@ -121,3 +122,35 @@ func Test_notificationRepo_UpdateNotificationContent(t *testing.T) {
 	assert.True(t, exists)
 	assert.Equal(t, got.Content, ent.Content)
 }
+
+func Test_notificationRepo_GetUnreadInboxNotifications(t *testing.T) {
+	notificationRepo := notification.NewNotificationRepo(testDataSource)
+	readEnt := buildNotificationEntity()
+	readEnt.UserID = "2"
+	err := notificationRepo.AddNotification(context.TODO(), readEnt)
+	assert.NoError(t, err)
+	unreadEnt := buildNotificationEntity()
+	unreadEnt.UserID = "2"
+	err = notificationRepo.AddNotification(context.TODO(), unreadEnt)
+	assert.NoError(t, err)
+
+	got, err := notificationRepo.GetUnreadInboxNotifications(context.TODO(), "2",
+		time.Now().Add(-time.Hour), time.Now().Add(time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(got))
+
+	// the read notification is not in the digest
+	err = notificationRepo.ClearIDUnRead(context.TODO(), readEnt.UserID, readEnt.ID)
+	assert.NoError(t, err)
+	got, err = notificationRepo.GetUnreadInboxNotifications(context.TODO(), "2",
+		time.Now().Add(-time.Hour), time.Now().Add(time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(got))
+	assert.Equal(t, unreadEnt.ID, got[0].ID)
+
+	// the notification created before the last digest is excluded
+	got, err = notificationRepo.GetUnreadInboxNotifications(context.TODO(), "2",
+		time.Now().Add(time.Hour), time.Now().Add(2*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(got))
+}
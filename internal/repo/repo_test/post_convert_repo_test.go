@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/activity"
+	"github.com/apache/incubator-answer/internal/repo/activity_common"
+	"github.com/apache/incubator-answer/internal/repo/comment"
+	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	config2 "github.com/apache/incubator-answer/internal/service/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_postConvertRepo_ConvertCommentToAnswer(t *testing.T) {
+	var (
+		uniqueIDRepo    = unique.NewUniqueIDRepo(testDataSource)
+		configService   = config2.NewConfigService(config.NewConfigRepo(testDataSource))
+		activityRepo    = activity_common.NewActivityRepo(testDataSource, uniqueIDRepo, configService)
+		commentRepo     = comment.NewCommentRepo(testDataSource, uniqueIDRepo)
+		postConvertRepo = activity.NewPostConvertRepo(testDataSource, activityRepo)
+	)
+
+	commentInfo := buildCommentEntity()
+	err := commentRepo.AddComment(context.TODO(), commentInfo)
+	assert.NoError(t, err)
+	commentInfo.CreatedAt = time.Now().Add(-time.Hour).Truncate(time.Second)
+	commentInfo.UpdatedAt = commentInfo.CreatedAt
+
+	commentVoteUp, err := activityRepo.GetActivityTypeByConfigKey(context.TODO(), activity_type.CommentVoteUp)
+	assert.NoError(t, err)
+	_, err = testDataSource.DB.Insert(&entity.Activity{
+		UserID:           "2",
+		ObjectID:         commentInfo.ID,
+		OriginalObjectID: commentInfo.ID,
+		ActivityType:     commentVoteUp,
+		Cancelled:        entity.ActivityAvailable,
+	})
+	assert.NoError(t, err)
+
+	answerID, err := uniqueIDRepo.GenUniqueIDStr(context.TODO(), entity.Answer{}.TableName())
+	assert.NoError(t, err)
+	_, err = testDataSource.DB.Insert(&entity.Answer{
+		ID:           answerID,
+		QuestionID:   commentInfo.QuestionID,
+		UserID:       commentInfo.UserID,
+		OriginalText: commentInfo.OriginalText,
+		ParsedText:   commentInfo.ParsedText,
+		Status:       entity.AnswerStatusAvailable,
+	})
+	assert.NoError(t, err)
+
+	err = postConvertRepo.ConvertCommentToAnswer(context.TODO(), commentInfo, answerID)
+	assert.NoError(t, err)
+
+	got := &entity.Answer{}
+	exist, err := testDataSource.DB.ID(answerID).Get(got)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 1, got.VoteCount)
+	assert.Equal(t, commentInfo.CreatedAt.Unix(), got.CreatedAt.Unix())
+
+	answerVoteUp, err := activityRepo.GetActivityTypeByConfigKey(context.TODO(), activity_type.AnswerVoteUp)
+	assert.NoError(t, err)
+	count, err := testDataSource.DB.Where("object_id = ?", answerID).
+		And("activity_type = ?", answerVoteUp).Count(&entity.Activity{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/question"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_questionCloseVoteRepo_GetPendingCloseVoteQuestionPage(t *testing.T) {
+	var (
+		closeVoteRepo = question.NewQuestionCloseVoteRepo(testDataSource)
+		since         = time.Now().Add(-time.Hour)
+		firstID       = "10010000000001401"
+		secondID      = "10010000000001402"
+	)
+
+	votes := []*entity.QuestionCloseVote{
+		{QuestionID: firstID, UserID: "1401", VoteType: entity.QuestionCloseVoteTypeClose, CloseType: 61},
+		{QuestionID: secondID, UserID: "1401", VoteType: entity.QuestionCloseVoteTypeClose, CloseType: 62},
+		{QuestionID: firstID, UserID: "1402", VoteType: entity.QuestionCloseVoteTypeClose, CloseType: 61},
+		{QuestionID: secondID, UserID: "1402", VoteType: entity.QuestionCloseVoteTypeReopen},
+	}
+	for _, vote := range votes {
+		vote.Status = entity.QuestionCloseVoteStatusPending
+		err := closeVoteRepo.AddCloseVote(context.TODO(), vote)
+		assert.NoError(t, err)
+	}
+
+	_, exist, err := closeVoteRepo.GetPendingCloseVote(context.TODO(), firstID, "1402",
+		entity.QuestionCloseVoteTypeClose, since)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	_, exist, err = closeVoteRepo.GetPendingCloseVote(context.TODO(), firstID, "1402",
+		entity.QuestionCloseVoteTypeReopen, since)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	// the latest voted question first
+	questionIDs, total, err := closeVoteRepo.GetPendingCloseVoteQuestionPage(context.TODO(), 1, 10,
+		entity.QuestionCloseVoteTypeClose, since)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, []string{firstID, secondID}, questionIDs)
+
+	got, err := closeVoteRepo.GetPendingCloseVotes(context.TODO(), []string{firstID},
+		entity.QuestionCloseVoteTypeClose, since)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(got))
+	assert.Equal(t, votes[0].ID, got[0].ID)
+
+	// the votes cast before the since time are stale
+	count, err := closeVoteRepo.CountPendingCloseVoteQuestions(context.TODO(), entity.QuestionCloseVoteTypeClose,
+		time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func Test_questionCloseVoteRepo_UpdateCloseVoteStatus(t *testing.T) {
+	var (
+		closeVoteRepo = question.NewQuestionCloseVoteRepo(testDataSource)
+		since         = time.Now().Add(-time.Hour)
+		questionID    = "10010000000001501"
+	)
+
+	for _, userID := range []string{"1501", "1502"} {
+		err := closeVoteRepo.AddCloseVote(context.TODO(), &entity.QuestionCloseVote{QuestionID: questionID,
+			UserID: userID, VoteType: entity.QuestionCloseVoteTypeClose, Status: entity.QuestionCloseVoteStatusPending})
+		assert.NoError(t, err)
+	}
+
+	err := closeVoteRepo.UpdateCloseVoteStatus(context.TODO(), questionID,
+		[]int{entity.QuestionCloseVoteTypeReopen}, entity.QuestionCloseVoteStatusCompleted)
+	assert.NoError(t, err)
+	votes, err := closeVoteRepo.GetPendingCloseVotes(context.TODO(), []string{questionID},
+		entity.QuestionCloseVoteTypeClose, since)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(votes))
+
+	err = closeVoteRepo.UpdateCloseVoteStatus(context.TODO(), questionID,
+		[]int{entity.QuestionCloseVoteTypeClose}, entity.QuestionCloseVoteStatusCompleted)
+	assert.NoError(t, err)
+	votes, err = closeVoteRepo.GetPendingCloseVotes(context.TODO(), []string{questionID},
+		entity.QuestionCloseVoteTypeClose, since)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(votes))
+
+	err = closeVoteRepo.AddCloseVote(context.TODO(), &entity.QuestionCloseVote{QuestionID: questionID,
+		UserID: "1503", VoteType: entity.QuestionCloseVoteTypeReopen, Status: entity.QuestionCloseVoteStatusPending})
+	assert.NoError(t, err)
+	err = closeVoteRepo.ExpireCloseVotes(context.TODO(), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	count, err := closeVoteRepo.CountPendingCloseVoteQuestions(context.TODO(), entity.QuestionCloseVoteTypeReopen,
+		time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func Test_questionCloseVoteRepo_AddCloseVoteAgain(t *testing.T) {
+	var (
+		closeVoteRepo = question.NewQuestionCloseVoteRepo(testDataSource)
+		since         = time.Now().Add(-time.Hour)
+		questionID    = "10010000000001601"
+		userID        = "1601"
+	)
+
+	err := closeVoteRepo.AddCloseVote(context.TODO(), &entity.QuestionCloseVote{QuestionID: questionID,
+		UserID: userID, VoteType: entity.QuestionCloseVoteTypeClose, Status: entity.QuestionCloseVoteStatusPending})
+	assert.NoError(t, err)
+	// only one vote of each type is allowed for the user on the question
+	_, err = testDataSource.DB.Context(context.TODO()).Insert(&entity.QuestionCloseVote{QuestionID: questionID,
+		UserID: userID, VoteType: entity.QuestionCloseVoteTypeClose, Status: entity.QuestionCloseVoteStatusPending})
+	assert.Error(t, err)
+
+	// the user can vote again once the previous votes are resolved
+	err = closeVoteRepo.UpdateCloseVoteStatus(context.TODO(), questionID,
+		[]int{entity.QuestionCloseVoteTypeClose}, entity.QuestionCloseVoteStatusCompleted)
+	assert.NoError(t, err)
+	vote := &entity.QuestionCloseVote{QuestionID: questionID, UserID: userID,
+		VoteType: entity.QuestionCloseVoteTypeClose, Status: entity.QuestionCloseVoteStatusPending}
+	err = closeVoteRepo.AddCloseVote(context.TODO(), vote)
+	assert.NoError(t, err)
+
+	votes, err := closeVoteRepo.GetPendingCloseVotes(context.TODO(), []string{questionID},
+		entity.QuestionCloseVoteTypeClose, since)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(votes))
+	assert.Equal(t, vote.ID, votes[0].ID)
+}
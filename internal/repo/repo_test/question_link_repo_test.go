@@ -0,0 +1,353 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/activity"
+	"github.com/apache/incubator-answer/internal/repo/activity_common"
+	"github.com/apache/incubator-answer/internal/repo/answer"
+	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/question"
+	"github.com/apache/incubator-answer/internal/repo/rank"
+	"github.com/apache/incubator-answer/internal/repo/search_common"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	config2 "github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_questionLinkRepo_AddGetRemoveQuestionLinks(t *testing.T) {
+	linkRepo := question.NewQuestionLinkRepo(testDataSource)
+
+	err := linkRepo.AddQuestionLinks(context.TODO(), []*entity.QuestionLink{
+		{FromQuestionID: "10010000000000101", ToQuestionID: "10010000000000102",
+			LinkType: entity.QuestionLinkTypeDuplicate, UserID: "1"},
+		{FromQuestionID: "10010000000000101", ToQuestionID: "10010000000000103",
+			LinkType: entity.QuestionLinkTypeDuplicate, UserID: "1"},
+	})
+	assert.NoError(t, err)
+
+	links, err := linkRepo.GetQuestionLinks(context.TODO(), "10010000000000101")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(links))
+	links, err = linkRepo.GetQuestionLinks(context.TODO(), "10010000000000102")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(links))
+
+	err = linkRepo.AddQuestionLinks(context.TODO(), []*entity.QuestionLink{
+		{FromQuestionID: "10010000000000101", ToQuestionID: "10010000000000104",
+			LinkType: entity.QuestionLinkTypeMerged, UserID: "1"},
+	})
+	assert.NoError(t, err)
+	err = linkRepo.RemoveDuplicateLinks(context.TODO(), "10010000000000101")
+	assert.NoError(t, err)
+	links, err = linkRepo.GetQuestionLinks(context.TODO(), "10010000000000102")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(links))
+	// the merged link is kept
+	links, err = linkRepo.GetQuestionLinks(context.TODO(), "10010000000000104")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(links))
+
+	// add the removed link again
+	err = linkRepo.AddQuestionLinks(context.TODO(), []*entity.QuestionLink{
+		{FromQuestionID: "10010000000000101", ToQuestionID: "10010000000000102",
+			LinkType: entity.QuestionLinkTypeDuplicate, UserID: "1"},
+	})
+	assert.NoError(t, err)
+	links, err = linkRepo.GetQuestionLinks(context.TODO(), "10010000000000101")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(links))
+}
+
+func Test_questionMergeRepo_MergeQuestion(t *testing.T) {
+	var (
+		uniqueIDRepo    = unique.NewUniqueIDRepo(testDataSource)
+		configService   = config2.NewConfigService(config.NewConfigRepo(testDataSource))
+		searchIndexRepo = search_common.NewSearchIndexRepo(testDataSource)
+		activityRepo    = activity_common.NewActivityRepo(testDataSource, uniqueIDRepo, configService)
+		questionRepo    = question.NewQuestionRepo(testDataSource, uniqueIDRepo, searchIndexRepo)
+		userRankRepo    = rank.NewUserRankRepo(testDataSource, configService)
+		answerRepo      = answer.NewAnswerRepo(testDataSource, uniqueIDRepo, userRankRepo, activityRepo, searchIndexRepo)
+		mergeRepo       = activity.NewQuestionMergeRepo(testDataSource, activityRepo, userRankRepo)
+	)
+
+	questions := make([]*entity.Question, 0)
+	for _, title := range []string{"Merge source question", "Merge target question"} {
+		questionInfo := &entity.Question{
+			UserID:       "1",
+			Title:        title,
+			OriginalText: title,
+			ParsedText:   title,
+			Status:       entity.QuestionStatusAvailable,
+			Show:         entity.QuestionShow,
+		}
+		err := questionRepo.AddQuestion(context.TODO(), questionInfo)
+		assert.NoError(t, err)
+		questions = append(questions, questionInfo)
+	}
+	source, target := questions[0], questions[1]
+
+	answerInfo := &entity.Answer{
+		QuestionID:   source.ID,
+		UserID:       "1",
+		OriginalText: "answer of the source question",
+		ParsedText:   "answer of the source question",
+		Status:       entity.AnswerStatusAvailable,
+	}
+	err := answerRepo.AddAnswer(context.TODO(), answerInfo)
+	assert.NoError(t, err)
+
+	// the source question has a duplicate link before it is merged
+	linkRepo := question.NewQuestionLinkRepo(testDataSource)
+	err = linkRepo.AddQuestionLinks(context.TODO(), []*entity.QuestionLink{{FromQuestionID: source.ID,
+		ToQuestionID: "10010000000000199", LinkType: entity.QuestionLinkTypeDuplicate, UserID: "1"}})
+	assert.NoError(t, err)
+
+	err = mergeRepo.MergeQuestion(context.TODO(), source.ID, target.ID, `{"duplicate_ids":["`+target.ID+`"]}`, "1")
+	assert.NoError(t, err)
+
+	gotAnswer, exist, err := answerRepo.GetAnswer(context.TODO(), answerInfo.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, target.ID, gotAnswer.QuestionID)
+
+	gotTarget, exist, err := questionRepo.GetQuestion(context.TODO(), target.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 1, gotTarget.AnswerCount)
+	assert.Equal(t, answerInfo.ID, gotTarget.LastAnswerID)
+
+	gotSource, exist, err := questionRepo.GetQuestion(context.TODO(), source.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 0, gotSource.AnswerCount)
+	assert.Equal(t, entity.QuestionStatusClosed, gotSource.Status)
+
+	links, err := linkRepo.GetQuestionLinks(context.TODO(), source.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(links))
+	assert.Equal(t, target.ID, links[0].ToQuestionID)
+	assert.Equal(t, entity.QuestionLinkTypeMerged, links[0].LinkType)
+}
+
+func Test_questionMergeRepo_MergeQuestionRank(t *testing.T) {
+	var (
+		uniqueIDRepo    = unique.NewUniqueIDRepo(testDataSource)
+		configService   = config2.NewConfigService(config.NewConfigRepo(testDataSource))
+		searchIndexRepo = search_common.NewSearchIndexRepo(testDataSource)
+		activityRepo    = activity_common.NewActivityRepo(testDataSource, uniqueIDRepo, configService)
+		questionRepo    = question.NewQuestionRepo(testDataSource, uniqueIDRepo, searchIndexRepo)
+		userRankRepo    = rank.NewUserRankRepo(testDataSource, configService)
+		answerRepo      = answer.NewAnswerRepo(testDataSource, uniqueIDRepo, userRankRepo, activityRepo, searchIndexRepo)
+		userRepo        = user.NewUserRepo(testDataSource)
+		mergeRepo       = activity.NewQuestionMergeRepo(testDataSource, activityRepo, userRankRepo)
+	)
+
+	users := make([]*entity.User, 0)
+	for _, name := range []string{"merge_source_author", "merge_target_author", "merge_voter"} {
+		userInfo := &entity.User{
+			Username:    name,
+			Pass:        name,
+			EMail:       name + "@example.com",
+			MailStatus:  entity.EmailStatusAvailable,
+			Status:      entity.UserStatusAvailable,
+			DisplayName: name,
+			Rank:        100,
+		}
+		assert.NoError(t, userRepo.AddUser(context.TODO(), userInfo))
+		users = append(users, userInfo)
+	}
+	sourceAuthor, targetAuthor, voter := users[0], users[1], users[2]
+
+	questions := make([]*entity.Question, 0)
+	for _, userInfo := range []*entity.User{sourceAuthor, targetAuthor} {
+		questionInfo := &entity.Question{
+			UserID:       userInfo.ID,
+			Title:        "Merge rank question of " + userInfo.Username,
+			OriginalText: "Merge rank question",
+			ParsedText:   "Merge rank question",
+			Status:       entity.QuestionStatusAvailable,
+			Show:         entity.QuestionShow,
+		}
+		assert.NoError(t, questionRepo.AddQuestion(context.TODO(), questionInfo))
+		questions = append(questions, questionInfo)
+	}
+	source, target := questions[0], questions[1]
+
+	// both of the questions have accepted answers from the voter
+	answers := make([]*entity.Answer, 0)
+	for _, questionInfo := range questions {
+		answerInfo := &entity.Answer{
+			QuestionID:   questionInfo.ID,
+			UserID:       voter.ID,
+			OriginalText: "accepted answer",
+			ParsedText:   "accepted answer",
+			Status:       entity.AnswerStatusAvailable,
+			Accepted:     schema.AnswerAcceptedEnable,
+		}
+		assert.NoError(t, answerRepo.AddAnswer(context.TODO(), answerInfo))
+		answers = append(answers, answerInfo)
+		questionInfo.AcceptedAnswerID = answerInfo.ID
+		assert.NoError(t, questionRepo.UpdateQuestion(context.TODO(), questionInfo, []string{"accepted_answer_id"}))
+	}
+
+	acceptedType, err := activityRepo.GetActivityTypeByConfigKey(context.TODO(), activity_type.AnswerAccepted)
+	assert.NoError(t, err)
+	voteUpType, err := activityRepo.GetActivityTypeByObjectType(context.TODO(), constant.QuestionObjectType, constant.ActVoteUp)
+	assert.NoError(t, err)
+	votedUpType, err := activityRepo.GetActivityTypeByObjectType(context.TODO(), constant.QuestionObjectType, constant.ActVotedUp)
+	assert.NoError(t, err)
+	activities := []*entity.Activity{
+		{UserID: voter.ID, ObjectID: answers[0].ID, OriginalObjectID: answers[0].ID, ActivityType: acceptedType,
+			Rank: 15, HasRank: 1},
+		{UserID: voter.ID, ObjectID: source.ID, OriginalObjectID: source.ID, ActivityType: voteUpType},
+		{UserID: sourceAuthor.ID, TriggerUserID: converter.StringToInt64(voter.ID), ObjectID: source.ID,
+			OriginalObjectID: source.ID, ActivityType: votedUpType, Rank: 10, HasRank: 1},
+	}
+	_, err = testDataSource.DB.Insert(activities)
+	assert.NoError(t, err)
+
+	err = mergeRepo.MergeQuestion(context.TODO(), source.ID, target.ID, "{}", "1")
+	assert.NoError(t, err)
+
+	// the rank of the source accepted answer and the vote are rolled back
+	gotUser, _, err := userRepo.GetByUserID(context.TODO(), voter.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 85, gotUser.Rank)
+	gotUser, _, err = userRepo.GetByUserID(context.TODO(), sourceAuthor.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 90, gotUser.Rank)
+	// the target author gets the rank of the moved vote
+	gotUser, _, err = userRepo.GetByUserID(context.TODO(), targetAuthor.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 110, gotUser.Rank)
+
+	moved := make([]*entity.Activity, 0)
+	err = testDataSource.DB.Where("object_id = ?", target.ID).
+		In("activity_type", voteUpType, votedUpType).Asc("id").Find(&moved)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(moved))
+	assert.Equal(t, voter.ID, moved[0].UserID)
+	assert.Equal(t, target.ID, moved[0].OriginalObjectID)
+	assert.Equal(t, targetAuthor.ID, moved[1].UserID)
+	assert.Equal(t, entity.ActivityAvailable, moved[1].Cancelled)
+	assert.Equal(t, 10, moved[1].Rank)
+
+	gotTarget, _, err := questionRepo.GetQuestion(context.TODO(), target.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, answers[1].ID, gotTarget.AcceptedAnswerID)
+	assert.Equal(t, 1, gotTarget.VoteCount)
+}
+
+func Test_questionMergeRepo_MergeQuestionCancelledVote(t *testing.T) {
+	var (
+		uniqueIDRepo    = unique.NewUniqueIDRepo(testDataSource)
+		configService   = config2.NewConfigService(config.NewConfigRepo(testDataSource))
+		searchIndexRepo = search_common.NewSearchIndexRepo(testDataSource)
+		activityRepo    = activity_common.NewActivityRepo(testDataSource, uniqueIDRepo, configService)
+		questionRepo    = question.NewQuestionRepo(testDataSource, uniqueIDRepo, searchIndexRepo)
+		userRankRepo    = rank.NewUserRankRepo(testDataSource, configService)
+		userRepo        = user.NewUserRepo(testDataSource)
+		mergeRepo       = activity.NewQuestionMergeRepo(testDataSource, activityRepo, userRankRepo)
+	)
+
+	users := make([]*entity.User, 0)
+	for _, name := range []string{"cancelled_source_author", "cancelled_target_author", "cancelled_voter"} {
+		userInfo := &entity.User{
+			Username:    name,
+			Pass:        name,
+			EMail:       name + "@example.com",
+			MailStatus:  entity.EmailStatusAvailable,
+			Status:      entity.UserStatusAvailable,
+			DisplayName: name,
+			Rank:        100,
+		}
+		assert.NoError(t, userRepo.AddUser(context.TODO(), userInfo))
+		users = append(users, userInfo)
+	}
+	sourceAuthor, targetAuthor, voter := users[0], users[1], users[2]
+
+	questions := make([]*entity.Question, 0)
+	for _, userInfo := range []*entity.User{sourceAuthor, targetAuthor} {
+		questionInfo := &entity.Question{
+			UserID:       userInfo.ID,
+			Title:        "Cancelled vote question of " + userInfo.Username,
+			OriginalText: "Cancelled vote question",
+			ParsedText:   "Cancelled vote question",
+			Status:       entity.QuestionStatusAvailable,
+			Show:         entity.QuestionShow,
+		}
+		assert.NoError(t, questionRepo.AddQuestion(context.TODO(), questionInfo))
+		questions = append(questions, questionInfo)
+	}
+	source, target := questions[0], questions[1]
+
+	voteUpType, err := activityRepo.GetActivityTypeByObjectType(context.TODO(), constant.QuestionObjectType, constant.ActVoteUp)
+	assert.NoError(t, err)
+	votedUpType, err := activityRepo.GetActivityTypeByObjectType(context.TODO(), constant.QuestionObjectType, constant.ActVotedUp)
+	assert.NoError(t, err)
+	followType, err := activityRepo.GetActivityTypeByObjectType(context.TODO(), constant.QuestionObjectType, constant.ActFollow)
+	assert.NoError(t, err)
+	voterID := converter.StringToInt64(voter.ID)
+	activities := []*entity.Activity{
+		// the voter withdrew the vote and the follow of the target question
+		{UserID: voter.ID, ObjectID: target.ID, OriginalObjectID: target.ID, ActivityType: voteUpType,
+			Cancelled: entity.ActivityCancelled},
+		{UserID: targetAuthor.ID, TriggerUserID: voterID, ObjectID: target.ID, OriginalObjectID: target.ID,
+			ActivityType: votedUpType, Rank: 10, HasRank: 1, Cancelled: entity.ActivityCancelled},
+		{UserID: voter.ID, ObjectID: target.ID, OriginalObjectID: target.ID, ActivityType: followType,
+			Cancelled: entity.ActivityCancelled},
+		// the voter still votes and follows the source question
+		{UserID: voter.ID, ObjectID: source.ID, OriginalObjectID: source.ID, ActivityType: voteUpType},
+		{UserID: sourceAuthor.ID, TriggerUserID: voterID, ObjectID: source.ID, OriginalObjectID: source.ID,
+			ActivityType: votedUpType, Rank: 10, HasRank: 1},
+		{UserID: voter.ID, ObjectID: source.ID, OriginalObjectID: source.ID, ActivityType: followType},
+	}
+	_, err = testDataSource.DB.Insert(activities)
+	assert.NoError(t, err)
+
+	err = mergeRepo.MergeQuestion(context.TODO(), source.ID, target.ID, "{}", "1")
+	assert.NoError(t, err)
+
+	// the withdrawn activities are replaced by the moved ones, so there is only one of each type
+	for _, activityType := range []int{voteUpType, votedUpType, followType} {
+		got := make([]*entity.Activity, 0)
+		err = testDataSource.DB.Where("object_id = ?", target.ID).And("activity_type = ?", activityType).Find(&got)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(got))
+		assert.Equal(t, entity.ActivityAvailable, got[0].Cancelled)
+	}
+	gotUser, _, err := userRepo.GetByUserID(context.TODO(), targetAuthor.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 110, gotUser.Rank)
+	gotTarget, _, err := questionRepo.GetQuestion(context.TODO(), target.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, gotTarget.VoteCount)
+	assert.Equal(t, 1, gotTarget.FollowCount)
+}
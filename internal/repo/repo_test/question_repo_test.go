@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/question"
+	"github.com/apache/incubator-answer/internal/repo/search_common"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_questionRepo_UpdateQuestionOperationWiki(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	questionRepo := question.NewQuestionRepo(testDataSource, uniqueIDRepo, search_common.NewSearchIndexRepo(testDataSource))
+
+	questionInfo := &entity.Question{
+		UserID:       "1",
+		Title:        "Community wiki question",
+		OriginalText: "Community wiki question",
+		ParsedText:   "Community wiki question",
+		Status:       entity.QuestionStatusAvailable,
+		Show:         entity.QuestionShow,
+		Pin:          entity.QuestionUnPin,
+	}
+	err := questionRepo.AddQuestion(context.TODO(), questionInfo)
+	assert.NoError(t, err)
+
+	questionInfo.Wiki = true
+	err = questionRepo.UpdateQuestionOperation(context.TODO(), questionInfo)
+	assert.NoError(t, err)
+	got, exist, err := questionRepo.GetQuestion(context.TODO(), questionInfo.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.True(t, got.Wiki)
+
+	got.Wiki = false
+	err = questionRepo.UpdateQuestionOperation(context.TODO(), got)
+	assert.NoError(t, err)
+	got, _, err = questionRepo.GetQuestion(context.TODO(), questionInfo.ID)
+	assert.NoError(t, err)
+	assert.False(t, got.Wiki)
+	assert.Equal(t, entity.QuestionShow, got.Show)
+}
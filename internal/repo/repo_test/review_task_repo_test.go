@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/review"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_reviewTaskRepo_CompleteReviewTask(t *testing.T) {
+	var (
+		reviewTaskRepo = review.NewReviewTaskRepo(testDataSource)
+		authorID       = "1601"
+		reviewerID     = "1602"
+	)
+
+	tasks := []*entity.ReviewTask{
+		{QueueType: entity.ReviewTaskQueueFirstPost, ObjectID: "10010000000001601", UserID: authorID},
+		{QueueType: entity.ReviewTaskQueueFirstPost, ObjectID: "10020000000001602", UserID: authorID},
+		{QueueType: entity.ReviewTaskQueueLowQuality, ObjectID: "10020000000001602", UserID: authorID,
+			Reason: "too_short"},
+	}
+	for _, task := range tasks {
+		task.ReviewerUserID = "0"
+		task.Status = entity.ReviewTaskStatusPending
+		err := reviewTaskRepo.AddReviewTask(context.TODO(), task)
+		assert.NoError(t, err)
+	}
+
+	// the author can not review the own posts
+	count, err := reviewTaskRepo.GetPendingReviewTaskCount(context.TODO(), entity.ReviewTaskQueueFirstPost, authorID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	got, total, err := reviewTaskRepo.GetPendingReviewTaskPage(context.TODO(), 1, 10,
+		entity.ReviewTaskQueueFirstPost, reviewerID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, tasks[0].ID, got[0].ID)
+
+	completed, err := reviewTaskRepo.CompleteReviewTask(context.TODO(), tasks[0].ID, reviewerID,
+		constant.ReasonLooksOk)
+	assert.NoError(t, err)
+	assert.True(t, completed)
+	completed, err = reviewTaskRepo.CompleteReviewTask(context.TODO(), tasks[0].ID, authorID, constant.ReasonNeedsEdit)
+	assert.NoError(t, err)
+	assert.False(t, completed)
+	err = reviewTaskRepo.InvalidateReviewTask(context.TODO(), tasks[1].ID)
+	assert.NoError(t, err)
+
+	task, exist, err := reviewTaskRepo.GetReviewTask(context.TODO(), tasks[0].ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, entity.ReviewTaskStatusCompleted, task.Status)
+	assert.Equal(t, reviewerID, task.ReviewerUserID)
+	assert.Equal(t, constant.ReasonLooksOk, task.Outcome)
+
+	count, err = reviewTaskRepo.GetPendingReviewTaskCount(context.TODO(), entity.ReviewTaskQueueFirstPost, reviewerID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	count, err = reviewTaskRepo.GetPendingReviewTaskCount(context.TODO(), entity.ReviewTaskQueueLowQuality, reviewerID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func Test_reviewTaskRepo_GetReviewTaskCounts(t *testing.T) {
+	var (
+		reviewTaskRepo = review.NewReviewTaskRepo(testDataSource)
+		startTime      = time.Now().Add(-time.Hour)
+		reviewerID     = "1701"
+	)
+
+	for _, objectID := range []string{"10020000000001701", "10020000000001702"} {
+		task := &entity.ReviewTask{QueueType: entity.ReviewTaskQueueLateAnswer, ObjectID: objectID, UserID: "1702",
+			ReviewerUserID: "0", Status: entity.ReviewTaskStatusPending}
+		err := reviewTaskRepo.AddReviewTask(context.TODO(), task)
+		assert.NoError(t, err)
+		_, err = reviewTaskRepo.CompleteReviewTask(context.TODO(), task.ID, reviewerID, constant.ReasonNeedsDelete)
+		assert.NoError(t, err)
+	}
+
+	counts, err := reviewTaskRepo.GetReviewTaskCounts(context.TODO(), startTime, time.Time{})
+	assert.NoError(t, err)
+	var lateAnswerCount int64
+	for _, count := range counts {
+		if count.QueueType == entity.ReviewTaskQueueLateAnswer && count.Status == entity.ReviewTaskStatusCompleted {
+			assert.Equal(t, constant.ReasonNeedsDelete, count.Outcome)
+			lateAnswerCount += count.Count
+		}
+	}
+	assert.Equal(t, int64(2), lateAnswerCount)
+
+	reviewerCounts, err := reviewTaskRepo.GetReviewerTaskCounts(context.TODO(), startTime, time.Time{}, 10)
+	assert.NoError(t, err)
+	found := false
+	for _, count := range reviewerCounts {
+		if count.ReviewerUserID == reviewerID {
+			found = true
+			assert.Equal(t, int64(2), count.Count)
+		}
+	}
+	assert.True(t, found)
+
+	// nothing is created in the future
+	counts, err = reviewTaskRepo.GetReviewTaskCounts(context.TODO(), time.Now().Add(time.Hour), time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(counts))
+}
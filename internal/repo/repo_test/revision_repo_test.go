@@ -27,6 +27,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/repo/question"
 	"github.com/apache/incubator-answer/internal/repo/revision"
+	"github.com/apache/incubator-answer/internal/repo/search_common"
 	"github.com/apache/incubator-answer/internal/repo/unique"
 	"github.com/stretchr/testify/assert"
 )
@@ -64,7 +65,7 @@ func Test_revisionRepo_AddRevision(t *testing.T) {
 	var (
 		uniqueIDRepo = unique.NewUniqueIDRepo(testDataSource)
 		revisionRepo = revision.NewRevisionRepo(testDataSource, uniqueIDRepo)
-		questionRepo = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		questionRepo = question.NewQuestionRepo(testDataSource, uniqueIDRepo, search_common.NewSearchIndexRepo(testDataSource))
 	)
 
 	// create question
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_roleRepo_CustomRole(t *testing.T) {
+	var (
+		roleRepo         = role.NewRoleRepo(testDataSource)
+		rolePowerRelRepo = role.NewRolePowerRelRepo(testDataSource)
+		userRoleRelRepo  = role.NewUserRoleRelRepo(testDataSource)
+		powerRepo        = role.NewPowerRepo(testDataSource)
+	)
+
+	powers, err := powerRepo.GetPowerList(context.TODO(), &entity.Power{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, powers)
+
+	curator := &entity.Role{Name: "Tag curator", Description: "Curate the tags"}
+	err = roleRepo.AddRole(context.TODO(), curator, []string{"tag.edit", "tag.synonym"})
+	assert.NoError(t, err)
+	assert.NotZero(t, curator.ID)
+
+	got, exist, err := roleRepo.GetRole(context.TODO(), curator.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "Tag curator", got.Name)
+
+	powerTypes, err := rolePowerRelRepo.GetRolePowerTypeList(context.TODO(), curator.ID)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"tag.edit", "tag.synonym"}, powerTypes)
+
+	err = rolePowerRelRepo.SaveRolePowerTypeList(context.TODO(), curator.ID, []string{"tag.delete"})
+	assert.NoError(t, err)
+	powerTypes, err = rolePowerRelRepo.GetRolePowerTypeList(context.TODO(), curator.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tag.delete"}, powerTypes)
+
+	curator.Name = "Tag gardener"
+	err = roleRepo.UpdateRole(context.TODO(), curator)
+	assert.NoError(t, err)
+	got, _, err = roleRepo.GetRole(context.TODO(), curator.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Tag gardener", got.Name)
+
+	err = userRoleRelRepo.SaveUserRoleRelList(context.TODO(), []string{"101", "102"}, curator.ID)
+	assert.NoError(t, err)
+	rels, err := userRoleRelRepo.GetUserRoleRelListByRoleID(context.TODO(), []int{curator.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(rels))
+
+	err = userRoleRelRepo.SaveUserRoleRelList(context.TODO(), []string{"101", "102"}, 1)
+	assert.NoError(t, err)
+	err = roleRepo.RemoveRole(context.TODO(), curator.ID)
+	assert.NoError(t, err)
+	_, exist, err = roleRepo.GetRole(context.TODO(), curator.ID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	powerTypes, err = rolePowerRelRepo.GetRolePowerTypeList(context.TODO(), curator.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, powerTypes)
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/saved_search"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_savedSearchRepo_GetNotifiableSavedSearchPage(t *testing.T) {
+	savedSearchRepo := saved_search.NewSavedSearchRepo(testDataSource)
+	notifiable := &entity.SavedSearch{
+		UserID:      "1",
+		Name:        "nginx",
+		Query:       "[nginx] is:question",
+		NotifyEmail: true,
+	}
+	err := savedSearchRepo.AddSavedSearch(context.TODO(), notifiable)
+	assert.NoError(t, err)
+	silent := &entity.SavedSearch{
+		UserID: "1",
+		Name:   "caddy",
+		Query:  "caddy",
+	}
+	err = savedSearchRepo.AddSavedSearch(context.TODO(), silent)
+	assert.NoError(t, err)
+
+	count, err := savedSearchRepo.CountSavedSearch(context.TODO(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	savedSearches, err := savedSearchRepo.GetNotifiableSavedSearchPage(context.TODO(), 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(savedSearches))
+	assert.Equal(t, notifiable.ID, savedSearches[0].ID)
+
+	// the saved search belongs to other user can not be updated
+	silent.UserID, silent.NotifyInbox = "2", true
+	affected, err := savedSearchRepo.UpdateSavedSearch(context.TODO(), silent)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), affected)
+
+	silent.UserID = "1"
+	affected, err = savedSearchRepo.UpdateSavedSearch(context.TODO(), silent)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	err = savedSearchRepo.UpdateLastCheckedAt(context.TODO(), silent.ID, time.Now())
+	assert.NoError(t, err)
+	savedSearches, err = savedSearchRepo.GetNotifiableSavedSearchPage(context.TODO(), 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(savedSearches))
+	assert.False(t, savedSearches[1].LastCheckedAt.IsZero())
+
+	for _, savedSearch := range savedSearches {
+		affected, err = savedSearchRepo.RemoveSavedSearch(context.TODO(), "1", savedSearch.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), affected)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/auth"
+	"github.com/apache/incubator-answer/internal/repo/question"
+	"github.com/apache/incubator-answer/internal/repo/revision"
+	"github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/apache/incubator-answer/internal/repo/search_common"
+	"github.com/apache/incubator-answer/internal/repo/site_info"
+	"github.com/apache/incubator-answer/internal/repo/tag"
+	"github.com/apache/incubator-answer/internal/repo/tag_common"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	auth2 "github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/revision_common"
+	role2 "github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_searchIndexRepo_UpdateSearchIndex(t *testing.T) {
+	searchIndexRepo := search_common.NewSearchIndexRepo(testDataSource)
+	count, err := testDataSource.DB.Table("search_index").Count()
+	assert.NoError(t, err)
+
+	session := testDataSource.DB.NewSession()
+	defer session.Close()
+	err = searchIndexRepo.UpdateSearchIndex(context.TODO(), session, "10010000000099999", "title", "content")
+	assert.NoError(t, err)
+	err = searchIndexRepo.UpdateSearchIndex(context.TODO(), session, "10010000000099999", "new title", "new content")
+	assert.NoError(t, err)
+
+	newCount, err := testDataSource.DB.Table("search_index").Count()
+	assert.NoError(t, err)
+	assert.Equal(t, count+1, newCount)
+}
+
+func Test_searchRepo_SearchQuestions(t *testing.T) {
+	var (
+		uniqueIDRepo          = unique.NewUniqueIDRepo(testDataSource)
+		searchIndexRepo       = search_common.NewSearchIndexRepo(testDataSource)
+		questionRepo          = question.NewQuestionRepo(testDataSource, uniqueIDRepo, searchIndexRepo)
+		userRepo              = user.NewUserRepo(testDataSource)
+		siteInfoCommonService = siteinfo_common.NewSiteInfoCommonService(site_info.NewSiteInfo(testDataSource))
+		userRoleRelService    = role2.NewUserRoleRelService(role.NewUserRoleRelRepo(testDataSource),
+			role2.NewRoleService(role.NewRoleRepo(testDataSource), role.NewPowerRepo(testDataSource),
+				role.NewRolePowerRelRepo(testDataSource), role.NewUserRoleRelRepo(testDataSource)))
+		userCommon = usercommon.NewUserCommon(userRepo, userRoleRelService,
+			auth2.NewAuthService(auth.NewAuthRepo(testDataSource)), siteInfoCommonService)
+		tagCommonService = tagcommon.NewTagCommonService(
+			tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo),
+			tag.NewTagRelRepo(testDataSource, uniqueIDRepo),
+			tag.NewTagRepo(testDataSource, uniqueIDRepo),
+			revision_common.NewRevisionService(revision.NewRevisionRepo(testDataSource, uniqueIDRepo), userRepo),
+			siteInfoCommonService,
+			activity_queue.NewActivityQueueService(),
+		)
+		searchRepo = search_common.NewSearchRepo(testDataSource, uniqueIDRepo, userCommon, tagCommonService)
+	)
+
+	questionInfo := &entity.Question{
+		UserID:       "1",
+		Title:        "how to configure the reverse proxy",
+		OriginalText: "nginx is in front of the application",
+		ParsedText:   "nginx is in front of the application",
+		Status:       entity.QuestionStatusAvailable,
+		Show:         entity.QuestionShow,
+		RevisionID:   "0",
+	}
+	err := questionRepo.AddQuestion(context.TODO(), questionInfo)
+	assert.NoError(t, err)
+
+	cond := &schema.SearchCondition{Words: []string{"nginx"}, WordGroups: [][]string{{"nginx"}}, Views: -1, AnswerAmount: -1}
+	resp, total, err := searchRepo.SearchQuestions(context.TODO(), cond, 1, 10, "relevance")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, 1, len(resp))
+	assert.Equal(t, questionInfo.ID, resp[0].Object.ID)
+	assert.Equal(t, []string{"<mark>nginx</mark> is in front of the application"}, resp[0].Object.Highlight.Fragments)
+
+	questionInfo.Title = "how to configure the load balancer"
+	questionInfo.OriginalText = "haproxy is in front of the application"
+	err = questionRepo.UpdateQuestion(context.TODO(), questionInfo, []string{"title", "original_text"})
+	assert.NoError(t, err)
+
+	_, total, err = searchRepo.SearchQuestions(context.TODO(), cond, 1, 10, "relevance")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
+	cond = &schema.SearchCondition{Words: []string{"balancer"}, WordGroups: [][]string{{"balancer"}}, VoteAmount: -1}
+	resp, total, err = searchRepo.SearchContents(context.TODO(), cond, 1, 10, "relevance")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, 1, len(resp))
+	assert.Equal(t, "how to configure the load <mark>balancer</mark>", resp[0].Object.Highlight.Title)
+
+	// both of the groups should be matched, and one of the words in group is enough
+	cond.Words = []string{"balancer", "nginx", "haproxy"}
+	cond.WordGroups = [][]string{{"balancer"}, {"nginx", "haproxy"}}
+	_, total, err = searchRepo.SearchContents(context.TODO(), cond, 1, 10, "relevance")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	cond.ExcludeWords = []string{"haproxy"}
+	_, total, err = searchRepo.SearchContents(context.TODO(), cond, 1, 10, "relevance")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
+	cond.ExcludeWords = nil
+	cond.CreatedFrom = time.Now().AddDate(0, 0, 1).Unix()
+	_, total, err = searchRepo.SearchContents(context.TODO(), cond, 1, 10, "relevance")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
+	// the part of word is matched as the LIKE condition does
+	cond = &schema.SearchCondition{Words: []string{"balan"}, WordGroups: [][]string{{"balan"}}, Views: -1, AnswerAmount: -1}
+	_, total, err = searchRepo.SearchQuestions(context.TODO(), cond, 1, 10, "relevance")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	// the words which can not be tokenized fall back to the LIKE condition
+	questionInfo = &entity.Question{
+		UserID:       "1",
+		Title:        "如何配置反向代理服务器",
+		OriginalText: "应用前面有一个代理，c++ 编译",
+		ParsedText:   "应用前面有一个代理，c++ 编译",
+		Status:       entity.QuestionStatusAvailable,
+		Show:         entity.QuestionShow,
+		RevisionID:   "0",
+	}
+	err = questionRepo.AddQuestion(context.TODO(), questionInfo)
+	assert.NoError(t, err)
+	for _, word := range []string{"反向代理", "c++"} {
+		cond = &schema.SearchCondition{Words: []string{word}, WordGroups: [][]string{{word}}, Views: -1, AnswerAmount: -1}
+		resp, total, err = searchRepo.SearchQuestions(context.TODO(), cond, 1, 10, "relevance")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, 1, len(resp))
+		assert.Equal(t, questionInfo.ID, resp[0].Object.ID)
+	}
+
+	// the plain title is used if the search plugin only highlights the content
+	questionInfo = &entity.Question{
+		UserID:       "1",
+		Title:        "how to render <script> in the template",
+		OriginalText: "the template engine escapes the tags",
+		ParsedText:   "the template engine escapes the tags",
+		Status:       entity.QuestionStatusAvailable,
+		Show:         entity.QuestionShow,
+		RevisionID:   "0",
+	}
+	err = questionRepo.AddQuestion(context.TODO(), questionInfo)
+	assert.NoError(t, err)
+	resp, err = searchRepo.ParseSearchPluginResult(context.TODO(), []plugin.SearchResult{{
+		ID:   questionInfo.ID,
+		Type: "question",
+		Highlight: &plugin.SearchHighlight{
+			Fragments: []string{"the <mark>template</mark> engine"},
+		},
+	}}, []string{"template"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp))
+	assert.Equal(t, "how to render &lt;script&gt; in the template", resp[0].Object.Highlight.Title)
+	assert.Equal(t, []string{"the <mark>template</mark> engine"}, resp[0].Object.Highlight.Fragments)
+}
@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/review"
+	"github.com/apache/incubator-answer/internal/repo/tag"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tagModeratorRepo_IsTagModeratorOfQuestion(t *testing.T) {
+	var (
+		tagModeratorRepo = tag.NewTagModeratorRepo(testDataSource)
+		reviewRepo       = review.NewReviewRepo(testDataSource)
+		questionID       = "10010000000000901"
+		answerID         = "10020000000000901"
+		tagID            = "10030000000000901"
+		otherTagID       = "10030000000000902"
+		moderatorUserID  = "901"
+	)
+
+	_, err := testDataSource.DB.Insert(&entity.TagRel{ObjectID: questionID, TagID: tagID, Status: entity.TagRelStatusAvailable})
+	assert.NoError(t, err)
+	_, err = testDataSource.DB.Insert(&entity.Answer{ID: answerID, QuestionID: questionID, UserID: "1",
+		Status: entity.AnswerStatusPending})
+	assert.NoError(t, err)
+
+	err = tagModeratorRepo.AddTagModerator(context.TODO(), &entity.TagModerator{TagID: tagID, UserID: moderatorUserID})
+	assert.NoError(t, err)
+	// adding the same moderator again is ignored
+	err = tagModeratorRepo.AddTagModerator(context.TODO(), &entity.TagModerator{TagID: tagID, UserID: moderatorUserID})
+	assert.NoError(t, err)
+	err = tagModeratorRepo.AddTagModerator(context.TODO(), &entity.TagModerator{TagID: otherTagID, UserID: moderatorUserID})
+	assert.NoError(t, err)
+
+	moderators, err := tagModeratorRepo.GetTagModeratorList(context.TODO(), tagID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(moderators))
+	tagIDs, err := tagModeratorRepo.GetModeratedTagIDs(context.TODO(), moderatorUserID)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{tagID, otherTagID}, tagIDs)
+
+	ok, err := tagModeratorRepo.IsTagModeratorOfQuestion(context.TODO(), moderatorUserID, questionID, false)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = tagModeratorRepo.IsTagModeratorOfQuestion(context.TODO(), "902", questionID, false)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// the tag relations of the deleted question are deleted
+	_, err = testDataSource.DB.Where("object_id = ?", questionID).Cols("status").
+		Update(&entity.TagRel{Status: entity.TagRelStatusDeleted})
+	assert.NoError(t, err)
+	ok, err = tagModeratorRepo.IsTagModeratorOfQuestion(context.TODO(), moderatorUserID, questionID, false)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = tagModeratorRepo.IsTagModeratorOfQuestion(context.TODO(), moderatorUserID, questionID, true)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// only the queued posts with the tags are shown to the tag moderator
+	_, err = testDataSource.DB.Insert(&entity.Review{UserID: "1", ObjectID: answerID, Status: entity.ReviewStatusPending})
+	assert.NoError(t, err)
+	_, err = testDataSource.DB.Insert(&entity.Review{UserID: "1", ObjectID: "10010000000000999",
+		Status: entity.ReviewStatusPending})
+	assert.NoError(t, err)
+	count, err := reviewRepo.GetReviewCountByTagIDs(context.TODO(), entity.ReviewStatusPending, tagIDs)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	reviews, total, err := reviewRepo.GetReviewPageByTagIDs(context.TODO(), 1, 10, tagIDs,
+		&entity.Review{Status: entity.ReviewStatusPending})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, answerID, reviews[0].ObjectID)
+
+	err = tagModeratorRepo.RemoveTagModerator(context.TODO(), tagID, moderatorUserID)
+	assert.NoError(t, err)
+	tagIDs, err = tagModeratorRepo.GetModeratedTagIDs(context.TODO(), moderatorUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{otherTagID}, tagIDs)
+}
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/user_access_token"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userAccessTokenRepo_RevokeUserAccessToken(t *testing.T) {
+	userAccessTokenRepo := user_access_token.NewUserAccessTokenRepo(testDataSource)
+	token := &entity.UserAccessToken{
+		UserID:      "1",
+		Name:        "ci",
+		TokenHash:   "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		TokenPrefix: "ans_abcd",
+		Scopes:      "read",
+		Status:      entity.UserAccessTokenStatusAvailable,
+	}
+	err := userAccessTokenRepo.AddUserAccessToken(context.TODO(), token)
+	assert.NoError(t, err)
+
+	gotToken, exist, err := userAccessTokenRepo.GetUserAccessTokenByHash(context.TODO(), token.TokenHash)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, token.ID, gotToken.ID)
+
+	tokens, err := userAccessTokenRepo.GetUserAccessTokenList(context.TODO(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(tokens))
+
+	// the token belongs to other user can not be revoked
+	affected, err := userAccessTokenRepo.RevokeUserAccessToken(context.TODO(), "2", token.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), affected)
+
+	affected, err = userAccessTokenRepo.RevokeUserAccessToken(context.TODO(), "1", token.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	gotToken, exist, err = userAccessTokenRepo.GetUserAccessTokenByHash(context.TODO(), token.TokenHash)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, entity.UserAccessTokenStatusRevoked, gotToken.Status)
+}
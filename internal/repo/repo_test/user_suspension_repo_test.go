@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/auth"
+	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userSuspensionRepo_SuspendAndExpire(t *testing.T) {
+	var (
+		userRepo           = user.NewUserRepo(testDataSource)
+		userAdminRepo      = user.NewUserAdminRepo(testDataSource, auth.NewAuthRepo(testDataSource))
+		userSuspensionRepo = user.NewUserSuspensionRepo(testDataSource)
+	)
+
+	userInfo := &entity.User{
+		Username:    "suspended_user",
+		Pass:        "suspended_user",
+		EMail:       "suspended_user@example.com",
+		MailStatus:  entity.EmailStatusAvailable,
+		Status:      entity.UserStatusAvailable,
+		DisplayName: "suspended_user",
+	}
+	err := userRepo.AddUser(context.TODO(), userInfo)
+	assert.NoError(t, err)
+	err = userAdminRepo.UpdateUserStatus(context.TODO(), userInfo.ID, entity.UserStatusSuspended,
+		entity.EmailStatusAvailable, userInfo.EMail)
+	assert.NoError(t, err)
+
+	// the forever suspension is replaced by the time-limited one
+	err = userSuspensionRepo.AddUserSuspension(context.TODO(), &entity.UserSuspension{
+		UserID:    userInfo.ID,
+		ReasonKey: "reason.suspend_spam",
+		Status:    entity.UserSuspensionStatusActive,
+	})
+	assert.NoError(t, err)
+	suspension := &entity.UserSuspension{
+		UserID:         userInfo.ID,
+		ReasonKey:      "reason.suspend_something",
+		Message:        "Please stop posting links",
+		Duration:       24,
+		SuspendedUntil: time.Now().Add(24 * time.Hour),
+		Status:         entity.UserSuspensionStatusActive,
+	}
+	err = userSuspensionRepo.AddUserSuspension(context.TODO(), suspension)
+	assert.NoError(t, err)
+
+	suspensions, err := userSuspensionRepo.GetUserSuspensionList(context.TODO(), userInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(suspensions))
+	assert.Equal(t, suspension.ID, suspensions[0].ID)
+	assert.Equal(t, entity.UserSuspensionStatusLifted, suspensions[1].Status)
+	active, exist, err := userSuspensionRepo.GetActiveUserSuspension(context.TODO(), userInfo.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, suspension.ID, active.ID)
+	got, _, err := userRepo.GetByUserID(context.TODO(), userInfo.ID)
+	assert.NoError(t, err)
+	assert.False(t, got.SuspendedUntil.IsZero())
+
+	expired, err := userSuspensionRepo.GetExpiredUserSuspensions(context.TODO(), time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(expired))
+	expired, err = userSuspensionRepo.GetExpiredUserSuspensions(context.TODO(), time.Now().Add(48*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(expired))
+	assert.Equal(t, suspension.ID, expired[0].ID)
+
+	reinstated, err := userSuspensionRepo.ExpireUserSuspension(context.TODO(), expired[0])
+	assert.NoError(t, err)
+	assert.True(t, reinstated)
+	got, _, err = userRepo.GetByUserID(context.TODO(), userInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.UserStatusAvailable, got.Status)
+
+	// the suspension can not be expired twice
+	reinstated, err = userSuspensionRepo.ExpireUserSuspension(context.TODO(), expired[0])
+	assert.NoError(t, err)
+	assert.False(t, reinstated)
+	_, exist, err = userSuspensionRepo.GetActiveUserSuspension(context.TODO(), userInfo.ID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_userSuspensionRepo_LiftUserSuspension(t *testing.T) {
+	var (
+		userRepo           = user.NewUserRepo(testDataSource)
+		userSuspensionRepo = user.NewUserSuspensionRepo(testDataSource)
+	)
+
+	userInfo := &entity.User{
+		Username:    "lifted_user",
+		Pass:        "lifted_user",
+		EMail:       "lifted_user@example.com",
+		MailStatus:  entity.EmailStatusAvailable,
+		Status:      entity.UserStatusSuspended,
+		DisplayName: "lifted_user",
+	}
+	err := userRepo.AddUser(context.TODO(), userInfo)
+	assert.NoError(t, err)
+	err = userSuspensionRepo.AddUserSuspension(context.TODO(), &entity.UserSuspension{
+		UserID:         userInfo.ID,
+		ReasonKey:      "reason.suspend_spam",
+		Duration:       1,
+		SuspendedUntil: time.Now().Add(-time.Minute),
+		Status:         entity.UserSuspensionStatusActive,
+	})
+	assert.NoError(t, err)
+
+	err = userSuspensionRepo.LiftUserSuspension(context.TODO(), userInfo.ID)
+	assert.NoError(t, err)
+	suspensions, err := userSuspensionRepo.GetUserSuspensionList(context.TODO(), userInfo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(suspensions))
+	assert.Equal(t, entity.UserSuspensionStatusLifted, suspensions[0].Status)
+	assert.False(t, suspensions[0].LiftedAt.IsZero())
+	got, _, err := userRepo.GetByUserID(context.TODO(), userInfo.ID)
+	assert.NoError(t, err)
+	assert.True(t, got.SuspendedUntil.IsZero())
+
+	// the lifted suspension will not be expired by cron
+	expired, err := userSuspensionRepo.GetExpiredUserSuspensions(context.TODO(), time.Now(), 10)
+	assert.NoError(t, err)
+	for _, suspension := range expired {
+		assert.NotEqual(t, userInfo.ID, suspension.UserID)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_webhookRepo_ClaimWebhookTask(t *testing.T) {
+	webhookRepo := webhook.NewWebhookRepo(testDataSource)
+	err := webhookRepo.AddWebhookTasks(context.TODO(), []*entity.WebhookTask{{
+		WebhookID:   1,
+		DeliveryID:  "delivery",
+		EventType:   "question.create",
+		Payload:     `{"event_type":"question.create"}`,
+		Status:      entity.WebhookTaskStatusPending,
+		NextRetryAt: time.Now().Add(-time.Minute),
+	}})
+	assert.NoError(t, err)
+
+	tasks, err := webhookRepo.GetAvailableWebhookTasks(context.TODO(), time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(tasks))
+	stale := *tasks[0]
+
+	claimed, err := webhookRepo.ClaimWebhookTask(context.TODO(), tasks[0], time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, 1, tasks[0].Attempts)
+
+	// the task has been claimed by others, so it can not be claimed again
+	claimed, err = webhookRepo.ClaimWebhookTask(context.TODO(), &stale, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+
+	tasks[0].Status = entity.WebhookTaskStatusDone
+	err = webhookRepo.UpdateWebhookTaskResult(context.TODO(), tasks[0])
+	assert.NoError(t, err)
+
+	tasks, err = webhookRepo.GetAvailableWebhookTasks(context.TODO(), time.Now().Add(time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(tasks))
+
+	err = webhookRepo.RemoveWebhookTasksBefore(context.TODO(), entity.WebhookTaskStatusDone, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+}
+
+func Test_webhookRepo_RemoveWebhookDeliveriesBefore(t *testing.T) {
+	webhookRepo := webhook.NewWebhookRepo(testDataSource)
+	err := webhookRepo.AddWebhookDelivery(context.TODO(), &entity.WebhookDelivery{
+		WebhookID:   2,
+		DeliveryID:  "delivery",
+		EventType:   "question.create",
+		RequestBody: `{"event_type":"question.create"}`,
+	})
+	assert.NoError(t, err)
+
+	// the delivery in the retention window is kept
+	err = webhookRepo.RemoveWebhookDeliveriesBefore(context.TODO(), time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	_, total, err := webhookRepo.GetWebhookDeliveryPage(context.TODO(), 1, 10, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	err = webhookRepo.RemoveWebhookDeliveriesBefore(context.TODO(), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	_, total, err = webhookRepo.GetWebhookDeliveryPage(context.TODO(), 1, 10, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
 )
 
 // reviewRepo review repository
@@ -92,3 +93,33 @@ func (cr *reviewRepo) GetReviewPage(ctx context.Context, page, pageSize int, con
 	}
 	return
 }
+
+// GetReviewCountByTagIDs get review count of the posts with the tags
+func (cr *reviewRepo) GetReviewCountByTagIDs(ctx context.Context, status int, tagIDs []string) (count int64, err error) {
+	count, err = cr.data.DB.Context(ctx).Where(reviewTagIDsCond(tagIDs)).Count(&entity.Review{Status: status})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetReviewPageByTagIDs get review page of the posts with the tags
+func (cr *reviewRepo) GetReviewPageByTagIDs(ctx context.Context, page, pageSize int, tagIDs []string,
+	cond *entity.Review) (reviewList []*entity.Review, total int64, err error) {
+	session := cr.data.DB.Context(ctx).Where(reviewTagIDsCond(tagIDs)).Asc("created_at")
+	reviewList = make([]*entity.Review, 0)
+	total, err = pager.Help(page, pageSize, &reviewList, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// reviewTagIDsCond the reviewed object is the question with the tags or the answer of such question
+func reviewTagIDsCond(tagIDs []string) builder.Cond {
+	questionIDs := builder.Select("object_id").From(entity.TagRel{}.TableName()).
+		Where(builder.In("tag_id", tagIDs))
+	answerIDs := builder.Select("id").From(entity.Answer{}.TableName()).
+		Where(builder.In("question_id", questionIDs))
+	return builder.Or(builder.In("object_id", questionIDs), builder.In("object_id", answerIDs))
+}
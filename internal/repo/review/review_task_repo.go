@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package review
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/review"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// reviewTaskRepo review task repository
+type reviewTaskRepo struct {
+	data *data.Data
+}
+
+// NewReviewTaskRepo new repository
+func NewReviewTaskRepo(data *data.Data) review.ReviewTaskRepo {
+	return &reviewTaskRepo{
+		data: data,
+	}
+}
+
+// AddReviewTask add review task
+func (rr *reviewTaskRepo) AddReviewTask(ctx context.Context, task *entity.ReviewTask) (err error) {
+	_, err = rr.data.DB.Context(ctx).Insert(task)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetReviewTask get review task one
+func (rr *reviewTaskRepo) GetReviewTask(ctx context.Context, taskID int) (
+	task *entity.ReviewTask, exist bool, err error) {
+	task = &entity.ReviewTask{}
+	exist, err = rr.data.DB.Context(ctx).ID(taskID).Get(task)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPendingReviewTaskPage get the pending review tasks of the queue, the earliest first,
+// the tasks of the posts created by the excluded user are skipped
+func (rr *reviewTaskRepo) GetPendingReviewTaskPage(ctx context.Context, page, pageSize, queueType int,
+	excludeUserID string) (tasks []*entity.ReviewTask, total int64, err error) {
+	session := rr.data.DB.Context(ctx).Where(builder.Neq{"user_id": excludeUserID}).Asc("id")
+	tasks = make([]*entity.ReviewTask, 0)
+	cond := &entity.ReviewTask{QueueType: queueType, Status: entity.ReviewTaskStatusPending}
+	total, err = pager.Help(page, pageSize, &tasks, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPendingReviewTaskCount get the pending review task count of the queue,
+// the tasks of the posts created by the excluded user are skipped
+func (rr *reviewTaskRepo) GetPendingReviewTaskCount(ctx context.Context, queueType int, excludeUserID string) (
+	count int64, err error) {
+	count, err = rr.data.DB.Context(ctx).Where(builder.Neq{"user_id": excludeUserID}).
+		Count(&entity.ReviewTask{QueueType: queueType, Status: entity.ReviewTaskStatusPending})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CompleteReviewTask complete the pending review task with the outcome,
+// completed is false if the task has been completed by others
+func (rr *reviewTaskRepo) CompleteReviewTask(ctx context.Context, taskID int, reviewerUserID, outcome string) (
+	completed bool, err error) {
+	affected, err := rr.data.DB.Context(ctx).ID(taskID).Where("status = ?", entity.ReviewTaskStatusPending).
+		Cols("status", "reviewer_user_id", "outcome", "reviewed_at").Update(&entity.ReviewTask{
+		Status:         entity.ReviewTaskStatusCompleted,
+		ReviewerUserID: reviewerUserID,
+		Outcome:        outcome,
+		ReviewedAt:     time.Now(),
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// InvalidateReviewTask invalidate the pending review task whose post is no longer available
+func (rr *reviewTaskRepo) InvalidateReviewTask(ctx context.Context, taskID int) (err error) {
+	_, err = rr.data.DB.Context(ctx).ID(taskID).Where("status = ?", entity.ReviewTaskStatusPending).
+		Cols("status").Update(&entity.ReviewTask{Status: entity.ReviewTaskStatusInvalid})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetReviewTaskCounts get the number of the review tasks created in the time range,
+// grouped by the queue, status and outcome
+func (rr *reviewTaskRepo) GetReviewTaskCounts(ctx context.Context, startTime, endTime time.Time) (
+	counts []*review.ReviewTaskCount, err error) {
+	counts = make([]*review.ReviewTaskCount, 0)
+	err = rr.data.DB.Context(ctx).Table(entity.ReviewTask{}.TableName()).
+		Select("queue_type, status, outcome, COUNT(*) AS count").
+		Where(timeRangeCond("created_at", startTime, endTime)).
+		GroupBy("queue_type, status, outcome").Find(&counts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetReviewerTaskCounts get the number of the review tasks completed by the reviewers in the time range,
+// the most active reviewers first
+func (rr *reviewTaskRepo) GetReviewerTaskCounts(ctx context.Context, startTime, endTime time.Time, limit int) (
+	counts []*review.ReviewerTaskCount, err error) {
+	counts = make([]*review.ReviewerTaskCount, 0)
+	err = rr.data.DB.Context(ctx).Table(entity.ReviewTask{}.TableName()).
+		Select("reviewer_user_id, COUNT(*) AS count").
+		Where(builder.Eq{"status": entity.ReviewTaskStatusCompleted}).
+		And(timeRangeCond("reviewed_at", startTime, endTime)).
+		GroupBy("reviewer_user_id").Desc("count").Limit(limit).Find(&counts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// timeRangeCond the time of the column is in the range, the zero time means no limit
+func timeRangeCond(column string, startTime, endTime time.Time) builder.Cond {
+	cond := builder.NewCond()
+	if !startTime.IsZero() {
+		cond = cond.And(builder.Gte{column: startTime})
+	}
+	if !endTime.IsZero() {
+		cond = cond.And(builder.Lt{column: endTime})
+	}
+	return cond
+}
@@ -183,6 +183,8 @@ func (rr *revisionRepo) allowRecord(objectType int) (ok bool) {
 		return true
 	case constant.ObjectTypeStrMapping["tag"]:
 		return true
+	case constant.ObjectTypeStrMapping["comment"]:
+		return true
 	default:
 		return false
 	}
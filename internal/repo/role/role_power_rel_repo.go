@@ -24,9 +24,11 @@ import (
 
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/segmentfault/pacman/errors"
 	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // rolePowerRelRepo rolePowerRel repository
@@ -51,3 +53,27 @@ func (rr *rolePowerRelRepo) GetRolePowerTypeList(ctx context.Context, roleID int
 	}
 	return
 }
+
+// SaveRolePowerTypeList replace all powers of the role
+func (rr *rolePowerRelRepo) SaveRolePowerTypeList(ctx context.Context, roleID int, powerTypes []string) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where(builder.Eq{"role_id": roleID}).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		rels := make([]*entity.RolePowerRel, 0, len(powerTypes))
+		for _, powerType := range powerTypes {
+			rels = append(rels, &entity.RolePowerRel{RoleID: roleID, PowerType: powerType})
+		}
+		if len(rels) > 0 {
+			if _, err := session.Insert(rels); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	service "github.com/apache/incubator-answer/internal/service/role"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // roleRepo role repository
@@ -41,6 +43,67 @@ func NewRoleRepo(data *data.Data) service.RoleRepo {
 	}
 }
 
+// AddRole add role with its powers
+func (rr *roleRepo) AddRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Insert(role); err != nil {
+			return nil, err
+		}
+		rels := make([]*entity.RolePowerRel, 0, len(powerTypes))
+		for _, powerType := range powerTypes {
+			rels = append(rels, &entity.RolePowerRel{RoleID: role.ID, PowerType: powerType})
+		}
+		if len(rels) > 0 {
+			if _, err := session.Insert(rels); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateRole update the name and description of the role
+func (rr *roleRepo) UpdateRole(ctx context.Context, role *entity.Role) (err error) {
+	_, err = rr.data.DB.Context(ctx).ID(role.ID).Cols("name", "description").Update(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveRole remove role and its powers
+func (rr *roleRepo) RemoveRole(ctx context.Context, roleID int) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where(builder.Eq{"role_id": roleID}).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		if _, err := session.ID(roleID).Delete(&entity.Role{}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRole get role by id
+func (rr *roleRepo) GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error) {
+	role = &entity.Role{}
+	exist, err = rr.data.DB.Context(ctx).ID(roleID).Get(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetRoleAllList get role list all
 func (rr *roleRepo) GetRoleAllList(ctx context.Context) (roleList []*entity.Role, err error) {
 	roleList = make([]*entity.Role, 0)
@@ -69,6 +69,34 @@ func (ur *userRoleRelRepo) SaveUserRoleRel(ctx context.Context, userID string, r
 	return
 }
 
+// SaveUserRoleRelList save the same role for users
+func (ur *userRoleRelRepo) SaveUserRoleRelList(ctx context.Context, userIDs []string, roleID int) (err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		for _, userID := range userIDs {
+			item := &entity.UserRoleRel{UserID: userID}
+			exist, err := session.Get(item)
+			if err != nil {
+				return nil, err
+			}
+			if exist {
+				item.RoleID = roleID
+				_, err = session.ID(item.ID).Update(item)
+			} else {
+				_, err = session.Insert(&entity.UserRoleRel{UserID: userID, RoleID: roleID})
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetUserRoleRelList get user role all
 func (ur *userRoleRelRepo) GetUserRoleRelList(ctx context.Context, userIDs []string) (
 	userRoleRelList []*entity.UserRoleRel, err error) {
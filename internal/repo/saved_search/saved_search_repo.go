@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saved_search
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/saved_search"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// savedSearchRepo saved search repository
+type savedSearchRepo struct {
+	data *data.Data
+}
+
+// NewSavedSearchRepo new repository
+func NewSavedSearchRepo(data *data.Data) saved_search.SavedSearchRepo {
+	return &savedSearchRepo{
+		data: data,
+	}
+}
+
+// AddSavedSearch add saved search
+func (sr *savedSearchRepo) AddSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (err error) {
+	_, err = sr.data.DB.Context(ctx).Insert(savedSearch)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateSavedSearch update the saved search of the user
+func (sr *savedSearchRepo) UpdateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (affected int64, err error) {
+	affected, err = sr.data.DB.Context(ctx).ID(savedSearch.ID).Where("user_id = ?", savedSearch.UserID).
+		Cols("name", "query", "notify_inbox", "notify_email").Update(savedSearch)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveSavedSearch remove the saved search of the user
+func (sr *savedSearchRepo) RemoveSavedSearch(ctx context.Context, userID string, id int) (affected int64, err error) {
+	affected, err = sr.data.DB.Context(ctx).ID(id).Where("user_id = ?", userID).Delete(&entity.SavedSearch{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSavedSearchList get all saved searches of the user
+func (sr *savedSearchRepo) GetSavedSearchList(ctx context.Context, userID string) (
+	savedSearches []*entity.SavedSearch, err error) {
+	savedSearches = make([]*entity.SavedSearch, 0)
+	err = sr.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id").Find(&savedSearches)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountSavedSearch count saved searches of the user
+func (sr *savedSearchRepo) CountSavedSearch(ctx context.Context, userID string) (count int64, err error) {
+	count, err = sr.data.DB.Context(ctx).Where("user_id = ?", userID).Count(&entity.SavedSearch{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetNotifiableSavedSearchPage get the saved searches which need to notify, page starts from 1
+func (sr *savedSearchRepo) GetNotifiableSavedSearchPage(ctx context.Context, page, pageSize int) (
+	savedSearches []*entity.SavedSearch, err error) {
+	savedSearches = make([]*entity.SavedSearch, 0)
+	err = sr.data.DB.Context(ctx).Where("notify_inbox = ? OR notify_email = ?", true, true).
+		Asc("id").Limit(pageSize, (page-1)*pageSize).Find(&savedSearches)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateLastCheckedAt update the last time that new matches were checked
+func (sr *savedSearchRepo) UpdateLastCheckedAt(ctx context.Context, id int, lastCheckedAt time.Time) (err error) {
+	_, err = sr.data.DB.Context(ctx).ID(id).NoAutoTime().
+		Cols("last_checked_at").Update(&entity.SavedSearch{LastCheckedAt: lastCheckedAt})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/service/search_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// searchIndexRepo native full-text search index repository
+type searchIndexRepo struct {
+	data *data.Data
+}
+
+// NewSearchIndexRepo new repository
+func NewSearchIndexRepo(data *data.Data) search_common.SearchIndexRepo {
+	return &searchIndexRepo{
+		data: data,
+	}
+}
+
+// UpdateSearchIndex replace the index content of the question or answer in the session of the post writing
+func (sr *searchIndexRepo) UpdateSearchIndex(ctx context.Context, session *xorm.Session,
+	objectID, title, content string) (err error) {
+	idColumn := searchIndexIDColumn(sr.data.DB.Dialect().URI().DBType)
+	if len(idColumn) == 0 {
+		return nil
+	}
+	objectID = uid.DeShortID(objectID)
+	session = session.Context(ctx)
+	_, err = session.Exec(fmt.Sprintf("DELETE FROM search_index WHERE %s = ?", idColumn), objectID)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	_, err = session.Exec(fmt.Sprintf("INSERT INTO search_index (%s, title, content) VALUES (?, ?, ?)", idColumn),
+		objectID, title, content)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// searchIndexIDColumn the object id column of search index, empty if the database is not supported
+func searchIndexIDColumn(dbType schemas.DBType) string {
+	switch dbType {
+	case schemas.SQLITE:
+		return "rowid"
+	case schemas.MYSQL, schemas.POSTGRES:
+		return "object_id"
+	}
+	return ""
+}
+
+// mysqlMinTokenSize the default innodb_ft_min_token_size, the shorter words are not indexed by MySQL
+const mysqlMinTokenSize = 3
+
+// fullTextSearchable check whether every word can be matched by the tokenizer of the database as a whole token.
+// The default tokenizers split the text by spaces and punctuations, so the words of the languages
+// without spaces (like CJK) and the words with punctuations (like c++ or node.js) are not supported.
+func fullTextSearchable(dbType schemas.DBType, wordGroups [][]string) bool {
+	for _, words := range wordGroups {
+		for _, word := range words {
+			if dbType == schemas.MYSQL && utf8.RuneCountInString(word) < mysqlMinTokenSize {
+				return false
+			}
+			for _, r := range word {
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					return false
+				}
+				if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul, unicode.Thai) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// postgresSearchVector must be the same as the expression of the GIN index created in migrations
+const postgresSearchVector = `(setweight(to_tsvector('simple', search_index.title), 'A') || ` +
+	`setweight(to_tsvector('simple', search_index.content), 'B'))`
+
+// fullTextCond full-text search condition based on the native search index
+type fullTextCond struct {
+	join          string
+	where         string
+	whereArgs     []interface{}
+	relevance     string
+	relevanceArgs []interface{}
+}
+
+// buildFullTextCond build the full-text search condition, every word group must be matched.
+// The words are matched as prefixes of the tokens, like the LIKE condition matches the part of words.
+// Return nil if the database or the words are not supported, then the LIKE condition should be used.
+func (sr *searchRepo) buildFullTextCond(wordGroups [][]string, objectIDField string) *fullTextCond {
+	if len(wordGroups) == 0 {
+		return nil
+	}
+	dbType := sr.data.DB.Dialect().URI().DBType
+	idColumn := searchIndexIDColumn(dbType)
+	if len(idColumn) == 0 || !fullTextSearchable(dbType, wordGroups) {
+		return nil
+	}
+	cond := &fullTextCond{join: fmt.Sprintf("search_index.%s = %s", idColumn, objectIDField)}
+	switch dbType {
+	case schemas.SQLITE:
+		groups := make([]string, 0, len(wordGroups))
+		for _, words := range wordGroups {
+			phrases := make([]string, 0, len(words))
+			for _, word := range words {
+				phrases = append(phrases, `"`+word+`"*`)
+			}
+			groups = append(groups, "("+strings.Join(phrases, " OR ")+")")
+		}
+		cond.where = "search_index MATCH ?"
+		cond.whereArgs = []interface{}{strings.Join(groups, " AND ")}
+		// bm25 returns a lower value for a better match, and the title weighs more than the content
+		cond.relevance = "-bm25(search_index, 10.0, 1.0)"
+	case schemas.MYSQL:
+		// in boolean mode, the words without operator in the same group are optional
+		match := "MATCH(search_index.title, search_index.content) AGAINST (? IN BOOLEAN MODE)"
+		matches := make([]string, 0, len(wordGroups))
+		allWords := make([]string, 0)
+		for _, words := range wordGroups {
+			phrases := make([]string, 0, len(words))
+			for _, word := range words {
+				phrases = append(phrases, word+"*")
+			}
+			matches = append(matches, match)
+			cond.whereArgs = append(cond.whereArgs, strings.Join(phrases, " "))
+			allWords = append(allWords, words...)
+		}
+		cond.where = strings.Join(matches, " AND ")
+		cond.relevance = "MATCH(search_index.title, search_index.content) AGAINST (? IN NATURAL LANGUAGE MODE)"
+		cond.relevanceArgs = []interface{}{strings.Join(allWords, " ")}
+	case schemas.POSTGRES:
+		groups := make([]string, 0, len(wordGroups))
+		args := make([]interface{}, 0)
+		for _, words := range wordGroups {
+			queries := make([]string, 0, len(words))
+			for _, word := range words {
+				queries = append(queries, "to_tsquery('simple', ? || ':*')")
+				args = append(args, word)
+			}
+			groups = append(groups, "("+strings.Join(queries, " || ")+")")
+		}
+		query := "(" + strings.Join(groups, " && ") + ")"
+		cond.where, cond.whereArgs = postgresSearchVector+" @@ "+query, args
+		cond.relevance, cond.relevanceArgs = "ts_rank("+postgresSearchVector+", "+query+")", args
+	}
+	return cond
+}
@@ -22,6 +22,7 @@ package search_common
 import (
 	"context"
 	"fmt"
+	"html"
 	"strconv"
 	"strings"
 	"time"
@@ -46,11 +47,18 @@ import (
 	"xorm.io/builder"
 )
 
+const (
+	// searchHighlightTrimLength the runes reserved before and after the matched word in every fragment
+	searchHighlightTrimLength = 50
+	// searchHighlightMaxFragments the max fragments returned for every search result
+	searchHighlightMaxFragments = 3
+)
+
 var (
 	qFields = []string{
 		"`question`.`id`",
 		"`question`.`id` as `question_id`",
-		"`title`",
+		"`question`.`title` as `title`",
 		"`parsed_text`",
 		"`question`.`created_at` as `created_at`",
 		"`user_id`",
@@ -99,8 +107,9 @@ func NewSearchRepo(
 }
 
 // SearchContents search question and answer data
-func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs [][]string, userID string, votes int, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
+func (sr *searchRepo) SearchContents(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
+	wordGroups := filterWordGroups(cond.WordGroups)
 
 	var (
 		b     *builder.Builder
@@ -111,12 +120,17 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 		argsA = []interface{}{}
 	)
 
+	ftQ := sr.buildFullTextCond(wordGroups, "`question`.`id`")
+	ftA := sr.buildFullTextCond(wordGroups, "`answer`.`id`")
 	if order == "relevance" {
-		if len(words) > 0 {
+		if len(words) == 0 {
+			order = "newest"
+		} else if ftQ != nil {
+			qfs, argsQ = append(qfs, ftQ.relevance+" as relevance"), append(argsQ, ftQ.relevanceArgs...)
+			afs, argsA = append(afs, ftA.relevance+" as relevance"), append(argsA, ftA.relevanceArgs...)
+		} else {
 			qfs, argsQ = addRelevanceField([]string{"title", "original_text"}, words, qfs)
 			afs, argsA = addRelevanceField([]string{"`answer`.`original_text`"}, words, afs)
-		} else {
-			order = "newest"
 		}
 	}
 
@@ -133,23 +147,20 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	argsQ = append(argsQ, entity.QuestionStatusDeleted, entity.QuestionShow)
 	argsA = append(argsA, entity.QuestionStatusDeleted, entity.AnswerStatusDeleted, entity.QuestionShow)
 
-	likeConQ := builder.NewCond()
-	likeConA := builder.NewCond()
-	for _, word := range words {
-		likeConQ = likeConQ.Or(builder.Like{"title", word}).
-			Or(builder.Like{"original_text", word})
-		argsQ = append(argsQ, "%"+word+"%")
-		argsQ = append(argsQ, "%"+word+"%")
-
-		likeConA = likeConA.Or(builder.Like{"`answer`.original_text", word})
-		argsA = append(argsA, "%"+word+"%")
+	if ftQ != nil {
+		b.Join("INNER", "search_index", ftQ.join).Where(builder.Expr(ftQ.where, ftQ.whereArgs...))
+		ub.Join("INNER", "search_index", ftA.join).Where(builder.Expr(ftA.where, ftA.whereArgs...))
+		argsQ = append(argsQ, ftQ.whereArgs...)
+		argsA = append(argsA, ftA.whereArgs...)
+	} else {
+		argsQ = addWordGroupsCond(b, wordGroups, []string{"title", "original_text"}, argsQ)
+		argsA = addWordGroupsCond(ub, wordGroups, []string{"`answer`.original_text"}, argsA)
 	}
-
-	b.Where(likeConQ)
-	ub.Where(likeConA)
+	argsQ = addExcludeWordsCond(b, cond.ExcludeWords, []string{"`question`.`title`", "`question`.`original_text`"}, argsQ)
+	argsA = addExcludeWordsCond(ub, cond.ExcludeWords, []string{"`answer`.original_text"}, argsA)
 
 	// check tag
-	for ti, tagID := range tagIDs {
+	for ti, tagID := range cond.Tags {
 		ast := "tag_rel" + strconv.Itoa(ti)
 		b.Join("INNER", "tag_rel as "+ast, "question.id = "+ast+".object_id").
 			And(builder.Eq{
@@ -168,27 +179,37 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 			argsA = append(argsA, t)
 		}
 	}
+	argsQ = addExcludeTagsCond(b, "`question`.`id`", cond.ExcludeTags, argsQ)
+	argsA = addExcludeTagsCond(ub, "`answer`.`question_id`", cond.ExcludeTags, argsA)
 
 	// check user
-	if userID != "" {
-		b.Where(builder.Eq{"question.user_id": userID})
-		ub.Where(builder.Eq{"answer.user_id": userID})
-		argsQ = append(argsQ, userID)
-		argsA = append(argsA, userID)
+	if cond.UserID != "" {
+		b.Where(builder.Eq{"question.user_id": cond.UserID})
+		ub.Where(builder.Eq{"answer.user_id": cond.UserID})
+		argsQ = append(argsQ, cond.UserID)
+		argsA = append(argsA, cond.UserID)
 	}
 
 	// check vote
-	if votes == 0 {
-		b.Where(builder.Eq{"question.vote_count": votes})
-		ub.Where(builder.Eq{"answer.vote_count": votes})
-		argsQ = append(argsQ, votes)
-		argsA = append(argsA, votes)
-	} else if votes > 0 {
-		b.Where(builder.Gte{"question.vote_count": votes})
-		ub.Where(builder.Gte{"answer.vote_count": votes})
-		argsQ = append(argsQ, votes)
-		argsA = append(argsA, votes)
-	}
+	if cond.VoteAmount == 0 {
+		b.Where(builder.Eq{"question.vote_count": cond.VoteAmount})
+		ub.Where(builder.Eq{"answer.vote_count": cond.VoteAmount})
+		argsQ = append(argsQ, cond.VoteAmount)
+		argsA = append(argsA, cond.VoteAmount)
+	} else if cond.VoteAmount > 0 {
+		b.Where(builder.Gte{"question.vote_count": cond.VoteAmount})
+		ub.Where(builder.Gte{"answer.vote_count": cond.VoteAmount})
+		argsQ = append(argsQ, cond.VoteAmount)
+		argsA = append(argsA, cond.VoteAmount)
+	}
+
+	// check time range and commenter
+	argsQ = addTimeRangeCond(b, "`question`.`created_at`", cond.CreatedFrom, cond.CreatedTo, argsQ)
+	argsA = addTimeRangeCond(ub, "`answer`.`created_at`", cond.CreatedFrom, cond.CreatedTo, argsA)
+	argsQ = addTimeRangeCond(b, "`question`.`post_update_time`", cond.ActiveFrom, cond.ActiveTo, argsQ)
+	argsA = addTimeRangeCond(ub, "`answer`.`updated_at`", cond.ActiveFrom, cond.ActiveTo, argsA)
+	argsQ = addCommenterCond(b, "`question`.`id`", cond.CommenterID, argsQ)
+	argsA = addCommenterCond(ub, "`answer`.`id`", cond.CommenterID, argsA)
 
 	//b = b.Union("all", ub)
 	ubSQL, _, err := ub.ToSQL()
@@ -235,23 +256,27 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 		return
 	} else {
-		resp, err = sr.parseResult(ctx, res, words)
+		resp, err = sr.parseResult(ctx, res, words, nil)
 		return
 	}
 }
 
 // SearchQuestions search question data
-func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagIDs [][]string, notAccepted bool, views, answers int, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
+func (sr *searchRepo) SearchQuestions(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
+	wordGroups := filterWordGroups(cond.WordGroups)
 	var (
 		qfs  = qFields
 		args = []interface{}{}
 	)
+	ft := sr.buildFullTextCond(wordGroups, "`question`.`id`")
 	if order == "relevance" {
-		if len(words) > 0 {
-			qfs, args = addRelevanceField([]string{"title", "original_text"}, words, qfs)
-		} else {
+		if len(words) == 0 {
 			order = "newest"
+		} else if ft != nil {
+			qfs, args = append(qfs, ft.relevance+" as relevance"), append(args, ft.relevanceArgs...)
+		} else {
+			qfs, args = addRelevanceField([]string{"title", "original_text"}, words, qfs)
 		}
 	}
 
@@ -260,17 +285,16 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 	b.Where(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow})
 	args = append(args, entity.QuestionStatusDeleted, entity.QuestionShow)
 
-	likeConQ := builder.NewCond()
-	for _, word := range words {
-		likeConQ = likeConQ.Or(builder.Like{"title", word}).
-			Or(builder.Like{"original_text", word})
-		args = append(args, "%"+word+"%")
-		args = append(args, "%"+word+"%")
+	if ft != nil {
+		b.Join("INNER", "search_index", ft.join).Where(builder.Expr(ft.where, ft.whereArgs...))
+		args = append(args, ft.whereArgs...)
+	} else {
+		args = addWordGroupsCond(b, wordGroups, []string{"title", "original_text"}, args)
 	}
-	b.Where(likeConQ)
+	args = addExcludeWordsCond(b, cond.ExcludeWords, []string{"`question`.`title`", "`question`.`original_text`"}, args)
 
 	// check tag
-	for ti, tagID := range tagIDs {
+	for ti, tagID := range cond.Tags {
 		ast := "tag_rel" + strconv.Itoa(ti)
 		b.Join("INNER", "tag_rel as "+ast, "question.id = "+ast+".object_id").
 			And(builder.Eq{
@@ -282,34 +306,56 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 			args = append(args, t)
 		}
 	}
+	args = addExcludeTagsCond(b, "`question`.`id`", cond.ExcludeTags, args)
 
-	// check need filter has not accepted
-	if notAccepted {
+	// check need filter has not accepted or has accepted
+	if cond.NotAccepted {
 		b.And(builder.Eq{"accepted_answer_id": 0})
 		args = append(args, 0)
+	} else if cond.HasAccepted {
+		b.And(builder.Gt{"accepted_answer_id": 0})
+		args = append(args, 0)
+	}
+
+	// check closed
+	if cond.Closed {
+		b.And(builder.Eq{"`question`.`status`": entity.QuestionStatusClosed})
+		args = append(args, entity.QuestionStatusClosed)
+	} else if cond.NotClosed {
+		b.And(builder.Neq{"`question`.`status`": entity.QuestionStatusClosed})
+		args = append(args, entity.QuestionStatusClosed)
 	}
 
 	// check views
-	if views > -1 {
-		b.And(builder.Gte{"view_count": views})
-		args = append(args, views)
+	if cond.Views > -1 {
+		b.And(builder.Gte{"view_count": cond.Views})
+		args = append(args, cond.Views)
 	}
 
 	// check answers
-	if answers == 0 {
-		b.And(builder.Eq{"answer_count": answers})
-		args = append(args, answers)
-	} else if answers > 0 {
-		b.And(builder.Gte{"answer_count": answers})
-		args = append(args, answers)
+	if cond.AnswerAmount == 0 {
+		b.And(builder.Eq{"answer_count": cond.AnswerAmount})
+		args = append(args, cond.AnswerAmount)
+	} else if cond.AnswerAmount > 0 {
+		b.And(builder.Gte{"answer_count": cond.AnswerAmount})
+		args = append(args, cond.AnswerAmount)
 	}
 
-	if answers == 0 {
+	if cond.AnswerAmount == 0 {
 		b.And(builder.Eq{"answer_count": 0})
 		args = append(args, 0)
-	} else if answers > 0 {
-		b.And(builder.Gte{"answer_count": answers})
-		args = append(args, answers)
+	} else if cond.AnswerAmount > 0 {
+		b.And(builder.Gte{"answer_count": cond.AnswerAmount})
+		args = append(args, cond.AnswerAmount)
+	}
+
+	// check time range, commenter and collector
+	args = addTimeRangeCond(b, "`question`.`created_at`", cond.CreatedFrom, cond.CreatedTo, args)
+	args = addTimeRangeCond(b, "`question`.`post_update_time`", cond.ActiveFrom, cond.ActiveTo, args)
+	args = addCommenterCond(b, "`question`.`id`", cond.CommenterID, args)
+	if cond.CollectorID != "" {
+		b.And(builder.Expr("`question`.`id` IN (SELECT `object_id` FROM `collection` WHERE `user_id` = ?)", cond.CollectorID))
+		args = append(args, cond.CollectorID)
 	}
 
 	queryArgs := []interface{}{}
@@ -343,7 +389,7 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 	if len(tr) != 0 {
 		total = converter.StringToInt64(string(tr[0]["total"]))
 	}
-	resp, err = sr.parseResult(ctx, res, words)
+	resp, err = sr.parseResult(ctx, res, words, nil)
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -351,18 +397,22 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 }
 
 // SearchAnswers search answer data
-func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs [][]string, accepted bool, questionID string, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
+func (sr *searchRepo) SearchAnswers(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
+	wordGroups := filterWordGroups(cond.WordGroups)
 
 	var (
 		afs  = aFields
 		args = []interface{}{}
 	)
+	ft := sr.buildFullTextCond(wordGroups, "`answer`.`id`")
 	if order == "relevance" {
-		if len(words) > 0 {
-			afs, args = addRelevanceField([]string{"`answer`.`original_text`"}, words, afs)
-		} else {
+		if len(words) == 0 {
 			order = "newest"
+		} else if ft != nil {
+			afs, args = append(afs, ft.relevance+" as relevance"), append(args, ft.relevanceArgs...)
+		} else {
+			afs, args = addRelevanceField([]string{"`answer`.`original_text`"}, words, afs)
 		}
 	}
 
@@ -373,16 +423,16 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 		And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow})
 	args = append(args, entity.QuestionStatusDeleted, entity.AnswerStatusDeleted, entity.QuestionShow)
 
-	likeConA := builder.NewCond()
-	for _, word := range words {
-		likeConA = likeConA.Or(builder.Like{"`answer`.original_text", word})
-		args = append(args, "%"+word+"%")
+	if ft != nil {
+		b.Join("INNER", "search_index", ft.join).Where(builder.Expr(ft.where, ft.whereArgs...))
+		args = append(args, ft.whereArgs...)
+	} else {
+		args = addWordGroupsCond(b, wordGroups, []string{"`answer`.original_text"}, args)
 	}
-
-	b.Where(likeConA)
+	args = addExcludeWordsCond(b, cond.ExcludeWords, []string{"`answer`.original_text"}, args)
 
 	// check tag
-	for ti, tagID := range tagIDs {
+	for ti, tagID := range cond.Tags {
 		ast := "tag_rel" + strconv.Itoa(ti)
 		b.Join("INNER", "tag_rel as "+ast, "question_id = "+ast+".object_id").
 			And(builder.Eq{
@@ -394,19 +444,25 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 			args = append(args, t)
 		}
 	}
+	args = addExcludeTagsCond(b, "`answer`.`question_id`", cond.ExcludeTags, args)
 
 	// check limit accepted
-	if accepted {
+	if cond.Accepted {
 		b.Where(builder.Eq{"adopted": schema.AnswerAcceptedEnable})
 		args = append(args, schema.AnswerAcceptedEnable)
 	}
 
 	// check question id
-	if questionID != "" {
-		b.Where(builder.Eq{"question_id": questionID})
-		args = append(args, questionID)
+	if cond.QuestionID != "" {
+		b.Where(builder.Eq{"question_id": cond.QuestionID})
+		args = append(args, cond.QuestionID)
 	}
 
+	// check time range and commenter
+	args = addTimeRangeCond(b, "`answer`.`created_at`", cond.CreatedFrom, cond.CreatedTo, args)
+	args = addTimeRangeCond(b, "`answer`.`updated_at`", cond.ActiveFrom, cond.ActiveTo, args)
+	args = addCommenterCond(b, "`answer`.`id`", cond.CommenterID, args)
+
 	queryArgs := []interface{}{}
 	countArgs := []interface{}{}
 
@@ -437,13 +493,78 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 	}
 
 	total = converter.StringToInt64(string(tr[0]["total"]))
-	resp, err = sr.parseResult(ctx, res, words)
+	resp, err = sr.parseResult(ctx, res, words, nil)
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	return
 }
 
+// addWordGroupsCond every group matches at least one word in any of the fields, return the appended args
+func addWordGroupsCond(b *builder.Builder, wordGroups [][]string, fields []string, args []interface{}) []interface{} {
+	for _, group := range wordGroups {
+		likeCond := builder.NewCond()
+		for _, word := range group {
+			for _, field := range fields {
+				likeCond = likeCond.Or(builder.Like{field, word})
+				args = append(args, "%"+word+"%")
+			}
+		}
+		b.Where(likeCond)
+	}
+	return args
+}
+
+// addExcludeWordsCond none of the fields contains the excluded words, return the appended args
+func addExcludeWordsCond(b *builder.Builder, words []string, fields []string, args []interface{}) []interface{} {
+	for _, word := range filterWords(words) {
+		for _, field := range fields {
+			b.Where(builder.Not{builder.Like{field, word}})
+			args = append(args, "%"+word+"%")
+		}
+	}
+	return args
+}
+
+// addExcludeTagsCond the question has none of the excluded tags, return the appended args
+func addExcludeTagsCond(b *builder.Builder, questionIDField string, tagIDs []string, args []interface{}) []interface{} {
+	if len(tagIDs) == 0 {
+		return args
+	}
+	excludeArgs := []interface{}{entity.TagRelStatusAvailable}
+	for _, tagID := range tagIDs {
+		excludeArgs = append(excludeArgs, tagID)
+	}
+	b.Where(builder.Expr(questionIDField+" NOT IN (SELECT `object_id` FROM `tag_rel` WHERE `status` = ? AND `tag_id` IN ("+
+		strings.TrimSuffix(strings.Repeat("?,", len(tagIDs)), ",")+"))", excludeArgs...))
+	return append(args, excludeArgs...)
+}
+
+// addTimeRangeCond the time field is in [from, to), zero means unlimited, return the appended args
+func addTimeRangeCond(b *builder.Builder, field string, from, to int64, args []interface{}) []interface{} {
+	if from > 0 {
+		t := time.Unix(from, 0).In(time.Local).Format("2006-01-02 15:04:05")
+		b.Where(builder.Gte{field: t})
+		args = append(args, t)
+	}
+	if to > 0 {
+		t := time.Unix(to, 0).In(time.Local).Format("2006-01-02 15:04:05")
+		b.Where(builder.Lt{field: t})
+		args = append(args, t)
+	}
+	return args
+}
+
+// addCommenterCond the object has available comment of the user, return the appended args
+func addCommenterCond(b *builder.Builder, objectIDField, commenterID string, args []interface{}) []interface{} {
+	if commenterID == "" {
+		return args
+	}
+	b.Where(builder.Expr(objectIDField+" IN (SELECT `object_id` FROM `comment` WHERE `user_id` = ? AND `status` = ?)",
+		commenterID, entity.CommentStatusAvailable))
+	return append(args, commenterID, entity.CommentStatusAvailable)
+}
+
 func (sr *searchRepo) parseOrder(ctx context.Context, order string) (res string) {
 	switch order {
 	case "newest":
@@ -463,9 +584,10 @@ func (sr *searchRepo) parseOrder(ctx context.Context, order string) (res string)
 // ParseSearchPluginResult parse search plugin result
 func (sr *searchRepo) ParseSearchPluginResult(ctx context.Context, sres []plugin.SearchResult, words []string) (resp []*schema.SearchResult, err error) {
 	var (
-		qres []map[string][]byte
-		res  = make([]map[string][]byte, 0)
-		b    *builder.Builder
+		qres       []map[string][]byte
+		res        = make([]map[string][]byte, 0)
+		b          *builder.Builder
+		highlights = make(map[string]*schema.SearchHighlight)
 	)
 	for _, r := range sres {
 		switch r.Type {
@@ -483,12 +605,23 @@ func (sr *searchRepo) ParseSearchPluginResult(ctx context.Context, sres []plugin
 			continue
 		}
 		res = append(res, qres[0])
+		if r.Highlight != nil {
+			highlights[r.ID] = &schema.SearchHighlight{
+				Title:     htmltext.SanitizeHighlight(r.Highlight.Title),
+				Fragments: make([]string, 0, len(r.Highlight.Fragments)),
+			}
+			for _, fragment := range r.Highlight.Fragments {
+				highlights[r.ID].Fragments = append(highlights[r.ID].Fragments, htmltext.SanitizeHighlight(fragment))
+			}
+		}
 	}
-	return sr.parseResult(ctx, res, words)
+	return sr.parseResult(ctx, res, words, highlights)
 }
 
-// parseResult parse search result, return the data structure
-func (sr *searchRepo) parseResult(ctx context.Context, res []map[string][]byte, words []string) (resp []*schema.SearchResult, err error) {
+// parseResult parse search result, return the data structure.
+// highlights are provided by search plugin, the result without highlight will be highlighted by words.
+func (sr *searchRepo) parseResult(ctx context.Context, res []map[string][]byte, words []string,
+	highlights map[string]*schema.SearchHighlight) (resp []*schema.SearchResult, err error) {
 	questionIDs := make([]string, 0)
 	userIDs := make([]string, 0)
 	resultList := make([]*schema.SearchResult, 0)
@@ -518,6 +651,17 @@ func (sr *searchRepo) parseResult(ctx context.Context, res []map[string][]byte,
 			VoteCount:   converter.StringToInt(string(r["vote_count"])),
 			Accepted:    string(r["accepted"]) == "2",
 			AnswerCount: converter.StringToInt(string(r["answer_count"])),
+			Highlight:   highlights[string(r["id"])],
+		}
+		if object.Highlight == nil {
+			object.Highlight = &schema.SearchHighlight{
+				Title: htmltext.HighlightMatchedWords(object.Title, words),
+				Fragments: htmltext.FetchMatchedFragments(string(r["parsed_text"]), words, "...",
+					searchHighlightTrimLength, searchHighlightMaxFragments),
+			}
+		} else if len(object.Highlight.Title) == 0 {
+			// the search plugin may only highlight the content, the title is shown as the plain text
+			object.Highlight.Title = html.EscapeString(object.Title)
 		}
 
 		objectKey, err := obj.GetObjectTypeStrByObjectID(string(r["id"]))
@@ -605,6 +749,16 @@ func addRelevanceField(searchFields, words, fields []string) (res []string, args
 	return
 }
 
+// filterWordGroups remove the empty words and empty groups
+func filterWordGroups(wordGroups [][]string) (res [][]string) {
+	for _, group := range wordGroups {
+		if words := filterWords(group); len(words) > 0 {
+			res = append(res, words)
+		}
+	}
+	return
+}
+
 func filterWords(words []string) (res []string) {
 	for _, word := range words {
 		if strings.TrimSpace(word) != "" {
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// tagModeratorRepo tag moderator repository
+type tagModeratorRepo struct {
+	data *data.Data
+}
+
+// NewTagModeratorRepo new repository
+func NewTagModeratorRepo(data *data.Data) tag_moderator.TagModeratorRepo {
+	return &tagModeratorRepo{
+		data: data,
+	}
+}
+
+// AddTagModerator add tag moderator, do nothing if the user already moderates the tag
+func (tr *tagModeratorRepo) AddTagModerator(ctx context.Context, tagModerator *entity.TagModerator) (err error) {
+	exist, err := tr.data.DB.Context(ctx).Exist(&entity.TagModerator{
+		TagID:  tagModerator.TagID,
+		UserID: tagModerator.UserID,
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return nil
+	}
+	_, err = tr.data.DB.Context(ctx).Insert(tagModerator)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// RemoveTagModerator remove tag moderator
+func (tr *tagModeratorRepo) RemoveTagModerator(ctx context.Context, tagID, userID string) (err error) {
+	_, err = tr.data.DB.Context(ctx).Where(builder.Eq{"tag_id": tagID, "user_id": userID}).
+		Delete(&entity.TagModerator{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagModeratorList get the moderators of the tag
+func (tr *tagModeratorRepo) GetTagModeratorList(ctx context.Context, tagID string) (
+	tagModerators []*entity.TagModerator, err error) {
+	tagModerators = make([]*entity.TagModerator, 0)
+	err = tr.data.DB.Context(ctx).Where(builder.Eq{"tag_id": tagID}).Asc("id").Find(&tagModerators)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetModeratedTagIDs get the tags moderated by the user
+func (tr *tagModeratorRepo) GetModeratedTagIDs(ctx context.Context, userID string) (tagIDs []string, err error) {
+	tagIDs = make([]string, 0)
+	err = tr.data.DB.Context(ctx).Table(entity.TagModerator{}.TableName()).
+		Cols("tag_id").Where(builder.Eq{"user_id": userID}).Find(&tagIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// IsTagModeratorOfQuestion whether the user moderates any tag of the question.
+// The tag relations of the deleted question are deleted too, so they should be included for the deleted question.
+func (tr *tagModeratorRepo) IsTagModeratorOfQuestion(ctx context.Context, userID, questionID string,
+	includeDeleted bool) (ok bool, err error) {
+	questionID = uid.DeShortID(questionID)
+	tagRelCond := builder.NewCond().And(builder.Eq{"object_id": questionID})
+	if !includeDeleted {
+		tagRelCond = tagRelCond.And(builder.Neq{"status": entity.TagRelStatusDeleted})
+	}
+	ok, err = tr.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).
+		And(builder.In("tag_id", builder.Select("tag_id").From(entity.TagRel{}.TableName()).Where(tagRelCond))).
+		Exist(&entity.TagModerator{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// userSuspensionRepo user suspension repository
+type userSuspensionRepo struct {
+	data *data.Data
+}
+
+// NewUserSuspensionRepo new repository
+func NewUserSuspensionRepo(data *data.Data) user_suspension.UserSuspensionRepo {
+	return &userSuspensionRepo{
+		data: data,
+	}
+}
+
+// AddUserSuspension lift the previous active suspension of the user, add the new one
+// and record the time when the user will be reinstated.
+func (ur *userSuspensionRepo) AddUserSuspension(ctx context.Context, suspension *entity.UserSuspension) (err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		if err = ur.liftUserSuspension(session, suspension.UserID); err != nil {
+			return nil, err
+		}
+		if _, err = session.Insert(suspension); err != nil {
+			return nil, err
+		}
+		_, err = session.ID(suspension.UserID).Cols("suspended_until").
+			Update(&entity.User{SuspendedUntil: suspension.SuspendedUntil})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// LiftUserSuspension lift the active suspension of the user
+func (ur *userSuspensionRepo) LiftUserSuspension(ctx context.Context, userID string) (err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		if err = ur.liftUserSuspension(session, userID); err != nil {
+			return nil, err
+		}
+		_, err = session.ID(userID).Cols("suspended_until").Update(&entity.User{})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func (ur *userSuspensionRepo) liftUserSuspension(session *xorm.Session, userID string) (err error) {
+	_, err = session.Where("user_id = ?", userID).And("status = ?", entity.UserSuspensionStatusActive).
+		Cols("status", "lifted_at").
+		Update(&entity.UserSuspension{Status: entity.UserSuspensionStatusLifted, LiftedAt: time.Now()})
+	return err
+}
+
+// ExpireUserSuspension change the active suspension to expired and reinstate the user.
+// If the suspension is already lifted or the user is not suspended any more, reinstated will be false.
+func (ur *userSuspensionRepo) ExpireUserSuspension(ctx context.Context, suspension *entity.UserSuspension) (
+	reinstated bool, err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		affected, err := session.ID(suspension.ID).Where("status = ?", entity.UserSuspensionStatusActive).
+			Cols("status", "lifted_at").
+			Update(&entity.UserSuspension{Status: entity.UserSuspensionStatusExpired, LiftedAt: time.Now()})
+		if err != nil || affected == 0 {
+			return nil, err
+		}
+		affected, err = session.ID(suspension.UserID).Where("status = ?", entity.UserStatusSuspended).
+			Cols("status", "suspended_until").Update(&entity.User{Status: entity.UserStatusAvailable})
+		if err != nil {
+			return nil, err
+		}
+		reinstated = affected > 0
+		return nil, nil
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return reinstated, nil
+}
+
+// GetActiveUserSuspension get the active suspension of the user
+func (ur *userSuspensionRepo) GetActiveUserSuspension(ctx context.Context, userID string) (
+	suspension *entity.UserSuspension, exist bool, err error) {
+	suspension = &entity.UserSuspension{}
+	exist, err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).
+		And("status = ?", entity.UserSuspensionStatusActive).Desc("id").Get(suspension)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserSuspensionList get the suspension history of the user, the latest first
+func (ur *userSuspensionRepo) GetUserSuspensionList(ctx context.Context, userID string) (
+	suspensions []*entity.UserSuspension, err error) {
+	suspensions = make([]*entity.UserSuspension, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id").Find(&suspensions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetExpiredUserSuspensions get the active time-limited suspensions which are expired before the time
+func (ur *userSuspensionRepo) GetExpiredUserSuspensions(ctx context.Context, expiredAt time.Time, limit int) (
+	suspensions []*entity.UserSuspension, err error) {
+	suspensions = make([]*entity.UserSuspension, 0)
+	err = ur.data.DB.Context(ctx).Where("status = ?", entity.UserSuspensionStatusActive).
+		And("duration > 0").And("suspended_until <= ?", expiredAt).
+		Asc("suspended_until").Limit(limit).Find(&suspensions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_access_token
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/user_access_token"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// userAccessTokenRepo user access token repository
+type userAccessTokenRepo struct {
+	data *data.Data
+}
+
+// NewUserAccessTokenRepo new repository
+func NewUserAccessTokenRepo(data *data.Data) user_access_token.UserAccessTokenRepo {
+	return &userAccessTokenRepo{
+		data: data,
+	}
+}
+
+// AddUserAccessToken add user access token
+func (ur *userAccessTokenRepo) AddUserAccessToken(ctx context.Context, token *entity.UserAccessToken) (err error) {
+	_, err = ur.data.DB.Context(ctx).Insert(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserAccessTokenByHash get user access token by the hash of token
+func (ur *userAccessTokenRepo) GetUserAccessTokenByHash(ctx context.Context, tokenHash string) (
+	token *entity.UserAccessToken, exist bool, err error) {
+	token = &entity.UserAccessToken{}
+	exist, err = ur.data.DB.Context(ctx).Where("token_hash = ?", tokenHash).Get(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserAccessTokenList get all tokens of the user
+func (ur *userAccessTokenRepo) GetUserAccessTokenList(ctx context.Context, userID string) (
+	tokens []*entity.UserAccessToken, err error) {
+	tokens = make([]*entity.UserAccessToken, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id").Find(&tokens)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RevokeUserAccessToken revoke the token of the user
+func (ur *userAccessTokenRepo) RevokeUserAccessToken(ctx context.Context, userID string, id int) (affected int64, err error) {
+	affected, err = ur.data.DB.Context(ctx).ID(id).Where("user_id = ?", userID).
+		Cols("status").Update(&entity.UserAccessToken{Status: entity.UserAccessTokenStatusRevoked})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RevokeAllUserAccessTokens revoke all tokens of the user
+func (ur *userAccessTokenRepo) RevokeAllUserAccessTokens(ctx context.Context, userID string) (err error) {
+	_, err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).
+		Cols("status").Update(&entity.UserAccessToken{Status: entity.UserAccessTokenStatusRevoked})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateLastUsedAt update the last used time of the token
+func (ur *userAccessTokenRepo) UpdateLastUsedAt(ctx context.Context, id int, lastUsedAt time.Time) (err error) {
+	_, err = ur.data.DB.Context(ctx).ID(id).NoAutoTime().
+		Cols("last_used_at").Update(&entity.UserAccessToken{LastUsedAt: lastUsedAt})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -21,6 +21,8 @@ package user_notification_config
 
 import (
 	"context"
+	"time"
+
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/reason"
@@ -79,6 +81,16 @@ func (ur *userNotificationConfigRepo) Save(ctx context.Context, uc *entity.UserN
 	return nil
 }
 
+// UpdateDigestSentAt update the time when the last digest is sent
+func (ur *userNotificationConfigRepo) UpdateDigestSentAt(ctx context.Context, id string, sentAt time.Time) (err error) {
+	_, err = ur.data.DB.Context(ctx).ID(id).Cols("digest_sent_at").
+		Update(&entity.UserNotificationConfig{DigestSentAt: sentAt})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
 // GetByUserID get notification config by user id
 func (ur *userNotificationConfigRepo) GetByUserID(ctx context.Context, userID string) (
 	[]*entity.UserNotificationConfig, error) {
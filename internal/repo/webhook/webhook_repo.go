@@ -0,0 +1,211 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/webhook"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// webhookRepo webhook repository
+type webhookRepo struct {
+	data *data.Data
+}
+
+// NewWebhookRepo new repository
+func NewWebhookRepo(data *data.Data) webhook.WebhookRepo {
+	return &webhookRepo{
+		data: data,
+	}
+}
+
+// AddWebhook add webhook
+func (wr *webhookRepo) AddWebhook(ctx context.Context, webhook *entity.Webhook) (err error) {
+	_, err = wr.data.DB.Context(ctx).Insert(webhook)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateWebhook update webhook
+func (wr *webhookRepo) UpdateWebhook(ctx context.Context, webhook *entity.Webhook) (err error) {
+	_, err = wr.data.DB.Context(ctx).ID(webhook.ID).
+		Cols("name", "url", "secret", "events", "active", "description").Update(webhook)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveWebhook remove webhook and its delivery records and tasks
+func (wr *webhookRepo) RemoveWebhook(ctx context.Context, id int) (err error) {
+	_, err = wr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.ID(id).Delete(&entity.Webhook{}); err != nil {
+			return nil, err
+		}
+		if _, err = session.Where("webhook_id = ?", id).Delete(&entity.WebhookDelivery{}); err != nil {
+			return nil, err
+		}
+		_, err = session.Where("webhook_id = ?", id).Delete(&entity.WebhookTask{})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetWebhook get webhook one
+func (wr *webhookRepo) GetWebhook(ctx context.Context, id int) (webhook *entity.Webhook, exist bool, err error) {
+	webhook = &entity.Webhook{}
+	exist, err = wr.data.DB.Context(ctx).ID(id).Get(webhook)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetWebhookList get all webhooks
+func (wr *webhookRepo) GetWebhookList(ctx context.Context) (webhooks []*entity.Webhook, err error) {
+	webhooks = make([]*entity.Webhook, 0)
+	err = wr.data.DB.Context(ctx).Asc("id").Find(&webhooks)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetActiveWebhookList get all active webhooks
+func (wr *webhookRepo) GetActiveWebhookList(ctx context.Context) (webhooks []*entity.Webhook, err error) {
+	webhooks = make([]*entity.Webhook, 0)
+	err = wr.data.DB.Context(ctx).Where("active = ?", true).Asc("id").Find(&webhooks)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddWebhookDelivery add webhook delivery record
+func (wr *webhookRepo) AddWebhookDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (err error) {
+	_, err = wr.data.DB.Context(ctx).Insert(delivery)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetWebhookDeliveryPage get webhook delivery records page, the newest first
+func (wr *webhookRepo) GetWebhookDeliveryPage(ctx context.Context, page, pageSize int, webhookID int) (
+	deliveries []*entity.WebhookDelivery, total int64, err error) {
+	deliveries = make([]*entity.WebhookDelivery, 0)
+	session := wr.data.DB.Context(ctx).Where("webhook_id = ?", webhookID).Desc("id")
+	total, err = pager.Help(page, pageSize, &deliveries, &entity.WebhookDelivery{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddWebhookTasks add webhook tasks
+func (wr *webhookRepo) AddWebhookTasks(ctx context.Context, tasks []*entity.WebhookTask) (err error) {
+	_, err = wr.data.DB.Context(ctx).Insert(tasks)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAvailableWebhookTasks get the tasks that should be sent now, including the processing tasks whose lease has expired
+func (wr *webhookRepo) GetAvailableWebhookTasks(ctx context.Context, now time.Time, limit int) (
+	tasks []*entity.WebhookTask, err error) {
+	tasks = make([]*entity.WebhookTask, 0)
+	err = wr.data.DB.Context(ctx).
+		In("status", entity.WebhookTaskStatusPending, entity.WebhookTaskStatusProcessing).
+		And(builder.Lte{"next_retry_at": now}).
+		Asc("id").Limit(limit).Find(&tasks)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClaimWebhookTask lock the task until leaseUntil. The attempts is used as a version,
+// so only one worker can claim the task at the same time.
+func (wr *webhookRepo) ClaimWebhookTask(ctx context.Context, task *entity.WebhookTask, leaseUntil time.Time) (
+	claimed bool, err error) {
+	affected, err := wr.data.DB.Context(ctx).ID(task.ID).
+		Where(builder.Eq{"attempts": task.Attempts}).
+		In("status", entity.WebhookTaskStatusPending, entity.WebhookTaskStatusProcessing).
+		Cols("status", "attempts", "next_retry_at").
+		Update(&entity.WebhookTask{
+			Status:      entity.WebhookTaskStatusProcessing,
+			Attempts:    task.Attempts + 1,
+			NextRetryAt: leaseUntil,
+		})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	task.Status = entity.WebhookTaskStatusProcessing
+	task.Attempts++
+	task.NextRetryAt = leaseUntil
+	return true, nil
+}
+
+// UpdateWebhookTaskResult update the task status after it is sent
+func (wr *webhookRepo) UpdateWebhookTaskResult(ctx context.Context, task *entity.WebhookTask) (err error) {
+	_, err = wr.data.DB.Context(ctx).ID(task.ID).Cols("status", "next_retry_at").Update(task)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveWebhookDeliveriesBefore remove the deliveries which are created before the time
+func (wr *webhookRepo) RemoveWebhookDeliveriesBefore(ctx context.Context, before time.Time) (err error) {
+	_, err = wr.data.DB.Context(ctx).Where(builder.Lt{"created_at": before}).Delete(&entity.WebhookDelivery{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveWebhookTasksBefore remove the tasks with the status which are updated before the time
+func (wr *webhookRepo) RemoveWebhookTasksBefore(ctx context.Context, status int, before time.Time) (err error) {
+	_, err = wr.data.DB.Context(ctx).Where(builder.Eq{"status": status}).
+		And(builder.Lt{"updated_at": before}).Delete(&entity.WebhookTask{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
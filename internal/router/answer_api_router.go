@@ -27,36 +27,44 @@ import (
 )
 
 type AnswerAPIRouter struct {
-	langController          *controller.LangController
-	userController          *controller.UserController
-	commentController       *controller.CommentController
-	reportController        *controller.ReportController
-	voteController          *controller.VoteController
-	tagController           *controller.TagController
-	followController        *controller.FollowController
-	collectionController    *controller.CollectionController
-	questionController      *controller.QuestionController
-	answerController        *controller.AnswerController
-	searchController        *controller.SearchController
-	revisionController      *controller.RevisionController
-	rankController          *controller.RankController
-	adminUserController     *controller_admin.UserAdminController
-	reasonController        *controller.ReasonController
-	themeController         *controller_admin.ThemeController
-	adminSiteInfoController *controller_admin.SiteInfoController
-	siteInfoController      *controller.SiteInfoController
-	notificationController  *controller.NotificationController
-	dashboardController     *controller.DashboardController
-	uploadController        *controller.UploadController
-	activityController      *controller.ActivityController
-	roleController          *controller_admin.RoleController
-	pluginController        *controller_admin.PluginController
-	permissionController    *controller.PermissionController
-	userPluginController    *controller.UserPluginController
-	reviewController        *controller.ReviewController
-	metaController          *controller.MetaController
-	badgeController         *controller.BadgeController
-	adminBadgeController    *controller_admin.BadgeController
+	langController             *controller.LangController
+	userController             *controller.UserController
+	commentController          *controller.CommentController
+	reportController           *controller.ReportController
+	voteController             *controller.VoteController
+	tagController              *controller.TagController
+	followController           *controller.FollowController
+	collectionController       *controller.CollectionController
+	questionController         *controller.QuestionController
+	answerController           *controller.AnswerController
+	searchController           *controller.SearchController
+	revisionController         *controller.RevisionController
+	rankController             *controller.RankController
+	adminUserController        *controller_admin.UserAdminController
+	reasonController           *controller.ReasonController
+	themeController            *controller_admin.ThemeController
+	adminSiteInfoController    *controller_admin.SiteInfoController
+	siteInfoController         *controller.SiteInfoController
+	notificationController     *controller.NotificationController
+	dashboardController        *controller.DashboardController
+	uploadController           *controller.UploadController
+	activityController         *controller.ActivityController
+	roleController             *controller_admin.RoleController
+	pluginController           *controller_admin.PluginController
+	permissionController       *controller.PermissionController
+	userPluginController       *controller.UserPluginController
+	reviewController           *controller.ReviewController
+	metaController             *controller.MetaController
+	badgeController            *controller.BadgeController
+	adminBadgeController       *controller_admin.BadgeController
+	webhookController          *controller_admin.WebhookController
+	userAccessTokenController  *controller.UserAccessTokenController
+	savedSearchController      *controller.SavedSearchController
+	bountyController           *controller.BountyController
+	draftController            *controller.DraftController
+	tagModeratorController     *controller_admin.TagModeratorController
+	moderatorMessageController *controller.ModeratorMessageController
+	auditLogController         *controller_admin.AuditLogController
 }
 
 func NewAnswerAPIRouter(
@@ -90,38 +98,54 @@ func NewAnswerAPIRouter(
 	metaController *controller.MetaController,
 	badgeController *controller.BadgeController,
 	adminBadgeController *controller_admin.BadgeController,
+	webhookController *controller_admin.WebhookController,
+	userAccessTokenController *controller.UserAccessTokenController,
+	savedSearchController *controller.SavedSearchController,
+	bountyController *controller.BountyController,
+	draftController *controller.DraftController,
+	tagModeratorController *controller_admin.TagModeratorController,
+	moderatorMessageController *controller.ModeratorMessageController,
+	auditLogController *controller_admin.AuditLogController,
 ) *AnswerAPIRouter {
 	return &AnswerAPIRouter{
-		langController:          langController,
-		userController:          userController,
-		commentController:       commentController,
-		reportController:        reportController,
-		voteController:          voteController,
-		tagController:           tagController,
-		followController:        followController,
-		collectionController:    collectionController,
-		questionController:      questionController,
-		answerController:        answerController,
-		searchController:        searchController,
-		revisionController:      revisionController,
-		rankController:          rankController,
-		adminUserController:     adminUserController,
-		reasonController:        reasonController,
-		themeController:         themeController,
-		adminSiteInfoController: adminSiteInfoController,
-		notificationController:  notificationController,
-		siteInfoController:      siteInfoController,
-		dashboardController:     dashboardController,
-		uploadController:        uploadController,
-		activityController:      activityController,
-		roleController:          roleController,
-		pluginController:        pluginController,
-		permissionController:    permissionController,
-		userPluginController:    userPluginController,
-		reviewController:        reviewController,
-		metaController:          metaController,
-		badgeController:         badgeController,
-		adminBadgeController:    adminBadgeController,
+		langController:             langController,
+		userController:             userController,
+		commentController:          commentController,
+		reportController:           reportController,
+		voteController:             voteController,
+		tagController:              tagController,
+		followController:           followController,
+		collectionController:       collectionController,
+		questionController:         questionController,
+		answerController:           answerController,
+		searchController:           searchController,
+		revisionController:         revisionController,
+		rankController:             rankController,
+		adminUserController:        adminUserController,
+		reasonController:           reasonController,
+		themeController:            themeController,
+		adminSiteInfoController:    adminSiteInfoController,
+		notificationController:     notificationController,
+		siteInfoController:         siteInfoController,
+		dashboardController:        dashboardController,
+		uploadController:           uploadController,
+		activityController:         activityController,
+		roleController:             roleController,
+		pluginController:           pluginController,
+		permissionController:       permissionController,
+		userPluginController:       userPluginController,
+		reviewController:           reviewController,
+		metaController:             metaController,
+		badgeController:            badgeController,
+		adminBadgeController:       adminBadgeController,
+		webhookController:          webhookController,
+		userAccessTokenController:  userAccessTokenController,
+		savedSearchController:      savedSearchController,
+		draftController:            draftController,
+		bountyController:           bountyController,
+		tagModeratorController:     tagModeratorController,
+		moderatorMessageController: moderatorMessageController,
+		auditLogController:         auditLogController,
 	}
 }
 
@@ -169,6 +193,8 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/question/similar/tag", a.questionController.SimilarQuestion)
 	r.GET("/personal/qa/top", a.questionController.UserTop)
 	r.GET("/personal/question/page", a.questionController.PersonalQuestionPage)
+	r.GET("/question/bounty", a.bountyController.GetBounty)
+	r.GET("/question/links", a.questionController.GetQuestionLinks)
 
 	// comment
 	r.GET("/comment/page", a.commentController.GetCommentWithPage)
@@ -177,6 +203,8 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 
 	// revision
 	r.GET("/revisions", a.revisionController.GetRevisionList)
+	r.GET("/revisions/contributors", a.revisionController.GetContributors)
+	r.GET("/revisions/diff", a.revisionController.GetRevisionDiff)
 
 	// tag
 	r.GET("/tags/page", a.tagController.GetTagWithPage)
@@ -205,11 +233,18 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 
 func (a *AnswerAPIRouter) RegisterAuthUserWithAnyStatusAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/user/logout", a.userController.UserLogout)
+	r.GET("/user/suspension", a.userController.GetCurrentUserSuspension)
+	r.GET("/user/moderator-messages", a.moderatorMessageController.GetUserModeratorMessageList)
+	r.PUT("/user/moderator-message/acknowledge", a.moderatorMessageController.AcknowledgeModeratorMessage)
 	r.POST("/user/email/change/code", middleware.BanAPIForUserCenter, a.userController.UserChangeEmailSendCode)
 	r.POST("/user/email/verification/send", middleware.BanAPIForUserCenter, a.userController.UserVerifyEmailSend)
 }
 
-func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
+func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(
+	moderatorMessageMiddleware *middleware.ModeratorMessageMiddleware, r *gin.RouterGroup) {
+	// the user must acknowledge the moderator messages before posting, voting and commenting
+	mustAcknowledged := moderatorMessageMiddleware.MustAcknowledgeModeratorMessage()
+
 	// revisions
 	r.GET("/revisions/unreviewed", a.revisionController.GetUnreviewedRevisionList)
 	r.PUT("/revisions/audit", a.revisionController.RevisionAudit)
@@ -217,9 +252,10 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/reviewing/type", a.revisionController.GetReviewingType)
 
 	// comment
-	r.POST("/comment", a.commentController.AddComment)
+	r.POST("/comment", mustAcknowledged, a.commentController.AddComment)
 	r.DELETE("/comment", a.commentController.RemoveComment)
-	r.PUT("/comment", a.commentController.UpdateComment)
+	r.PUT("/comment", mustAcknowledged, a.commentController.UpdateComment)
+	r.POST("/comment/convert", a.commentController.ConvertCommentToAnswer)
 
 	// report
 	r.POST("/report", a.reportController.AddReport)
@@ -229,10 +265,12 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	// review
 	r.GET("/review/pending/post/page", a.reviewController.GetUnreviewedPostPage)
 	r.PUT("/review/pending/post", a.reviewController.UpdateReview)
+	r.GET("/review/tasks/page", a.reviewController.GetReviewTaskPage)
+	r.PUT("/review/task", a.reviewController.UpdateReviewTask)
 
 	// vote
-	r.POST("/vote/up", a.voteController.VoteUp)
-	r.POST("/vote/down", a.voteController.VoteDown)
+	r.POST("/vote/up", mustAcknowledged, a.voteController.VoteUp)
+	r.POST("/vote/down", mustAcknowledged, a.voteController.VoteDown)
 
 	// follow
 	r.POST("/follow", a.followController.Follow)
@@ -251,9 +289,9 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/personal/collection/page", a.questionController.PersonalCollectionPage)
 
 	// question
-	r.POST("/question", a.questionController.AddQuestion)
-	r.POST("/question/answer", a.questionController.AddQuestionByAnswer)
-	r.PUT("/question", a.questionController.UpdateQuestion)
+	r.POST("/question", mustAcknowledged, a.questionController.AddQuestion)
+	r.POST("/question/answer", mustAcknowledged, a.questionController.AddQuestionByAnswer)
+	r.PUT("/question", mustAcknowledged, a.questionController.UpdateQuestion)
 	r.PUT("/question/invite", a.questionController.UpdateQuestionInviteUser)
 	r.DELETE("/question", a.questionController.RemoveQuestion)
 	r.PUT("/question/status", a.questionController.CloseQuestion)
@@ -261,13 +299,25 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/question/reopen", a.questionController.ReopenQuestion)
 	r.GET("/question/similar", a.questionController.GetSimilarQuestions)
 	r.POST("/question/recover", a.questionController.QuestionRecover)
+	r.POST("/question/merge", a.questionController.MergeQuestion)
+	r.GET("/question/close-votes/page", a.questionController.GetCloseVoteQueuePage)
+	r.PUT("/question/close-votes/dismiss", a.questionController.DismissCloseVotes)
+
+	// bounty
+	r.POST("/question/bounty", a.bountyController.AddBounty)
+	r.PUT("/question/bounty/award", a.bountyController.AwardBounty)
+
+	// moderator message
+	r.POST("/moderator-message", a.moderatorMessageController.AddModeratorMessage)
 
 	// answer
-	r.POST("/answer", a.answerController.Add)
-	r.PUT("/answer", a.answerController.Update)
+	r.POST("/answer", mustAcknowledged, a.answerController.Add)
+	r.PUT("/answer", mustAcknowledged, a.answerController.Update)
 	r.POST("/answer/acceptance", a.answerController.Accepted)
 	r.DELETE("/answer", a.answerController.RemoveAnswer)
 	r.POST("/answer/recover", a.answerController.RecoverAnswer)
+	r.PUT("/answer/operation", a.answerController.OperationAnswer)
+	r.POST("/answer/convert", a.answerController.ConvertAnswerToComment)
 
 	// user
 	r.PUT("/user/password", middleware.BanAPIForUserCenter, a.userController.UserModifyPassWord)
@@ -277,6 +327,23 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/user/notification/config", a.userController.UpdateUserNotificationConfig)
 	r.GET("/user/info/search", a.userController.SearchUserListByName)
 
+	// personal access token
+	r.GET("/user/access-tokens", a.userAccessTokenController.GetUserAccessTokenList)
+	r.POST("/user/access-token", a.userAccessTokenController.AddUserAccessToken)
+	r.DELETE("/user/access-token", a.userAccessTokenController.RevokeUserAccessToken)
+
+	// saved search
+	r.GET("/user/saved-searches", a.savedSearchController.GetSavedSearchList)
+	r.POST("/user/saved-search", a.savedSearchController.AddSavedSearch)
+	r.PUT("/user/saved-search", a.savedSearchController.UpdateSavedSearch)
+	r.DELETE("/user/saved-search", a.savedSearchController.RemoveSavedSearch)
+
+	// draft
+	r.GET("/user/drafts", a.draftController.GetDraftList)
+	r.GET("/user/draft", a.draftController.GetDraft)
+	r.PUT("/user/draft", a.draftController.SaveDraft)
+	r.DELETE("/user/draft", a.draftController.RemoveDraft)
+
 	// vote
 	r.GET("/personal/vote/page", a.voteController.UserVotes)
 
@@ -319,7 +386,10 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	// user
 	r.GET("/users/page", a.adminUserController.GetUserPage)
 	r.PUT("/user/status", a.adminUserController.UpdateUserStatus)
+	r.GET("/user/suspensions", a.adminUserController.GetUserSuspensionList)
+	r.GET("/user/moderator-messages", a.adminUserController.GetModeratorMessageList)
 	r.PUT("/user/role", a.adminUserController.UpdateUserRole)
+	r.PUT("/users/role", a.adminUserController.UpdateUsersRole)
 	r.GET("/user/activation", a.adminUserController.GetUserActivation)
 	r.POST("/user/activation", a.adminUserController.SendUserActivation)
 	r.POST("/user", a.adminUserController.AddUser)
@@ -357,16 +427,34 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/siteinfo/theme", a.adminSiteInfoController.SaveSiteTheme)
 	r.GET("/siteinfo/users", a.adminSiteInfoController.GetSiteUsers)
 	r.PUT("/siteinfo/users", a.adminSiteInfoController.UpdateSiteUsers)
+	r.GET("/siteinfo/review", a.adminSiteInfoController.GetSiteReview)
+	r.PUT("/siteinfo/review", a.adminSiteInfoController.UpdateSiteReview)
 	r.GET("/setting/smtp", a.adminSiteInfoController.GetSMTPConfig)
 	r.PUT("/setting/smtp", a.adminSiteInfoController.UpdateSMTPConfig)
+	r.GET("/setting/smtp/logs", a.adminSiteInfoController.GetEmailLogPage)
+	r.PUT("/setting/smtp/log/retry", a.adminSiteInfoController.RetryEmailLog)
 	r.GET("/setting/privileges", a.adminSiteInfoController.GetPrivilegesConfig)
 	r.PUT("/setting/privileges", a.adminSiteInfoController.UpdatePrivilegesConfig)
 
 	// dashboard
 	r.GET("/dashboard", a.dashboardController.DashboardInfo)
 
+	// review
+	r.GET("/review/tasks/stats", a.reviewController.AdminGetReviewTaskStats)
+
 	// roles
 	r.GET("/roles", a.roleController.GetRoleList)
+	r.POST("/role", a.roleController.AddRole)
+	r.PUT("/role", a.roleController.UpdateRole)
+	r.DELETE("/role", a.roleController.RemoveRole)
+	r.GET("/role/powers", a.roleController.GetRolePowerList)
+	r.PUT("/role/powers", a.roleController.UpdateRolePowerList)
+	r.GET("/powers", a.roleController.GetPowerList)
+
+	// tag moderators
+	r.GET("/tag/moderators", a.tagModeratorController.GetTagModeratorList)
+	r.POST("/tag/moderator", a.tagModeratorController.AddTagModerator)
+	r.DELETE("/tag/moderator", a.tagModeratorController.RemoveTagModerator)
 
 	// plugin
 	r.GET("/plugins", a.pluginController.GetPluginList)
@@ -377,4 +465,17 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	// badge
 	r.GET("/badges", a.adminBadgeController.GetBadgeList)
 	r.PUT("/badge/status", a.adminBadgeController.UpdateBadgeStatus)
+
+	// webhook
+	r.GET("/webhooks", a.webhookController.GetWebhookList)
+	r.GET("/webhook/events", a.webhookController.GetWebhookEventTypes)
+	r.POST("/webhook", a.webhookController.AddWebhook)
+	r.PUT("/webhook", a.webhookController.UpdateWebhook)
+	r.DELETE("/webhook", a.webhookController.DeleteWebhook)
+	r.GET("/webhook/deliveries", a.webhookController.GetWebhookDeliveryPage)
+
+	// audit log
+	r.GET("/audit-logs/page", a.auditLogController.GetAuditLogPage)
+	r.GET("/audit-log/actions", a.auditLogController.GetAuditLogActions)
+	r.GET("/audit-logs/export", a.auditLogController.ExportAuditLog)
 }
@@ -62,6 +62,11 @@ func (a *TemplateRouter) RegisterTemplateRouter(r *gin.RouterGroup, baseURLPath
 
 	seoNoAuth.GET("/opensearch.xml", a.templateController.OpenSearch)
 
+	seoNoAuth.GET("/feed", a.templateController.QuestionsFeed)
+	seoNoAuth.GET("/tags/:tag/feed", a.templateController.TagFeed)
+	seoNoAuth.GET("/questions/:id/feed", a.templateController.QuestionFeed)
+	seoNoAuth.GET("/users/:username/feed", a.templateController.UserFeed)
+
 	seo := r.Group(baseURLPath)
 	seo.Use(a.authUserMiddleware.CheckPrivateMode())
 	seo.GET("/", a.templateController.Index)
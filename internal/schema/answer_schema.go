@@ -54,8 +54,10 @@ type AnswerAddReq struct {
 	CanRecover  bool   `json:"-"`
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
-	IP          string `json:"-"`
-	UserAgent   string `json:"-"`
+	// the draft which is published as this answer, it will be removed after published
+	DraftID   int    `json:"draft_id"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 func (req *AnswerAddReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -69,6 +71,7 @@ type AnswerUpdateReq struct {
 	Title        string `json:"title"`
 	Content      string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
 	EditSummary  string `validate:"omitempty" json:"edit_summary"`
+	RevisionID   string `validate:"omitempty" json:"revision_id"`
 	HTML         string `json:"-"`
 	UserID       string `json:"-"`
 	NoNeedReview bool   `json:"-"`
@@ -97,11 +100,30 @@ type AnswerListReq struct {
 	CanEdit    bool   `json:"-"`
 	CanDelete  bool   `json:"-"`
 	CanRecover bool   `json:"-"`
+	// whether user can make the answers community wiki
+	CanWiki bool `json:"-"`
+	// whether user can edit the community wiki answers
+	CanWikiEdit bool `json:"-"`
+	// whether user can convert the answers into comments
+	CanConvert bool `json:"-"`
+}
+
+const (
+	AnswerOperationWiki   = "wiki"
+	AnswerOperationUnWiki = "unwiki"
+)
+
+// OperationAnswerReq operation answer request
+type OperationAnswerReq struct {
+	ID        string `validate:"required" json:"id"`
+	Operation string `validate:"required,oneof=wiki unwiki" json:"operation"` // operation [wiki unwiki]
+	UserID    string `json:"-"`
 }
 
 type AnswerInfo struct {
 	ID             string            `json:"id"`
 	QuestionID     string            `json:"question_id"`
+	RevisionID     string            `json:"revision_id"`
 	Content        string            `json:"content"`
 	HTML           string            `json:"html"`
 	CreateTime     int64             `json:"create_time"`
@@ -116,6 +138,7 @@ type AnswerInfo struct {
 	VoteCount      int               `json:"vote_count"`
 	QuestionInfo   *QuestionInfoResp `json:"question_info,omitempty"`
 	Status         int               `json:"status"`
+	Wiki           bool              `json:"wiki"`
 
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
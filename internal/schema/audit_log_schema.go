@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// AuditLogRedactedValue replace the secret values in the snapshots, such as passwords
+const AuditLogRedactedValue = "******"
+
+// AuditLogMsg the operation of the admin or moderator to be recorded
+type AuditLogMsg struct {
+	OperatorUserID string
+	Action         string
+	ObjectType     string
+	ObjectID       string
+	// the snapshot of the object before the operation, it will be encoded as json
+	Before any
+	// the snapshot of the object after the operation, it will be encoded as json
+	After any
+}
+
+// AuditLogCondition the filter of the audit logs
+type AuditLogCondition struct {
+	// the user who did the operation
+	OperatorUserID string `validate:"omitempty" form:"operator_user_id"`
+	// action
+	Action string `validate:"omitempty,lte=100" form:"action"`
+	// the type of the operated object
+	ObjectType string `validate:"omitempty,lte=100" form:"object_type"`
+	// the id of the operated object
+	ObjectID string `validate:"omitempty,lte=100" form:"object_id"`
+	// the unix time from which the logs are created, 0 means no limit
+	StartTime int64 `validate:"omitempty,min=0" form:"start_time"`
+	// the unix time before which the logs are created, 0 means no limit
+	EndTime int64 `validate:"omitempty,min=0" form:"end_time"`
+}
+
+// GetAuditLogPageReq get audit log page request
+type GetAuditLogPageReq struct {
+	AuditLogCondition
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+}
+
+// ExportAuditLogReq export the audit logs as csv request
+type ExportAuditLogReq struct {
+	AuditLogCondition
+}
+
+// GetAuditLogResp audit log info
+type GetAuditLogResp struct {
+	ID int64 `json:"id"`
+	// the user who did the operation
+	Operator *UserBasicInfo `json:"operator"`
+	// action
+	Action string `json:"action"`
+	// the type of the operated object
+	ObjectType string `json:"object_type"`
+	// the id of the operated object
+	ObjectID string `json:"object_id"`
+	// the json snapshot of the object before the operation
+	BeforeData string `json:"before_data"`
+	// the json snapshot of the object after the operation
+	AfterData string `json:"after_data"`
+	// the ip of the operator
+	IP string `json:"ip"`
+	// created time
+	CreatedAt int64 `json:"created_at"`
+}
+
+// GetAuditLogActionsResp the actions recorded in the audit log
+type GetAuditLogActionsResp struct {
+	Actions []string `json:"actions"`
+}
@@ -35,7 +35,13 @@ type UpdateUserStatusReq struct {
 	UserID           string `validate:"required" json:"user_id"`
 	Status           string `validate:"required,oneof=normal suspended deleted inactive" json:"status" enums:"normal,suspended,deleted,inactive"`
 	RemoveAllContent bool   `validate:"omitempty" json:"remove_all_content"`
-	LoginUserID      string `json:"-"`
+	// suspend duration in hours, 0 means suspended until manually unsuspended
+	SuspendDuration int `validate:"omitempty,min=0,max=87600" json:"suspend_duration"`
+	// suspend reason key, must be one of the user suspend reasons
+	SuspendReason string `validate:"omitempty,lte=100" json:"suspend_reason"`
+	// the message from the operator shown to the suspended user
+	SuspendMessage string `validate:"omitempty,lte=2000" json:"suspend_message"`
+	LoginUserID    string `json:"-"`
 }
 
 func (r *UpdateUserStatusReq) IsNormal() bool    { return r.Status == constant.UserNormal }
@@ -71,6 +77,8 @@ type GetUserPageResp struct {
 	DeletedAt int64 `json:"deleted_at"`
 	// suspended time
 	SuspendedAt int64 `json:"suspended_at"`
+	// suspended until time, 0 means suspended until manually unsuspended
+	SuspendedUntil int64 `json:"suspended_until"`
 	// username
 	Username string `json:"username"`
 	// email
@@ -108,6 +116,16 @@ type UpdateUserRoleReq struct {
 	LoginUserID string `json:"-"`
 }
 
+// UpdateUsersRoleReq update the role of users in bulk request
+type UpdateUsersRoleReq struct {
+	// user id list
+	UserIDs []string `validate:"required,gt=0,lte=100,dive,required" json:"user_ids"`
+	// role id
+	RoleID int `validate:"required" json:"role_id"`
+	// login user id
+	LoginUserID string `json:"-"`
+}
+
 // EditUserProfileReq edit user profile request
 type EditUserProfileReq struct {
 	UserID      string `validate:"required" json:"user_id"`
@@ -129,8 +147,9 @@ type AddUserReq struct {
 // AddUsersReq add users request
 type AddUsersReq struct {
 	// users info line by line
-	UsersStr string        `json:"users"`
-	Users    []*AddUserReq `json:"-"`
+	UsersStr    string        `json:"users"`
+	Users       []*AddUserReq `json:"-"`
+	LoginUserID string        `json:"-"`
 }
 
 type AddUsersErrorData struct {
@@ -66,6 +66,8 @@ type UpdateBadgeStatusReq struct {
 	ID string `validate:"required" json:"id"`
 	// badge status
 	Status BadgeStatus `validate:"required" json:"status"`
+	// login user id
+	UserID string `json:"-"`
 }
 
 type GetBadgeListPagedReq struct {
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// BountyMinAmount the min reputation amount of a bounty
+	BountyMinAmount = 50
+	// BountyMaxAmount the max reputation amount of a bounty
+	BountyMaxAmount = 500
+	// BountyDurationDays how long a bounty stays active before it expires
+	BountyDurationDays = 7
+	// BountyAutoAwardMinVotes the min votes an answer needs to be awarded automatically when the bounty expires
+	BountyAutoAwardMinVotes = 2
+)
+
+// AddBountyReq add bounty request
+type AddBountyReq struct {
+	// question id
+	QuestionID string `validate:"required" json:"question_id"`
+	// reputation amount
+	Amount int    `validate:"required,min=50,max=500" json:"amount"`
+	UserID string `json:"-"`
+}
+
+// AwardBountyReq award bounty request
+type AwardBountyReq struct {
+	// question id
+	QuestionID string `validate:"required" json:"question_id"`
+	// answer id
+	AnswerID string `validate:"required" json:"answer_id"`
+	UserID   string `json:"-"`
+}
+
+// GetBountyReq get bounty request
+type GetBountyReq struct {
+	// question id
+	QuestionID string `validate:"required" form:"question_id"`
+}
+
+// GetBountyResp get bounty response
+type GetBountyResp struct {
+	// question id
+	QuestionID string `json:"question_id"`
+	// reputation amount
+	Amount int `json:"amount"`
+	// expire time
+	ExpiresAt int64 `json:"expires_at"`
+	// created time
+	CreatedAt int64 `json:"created_at"`
+	// the user who offered the bounty
+	UserInfo *UserBasicInfo `json:"user_info"`
+}
@@ -132,12 +132,38 @@ type GetCommentWithPageReq struct {
 	CommentID string `validate:"omitempty" form:"comment_id"`
 	// query condition
 	QueryCond string `validate:"omitempty,oneof=vote created_at" form:"query_cond"`
+	// response mode, flat: all comments in one list, tree: the replies are nested in the children of the comment
+	Mode string `validate:"omitempty,oneof=flat tree" form:"mode"`
+	// the max depth of the comment tree, the deeper replies are attached to the comment at the max depth
+	MaxDepth int `validate:"omitempty,min=1,max=10" form:"max_depth"`
 	// user id
 	UserID string `json:"-"`
 	// whether user can edit it
 	CanEdit bool `json:"-"`
 	// whether user can delete it
 	CanDelete bool `json:"-"`
+	// whether user can convert it into an answer
+	CanConvert bool `json:"-"`
+}
+
+const (
+	CommentListModeFlat = "flat"
+	CommentListModeTree = "tree"
+
+	CommentTreeDefaultMaxDepth = 3
+)
+
+// IsTreeMode whether the comments are returned as trees
+func (req *GetCommentWithPageReq) IsTreeMode() bool {
+	return req.Mode == CommentListModeTree
+}
+
+// GetMaxDepth get the max depth of the comment tree
+func (req *GetCommentWithPageReq) GetMaxDepth() int {
+	if req.MaxDepth <= 0 {
+		return CommentTreeDefaultMaxDepth
+	}
+	return req.MaxDepth
 }
 
 // GetCommentReq get comment list page request
@@ -194,6 +220,9 @@ type GetCommentResp struct {
 
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
+
+	// the replies of the comment, only in tree mode
+	Children []*GetCommentResp `json:"children,omitempty"`
 }
 
 func (r *GetCommentResp) SetFromComment(comment *entity.Comment) {
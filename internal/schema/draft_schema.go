@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// DraftMaxAmount the max amount of drafts per user
+	DraftMaxAmount = 50
+	// DraftExpiredDays the drafts not updated for these days will be cleared
+	DraftExpiredDays = 30
+)
+
+// SaveDraftReq autosave draft request
+type SaveDraftReq struct {
+	// draft id, empty means creating a new draft
+	ID int `json:"id"`
+	// object type, question or answer
+	ObjectType string `validate:"required,oneof=question answer" json:"object_type"`
+	// the question id which the answer draft belongs to
+	QuestionID string `validate:"omitempty" json:"question_id"`
+	// title
+	Title string `validate:"omitempty,lte=150" json:"title"`
+	// content
+	Content string `validate:"omitempty,lte=65535" json:"content"`
+	// tags
+	Tags []*TagItem `validate:"omitempty,lte=5,dive" json:"tags"`
+	// the answer content written together with the question
+	AnswerContent string `validate:"omitempty,lte=65535" json:"answer_content"`
+	UserID        string `json:"-"`
+}
+
+// SaveDraftResp autosave draft response
+type SaveDraftResp struct {
+	// draft id
+	ID int `json:"id"`
+	// updated time
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// GetDraftListReq get draft list request
+type GetDraftListReq struct {
+	// object type, question or answer, empty means all
+	ObjectType string `validate:"omitempty,oneof=question answer" form:"object_type"`
+	UserID     string `json:"-"`
+}
+
+// GetDraftReq get draft request
+type GetDraftReq struct {
+	// draft id
+	ID int `validate:"omitempty" form:"id"`
+	// get the answer draft of the question when id is empty
+	QuestionID string `validate:"omitempty" form:"question_id"`
+	UserID     string `json:"-"`
+}
+
+// DraftInfo draft info
+type DraftInfo struct {
+	// draft id
+	ID int `json:"id"`
+	// object type, question or answer
+	ObjectType string `json:"object_type"`
+	// the question id which the answer draft belongs to
+	QuestionID string `json:"question_id"`
+	// title
+	Title string `json:"title"`
+	// content
+	Content string `json:"content"`
+	// tags
+	Tags []*TagItem `json:"tags"`
+	// the answer content written together with the question
+	AnswerContent string `json:"answer_content"`
+	// created time
+	CreatedAt int64 `json:"created_at"`
+	// updated time
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// RemoveDraftReq remove draft request
+type RemoveDraftReq struct {
+	// draft id
+	ID     int    `validate:"required" json:"id"`
+	UserID string `json:"-"`
+}
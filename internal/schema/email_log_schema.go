@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package schema
+
+import "github.com/apache/incubator-answer/internal/entity"
+
+var (
+	// EmailLogStatus email log status mapping, the key is used in the api
+	EmailLogStatus = map[string]int{
+		"pending": entity.EmailLogStatusPending,
+		"sending": entity.EmailLogStatusSending,
+		"sent":    entity.EmailLogStatusSent,
+		"failed":  entity.EmailLogStatusFailed,
+	}
+)
+
+// GetEmailLogPageReq get email log page request
+type GetEmailLogPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// status, empty means all
+	Status string `validate:"omitempty,oneof=pending sending sent failed" form:"status"`
+}
+
+// GetEmailLogResp email log info
+type GetEmailLogResp struct {
+	// email log id
+	ID int64 `json:"id"`
+	// recipient
+	ToEmail string `json:"to_email"`
+	// subject
+	Subject string `json:"subject"`
+	// the transport used by the last attempt, smtp, spool or the slug name of email sender plugin
+	Transport string `json:"transport"`
+	// status: pending, sending, sent, failed
+	Status string `json:"status"`
+	// attempts
+	Attempts int `json:"attempts"`
+	// error of the last attempt
+	LastError string `json:"last_error"`
+	// created time
+	CreatedAt int64 `json:"created_at"`
+	// updated time
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// RetryEmailLogReq retry failed email request
+type RetryEmailLogReq struct {
+	// email log id
+	ID int64 `validate:"required" json:"id"`
+}
@@ -21,6 +21,8 @@ package schema
 
 import (
 	"encoding/json"
+	"time"
+
 	"github.com/apache/incubator-answer/internal/base/constant"
 )
 
@@ -142,3 +144,82 @@ type NewQuestionTemplateData struct {
 	Tags           string
 	UnsubscribeUrl string
 }
+
+type SavedSearchDigestTemplateRawData struct {
+	SearchName string
+	Query      string
+	Questions  []*SavedSearchDigestQuestion
+}
+
+type SavedSearchDigestQuestion struct {
+	QuestionID    string
+	QuestionTitle string
+}
+
+type SavedSearchDigestTemplateData struct {
+	SiteName     string
+	SearchName   string
+	SearchUrl    string
+	Count        int
+	QuestionList string
+}
+
+type DigestTemplateRawData struct {
+	Notifications   []*DigestNotification
+	Questions       []*DigestQuestion
+	UnsubscribeCode string
+}
+
+type DigestNotification struct {
+	DisplayName   string
+	Action        string
+	QuestionID    string
+	QuestionTitle string
+	AnswerID      string
+}
+
+type DigestQuestion struct {
+	QuestionID    string
+	QuestionTitle string
+}
+
+type DigestTemplateData struct {
+	SiteName          string
+	NotificationCount int
+	NotificationList  string
+	QuestionList      string
+	SettingsUrl       string
+	UnsubscribeUrl    string
+}
+
+type UserSuspendedTemplateRawData struct {
+	DisplayName       string
+	ReasonName        string
+	ReasonDescription string
+	Message           string
+	SuspendedUntil    time.Time
+	Forever           bool
+}
+
+type UserSuspendedTemplateData struct {
+	SiteName          string
+	SiteUrl           string
+	DisplayName       string
+	Duration          string
+	ReasonName        string
+	ReasonDescription string
+	Message           string
+}
+
+type ModeratorMessageTemplateRawData struct {
+	DisplayName string
+	Content     string
+}
+
+type ModeratorMessageTemplateData struct {
+	SiteName    string
+	SiteUrl     string
+	DisplayName string
+	Content     string
+	MessageUrl  string
+}
@@ -26,21 +26,21 @@ import (
 
 // EventMsg event message
 type EventMsg struct {
-	EventType constant.EventType
-	UserID    string
+	EventType constant.EventType `json:"event_type"`
+	UserID    string             `json:"user_id"`
 
-	TriggerObjectID string
+	TriggerObjectID string `json:"trigger_object_id"`
 
-	QuestionID     string
-	QuestionUserID string
+	QuestionID     string `json:"question_id"`
+	QuestionUserID string `json:"question_user_id"`
 
-	AnswerID     string
-	AnswerUserID string
+	AnswerID     string `json:"answer_id"`
+	AnswerUserID string `json:"answer_user_id"`
 
-	CommentID     string
-	CommentUserID string
+	CommentID     string `json:"comment_id"`
+	CommentUserID string `json:"comment_user_id"`
 
-	ExtraInfo map[string]string
+	ExtraInfo map[string]string `json:"extra_info"`
 }
 
 // NewEvent create a new event
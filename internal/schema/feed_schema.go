@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "time"
+
+const (
+	FeedFormatAtom = "atom"
+	FeedFormatRSS  = "rss"
+	// FeedMaxSize max number of entries in a feed
+	FeedMaxSize = 30
+)
+
+// FeedReq feed request
+type FeedReq struct {
+	// feed format, atom or rss, default is atom
+	Format string `validate:"omitempty,oneof=atom rss" form:"format"`
+	// question order, only used by question list feeds
+	OrderCond string `validate:"omitempty,oneof=newest active" form:"order"`
+}
+
+// FeedInfo feed information
+type FeedInfo struct {
+	// unique id of the feed, use the self link
+	ID          string
+	Title       string
+	Description string
+	Author      string
+	// Link the html page of the feed
+	Link string
+	// SelfLink the feed itself
+	SelfLink string
+	Updated  time.Time
+	Items    []*FeedItem
+}
+
+// FeedItem feed entry
+type FeedItem struct {
+	ID         string
+	Title      string
+	Link       string
+	Author     string
+	Summary    string
+	Categories []string
+	Published  time.Time
+	Updated    time.Time
+}
@@ -20,13 +20,14 @@
 package schema
 
 const (
-	ForbiddenReasonTypeInactive      = "inactive"
-	ForbiddenReasonTypeURLExpired    = "url_expired"
-	ForbiddenReasonTypeUserSuspended = "suspended"
+	ForbiddenReasonTypeInactive         = "inactive"
+	ForbiddenReasonTypeURLExpired       = "url_expired"
+	ForbiddenReasonTypeUserSuspended    = "suspended"
+	ForbiddenReasonTypeModeratorMessage = "moderator_message"
 )
 
 // ForbiddenResp forbidden response
 type ForbiddenResp struct {
 	// forbidden reason type
-	Type string `json:"type" enums:"inactive,url_expired"`
+	Type string `json:"type" enums:"inactive,url_expired,moderator_message"`
 }
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	ModeratorMessageStatusUnacknowledged = "unacknowledged"
+	ModeratorMessageStatusAcknowledged   = "acknowledged"
+)
+
+// AddModeratorMessageReq send a private moderator message to the user request
+type AddModeratorMessageReq struct {
+	// the user who receives the message
+	UserID string `validate:"required" json:"user_id"`
+	// the post which the message is about, optional
+	ObjectID string `validate:"omitempty" json:"object_id"`
+	// the message template key, optional
+	TemplateKey string `validate:"omitempty,lte=100" json:"template_key"`
+	// message content
+	Content     string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	LoginUserID string `json:"-"`
+}
+
+// AddModeratorMessageResp send a private moderator message response
+type AddModeratorMessageResp struct {
+	ID int `json:"id"`
+}
+
+// GetModeratorMessageListReq get the moderator messages of the user request, for the staff
+type GetModeratorMessageListReq struct {
+	UserID string `validate:"required" form:"user_id"`
+}
+
+// GetUserModeratorMessageListReq get the moderator messages received by the login user request
+type GetUserModeratorMessageListReq struct {
+	// only show the messages which are not acknowledged
+	Unacknowledged bool   `validate:"omitempty" form:"unacknowledged"`
+	UserID         string `json:"-"`
+}
+
+// AcknowledgeModeratorMessageReq acknowledge the moderator message request
+type AcknowledgeModeratorMessageReq struct {
+	ID     int    `validate:"required" json:"id"`
+	UserID string `json:"-"`
+}
+
+// ModeratorMessageResp moderator message response
+type ModeratorMessageResp struct {
+	ID int `json:"id"`
+	// the admin or moderator who sent the message, only shown to the staff
+	Operator *UserBasicInfo `json:"operator,omitempty"`
+	// message template key
+	TemplateKey string `json:"template_key"`
+	// message template name
+	TemplateName string `json:"template_name"`
+	// message content
+	Content string `json:"content"`
+	// the post which the message is about
+	ObjectID    string `json:"object_id"`
+	ObjectType  string `json:"object_type"`
+	ObjectTitle string `json:"object_title"`
+	QuestionID  string `json:"question_id"`
+	// message status(unacknowledged,acknowledged)
+	Status         string `json:"status"`
+	CreatedAt      int64  `json:"created_at"`
+	AcknowledgedAt int64  `json:"acknowledged_at"`
+}
@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// ConvertCommentToAnswerReq convert the comment into an answer of the question request
+type ConvertCommentToAnswerReq struct {
+	// comment id
+	CommentID string `validate:"required" json:"comment_id"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// ConvertCommentToAnswerResp convert the comment into an answer of the question response
+type ConvertCommentToAnswerResp struct {
+	QuestionID string `json:"question_id"`
+	AnswerID   string `json:"answer_id"`
+}
+
+// ConvertAnswerToCommentReq convert the answer into a comment on the question request
+type ConvertAnswerToCommentReq struct {
+	// answer id
+	AnswerID string `validate:"required" json:"answer_id"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// ConvertAnswerToCommentResp convert the answer into a comment on the question response
+type ConvertAnswerToCommentResp struct {
+	QuestionID string `json:"question_id"`
+	CommentID  string `json:"comment_id"`
+}
+
+// CommentMaxLength the max length of the comment content, the answer longer than it can not be converted
+const CommentMaxLength = 600
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/incubator-answer/internal/entity"
+
+const (
+	CloseVoteTypeClose  = "close"
+	CloseVoteTypeReopen = "reopen"
+)
+
+var CloseVoteTypeMapping = map[string]int{
+	CloseVoteTypeClose:  entity.QuestionCloseVoteTypeClose,
+	CloseVoteTypeReopen: entity.QuestionCloseVoteTypeReopen,
+}
+
+// QuestionCloseVoteResp the result of voting to close or reopen the question
+type QuestionCloseVoteResp struct {
+	// whether the question is closed or reopened
+	Done bool `json:"done"`
+	// the number of the valid votes, only used when the vote is not binding
+	VoteCount int `json:"vote_count"`
+	// the number of the votes required
+	Threshold int `json:"threshold"`
+}
+
+// GetCloseVoteQueuePageReq get the questions with the pending votes request
+type GetCloseVoteQueuePageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1" form:"page_size"`
+	VoteType string `validate:"required,oneof=close reopen" form:"vote_type"`
+	UserID   string `json:"-"`
+}
+
+// GetCloseVoteQueueResp the question with the pending votes
+type GetCloseVoteQueueResp struct {
+	QuestionID string `json:"question_id"`
+	Title      string `json:"title"`
+	UrlTitle   string `json:"url_title"`
+	VoteType   string `json:"vote_type"`
+	VoteCount  int    `json:"vote_count"`
+	Threshold  int    `json:"threshold"`
+	// whether the current user has voted
+	Voted bool             `json:"voted"`
+	Votes []*CloseVoteInfo `json:"votes"`
+}
+
+// CloseVoteInfo the vote to close or reopen the question
+type CloseVoteInfo struct {
+	User *UserBasicInfo `json:"user"`
+	// the close reason, only used when voting to close
+	Reason    *ReasonItem `json:"reason,omitempty"`
+	CloseMsg  string      `json:"close_msg"`
+	CreatedAt int64       `json:"created_at"`
+}
+
+// DismissCloseVotesReq dismiss the pending votes of the question request
+type DismissCloseVotesReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	VoteType   string `validate:"required,oneof=close reopen" json:"vote_type"`
+	UserID     string `json:"-"`
+}
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// QuestionRelationDuplicateOf this question is closed as duplicate of the linked question
+	QuestionRelationDuplicateOf = "duplicate_of"
+	// QuestionRelationDuplicatedBy the linked question is closed as duplicate of this question
+	QuestionRelationDuplicatedBy = "duplicated_by"
+	// QuestionRelationMergedInto this question is merged into the linked question
+	QuestionRelationMergedInto = "merged_into"
+	// QuestionRelationMergedFrom the linked question is merged into this question
+	QuestionRelationMergedFrom = "merged_from"
+)
+
+// QuestionLinkInfo the question linked with another question
+type QuestionLinkInfo struct {
+	// question id
+	ID string `json:"id"`
+	// question title
+	Title string `json:"title"`
+	// url title
+	UrlTitle string `json:"url_title"`
+	// question status
+	Status int `json:"status"`
+	// answer count
+	AnswerCount int `json:"answer_count"`
+	// accepted answer id
+	AcceptedAnswerID string `json:"accepted_answer_id"`
+	// relation: duplicate_of, duplicated_by, merged_into, merged_from
+	Relation string `json:"relation"`
+}
+
+// GetQuestionLinksReq get question links request
+type GetQuestionLinksReq struct {
+	// question id
+	QuestionID string `validate:"required" form:"question_id"`
+}
+
+// MergeQuestionReq merge question request
+type MergeQuestionReq struct {
+	// the question which will be merged and closed
+	SourceID string `validate:"required" json:"source_id"`
+	// the question which receives the answers, comments, votes, followers and collections
+	TargetID string `validate:"required" json:"target_id"`
+	UserID   string `json:"-"`
+}
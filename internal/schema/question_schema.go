@@ -30,10 +30,12 @@ import (
 )
 
 const (
-	QuestionOperationPin   = "pin"
-	QuestionOperationUnPin = "unpin"
-	QuestionOperationHide  = "hide"
-	QuestionOperationShow  = "show"
+	QuestionOperationPin    = "pin"
+	QuestionOperationUnPin  = "unpin"
+	QuestionOperationHide   = "hide"
+	QuestionOperationShow   = "show"
+	QuestionOperationWiki   = "wiki"
+	QuestionOperationUnWiki = "unwiki"
 )
 
 // RemoveQuestionReq delete question request
@@ -50,26 +52,34 @@ type CloseQuestionReq struct {
 	ID        string `validate:"required" json:"id"`
 	CloseType int    `json:"close_type"` // close_type
 	CloseMsg  string `json:"close_msg"`  // close_type
-	UserID    string `json:"-"`          // user_id
+	// the questions which this question is duplicate of, only used when closed as duplicate
+	DuplicateIDs []string `validate:"omitempty,max=5,dive,required" json:"duplicate_ids"`
+	UserID       string   `json:"-"` // user_id
+	// the vote of the moderator closes the question directly even if the close voting is enabled
+	BindingVote bool `json:"-"`
 }
 
 type OperationQuestionReq struct {
 	ID        string `validate:"required" json:"id"`
-	Operation string `json:"operation"` // operation [pin unpin hide show]
+	Operation string `json:"operation"` // operation [pin unpin hide show wiki unwiki]
 	UserID    string `json:"-"`         // user_id
 	CanPin    bool   `json:"-"`
 	CanList   bool   `json:"-"`
+	CanWiki   bool   `json:"-"`
 }
 
 type CloseQuestionMeta struct {
-	CloseType int    `json:"close_type"`
-	CloseMsg  string `json:"close_msg"`
+	CloseType    int      `json:"close_type"`
+	CloseMsg     string   `json:"close_msg"`
+	DuplicateIDs []string `json:"duplicate_ids,omitempty"`
 }
 
 // ReopenQuestionReq reopen question request
 type ReopenQuestionReq struct {
 	QuestionID string `json:"question_id"`
 	UserID     string `json:"-"`
+	// the vote of the moderator reopens the question directly even if the close voting is enabled
+	BindingVote bool `json:"-"`
 }
 
 type QuestionAdd struct {
@@ -86,8 +96,10 @@ type QuestionAdd struct {
 	QuestionPermission
 	CaptchaID   string `json:"captcha_id"` // captcha_id
 	CaptchaCode string `json:"captcha_code"`
-	IP          string `json:"-"`
-	UserAgent   string `json:"-"`
+	// the draft which is published as this question, it will be removed after published
+	DraftID   int    `json:"draft_id"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 func (req *QuestionAdd) Check() (errFields []*validator.FormErrorField, err error) {
@@ -117,8 +129,10 @@ type QuestionAddByAnswer struct {
 	QuestionPermission
 	CaptchaID   string `json:"captcha_id"` // captcha_id
 	CaptchaCode string `json:"captcha_code"`
-	IP          string `json:"-"`
-	UserAgent   string `json:"-"`
+	// the draft which is published as this question, it will be removed after published
+	DraftID   int    `json:"draft_id"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 func (req *QuestionAddByAnswer) Check() (errFields []*validator.FormErrorField, err error) {
@@ -155,6 +169,10 @@ type QuestionPermission struct {
 	CanInviteOtherToAnswer bool `json:"-"`
 	CanAddTag              bool `json:"-"`
 	CanRecover             bool `json:"-"`
+	// whether user can make it community wiki
+	CanWiki bool `json:"-"`
+	// whether user can edit it when it is community wiki
+	CanWikiEdit bool `json:"-"`
 }
 
 type CheckCanQuestionUpdate struct {
@@ -179,6 +197,8 @@ type QuestionUpdate struct {
 	Tags []*TagItem `validate:"required,dive" json:"tags"`
 	// edit summary
 	EditSummary string `validate:"omitempty" json:"edit_summary"`
+	// the revision id which the edit is based on, the edit will be rejected if it is stale
+	RevisionID string `validate:"omitempty" json:"revision_id"`
 	// user id
 	UserID       string `json:"-"`
 	NoNeedReview bool   `json:"-"`
@@ -220,6 +240,7 @@ type QuestionBaseInfo struct {
 
 type QuestionInfoResp struct {
 	ID                   string         `json:"id" `
+	RevisionID           string         `json:"revision_id"`
 	Title                string         `json:"title"`
 	UrlTitle             string         `json:"url_title"`
 	Content              string         `json:"content"`
@@ -241,6 +262,7 @@ type QuestionInfoResp struct {
 	Pin                  int            `json:"pin"`
 	Show                 int            `json:"show"`
 	Status               int            `json:"status"`
+	Wiki                 bool           `json:"wiki"`
 	Operation            *Operation     `json:"operation,omitempty"`
 	UserID               string         `json:"-"`
 	LastEditUserID       string         `json:"-"`
@@ -253,6 +275,8 @@ type QuestionInfoResp struct {
 	Collected            bool           `json:"collected"`
 	VoteStatus           string         `json:"vote_status"`
 	IsFollowed           bool           `json:"is_followed"`
+	// the duplicate and merged relations of this question
+	LinkedQuestions []*QuestionLinkInfo `json:"linked_questions"`
 
 	// MemberActions
 	MemberActions  []*PermissionMemberAction `json:"member_actions"`
@@ -346,6 +370,7 @@ const (
 	QuestionOrderCondHot        = "hot"
 	QuestionOrderCondScore      = "score"
 	QuestionOrderCondUnanswered = "unanswered"
+	QuestionOrderCondBounty     = "bounty"
 
 	// HotInDays limit max days of the hottest question
 	HotInDays = 90
@@ -355,7 +380,7 @@ const (
 type QuestionPageReq struct {
 	Page      int    `validate:"omitempty,min=1" form:"page"`
 	PageSize  int    `validate:"omitempty,min=1" form:"page_size"`
-	OrderCond string `validate:"omitempty,oneof=newest active hot score unanswered recommend" form:"order"`
+	OrderCond string `validate:"omitempty,oneof=newest active hot score unanswered recommend bounty" form:"order"`
 	Tag       string `validate:"omitempty,gt=0,lte=100" form:"tag"`
 	Username  string `validate:"omitempty,gt=0,lte=100" form:"username"`
 	InDays    int    `validate:"omitempty,min=1" form:"in_days"`
@@ -390,6 +415,7 @@ type QuestionPageResp struct {
 	AnswerCount     int `json:"answer_count"`
 	CollectionCount int `json:"collection_count"`
 	FollowCount     int `json:"follow_count"`
+	BountyAmount    int `json:"bounty_amount"`
 
 	// answer information
 	AcceptedAnswerID   string    `json:"accepted_answer_id"`
@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/incubator-answer/internal/entity"
+
+const (
+	ReviewTaskQueueFirstPost  = "first_post"
+	ReviewTaskQueueLateAnswer = "late_answer"
+	ReviewTaskQueueLowQuality = "low_quality"
+)
+
+var ReviewTaskQueueMapping = map[string]int{
+	ReviewTaskQueueFirstPost:  entity.ReviewTaskQueueFirstPost,
+	ReviewTaskQueueLateAnswer: entity.ReviewTaskQueueLateAnswer,
+	ReviewTaskQueueLowQuality: entity.ReviewTaskQueueLowQuality,
+}
+
+var ReviewTaskQueueNameMapping = map[int]string{
+	entity.ReviewTaskQueueFirstPost:  ReviewTaskQueueFirstPost,
+	entity.ReviewTaskQueueLateAnswer: ReviewTaskQueueLateAnswer,
+	entity.ReviewTaskQueueLowQuality: ReviewTaskQueueLowQuality,
+}
+
+// the heuristics used to score the low quality posts
+const (
+	LowQualityReasonTooShort  = "too_short"
+	LowQualityReasonLinkHeavy = "link_heavy"
+	LowQualityReasonCodeOnly  = "code_only"
+)
+
+// GetReviewTaskPageReq get the pending review tasks of the queue request
+type GetReviewTaskPageReq struct {
+	Queue    string `validate:"required,oneof=first_post late_answer low_quality" form:"queue"`
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1" form:"page_size"`
+	UserID   string `json:"-"`
+}
+
+// GetReviewTaskPageResp get the pending review tasks of the queue response
+type GetReviewTaskPageResp struct {
+	ReviewTaskID   int           `json:"review_task_id"`
+	Queue          string        `json:"queue"`
+	ObjectID       string        `json:"object_id"`
+	QuestionID     string        `json:"question_id"`
+	AnswerID       string        `json:"answer_id"`
+	ObjectType     string        `json:"object_type" enums:"question,answer"`
+	Title          string        `json:"title"`
+	UrlTitle       string        `json:"url_title"`
+	OriginalText   string        `json:"original_text"`
+	ParsedText     string        `json:"parsed_text"`
+	Tags           []*TagResp    `json:"tags"`
+	AuthorUserInfo UserBasicInfo `json:"author_user_info"`
+	// the time when the post is created
+	CreatedAt int64 `json:"created_at"`
+	// the time when the post is added into the queue
+	SubmitAt int64 `json:"submit_at"`
+	// the matched low quality heuristics, only used in the low quality queue
+	Reasons []string `json:"reasons"`
+}
+
+// UpdateReviewTaskReq complete the review task request, the outcome is one of the review reasons of the post,
+// the reviewer edits, closes or deletes the post by the normal operations, the outcome is only recorded.
+type UpdateReviewTaskReq struct {
+	ReviewTaskID int    `validate:"required" json:"review_task_id"`
+	Queue        string `validate:"required,oneof=first_post late_answer low_quality" json:"queue"`
+	Outcome      string `validate:"required,oneof=reason.looks_ok reason.needs_edit reason.needs_close reason.needs_delete" json:"outcome"`
+	UserID       string `json:"-"`
+}
+
+// GetReviewTaskStatsReq get the review queues statistics request
+type GetReviewTaskStatsReq struct {
+	// unix timestamp, the statistics of the whole history are returned if the time range is empty
+	StartTime int64 `validate:"omitempty,min=0" form:"start_time"`
+	EndTime   int64 `validate:"omitempty,min=0" form:"end_time"`
+}
+
+// GetReviewTaskStatsResp get the review queues statistics response
+type GetReviewTaskStatsResp struct {
+	Queues    []*ReviewTaskQueueStat    `json:"queues"`
+	Reviewers []*ReviewTaskReviewerStat `json:"reviewers"`
+}
+
+// ReviewTaskQueueStat the statistics of the tasks added into the queue in the time range
+type ReviewTaskQueueStat struct {
+	Queue        string `json:"queue"`
+	PendingCount int64  `json:"pending_count"`
+	InvalidCount int64  `json:"invalid_count"`
+	// the number of the completed tasks by the outcome
+	Outcomes map[string]int64 `json:"outcomes"`
+}
+
+// ReviewTaskReviewerStat the number of the tasks completed by the reviewer in the time range
+type ReviewTaskReviewerStat struct {
+	User        *UserBasicInfo `json:"user"`
+	ReviewCount int64          `json:"review_count"`
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/pkg/diff"
 )
 
 // AddRevisionDTO add revision request
@@ -105,13 +106,126 @@ type GetRevisionResp struct {
 	Log             string        `json:"reason"`
 }
 
+// GetRevisionDiffReq get the diff between two revisions request
+type GetRevisionDiffReq struct {
+	// the old revision id
+	SourceID string `validate:"required" form:"source_id"`
+	// the new revision id
+	TargetID string `validate:"required" form:"target_id"`
+	// the unreviewed and rejected revisions can only be compared by reviewers
+	CanReview bool `json:"-"`
+	// the deleted and pending posts can be viewed by the author and the user who can reopen them
+	CanViewDeleted bool   `json:"-"`
+	UserID         string `json:"-"`
+}
+
+// GetRevisionDiffResp the diff between two revisions of a question, answer or tag
+type GetRevisionDiffResp struct {
+	ObjectID   string `json:"object_id"`
+	ObjectType string `json:"object_type"`
+	SourceID   string `json:"source_id"`
+	TargetID   string `json:"target_id"`
+	// question title or tag display name
+	Title *RevisionTextDiff `json:"title"`
+	// tag slug name, only for tag
+	SlugName *RevisionTextDiff `json:"slug_name,omitempty"`
+	// question tags, only for question
+	Tags *RevisionTagsDiff `json:"tags,omitempty"`
+	// the original text of the body
+	Content *RevisionTextDiff `json:"content"`
+}
+
+// RevisionTextDiff the diff of a text field
+type RevisionTextDiff struct {
+	Changed bool `json:"changed"`
+	// word level diff operations
+	WordOps []*diff.Op `json:"word_ops"`
+	// line level diff operations
+	LineOps []*diff.Op `json:"line_ops"`
+	// word level diff rendered as html, inserted and deleted words are wrapped by ins and del
+	WordHTML string `json:"word_html"`
+	// line level diff rendered as html, inserted and deleted lines are wrapped by ins and del
+	LineHTML string `json:"line_html"`
+}
+
+// NewRevisionTextDiff compare the text of source and target revision
+func NewRevisionTextDiff(source, target string) *RevisionTextDiff {
+	d := &RevisionTextDiff{
+		Changed: source != target,
+		WordOps: diff.Words(source, target),
+		LineOps: diff.Lines(source, target),
+	}
+	d.WordHTML = diff.HTML(d.WordOps, "")
+	d.LineHTML = diff.HTML(d.LineOps, "\n")
+	return d
+}
+
+// RevisionTagsDiff the diff of question tags, the slug names are compared
+type RevisionTagsDiff struct {
+	Changed bool     `json:"changed"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Kept    []string `json:"kept"`
+}
+
+// NewRevisionTagsDiff compare the tags of source and target revision
+func NewRevisionTagsDiff(source, target []string) *RevisionTagsDiff {
+	d := &RevisionTagsDiff{
+		Added:   make([]string, 0),
+		Removed: make([]string, 0),
+		Kept:    make([]string, 0),
+	}
+	sourceMapping := make(map[string]bool, len(source))
+	for _, tag := range source {
+		sourceMapping[tag] = true
+	}
+	targetMapping := make(map[string]bool, len(target))
+	for _, tag := range target {
+		targetMapping[tag] = true
+		if sourceMapping[tag] {
+			d.Kept = append(d.Kept, tag)
+		} else {
+			d.Added = append(d.Added, tag)
+		}
+	}
+	for _, tag := range source {
+		if !targetMapping[tag] {
+			d.Removed = append(d.Removed, tag)
+		}
+	}
+	d.Changed = len(d.Added) > 0 || len(d.Removed) > 0
+	return d
+}
+
+// GetContributorsReq get the contributors of the post request
+type GetContributorsReq struct {
+	// object id
+	ObjectID string `validate:"required" form:"object_id"`
+	// the deleted and pending posts can be viewed by the author and the user who can reopen them
+	CanViewDeleted bool   `json:"-"`
+	UserID         string `json:"-"`
+}
+
+// GetContributorsResp the contributor aggregated from the revision history
+type GetContributorsResp struct {
+	UserInfo *UserBasicInfo `json:"user_info"`
+	// the number of the revisions created by the user
+	RevisionCount int `json:"revision_count"`
+	// the time of the last revision created by the user
+	LastEditTime int64 `json:"last_edit_time"`
+}
+
 // GetReviewingTypeReq get reviewing type request
 type GetReviewingTypeReq struct {
-	CanReviewQuestion bool   `json:"-"`
-	CanReviewAnswer   bool   `json:"-"`
-	CanReviewTag      bool   `json:"-"`
-	IsAdmin           bool   `json:"-"`
-	UserID            string `json:"-"`
+	CanReviewQuestion bool `json:"-"`
+	CanReviewAnswer   bool `json:"-"`
+	CanReviewTag      bool `json:"-"`
+	IsAdmin           bool `json:"-"`
+	CanCloseQuestion  bool `json:"-"`
+	CanReopenQuestion bool `json:"-"`
+	// the review task queues which the user can review
+	ReviewTaskQueues []string `json:"-"`
+	UserID           string   `json:"-"`
 }
 
 func (r *GetReviewingTypeReq) GetCanReviewObjectTypes() []int {
@@ -134,3 +248,37 @@ type GetReviewingTypeResp struct {
 	Label      string `json:"label"`
 	TodoAmount int64  `json:"todo_amount"`
 }
+
+// EditConflictCheck check whether the edit is based on the latest revision of the object
+type EditConflictCheck struct {
+	// object id
+	ObjectID string
+	// the revision which the edit is based on, empty means no need to check
+	BaseRevisionID string
+	// the latest revision of the object
+	LatestRevisionID string
+	// the submitted title and content
+	Title   string
+	Content string
+	// the latest title and content of the object
+	LatestTitle   string
+	LatestContent string
+}
+
+// RevisionConflictResp the edit is based on a stale revision
+type RevisionConflictResp struct {
+	// the latest revision id, the merged result should be submitted with it
+	LatestRevisionID string `json:"latest_revision_id"`
+	// the latest title
+	LatestTitle string `json:"latest_title"`
+	// the latest content
+	LatestContent string `json:"latest_content"`
+	// the diff from the latest content to the submitted content
+	Diff []*diff.Op `json:"diff"`
+	// the three-way merge result of the title
+	MergedTitle string `json:"merged_title"`
+	// the three-way merge result of the content
+	MergedContent string `json:"merged_content"`
+	// the merged result contains conflict markers which need to be resolved manually
+	MergeConflict bool `json:"merge_conflict"`
+}
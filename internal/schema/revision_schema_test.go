@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRevisionTagsDiff(t *testing.T) {
+	d := NewRevisionTagsDiff([]string{"go", "mysql"}, []string{"go", "postgresql"})
+	assert.True(t, d.Changed)
+	assert.Equal(t, []string{"postgresql"}, d.Added)
+	assert.Equal(t, []string{"mysql"}, d.Removed)
+	assert.Equal(t, []string{"go"}, d.Kept)
+
+	d = NewRevisionTagsDiff([]string{"go"}, []string{"go"})
+	assert.False(t, d.Changed)
+}
+
+func TestNewRevisionTextDiff(t *testing.T) {
+	d := NewRevisionTextDiff("How to install", "How to install answer")
+	assert.True(t, d.Changed)
+	assert.Equal(t, "How to install<ins> answer</ins>", d.WordHTML)
+	assert.Equal(t, "<del>How to install\n</del><ins>How to install answer\n</ins>", d.LineHTML)
+}
@@ -24,4 +24,54 @@ type GetRoleResp struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// built-in roles can not be modified or removed
+	BuiltIn bool `json:"built_in"`
+}
+
+// AddRoleReq add role request
+type AddRoleReq struct {
+	Name        string   `validate:"required,notblank,lte=50" json:"name"`
+	Description string   `validate:"omitempty,lte=200" json:"description"`
+	PowerTypes  []string `validate:"omitempty,dive,required" json:"power_types"`
+}
+
+// AddRoleResp add role response
+type AddRoleResp struct {
+	ID int `json:"id"`
+}
+
+// UpdateRoleReq update role request
+type UpdateRoleReq struct {
+	RoleID      int    `validate:"required" json:"role_id"`
+	Name        string `validate:"required,notblank,lte=50" json:"name"`
+	Description string `validate:"omitempty,lte=200" json:"description"`
+}
+
+// RemoveRoleReq remove role request
+type RemoveRoleReq struct {
+	RoleID int `validate:"required" json:"role_id"`
+}
+
+// GetRolePowerListReq get role power list request
+type GetRolePowerListReq struct {
+	RoleID int `validate:"required" form:"role_id"`
+}
+
+// GetRolePowerListResp get role power list response
+type GetRolePowerListResp struct {
+	RoleID     int      `json:"role_id"`
+	PowerTypes []string `json:"power_types"`
+}
+
+// UpdateRolePowerListReq update role power list request
+type UpdateRolePowerListReq struct {
+	RoleID     int      `validate:"required" json:"role_id"`
+	PowerTypes []string `validate:"omitempty,dive,required" json:"power_types"`
+}
+
+// GetPowerResp get power response
+type GetPowerResp struct {
+	Name        string `json:"name"`
+	PowerType   string `json:"power_type"`
+	Description string `json:"description"`
 }
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// SavedSearchMaxAmount the max amount of saved searches per user
+const SavedSearchMaxAmount = 20
+
+// GetSavedSearchListReq get saved search list request
+type GetSavedSearchListReq struct {
+	UserID string `json:"-"`
+}
+
+// SavedSearchInfo saved search info
+type SavedSearchInfo struct {
+	// saved search id
+	ID int `json:"id"`
+	// name
+	Name string `json:"name"`
+	// search query, the same as the query of search page
+	Query string `json:"query"`
+	// send inbox notification when new questions match
+	NotifyInbox bool `json:"notify_inbox"`
+	// send email digest when new questions match
+	NotifyEmail bool `json:"notify_email"`
+	// created time
+	CreatedAt int64 `json:"created_at"`
+}
+
+// AddSavedSearchReq add saved search request
+type AddSavedSearchReq struct {
+	// name
+	Name string `validate:"required,notblank,lte=100" json:"name"`
+	// search query
+	Query string `validate:"required,notblank,lte=60" json:"query"`
+	// send inbox notification when new questions match
+	NotifyInbox bool `json:"notify_inbox"`
+	// send email digest when new questions match
+	NotifyEmail bool   `json:"notify_email"`
+	UserID      string `json:"-"`
+}
+
+// UpdateSavedSearchReq update saved search request
+type UpdateSavedSearchReq struct {
+	// saved search id
+	ID int `validate:"required" json:"id"`
+	// name
+	Name string `validate:"required,notblank,lte=100" json:"name"`
+	// search query
+	Query string `validate:"required,notblank,lte=60" json:"query"`
+	// send inbox notification when new questions match
+	NotifyInbox bool `json:"notify_inbox"`
+	// send email digest when new questions match
+	NotifyEmail bool   `json:"notify_email"`
+	UserID      string `json:"-"`
+}
+
+// RemoveSavedSearchReq remove saved search request
+type RemoveSavedSearchReq struct {
+	// saved search id
+	ID     int    `validate:"required" json:"id"`
+	UserID string `json:"-"`
+}
@@ -48,25 +48,33 @@ func (s *SearchDTO) Check() (errField []*validator.FormErrorField, err error) {
 }
 
 func ReplaceSearchContent(content string) (string, []string) {
-	// Define the regular expressions for key:value pairs and [tag]
+	// Define the regular expressions for key:value pairs, -[tag], -word and [tag]
 	keyValueRegex := regexp.MustCompile(`\w+:\S+`)
+	excludeRegex := regexp.MustCompile(`(?:^|\s)(-(?:\[\w+\]|\w+))`)
 	tagRegex := regexp.MustCompile(`\[\w+\]`)
 	// Define the pattern for characters to replace
 	replaceCharsPattern := regexp.MustCompile(`[+#.<>\-_()*]`)
 
 	// Extract key:value pairs
 	keyValues := keyValueRegex.FindAllString(content, -1)
-	// Extract [tag]
-	tags := tagRegex.FindAllString(content, -1)
-
-	// Replace key:value pairs and [tag] with empty string
 	contentWithoutPatterns := keyValueRegex.ReplaceAllString(content, "")
+
+	// Extract -[tag] and -word
+	excludes := make([]string, 0)
+	for _, match := range excludeRegex.FindAllStringSubmatch(contentWithoutPatterns, -1) {
+		excludes = append(excludes, match[1])
+	}
+	contentWithoutPatterns = excludeRegex.ReplaceAllString(contentWithoutPatterns, " ")
+
+	// Extract [tag]
+	tags := tagRegex.FindAllString(contentWithoutPatterns, -1)
 	contentWithoutPatterns = tagRegex.ReplaceAllString(contentWithoutPatterns, "")
 
 	// Replace characters with pattern [+#.<>_()*] with space
 	replacedContent := replaceCharsPattern.ReplaceAllString(contentWithoutPatterns, " ")
 
-	return strings.TrimSpace(replacedContent), append(keyValues, tags...)
+	patterns := append(keyValues, excludes...)
+	return strings.TrimSpace(replacedContent), append(patterns, tags...)
 }
 
 type SearchCondition struct {
@@ -88,8 +96,30 @@ type SearchCondition struct {
 	QuestionID string
 	// search query tags
 	Tags [][]string
-	// search query keywords
+	// search query keywords, the flattened list of WordGroups
 	Words []string
+	// search query keywords grouped by OR operator, a word contains spaces is an exact phrase
+	WordGroups [][]string
+	// excluded tags
+	ExcludeTags []string
+	// excluded keywords
+	ExcludeWords []string
+	// created time range, unix timestamp, zero means unlimited
+	CreatedFrom int64
+	CreatedTo   int64
+	// active time range, unix timestamp, zero means unlimited
+	ActiveFrom int64
+	ActiveTo   int64
+	// only show closed question
+	Closed bool
+	// only show not closed question
+	NotClosed bool
+	// only show the question that has accepted answer
+	HasAccepted bool
+	// the user id who commented
+	CommenterID string
+	// the user id who collected the question
+	CollectorID string
 }
 
 // SearchAll check if search all
@@ -110,16 +140,25 @@ func (s *SearchCondition) SearchAnswer() bool {
 // Convert2PluginSearchCond convert to plugin search condition
 func (s *SearchCondition) Convert2PluginSearchCond(page, pageSize int, order string) *plugin.SearchBasicCond {
 	basic := &plugin.SearchBasicCond{
-		Page:         page,
-		PageSize:     pageSize,
-		Words:        s.Words,
-		TagIDs:       s.Tags,
-		UserID:       s.UserID,
-		Order:        plugin.SearchOrderCond(order),
-		QuestionID:   s.QuestionID,
-		VoteAmount:   s.VoteAmount,
-		ViewAmount:   s.Views,
-		AnswerAmount: s.AnswerAmount,
+		Page:          page,
+		PageSize:      pageSize,
+		Words:         s.Words,
+		WordGroups:    s.WordGroups,
+		ExcludeWords:  s.ExcludeWords,
+		TagIDs:        s.Tags,
+		ExcludeTagIDs: s.ExcludeTags,
+		UserID:        s.UserID,
+		Order:         plugin.SearchOrderCond(order),
+		QuestionID:    s.QuestionID,
+		VoteAmount:    s.VoteAmount,
+		ViewAmount:    s.Views,
+		AnswerAmount:  s.AnswerAmount,
+		CreatedFrom:   s.CreatedFrom,
+		CreatedTo:     s.CreatedTo,
+		ActiveFrom:    s.ActiveFrom,
+		ActiveTo:      s.ActiveTo,
+		CommenterID:   s.CommenterID,
+		CollectorID:   s.CollectorID,
 	}
 	if s.Accepted {
 		basic.AnswerAccepted = plugin.AcceptedCondTrue
@@ -128,9 +167,18 @@ func (s *SearchCondition) Convert2PluginSearchCond(page, pageSize int, order str
 	}
 	if s.NotAccepted {
 		basic.QuestionAccepted = plugin.AcceptedCondFalse
+	} else if s.HasAccepted {
+		basic.QuestionAccepted = plugin.AcceptedCondTrue
 	} else {
 		basic.QuestionAccepted = plugin.AcceptedCondAll
 	}
+	if s.Closed {
+		basic.QuestionClosed = plugin.ClosedCondTrue
+	} else if s.NotClosed {
+		basic.QuestionClosed = plugin.ClosedCondFalse
+	} else {
+		basic.QuestionClosed = plugin.ClosedCondAll
+	}
 	return basic
 }
 
@@ -150,6 +198,16 @@ type SearchObject struct {
 	Tags []*TagResp `json:"tags"`
 	// Status
 	StatusStr string `json:"status"`
+	// highlight
+	Highlight *SearchHighlight `json:"highlight"`
+}
+
+// SearchHighlight the matched words are wrapped in <mark> tags, other HTML is escaped
+type SearchHighlight struct {
+	// highlighted title
+	Title string `json:"title"`
+	// highlighted fragments of the content, empty if no word matched literally
+	Fragments []string `json:"fragments"`
 }
 
 type SearchObjectUser struct {
@@ -38,4 +38,10 @@ func TestReplaceSearchContent(t *testing.T) {
 	ret = strings.Join(append(patterns, replacedContent), " ")
 
 	assert.Equal(t, "user:aaa-sss score:3 [tag1] [tag2] ssssfdfdf as fsadf", ret)
+
+	content = `-[tag1] [tag2] c++ -java "exact-phrase" created:2024-01..2024-06 active:<30d`
+	replacedContent, patterns = ReplaceSearchContent(content)
+	ret = strings.Join(append(patterns, replacedContent), " ")
+
+	assert.Equal(t, `created:2024-01..2024-06 active:<30d -[tag1] -java [tag2] c    "exact phrase"`, ret)
 }
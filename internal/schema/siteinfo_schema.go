@@ -76,7 +76,14 @@ type SiteWriteReq struct {
 	RequiredTag    bool            `validate:"omitempty" json:"required_tag"`
 	RecommendTags  []*SiteWriteTag `validate:"omitempty,dive" json:"recommend_tags"`
 	ReservedTags   []*SiteWriteTag `validate:"omitempty,dive" json:"reserved_tags"`
-	UserID         string          `json:"-"`
+	// the questions are closed or reopened by the votes of the users who have the privilege,
+	// the vote of the moderator is still binding
+	CloseVoteEnabled bool `validate:"omitempty" json:"close_vote_enabled"`
+	// the number of the votes required to close or reopen a question
+	CloseVoteThreshold int `validate:"omitempty,min=1,max=50" json:"close_vote_threshold"`
+	// the pending votes expire after these days
+	CloseVoteExpireDays int    `validate:"omitempty,min=1,max=365" json:"close_vote_expire_days"`
+	UserID              string `json:"-"`
 }
 
 // SiteWriteTag site write response tag
@@ -126,6 +133,26 @@ type SiteUsersReq struct {
 	AllowUpdateLocation    bool   `json:"allow_update_location"`
 }
 
+// SiteReviewReq site review queues config request
+type SiteReviewReq struct {
+	// the posts of the new users are added into the first posts queue
+	FirstPostEnabled bool `validate:"omitempty" json:"first_post_enabled"`
+	// the posts are regarded as the first posts until the author has more posts than this number
+	FirstPostCount int `validate:"omitempty,min=1,max=100" json:"first_post_count"`
+	// the answers to the old questions are added into the late answers queue
+	LateAnswerEnabled bool `validate:"omitempty" json:"late_answer_enabled"`
+	// the questions created before these days are regarded as old
+	LateAnswerDays int `validate:"omitempty,min=1,max=3650" json:"late_answer_days"`
+	// the posts scored as low quality by the heuristics are added into the low quality queue
+	LowQualityEnabled bool `validate:"omitempty" json:"low_quality_enabled"`
+	// the posts whose plain text is shorter than this length are regarded as too short
+	LowQualityMinLength int `validate:"omitempty,min=1,max=1000" json:"low_quality_min_length"`
+	// the posts whose link text takes more than this percentage of the plain text are regarded as link heavy
+	LowQualityMaxLinkRatio int `validate:"omitempty,min=1,max=100" json:"low_quality_max_link_ratio"`
+	// the number of the matched heuristics required to add the post into the low quality queue
+	LowQualityMinScore int `validate:"omitempty,min=1,max=2" json:"low_quality_min_score"`
+}
+
 // SiteLoginReq site login request
 type SiteLoginReq struct {
 	AllowNewRegistrations   bool     `json:"allow_new_registrations"`
@@ -179,6 +206,9 @@ type SiteCustomCssHTMLResp SiteCustomCssHTMLReq
 // SiteUsersResp site users response
 type SiteUsersResp SiteUsersReq
 
+// SiteReviewResp site review queues config response
+type SiteReviewResp SiteReviewReq
+
 // SiteThemeResp site theme response
 type SiteThemeResp struct {
 	ThemeOptions []*ThemeOption         `json:"theme_options"`
@@ -207,6 +237,75 @@ type ThemeOption struct {
 // SiteWriteResp site write response
 type SiteWriteResp SiteWriteReq
 
+const (
+	DefaultCloseVoteThreshold  = 3
+	DefaultCloseVoteExpireDays = 14
+)
+
+// GetCloseVoteThreshold get the number of the votes required to close or reopen a question
+func (r *SiteWriteResp) GetCloseVoteThreshold() int {
+	if r.CloseVoteThreshold <= 0 {
+		return DefaultCloseVoteThreshold
+	}
+	return r.CloseVoteThreshold
+}
+
+// GetCloseVoteExpireDays get the days after which the pending votes expire
+func (r *SiteWriteResp) GetCloseVoteExpireDays() int {
+	if r.CloseVoteExpireDays <= 0 {
+		return DefaultCloseVoteExpireDays
+	}
+	return r.CloseVoteExpireDays
+}
+
+const (
+	DefaultFirstPostCount         = 1
+	DefaultLateAnswerDays         = 30
+	DefaultLowQualityMinLength    = 30
+	DefaultLowQualityMaxLinkRatio = 50
+	DefaultLowQualityMinScore     = 1
+)
+
+// GetFirstPostCount get the number of the posts regarded as the first posts of the user
+func (r *SiteReviewResp) GetFirstPostCount() int {
+	if r.FirstPostCount <= 0 {
+		return DefaultFirstPostCount
+	}
+	return r.FirstPostCount
+}
+
+// GetLateAnswerDays get the days after which the answers to the question are late answers
+func (r *SiteReviewResp) GetLateAnswerDays() int {
+	if r.LateAnswerDays <= 0 {
+		return DefaultLateAnswerDays
+	}
+	return r.LateAnswerDays
+}
+
+// GetLowQualityMinLength get the minimum length of the plain text of the post
+func (r *SiteReviewResp) GetLowQualityMinLength() int {
+	if r.LowQualityMinLength <= 0 {
+		return DefaultLowQualityMinLength
+	}
+	return r.LowQualityMinLength
+}
+
+// GetLowQualityMaxLinkRatio get the maximum percentage of the link text in the plain text of the post
+func (r *SiteReviewResp) GetLowQualityMaxLinkRatio() int {
+	if r.LowQualityMaxLinkRatio <= 0 {
+		return DefaultLowQualityMaxLinkRatio
+	}
+	return r.LowQualityMaxLinkRatio
+}
+
+// GetLowQualityMinScore get the number of the matched heuristics required to regard the post as low quality
+func (r *SiteReviewResp) GetLowQualityMinScore() int {
+	if r.LowQualityMinScore <= 0 {
+		return DefaultLowQualityMinScore
+	}
+	return r.LowQualityMinScore
+}
+
 // SiteLegalResp site write response
 type SiteLegalResp SiteLegalReq
 
@@ -352,13 +451,19 @@ var (
 		constant.RankTagEditKey:                   {1, 50, 100},
 		constant.RankQuestionEditKey:              {1, 100, 200},
 		constant.RankAnswerEditKey:                {1, 100, 200},
+		constant.RankQuestionWikiEditKey:          {1, 50, 100},
+		constant.RankAnswerWikiEditKey:            {1, 50, 100},
 		constant.RankQuestionEditWithoutReviewKey: {1, 1000, 2000},
 		constant.RankAnswerEditWithoutReviewKey:   {1, 1000, 2000},
 		constant.RankQuestionAuditKey:             {1, 1000, 2000},
 		constant.RankAnswerAuditKey:               {1, 1000, 2000},
 		constant.RankTagAuditKey:                  {1, 2500, 5000},
+		constant.RankReviewFirstPostKey:           {1, 350, 500},
+		constant.RankReviewLateAnswerKey:          {1, 350, 500},
+		constant.RankReviewLowQualityKey:          {1, 1000, 2000},
 		constant.RankTagEditWithoutReviewKey:      {1, 10000, 20000},
 		constant.RankTagSynonymKey:                {1, 10000, 20000},
+		constant.RankQuestionBountyKey:            {1, 75, 75},
 	}
 )
 
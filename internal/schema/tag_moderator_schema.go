@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// GetTagModeratorListReq get tag moderator list request
+type GetTagModeratorListReq struct {
+	TagID string `validate:"required" form:"tag_id"`
+}
+
+// GetTagModeratorResp get tag moderator response
+type GetTagModeratorResp struct {
+	TagID     string         `json:"tag_id"`
+	User      *UserBasicInfo `json:"user"`
+	CreatedAt int64          `json:"created_at"`
+}
+
+// AddTagModeratorReq add tag moderator request
+type AddTagModeratorReq struct {
+	TagID  string `validate:"required" json:"tag_id"`
+	UserID string `validate:"required" json:"user_id"`
+}
+
+// RemoveTagModeratorReq remove tag moderator request
+type RemoveTagModeratorReq struct {
+	TagID  string `validate:"required" json:"tag_id"`
+	UserID string `validate:"required" json:"user_id"`
+}
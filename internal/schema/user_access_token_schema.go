@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// UserAccessTokenPrefix all personal access tokens start with this prefix, so they can be distinguished from session tokens
+	UserAccessTokenPrefix = "ans_"
+
+	AccessTokenScopeRead  = "read"
+	AccessTokenScopeWrite = "write"
+	AccessTokenScopeAdmin = "admin"
+)
+
+// AccessTokenScopes all available scopes
+var AccessTokenScopes = []string{AccessTokenScopeRead, AccessTokenScopeWrite, AccessTokenScopeAdmin}
+
+// GetUserAccessTokenListReq get user access token list request
+type GetUserAccessTokenListReq struct {
+	UserID string `json:"-"`
+}
+
+// UserAccessTokenInfo user access token info, the token itself will never be returned again after it is created
+type UserAccessTokenInfo struct {
+	// token id
+	ID int `json:"id"`
+	// token name
+	Name string `json:"name"`
+	// the first characters of the token, used to identify the token
+	TokenPrefix string `json:"token_prefix"`
+	// scopes
+	Scopes []string `json:"scopes"`
+	// status: available, revoked, expired
+	Status string `json:"status"`
+	// expired time, 0 means never expire
+	ExpiredAt int64 `json:"expired_at"`
+	// last used time, 0 means never used
+	LastUsedAt int64 `json:"last_used_at"`
+	// created time
+	CreatedAt int64 `json:"created_at"`
+}
+
+// AddUserAccessTokenReq add user access token request
+type AddUserAccessTokenReq struct {
+	// token name
+	Name string `validate:"required,notblank,lte=100" json:"name"`
+	// scopes
+	Scopes []string `validate:"required,gt=0,dive,oneof=read write admin" json:"scopes"`
+	// expire in days, 0 means never expire
+	ExpireDays int    `validate:"omitempty,min=0,max=3650" json:"expire_days"`
+	UserID     string `json:"-"`
+	IsAdmin    bool   `json:"-"`
+}
+
+// AddUserAccessTokenResp add user access token response
+type AddUserAccessTokenResp struct {
+	// token id
+	ID int `json:"id"`
+	// the token, it is only shown once
+	Token string `json:"token"`
+	// expired time, 0 means never expire
+	ExpiredAt int64 `json:"expired_at"`
+}
+
+// RevokeUserAccessTokenReq revoke user access token request
+type RevokeUserAccessTokenReq struct {
+	// token id
+	ID     int    `validate:"required" json:"id"`
+	UserID string `json:"-"`
+}
@@ -28,6 +28,20 @@ import (
 type NotificationChannelConfig struct {
 	Key    constant.NotificationChannelKey `json:"key"`
 	Enable bool                            `json:"enable"`
+	// Frequency only used by digest source, immediate means no digest
+	Frequency constant.NotificationFrequency `json:"frequency,omitempty"`
+}
+
+// DigestFrequency get the digest frequency, return immediate if digest is disabled
+func (n *NotificationChannelConfig) DigestFrequency() constant.NotificationFrequency {
+	if !n.Enable {
+		return constant.NotificationFrequencyImmediate
+	}
+	switch n.Frequency {
+	case constant.NotificationFrequencyDaily, constant.NotificationFrequencyWeekly:
+		return n.Frequency
+	}
+	return constant.NotificationFrequencyImmediate
 }
 
 type NotificationChannels []*NotificationChannelConfig
@@ -56,6 +70,7 @@ type NotificationConfig struct {
 	Inbox                          NotificationChannelConfig `json:"inbox"`
 	AllNewQuestion                 NotificationChannelConfig `json:"all_new_question"`
 	AllNewQuestionForFollowingTags NotificationChannelConfig `json:"all_new_question_for_following_tags"`
+	Digest                         NotificationChannelConfig `json:"digest"`
 }
 
 func NewNotificationConfig(configs []*entity.UserNotificationConfig) NotificationConfig {
@@ -68,6 +83,9 @@ func NewNotificationConfig(configs []*entity.UserNotificationConfig) Notificatio
 			nc.AllNewQuestion = NewNotificationChannelConfigFormJson(item.Channels)
 		case string(constant.AllNewQuestionForFollowingTagsSource):
 			nc.AllNewQuestionForFollowingTags = NewNotificationChannelConfigFormJson(item.Channels)
+		case string(constant.DigestSource):
+			nc.Digest = NewNotificationChannelConfigFormJson(item.Channels)
+			nc.Digest.Frequency = nc.Digest.DigestFrequency()
 		}
 	}
 	return nc
@@ -86,6 +104,16 @@ func (n *NotificationConfig) Format() {
 		n.AllNewQuestionForFollowingTags.Key = constant.EmailChannel
 		n.AllNewQuestionForFollowingTags.Enable = false
 	}
+	if n.Digest.Key == "" {
+		n.Digest.Key = constant.EmailChannel
+	}
+	switch n.Digest.Frequency {
+	case constant.NotificationFrequencyDaily, constant.NotificationFrequencyWeekly:
+		n.Digest.Enable = true
+	default:
+		n.Digest.Frequency = constant.NotificationFrequencyImmediate
+		n.Digest.Enable = false
+	}
 }
 
 // UpdateUserNotificationConfigReq update user notification config request
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	UserSuspensionStatusActive  = "active"
+	UserSuspensionStatusLifted  = "lifted"
+	UserSuspensionStatusExpired = "expired"
+)
+
+// GetUserSuspensionListReq get the suspension history of the user request
+type GetUserSuspensionListReq struct {
+	UserID string `validate:"required" form:"user_id"`
+}
+
+// UserSuspensionResp user suspension response
+type UserSuspensionResp struct {
+	ID int `json:"id"`
+	// the admin or moderator who suspended the user, only shown to the staff
+	Operator *UserBasicInfo `json:"operator,omitempty"`
+	// suspend reason key
+	ReasonKey string `json:"reason_key"`
+	// suspend reason name
+	ReasonName string `json:"reason_name"`
+	// suspend reason description
+	ReasonDescription string `json:"reason_description"`
+	// the message from the operator
+	Message string `json:"message"`
+	// suspend duration in hours, 0 means suspended until manually unsuspended
+	Duration int `json:"duration"`
+	// suspended time
+	SuspendedAt int64 `json:"suspended_at"`
+	// suspended until time, 0 means suspended until manually unsuspended
+	SuspendedUntil int64 `json:"suspended_until"`
+	// suspension status(active,lifted,expired)
+	Status string `json:"status"`
+	// the time when the suspension is lifted or expired
+	LiftedAt int64 `json:"lifted_at"`
+}
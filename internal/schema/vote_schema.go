@@ -48,6 +48,8 @@ type VoteOperationInfo struct {
 	VoteUp bool
 	// vote down
 	VoteDown bool
+	// the object is community wiki, the votes don't change the reputation of the owner
+	Wiki bool
 	// vote activity info
 	Activities []*VoteActivity
 }
@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"github.com/apache/incubator-answer/internal/base/constant"
+)
+
+const (
+	// WebhookSignatureHeader the HMAC-SHA256 signature of the request body, signed by the webhook secret
+	WebhookSignatureHeader = "X-Answer-Signature"
+	// WebhookEventHeader the event type of the request
+	WebhookEventHeader = "X-Answer-Event"
+	// WebhookDeliveryHeader the unique id of the delivery, it is the same for all retries
+	WebhookDeliveryHeader = "X-Answer-Delivery"
+)
+
+// WebhookInfo webhook info
+type WebhookInfo struct {
+	// webhook id
+	ID int `json:"id"`
+	// webhook name
+	Name string `json:"name"`
+	// payload url
+	URL string `json:"url"`
+	// secret is masked, only the last 4 characters will be shown
+	Secret string `json:"secret"`
+	// subscribed event types
+	Events []string `json:"events"`
+	// whether the webhook is active
+	Active bool `json:"active"`
+	// description
+	Description string `json:"description"`
+	// created time
+	CreatedAt int64 `json:"created_at"`
+	// updated time
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// AddWebhookReq add webhook request
+type AddWebhookReq struct {
+	// webhook name
+	Name string `validate:"required,notblank,lte=100" json:"name"`
+	// payload url
+	URL string `validate:"required,url,lte=1024" json:"url"`
+	// secret, a random one will be generated if it is empty
+	Secret string `validate:"omitempty,lte=255" json:"secret"`
+	// subscribed event types
+	Events []string `validate:"required,gt=0,dive,required" json:"events"`
+	// whether the webhook is active
+	Active bool `json:"active"`
+	// description
+	Description string `validate:"omitempty,lte=500" json:"description"`
+}
+
+// AddWebhookResp add webhook response, it is the only chance to get the full secret
+type AddWebhookResp struct {
+	// webhook id
+	ID int `json:"id"`
+	// secret
+	Secret string `json:"secret"`
+}
+
+// UpdateWebhookReq update webhook request
+type UpdateWebhookReq struct {
+	// webhook id
+	ID int `validate:"required" json:"id"`
+	// webhook name
+	Name string `validate:"required,notblank,lte=100" json:"name"`
+	// payload url
+	URL string `validate:"required,url,lte=1024" json:"url"`
+	// secret, keep the old secret if it is empty
+	Secret string `validate:"omitempty,lte=255" json:"secret"`
+	// subscribed event types
+	Events []string `validate:"required,gt=0,dive,required" json:"events"`
+	// whether the webhook is active
+	Active bool `json:"active"`
+	// description
+	Description string `validate:"omitempty,lte=500" json:"description"`
+}
+
+// DeleteWebhookReq delete webhook request
+type DeleteWebhookReq struct {
+	// webhook id
+	ID int `validate:"required" json:"id"`
+}
+
+// GetWebhookDeliveryPageReq get webhook delivery page request
+type GetWebhookDeliveryPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// webhook id
+	WebhookID int `validate:"required" form:"webhook_id"`
+}
+
+// GetWebhookDeliveryResp webhook delivery info
+type GetWebhookDeliveryResp struct {
+	// delivery record id
+	ID int `json:"id"`
+	// delivery id, all retries of one event share the same delivery id
+	DeliveryID string `json:"delivery_id"`
+	// event type
+	EventType string `json:"event_type"`
+	// attempt number, start from 1
+	Attempt int `json:"attempt"`
+	// whether the delivery is successful
+	Success bool `json:"success"`
+	// response status code, 0 if the request is not sent
+	StatusCode int `json:"status_code"`
+	// duration in milliseconds
+	Duration int64 `json:"duration"`
+	// request body
+	RequestBody string `json:"request_body"`
+	// response body
+	ResponseBody string `json:"response_body"`
+	// error message
+	Error string `json:"error"`
+	// created time
+	CreatedAt int64 `json:"created_at"`
+}
+
+// WebhookPayload the request body sent to the webhook
+type WebhookPayload struct {
+	// delivery id
+	DeliveryID string `json:"delivery_id"`
+	// event type
+	EventType constant.EventType `json:"event_type"`
+	// site url
+	SiteURL string `json:"site_url"`
+	// event triggered time
+	Timestamp int64 `json:"timestamp"`
+	// event data
+	Data *EventMsg `json:"data"`
+}
@@ -32,6 +32,9 @@ const (
 	AnswerAccept      = "answer.accept"
 	CommentVoteUp     = "comment.vote_up"
 	EditAccepted      = "edit.accepted"
+	QuestionBounty    = "question.bounty_offer"
+	QuestionRefund    = "question.bounty_refund"
+	AnswerBounty      = "answer.bounty_awarded"
 )
 
 var (
@@ -72,5 +75,8 @@ var (
 		AnswerAccept:      "action_activity_type.accept",
 		CommentVoteUp:     "action_activity_type.upvote",
 		EditAccepted:      "action_activity_type.edit",
+		QuestionBounty:    "action_activity_type.bounty_offer",
+		QuestionRefund:    "action_activity_type.bounty_refund",
+		AnswerBounty:      "action_activity_type.bounty_awarded",
 	}
 )
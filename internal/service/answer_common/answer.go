@@ -100,6 +100,7 @@ func (as *AnswerCommon) ShowFormat(ctx context.Context, data *entity.Answer) *sc
 	info := schema.AnswerInfo{}
 	info.ID = data.ID
 	info.QuestionID = data.QuestionID
+	info.RevisionID = data.RevisionID
 	info.Content = data.OriginalText
 	info.HTML = data.ParsedText
 	info.Accepted = data.Accepted
@@ -112,6 +113,7 @@ func (as *AnswerCommon) ShowFormat(ctx context.Context, data *entity.Answer) *sc
 	info.UserID = data.UserID
 	info.UpdateUserID = data.LastEditUserID
 	info.Status = data.Status
+	info.Wiki = data.Wiki
 	info.MemberActions = make([]*schema.PermissionMemberAction, 0)
 	return &info
 }
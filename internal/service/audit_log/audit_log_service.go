@@ -0,0 +1,214 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package audit_log
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// exportBatchSize the number of audit logs fetched at a time when exporting
+	exportBatchSize = 500
+	// exportMaxRows the max number of audit logs in one export
+	exportMaxRows = 100000
+)
+
+// AuditLogRepo audit log repository
+type AuditLogRepo interface {
+	AddAuditLog(ctx context.Context, auditLog *entity.AuditLog) (err error)
+	GetAuditLogPage(ctx context.Context, page, pageSize int, cond *schema.AuditLogCondition) (
+		auditLogs []*entity.AuditLog, total int64, err error)
+	GetAuditLogList(ctx context.Context, cond *schema.AuditLogCondition, beforeID int64, limit int) (
+		auditLogs []*entity.AuditLog, err error)
+}
+
+// AuditLogService the operations of the admins and moderators
+type AuditLogService struct {
+	auditLogRepo AuditLogRepo
+	userCommon   *usercommon.UserCommon
+}
+
+// NewAuditLogService new audit log service
+func NewAuditLogService(
+	auditLogRepo AuditLogRepo,
+	userCommon *usercommon.UserCommon,
+) *AuditLogService {
+	return &AuditLogService{
+		auditLogRepo: auditLogRepo,
+		userCommon:   userCommon,
+	}
+}
+
+// AddAuditLog record the operation with the client ip of the request.
+// The operation is already done, so the failure is only logged.
+func (as *AuditLogService) AddAuditLog(ctx context.Context, msg *schema.AuditLogMsg) {
+	auditLog := &entity.AuditLog{
+		OperatorUserID: msg.OperatorUserID,
+		Action:         msg.Action,
+		ObjectType:     msg.ObjectType,
+		ObjectID:       msg.ObjectID,
+		BeforeData:     as.encodeSnapshot(msg.Before),
+		AfterData:      as.encodeSnapshot(msg.After),
+		IP:             handler.GetClientIPByCtx(ctx),
+	}
+	if err := as.auditLogRepo.AddAuditLog(ctx, auditLog); err != nil {
+		log.Errorf("add audit log %s of %s failed: %v", msg.Action, msg.ObjectID, err)
+	}
+}
+
+func (as *AuditLogService) encodeSnapshot(snapshot any) string {
+	if snapshot == nil {
+		return ""
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		log.Errorf("encode audit log snapshot failed: %v", err)
+		return ""
+	}
+	// the typed nil snapshot is also treated as empty
+	if string(data) == "null" {
+		return ""
+	}
+	return string(data)
+}
+
+// GetAuditLogActions get all the actions recorded in the audit log
+func (as *AuditLogService) GetAuditLogActions(ctx context.Context) (resp *schema.GetAuditLogActionsResp, err error) {
+	return &schema.GetAuditLogActionsResp{Actions: constant.AuditLogActions}, nil
+}
+
+// GetAuditLogPage get audit log page, the latest first
+func (as *AuditLogService) GetAuditLogPage(ctx context.Context, req *schema.GetAuditLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	auditLogs, total, err := as.auditLogRepo.GetAuditLogPage(ctx, req.Page, req.PageSize, &req.AuditLogCondition)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := as.formatAuditLogs(ctx, auditLogs)
+	if err != nil {
+		return nil, err
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// ExportAuditLog export the audit logs matching the condition as csv, the latest first
+func (as *AuditLogService) ExportAuditLog(ctx context.Context, req *schema.ExportAuditLogReq) (
+	data []byte, err error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	err = writer.Write([]string{"id", "created_at", "operator_user_id", "operator_username", "action",
+		"object_type", "object_id", "before_data", "after_data", "ip"})
+	if err != nil {
+		return nil, err
+	}
+
+	var beforeID int64
+	for rows := 0; rows < exportMaxRows; {
+		auditLogs, err := as.auditLogRepo.GetAuditLogList(ctx, &req.AuditLogCondition, beforeID, exportBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := as.formatAuditLogs(ctx, auditLogs)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp {
+			var operatorUserID, operatorUsername string
+			if item.Operator != nil {
+				operatorUserID, operatorUsername = item.Operator.ID, item.Operator.Username
+			}
+			err = writer.Write([]string{
+				fmt.Sprintf("%d", item.ID),
+				time.Unix(item.CreatedAt, 0).UTC().Format(time.RFC3339),
+				escapeCSVCell(operatorUserID),
+				escapeCSVCell(operatorUsername),
+				escapeCSVCell(item.Action),
+				escapeCSVCell(item.ObjectType),
+				escapeCSVCell(item.ObjectID),
+				escapeCSVCell(item.BeforeData),
+				escapeCSVCell(item.AfterData),
+				escapeCSVCell(item.IP),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		rows += len(auditLogs)
+		if len(auditLogs) < exportBatchSize {
+			break
+		}
+		beforeID = auditLogs[len(auditLogs)-1].ID
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// escapeCSVCell prefix the cell that would be evaluated as a formula by the spreadsheet with a single quote
+func escapeCSVCell(cell string) string {
+	if len(cell) > 0 && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+func (as *AuditLogService) formatAuditLogs(ctx context.Context, auditLogs []*entity.AuditLog) (
+	resp []*schema.GetAuditLogResp, err error) {
+	operatorIDs := make([]string, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		operatorIDs = append(operatorIDs, auditLog.OperatorUserID)
+	}
+	operatorMapping, err := as.userCommon.BatchUserBasicInfoByID(ctx, operatorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = make([]*schema.GetAuditLogResp, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		resp = append(resp, &schema.GetAuditLogResp{
+			ID:         auditLog.ID,
+			Operator:   operatorMapping[auditLog.OperatorUserID],
+			Action:     auditLog.Action,
+			ObjectType: auditLog.ObjectType,
+			ObjectID:   auditLog.ObjectID,
+			BeforeData: auditLog.BeforeData,
+			AfterData:  auditLog.AfterData,
+			IP:         auditLog.IP,
+			CreatedAt:  auditLog.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
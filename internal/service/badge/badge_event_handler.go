@@ -57,7 +57,7 @@ func NewBadgeEventService(
 		eventRuleRepo:     eventRuleRepo,
 		badgeAwardService: badgeAwardService,
 	}
-	eventQueueService.RegisterHandler(n.Handler)
+	eventQueueService.RegisterHandler("badge", n.Handler)
 	return n
 }
 
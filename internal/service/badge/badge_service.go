@@ -21,11 +21,13 @@ package badge
 
 import (
 	"context"
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -53,6 +55,7 @@ type BadgeService struct {
 	badgeAwardRepo        BadgeAwardRepo
 	badgeEventService     *BadgeEventService
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	auditLogService       *audit_log.AuditLogService
 }
 
 func NewBadgeService(
@@ -61,6 +64,7 @@ func NewBadgeService(
 	badgeAwardRepo BadgeAwardRepo,
 	badgeEventService *BadgeEventService,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	auditLogService *audit_log.AuditLogService,
 ) *BadgeService {
 	return &BadgeService{
 		badgeRepo:             badgeRepo,
@@ -68,6 +72,7 @@ func NewBadgeService(
 		badgeAwardRepo:        badgeAwardRepo,
 		badgeEventService:     badgeEventService,
 		siteInfoCommonService: siteInfoCommonService,
+		auditLogService:       auditLogService,
 	}
 }
 
@@ -312,6 +317,14 @@ func (b *BadgeService) UpdateStatus(ctx *gin.Context, req *schema.UpdateBadgeSta
 	if err != nil {
 		return err
 	}
+	b.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionBadgeStatusUpdate,
+		ObjectType:     constant.BadgeObjectType,
+		ObjectID:       badge.ID,
+		Before:         map[string]any{"status": schema.BadgeStatusMap[badge.Status]},
+		After:          map[string]any{"status": req.Status},
+	})
 
 	if status == entity.BadgeStatusActive {
 		count, err := b.badgeAwardRepo.CountByBadgeID(ctx, badge.ID)
@@ -0,0 +1,321 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package bounty
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_common"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// expiredBountyBatchSize the max amount of expired bounties handled in one cron
+	expiredBountyBatchSize = 100
+	// bountyCandidateAnswerSize the max amount of answers checked when the bounty is awarded automatically
+	bountyCandidateAnswerSize = 100
+)
+
+// BountyRepo question bounty repository
+type BountyRepo interface {
+	AddBounty(ctx context.Context, bounty *entity.QuestionBounty, act *entity.Activity) (err error)
+	FinishBounty(ctx context.Context, bounty *entity.QuestionBounty, act *entity.Activity) (err error)
+	GetActiveBounty(ctx context.Context, questionID string) (bounty *entity.QuestionBounty, exist bool, err error)
+	GetActiveBountyAmounts(ctx context.Context, questionIDs []string) (amounts map[string]int, err error)
+	GetExpiredBounties(ctx context.Context, expiredAt time.Time, limit int) (bounties []*entity.QuestionBounty, err error)
+}
+
+// BountyService question bounty service
+type BountyService struct {
+	bountyRepo               BountyRepo
+	questionRepo             questioncommon.QuestionRepo
+	answerRepo               answercommon.AnswerRepo
+	activityRepo             activity_common.ActivityRepo
+	userCommon               *usercommon.UserCommon
+	rankService              *rank.RankService
+	notificationQueueService notice_queue.NotificationQueueService
+}
+
+// NewBountyService new bounty service
+func NewBountyService(
+	bountyRepo BountyRepo,
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	activityRepo activity_common.ActivityRepo,
+	userCommon *usercommon.UserCommon,
+	rankService *rank.RankService,
+	notificationQueueService notice_queue.NotificationQueueService,
+) *BountyService {
+	return &BountyService{
+		bountyRepo:               bountyRepo,
+		questionRepo:             questionRepo,
+		answerRepo:               answerRepo,
+		activityRepo:             activityRepo,
+		userCommon:               userCommon,
+		rankService:              rankService,
+		notificationQueueService: notificationQueueService,
+	}
+}
+
+// GetBounty get the active bounty of the question, return nil if the question has no active bounty
+func (bs *BountyService) GetBounty(ctx context.Context, req *schema.GetBountyReq) (resp *schema.GetBountyResp, err error) {
+	questionID := uid.DeShortID(req.QuestionID)
+	bounty, exist, err := bs.bountyRepo.GetActiveBounty(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	resp = &schema.GetBountyResp{
+		QuestionID: req.QuestionID,
+		Amount:     bounty.Amount,
+		ExpiresAt:  bounty.ExpiresAt.Unix(),
+		CreatedAt:  bounty.CreatedAt.Unix(),
+	}
+	userInfo, exist, err := bs.userCommon.GetUserBasicInfoByID(ctx, bounty.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		resp.UserInfo = userInfo
+	}
+	return resp, nil
+}
+
+// AddBounty escrow the reputation of the user on the question.
+// The asker or the user who has enough reputation can offer a bounty on an open question.
+func (bs *BountyService) AddBounty(ctx context.Context, req *schema.AddBountyReq) (err error) {
+	if plugin.RankAgentEnabled() {
+		return errors.BadRequest(reason.RankFailToMeetTheCondition)
+	}
+	if req.Amount < schema.BountyMinAmount || req.Amount > schema.BountyMaxAmount {
+		return errors.BadRequest(reason.BountyAmountInvalid)
+	}
+	questionID := uid.DeShortID(req.QuestionID)
+	questionInfo, exist, err := bs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.QuestionNotFound)
+	}
+	if questionInfo.Status != entity.QuestionStatusAvailable {
+		return errors.BadRequest(reason.BountyQuestionNotOpen)
+	}
+
+	can, err := bs.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionBounty, questionID)
+	if err != nil {
+		return err
+	}
+	if !can {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+
+	activityType, err := bs.activityRepo.GetActivityTypeByConfigKey(ctx, activity_type.QuestionBounty)
+	if err != nil {
+		return err
+	}
+	bounty := &entity.QuestionBounty{
+		QuestionID: questionID,
+		UserID:     req.UserID,
+		Amount:     req.Amount,
+		Status:     entity.QuestionBountyStatusActive,
+		ExpiresAt:  time.Now().AddDate(0, 0, schema.BountyDurationDays),
+	}
+	return bs.bountyRepo.AddBounty(ctx, bounty, &entity.Activity{
+		UserID:           req.UserID,
+		TriggerUserID:    converter.StringToInt64(req.UserID),
+		ObjectID:         questionID,
+		OriginalObjectID: questionID,
+		ActivityType:     activityType,
+		Rank:             -req.Amount,
+	})
+}
+
+// AwardBounty the sponsor awards the whole bounty to an answer of the question
+func (bs *BountyService) AwardBounty(ctx context.Context, req *schema.AwardBountyReq) (err error) {
+	questionID := uid.DeShortID(req.QuestionID)
+	bounty, exist, err := bs.bountyRepo.GetActiveBounty(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	if !exist || bounty.UserID != req.UserID {
+		return errors.BadRequest(reason.BountyNotFound)
+	}
+
+	answerInfo, exist, err := bs.answerRepo.GetAnswer(ctx, uid.DeShortID(req.AnswerID))
+	if err != nil {
+		return err
+	}
+	if !exist || answerInfo.QuestionID != questionID || answerInfo.Status != entity.AnswerStatusAvailable {
+		return errors.BadRequest(reason.BountyAnswerNotEligible)
+	}
+	if answerInfo.UserID == bounty.UserID {
+		return errors.BadRequest(reason.BountyCannotAwardYourself)
+	}
+	return bs.awardBounty(ctx, bounty, answerInfo, bounty.Amount)
+}
+
+// ExpireBountiesCron finish the expired bounties.
+// Half of the bounty is awarded to the accepted answer or the highest voted answer posted after the bounty started,
+// if there is no such answer, the bounty is refunded to the sponsor.
+func (bs *BountyService) ExpireBountiesCron(ctx context.Context) {
+	bounties, err := bs.bountyRepo.GetExpiredBounties(ctx, time.Now(), expiredBountyBatchSize)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, bounty := range bounties {
+		answerInfo, err := bs.getAutoAwardAnswer(ctx, bounty)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		if answerInfo != nil {
+			err = bs.awardBounty(ctx, bounty, answerInfo, bounty.Amount/2)
+		} else {
+			err = bs.refundBounty(ctx, bounty, entity.QuestionBountyStatusExpired)
+		}
+		if err != nil {
+			log.Errorf("finish expired bounty %d failed: %v", bounty.ID, err)
+		}
+	}
+}
+
+// getAutoAwardAnswer get the answer which is eligible to be awarded automatically, return nil if not found
+func (bs *BountyService) getAutoAwardAnswer(ctx context.Context, bounty *entity.QuestionBounty) (
+	answerInfo *entity.Answer, err error) {
+	answers, _, err := bs.answerRepo.SearchList(ctx, &entity.AnswerSearch{
+		Answer:   entity.Answer{QuestionID: bounty.QuestionID},
+		PageSize: bountyCandidateAnswerSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// answers are ordered by accepted status first, then by vote count
+	for _, answer := range answers {
+		if answer.UserID == bounty.UserID || answer.CreatedAt.Before(bounty.CreatedAt) {
+			continue
+		}
+		if answer.Accepted == schema.AnswerAcceptedEnable ||
+			answer.VoteCount >= schema.BountyAutoAwardMinVotes {
+			return answer, nil
+		}
+	}
+	return nil, nil
+}
+
+func (bs *BountyService) awardBounty(ctx context.Context, bounty *entity.QuestionBounty,
+	answerInfo *entity.Answer, amount int) (err error) {
+	activityType, err := bs.activityRepo.GetActivityTypeByConfigKey(ctx, activity_type.AnswerBounty)
+	if err != nil {
+		return err
+	}
+	answerID := uid.DeShortID(answerInfo.ID)
+	bounty.Status = entity.QuestionBountyStatusAwarded
+	bounty.AwardedAnswerID = answerID
+	bounty.AwardedUserID = answerInfo.UserID
+	bounty.AwardedAmount = amount
+	err = bs.bountyRepo.FinishBounty(ctx, bounty, &entity.Activity{
+		UserID:           answerInfo.UserID,
+		TriggerUserID:    converter.StringToInt64(bounty.UserID),
+		ObjectID:         answerID,
+		OriginalObjectID: answerID,
+		ActivityType:     activityType,
+		Rank:             amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	bs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		Type:           schema.NotificationTypeAchievement,
+		ObjectID:       answerID,
+		ObjectType:     constant.AnswerObjectType,
+		ReceiverUserID: answerInfo.UserID,
+		TriggerUserID:  bounty.UserID,
+	})
+	bs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		Type:               schema.NotificationTypeInbox,
+		ObjectID:           answerID,
+		ObjectType:         constant.AnswerObjectType,
+		ReceiverUserID:     answerInfo.UserID,
+		TriggerUserID:      bounty.UserID,
+		NotificationAction: constant.NotificationBountyAwarded,
+	})
+	return nil
+}
+
+// CloseBounty close the active bounty of the question and refund it to the sponsor,
+// it is used when the question is deleted or merged into another one, so the bounty can not be awarded.
+func (bs *BountyService) CloseBounty(ctx context.Context, questionID string) (err error) {
+	bounty, exist, err := bs.bountyRepo.GetActiveBounty(ctx, uid.DeShortID(questionID))
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return nil
+	}
+	return bs.refundBounty(ctx, bounty, entity.QuestionBountyStatusClosed)
+}
+
+func (bs *BountyService) refundBounty(ctx context.Context, bounty *entity.QuestionBounty, status int) (err error) {
+	activityType, err := bs.activityRepo.GetActivityTypeByConfigKey(ctx, activity_type.QuestionRefund)
+	if err != nil {
+		return err
+	}
+	bounty.Status = status
+	err = bs.bountyRepo.FinishBounty(ctx, bounty, &entity.Activity{
+		UserID:           bounty.UserID,
+		TriggerUserID:    converter.StringToInt64(bounty.UserID),
+		ObjectID:         bounty.QuestionID,
+		OriginalObjectID: bounty.QuestionID,
+		ActivityType:     activityType,
+		Rank:             bounty.Amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	bs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		Type:           schema.NotificationTypeAchievement,
+		ObjectID:       bounty.QuestionID,
+		ObjectType:     constant.QuestionObjectType,
+		ReceiverUserID: bounty.UserID,
+	})
+	return nil
+}
@@ -21,6 +21,7 @@ package comment
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/revision_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/token"
@@ -53,6 +55,10 @@ type CommentRepo interface {
 	GetComment(ctx context.Context, commentID string) (comment *entity.Comment, exist bool, err error)
 	GetCommentPage(ctx context.Context, commentQuery *CommentQuery) (
 		comments []*entity.Comment, total int64, err error)
+	GetRootCommentPage(ctx context.Context, commentQuery *CommentQuery) (
+		comments []*entity.Comment, total int64, err error)
+	GetReplyCommentList(ctx context.Context, commentQuery *CommentQuery, replyCommentIDs []string) (
+		comments []*entity.Comment, err error)
 }
 
 type CommentQuery struct {
@@ -88,6 +94,7 @@ type CommentService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	activityQueueService             activity_queue.ActivityQueueService
 	eventQueueService                event_queue.EventQueueService
+	revisionService                  *revision_common.RevisionService
 }
 
 // NewCommentService new comment service
@@ -103,6 +110,7 @@ func NewCommentService(
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService,
 	activityQueueService activity_queue.ActivityQueueService,
 	eventQueueService event_queue.EventQueueService,
+	revisionService *revision_common.RevisionService,
 ) *CommentService {
 	return &CommentService{
 		commentRepo:                      commentRepo,
@@ -116,6 +124,7 @@ func NewCommentService(
 		externalNotificationQueueService: externalNotificationQueueService,
 		activityQueueService:             activityQueueService,
 		eventQueueService:                eventQueueService,
+		revisionService:                  revisionService,
 	}
 }
 
@@ -159,6 +168,9 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 	if err != nil {
 		return nil, err
 	}
+	if err = cs.addCommentRevision(ctx, req.UserID, comment); err != nil {
+		return nil, err
+	}
 
 	resp = &schema.GetCommentResp{}
 	resp.SetFromComment(comment)
@@ -281,9 +293,28 @@ func (cs *CommentService) UpdateComment(ctx context.Context, req *schema.UpdateC
 		return nil, errors.BadRequest(reason.CommentCannotEditAfterDeadline)
 	}
 
+	// the comments created before the revision log was introduced have no revision,
+	// record the original content first so that the edit history is complete.
+	_, exist, err = cs.revisionService.GetLastRevisionByObjectID(ctx, old.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		if err = cs.addCommentRevision(ctx, old.UserID, old); err != nil {
+			return nil, err
+		}
+	}
+
 	if err = cs.commentRepo.UpdateCommentContent(ctx, old.ID, req.OriginalText, req.ParsedText); err != nil {
 		return nil, err
 	}
+	newComment := *old
+	newComment.OriginalText = req.OriginalText
+	newComment.ParsedText = req.ParsedText
+	newComment.UpdatedAt = time.Now()
+	if err = cs.addCommentRevision(ctx, req.UserID, &newComment); err != nil {
+		return nil, err
+	}
 	resp = &schema.UpdateCommentResp{
 		CommentID:    old.ID,
 		OriginalText: req.OriginalText,
@@ -294,6 +325,17 @@ func (cs *CommentService) UpdateComment(ctx context.Context, req *schema.UpdateC
 	return resp, nil
 }
 
+// addCommentRevision record the content of the comment in the revision log
+func (cs *CommentService) addCommentRevision(ctx context.Context, userID string, comment *entity.Comment) (err error) {
+	infoJSON, _ := json.Marshal(comment)
+	_, err = cs.revisionService.AddRevision(ctx, &schema.AddRevisionDTO{
+		UserID:   userID,
+		ObjectID: comment.ID,
+		Content:  string(infoJSON),
+	}, false)
+	return err
+}
+
 // GetComment get comment one
 func (cs *CommentService) GetComment(ctx context.Context, req *schema.GetCommentReq) (resp *schema.GetCommentResp, err error) {
 	comment, exist, err := cs.commentCommonRepo.GetComment(ctx, req.ID)
@@ -354,6 +396,9 @@ func (cs *CommentService) GetComment(ctx context.Context, req *schema.GetComment
 // GetCommentWithPage get comment list page
 func (cs *CommentService) GetCommentWithPage(ctx context.Context, req *schema.GetCommentWithPageReq) (
 	pageModel *pager.PageModel, err error) {
+	if req.IsTreeMode() {
+		return cs.getCommentTreeWithPage(ctx, req)
+	}
 	dto := &CommentQuery{
 		PageCond:  pager.PageCond{Page: req.Page, PageSize: req.PageSize},
 		ObjectID:  req.ObjectID,
@@ -398,6 +443,117 @@ func (cs *CommentService) GetCommentWithPage(ctx context.Context, req *schema.Ge
 	return pager.NewPageModel(total, resp), nil
 }
 
+// getCommentTreeWithPage get the comment trees of the object, the page is applied to the root comments
+func (cs *CommentService) getCommentTreeWithPage(ctx context.Context, req *schema.GetCommentWithPageReq) (
+	pageModel *pager.PageModel, err error) {
+	dto := &CommentQuery{
+		PageCond:  pager.PageCond{Page: req.Page, PageSize: req.PageSize},
+		ObjectID:  req.ObjectID,
+		QueryCond: req.QueryCond,
+	}
+	rootComments, total, err := cs.commentRepo.GetRootCommentPage(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	// if user request the specific comment, add the tree it belongs to if not exist.
+	if len(req.CommentID) > 0 {
+		root, err := cs.getRootComment(ctx, req.ObjectID, req.CommentID)
+		if err != nil {
+			return nil, err
+		}
+		if root != nil {
+			rootExist := false
+			for _, comment := range rootComments {
+				if comment.ID == root.ID {
+					rootExist = true
+					break
+				}
+			}
+			if !rootExist {
+				rootComments = append(rootComments, root)
+			}
+		}
+	}
+
+	// load the replies level by level, the comment already loaded is skipped in case the replies form a cycle
+	replyComments := make([]*entity.Comment, 0)
+	loaded := make(map[string]bool, len(rootComments))
+	replyCommentIDs := make([]string, 0, len(rootComments))
+	for _, comment := range rootComments {
+		loaded[comment.ID] = true
+		replyCommentIDs = append(replyCommentIDs, comment.ID)
+	}
+	for len(replyCommentIDs) > 0 {
+		replies, err := cs.commentRepo.GetReplyCommentList(ctx, dto, replyCommentIDs)
+		if err != nil {
+			return nil, err
+		}
+		replyCommentIDs = make([]string, 0, len(replies))
+		for _, reply := range replies {
+			if loaded[reply.ID] {
+				continue
+			}
+			loaded[reply.ID] = true
+			replyComments = append(replyComments, reply)
+			replyCommentIDs = append(replyCommentIDs, reply.ID)
+		}
+	}
+	// the replies of different levels are sorted together, so the flattened deep replies keep the order
+	sortComments(replyComments, req.QueryCond)
+	roots := buildCommentTree(append(rootComments, replyComments...), req.GetMaxDepth())
+
+	resp := make([]*schema.GetCommentResp, 0, len(roots))
+	for _, root := range roots {
+		commentResp, err := cs.convertCommentTree2Resp(ctx, req, root)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, commentResp)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// getRootComment get the root comment of the tree the comment belongs to,
+// nil is returned if the comment is not an available comment of the object
+func (cs *CommentService) getRootComment(ctx context.Context, objectID, commentID string) (
+	root *entity.Comment, err error) {
+	visited := make(map[string]bool)
+	for len(commentID) > 0 && !visited[commentID] {
+		visited[commentID] = true
+		comment, exist, err := cs.commentCommonRepo.GetComment(ctx, commentID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist || comment.ObjectID != objectID || comment.Status != entity.CommentStatusAvailable {
+			break
+		}
+		root = comment
+		commentID = comment.GetReplyCommentID()
+	}
+	return root, nil
+}
+
+func (cs *CommentService) convertCommentTree2Resp(ctx context.Context, req *schema.GetCommentWithPageReq,
+	node *commentTreeNode) (commentResp *schema.GetCommentResp, err error) {
+	commentResp, err = cs.convertCommentEntity2Resp(ctx, req, node.comment)
+	if err != nil {
+		return nil, err
+	}
+	if len(node.children) == 0 {
+		return commentResp, nil
+	}
+	commentResp.Children = make([]*schema.GetCommentResp, 0, len(node.children))
+	for _, child := range node.children {
+		childResp, err := cs.convertCommentTree2Resp(ctx, req, child)
+		if err != nil {
+			return nil, err
+		}
+		commentResp.Children = append(commentResp.Children, childResp)
+	}
+	return commentResp, nil
+}
+
 func (cs *CommentService) convertCommentEntity2Resp(ctx context.Context, req *schema.GetCommentWithPageReq,
 	comment *entity.Comment) (commentResp *schema.GetCommentResp, err error) {
 	commentResp = &schema.GetCommentResp{
@@ -444,6 +600,8 @@ func (cs *CommentService) convertCommentEntity2Resp(ctx context.Context, req *sc
 
 	commentResp.MemberActions = permission.GetCommentPermission(ctx,
 		req.UserID, commentResp.UserID, comment.CreatedAt, req.CanEdit, req.CanDelete)
+	commentResp.MemberActions = append(commentResp.MemberActions,
+		permission.GetConvertPermission(ctx, constant.CommentObjectType, req.CanConvert)...)
 	return commentResp, nil
 }
 
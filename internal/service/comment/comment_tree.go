@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package comment
+
+import (
+	"sort"
+
+	"github.com/apache/incubator-answer/internal/entity"
+)
+
+// commentTreeNode the comment and its replies
+type commentTreeNode struct {
+	comment  *entity.Comment
+	children []*commentTreeNode
+}
+
+// buildCommentTree group the comments into trees by the reply comment id,
+// the comments keep the order of the given list on every level.
+// The replies deeper than max depth are attached to their ancestor at the max depth,
+// and the replies whose replied comment is not in the list (e.g. deleted) are treated as root comments.
+func buildCommentTree(comments []*entity.Comment, maxDepth int) (roots []*commentTreeNode) {
+	roots = make([]*commentTreeNode, 0)
+	nodes := make(map[string]*commentTreeNode, len(comments))
+	for _, comment := range comments {
+		nodes[comment.ID] = &commentTreeNode{comment: comment}
+	}
+	parentOf := func(comment *entity.Comment) *entity.Comment {
+		parent, ok := nodes[comment.GetReplyCommentID()]
+		if !ok || parent.comment.ID == comment.ID {
+			return nil
+		}
+		return parent.comment
+	}
+	// the depth can not be greater than the amount of comments, unless the replies form a cycle
+	cycleDepth := len(comments) + 1
+	depths := make(map[string]int, len(comments))
+	var depthOf func(comment *entity.Comment) int
+	depthOf = func(comment *entity.Comment) int {
+		if depth, ok := depths[comment.ID]; ok {
+			return depth
+		}
+		// the comment is being visited, so the replies form a cycle
+		depths[comment.ID] = cycleDepth
+		depth := 1
+		if parent := parentOf(comment); parent != nil {
+			depth = depthOf(parent) + 1
+			if depth > cycleDepth {
+				depth = cycleDepth
+			}
+		}
+		depths[comment.ID] = depth
+		return depth
+	}
+
+	for _, comment := range comments {
+		parent := parentOf(comment)
+		if parent == nil || depthOf(comment) >= cycleDepth {
+			roots = append(roots, nodes[comment.ID])
+			continue
+		}
+		for depthOf(parent) > maxDepth {
+			parent = parentOf(parent)
+		}
+		nodes[parent.ID].children = append(nodes[parent.ID].children, nodes[comment.ID])
+	}
+	return roots
+}
+
+// sortComments sort the comments in the same order as the query condition
+func sortComments(comments []*entity.Comment, queryCond string) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		a, b := comments[i], comments[j]
+		switch queryCond {
+		case "vote":
+			if a.VoteCount != b.VoteCount {
+				return a.VoteCount > b.VoteCount
+			}
+			return a.CreatedAt.Before(b.CreatedAt)
+		case "created_at":
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+}
@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package comment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTreeTestComment(id, replyCommentID string) *entity.Comment {
+	comment := &entity.Comment{ID: id}
+	comment.SetReplyCommentID(replyCommentID)
+	return comment
+}
+
+// contains whether the comment is in the tree
+func (n *commentTreeNode) contains(commentID string) bool {
+	if n.comment.ID == commentID {
+		return true
+	}
+	for _, child := range n.children {
+		if child.contains(commentID) {
+			return true
+		}
+	}
+	return false
+}
+
+func treeIDs(nodes []*commentTreeNode) (ids []string) {
+	ids = make([]string, 0)
+	for _, node := range nodes {
+		ids = append(ids, node.comment.ID)
+	}
+	return ids
+}
+
+func Test_buildCommentTree(t *testing.T) {
+	comments := []*entity.Comment{
+		newTreeTestComment("1", ""),
+		newTreeTestComment("2", "1"),
+		newTreeTestComment("3", ""),
+		newTreeTestComment("4", "2"),
+		newTreeTestComment("5", "4"),
+		newTreeTestComment("6", "1"),
+		newTreeTestComment("7", "100"),
+	}
+
+	roots := buildCommentTree(comments, 2)
+	assert.Equal(t, []string{"1", "3", "7"}, treeIDs(roots))
+	assert.Equal(t, []string{"2", "6"}, treeIDs(roots[0].children))
+	// the replies deeper than max depth are attached to the comment at the max depth
+	assert.Equal(t, []string{"4", "5"}, treeIDs(roots[0].children[0].children))
+	assert.Empty(t, roots[0].children[0].children[0].children)
+	assert.True(t, roots[0].contains("5"))
+	assert.False(t, roots[1].contains("5"))
+
+	roots = buildCommentTree(comments, 10)
+	assert.Equal(t, []string{"4"}, treeIDs(roots[0].children[0].children))
+	assert.Equal(t, []string{"5"}, treeIDs(roots[0].children[0].children[0].children))
+
+	roots = buildCommentTree(comments, 1)
+	assert.Equal(t, []string{"2", "4", "5", "6"}, treeIDs(roots[0].children))
+}
+
+func Test_buildCommentTree_cycle(t *testing.T) {
+	comments := []*entity.Comment{
+		newTreeTestComment("1", "2"),
+		newTreeTestComment("2", "1"),
+	}
+	roots := buildCommentTree(comments, 3)
+	assert.Equal(t, []string{"1", "2"}, treeIDs(roots))
+}
+
+func Test_sortComments(t *testing.T) {
+	now := time.Now()
+	comments := []*entity.Comment{
+		{ID: "1", CreatedAt: now.Add(2 * time.Second), VoteCount: 1},
+		{ID: "2", CreatedAt: now, VoteCount: 0},
+		{ID: "3", CreatedAt: now.Add(time.Second), VoteCount: 1},
+	}
+	ids := func() []string {
+		ids := make([]string, 0, len(comments))
+		for _, comment := range comments {
+			ids = append(ids, comment.ID)
+		}
+		return ids
+	}
+
+	sortComments(comments, "")
+	assert.Equal(t, []string{"2", "3", "1"}, ids())
+	sortComments(comments, "created_at")
+	assert.Equal(t, []string{"1", "3", "2"}, ids())
+	sortComments(comments, "vote")
+	assert.Equal(t, []string{"3", "1", "2"}, ids())
+}
@@ -33,7 +33,9 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
+	"github.com/apache/incubator-answer/internal/service/draft"
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/permission"
@@ -69,6 +71,8 @@ type AnswerService struct {
 	activityQueueService             activity_queue.ActivityQueueService
 	reviewService                    *review.ReviewService
 	eventQueueService                event_queue.EventQueueService
+	draftService                     *draft.DraftService
+	auditLogService                  *audit_log.AuditLogService
 }
 
 func NewAnswerService(
@@ -89,6 +93,8 @@ func NewAnswerService(
 	activityQueueService activity_queue.ActivityQueueService,
 	reviewService *review.ReviewService,
 	eventQueueService event_queue.EventQueueService,
+	draftService *draft.DraftService,
+	auditLogService *audit_log.AuditLogService,
 ) *AnswerService {
 	return &AnswerService{
 		answerRepo:                       answerRepo,
@@ -108,6 +114,8 @@ func NewAnswerService(
 		activityQueueService:             activityQueueService,
 		reviewService:                    reviewService,
 		eventQueueService:                eventQueueService,
+		draftService:                     draftService,
+		auditLogService:                  auditLogService,
 	}
 }
 
@@ -303,9 +311,32 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 	})
 	as.eventQueueService.Send(ctx, schema.NewEvent(constant.EventAnswerCreate, req.UserID).TID(insertData.ID).
 		AID(insertData.ID, insertData.UserID))
+	as.draftService.RemovePublishedDraft(ctx, req.UserID, req.DraftID)
 	return insertData.ID, nil
 }
 
+// CheckEditConflict check whether the edit is based on the latest revision of the answer
+func (as *AnswerService) CheckEditConflict(ctx context.Context, req *schema.AnswerUpdateReq) (
+	resp *schema.RevisionConflictResp, err error) {
+	if len(req.RevisionID) == 0 {
+		return nil, nil
+	}
+	answerInfo, exist, err := as.answerRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.AnswerNotFound)
+	}
+	return as.revisionService.CheckEditConflict(ctx, &schema.EditConflictCheck{
+		ObjectID:         answerInfo.ID,
+		BaseRevisionID:   req.RevisionID,
+		LatestRevisionID: answerInfo.RevisionID,
+		Content:          req.Content,
+		LatestContent:    answerInfo.OriginalText,
+	})
+}
+
 func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq) (string, error) {
 	var canUpdate bool
 	_, existUnreviewed, err := as.revisionService.ExistUnreviewedByObjectID(ctx, req.ID)
@@ -579,6 +610,14 @@ func (as *AnswerService) AdminSetAnswerStatus(ctx context.Context, req *schema.A
 			return err
 		}
 	}
+	as.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionAnswerStatusUpdate,
+		ObjectType:     constant.AnswerObjectType,
+		ObjectID:       answerInfo.ID,
+		Before:         map[string]string{"status": entity.AdminAnswerSearchStatusIntToString[answerInfo.Status]},
+		After:          map[string]string{"status": req.Status},
+	})
 	return nil
 }
 
@@ -637,13 +676,58 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 			req.UserID,
 			item.UserID,
 			item.Status,
-			req.CanEdit,
+			req.CanEdit || (item.Wiki && req.CanWikiEdit),
 			req.CanDelete,
 			req.CanRecover)
+		item.MemberActions = append(item.MemberActions,
+			permission.GetWikiPermission(ctx, req.UserID, item.UserID, req.CanWiki, item.Wiki)...)
+		// the accepted answer can not be converted into a comment
+		item.MemberActions = append(item.MemberActions, permission.GetConvertPermission(ctx, constant.AnswerObjectType,
+			req.CanConvert && item.Status == entity.AnswerStatusAvailable && item.Accepted != schema.AnswerAcceptedEnable)...)
 	}
 	return list, nil
 }
 
+// OperationAnswer make the answer community wiki or not
+func (as *AnswerService) OperationAnswer(ctx context.Context, req *schema.OperationAnswerReq) (err error) {
+	answerInfo, exist, err := as.answerRepo.GetAnswer(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.AnswerNotFound)
+	}
+
+	wiki := req.Operation == schema.AnswerOperationWiki
+	if answerInfo.Wiki == wiki {
+		return nil
+	}
+	answerInfo.Wiki = wiki
+	if err = as.answerRepo.UpdateAnswer(ctx, answerInfo, []string{"wiki"}); err != nil {
+		return err
+	}
+
+	activityTypeKey, auditLogAction := constant.ActAnswerUnWiki, constant.AuditLogActionAnswerUnWiki
+	if wiki {
+		activityTypeKey, auditLogAction = constant.ActAnswerWiki, constant.AuditLogActionAnswerWiki
+	}
+	as.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		ObjectID:         answerInfo.ID,
+		OriginalObjectID: answerInfo.ID,
+		ActivityTypeKey:  activityTypeKey,
+	})
+	as.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         auditLogAction,
+		ObjectType:     constant.AnswerObjectType,
+		ObjectID:       answerInfo.ID,
+		Before:         map[string]bool{"wiki": !wiki},
+		After:          map[string]bool{"wiki": wiki},
+	})
+	return nil
+}
+
 func (as *AnswerService) ShowFormat(ctx context.Context, data *entity.Answer) *schema.AnswerInfo {
 	return as.AnswerCommon.ShowFormat(ctx, data)
 }
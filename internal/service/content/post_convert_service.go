@@ -0,0 +1,340 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/apache/incubator-answer/internal/service/comment"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/revision_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// PostConvertRepo convert post repository
+type PostConvertRepo interface {
+	ConvertCommentToAnswer(ctx context.Context, comment *entity.Comment, answerID string) (err error)
+	ConvertAnswerToComment(ctx context.Context, answer *entity.Answer, commentID string) (err error)
+}
+
+// PostConvertService convert the comment into an answer and the answer into a comment
+type PostConvertService struct {
+	postConvertRepo          PostConvertRepo
+	commentRepo              comment.CommentRepo
+	commentService           *comment.CommentService
+	answerRepo               answercommon.AnswerRepo
+	answerService            *AnswerService
+	questionRepo             questioncommon.QuestionRepo
+	questionCommon           *questioncommon.QuestionCommon
+	userCommon               *usercommon.UserCommon
+	revisionService          *revision_common.RevisionService
+	notificationQueueService notice_queue.NotificationQueueService
+	activityQueueService     activity_queue.ActivityQueueService
+	eventQueueService        event_queue.EventQueueService
+	auditLogService          *audit_log.AuditLogService
+}
+
+// NewPostConvertService new post convert service
+func NewPostConvertService(
+	postConvertRepo PostConvertRepo,
+	commentRepo comment.CommentRepo,
+	commentService *comment.CommentService,
+	answerRepo answercommon.AnswerRepo,
+	answerService *AnswerService,
+	questionRepo questioncommon.QuestionRepo,
+	questionCommon *questioncommon.QuestionCommon,
+	userCommon *usercommon.UserCommon,
+	revisionService *revision_common.RevisionService,
+	notificationQueueService notice_queue.NotificationQueueService,
+	activityQueueService activity_queue.ActivityQueueService,
+	eventQueueService event_queue.EventQueueService,
+	auditLogService *audit_log.AuditLogService,
+) *PostConvertService {
+	return &PostConvertService{
+		postConvertRepo:          postConvertRepo,
+		commentRepo:              commentRepo,
+		commentService:           commentService,
+		answerRepo:               answerRepo,
+		answerService:            answerService,
+		questionRepo:             questionRepo,
+		questionCommon:           questionCommon,
+		userCommon:               userCommon,
+		revisionService:          revisionService,
+		notificationQueueService: notificationQueueService,
+		activityQueueService:     activityQueueService,
+		eventQueueService:        eventQueueService,
+		auditLogService:          auditLogService,
+	}
+}
+
+// ConvertCommentToAnswer convert the comment on the question or its answers into an answer of the question,
+// the author, created time and up votes of the comment are kept. The comment that has replies can not be converted.
+func (ps *PostConvertService) ConvertCommentToAnswer(ctx context.Context, req *schema.ConvertCommentToAnswerReq) (
+	resp *schema.ConvertCommentToAnswerResp, err error) {
+	commentInfo, exist, err := ps.commentRepo.GetComment(ctx, req.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.CommentNotFound)
+	}
+	// the replies can not be kept under an answer, so the comment that has replies can not be converted
+	replies, err := ps.commentRepo.GetReplyCommentList(ctx, &comment.CommentQuery{ObjectID: commentInfo.ObjectID},
+		[]string{commentInfo.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(replies) > 0 {
+		return nil, errors.BadRequest(reason.CommentCannotConvert)
+	}
+	questionInfo, exist, err := ps.questionRepo.GetQuestion(ctx, commentInfo.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	if questionInfo.Status == entity.QuestionStatusClosed || questionInfo.Status == entity.QuestionStatusDeleted {
+		return nil, errors.BadRequest(reason.AnswerCannotAddByClosedQuestion)
+	}
+
+	answer := &entity.Answer{
+		QuestionID:     questionInfo.ID,
+		UserID:         commentInfo.UserID,
+		LastEditUserID: "0",
+		OriginalText:   commentInfo.OriginalText,
+		ParsedText:     commentInfo.ParsedText,
+		Status:         entity.AnswerStatusAvailable,
+		Accepted:       schema.AnswerAcceptedFailed,
+		RevisionID:     "0",
+	}
+	if err = ps.answerRepo.AddAnswer(ctx, answer); err != nil {
+		return nil, err
+	}
+	answer.ID = uid.DeShortID(answer.ID)
+	answer.QuestionID = uid.DeShortID(answer.QuestionID)
+	if err = ps.postConvertRepo.ConvertCommentToAnswer(ctx, commentInfo, answer.ID); err != nil {
+		if e := ps.answerRepo.RemoveAnswer(ctx, answer.ID); e != nil {
+			log.Errorf("remove the answer %s converted from comment failed: %v", answer.ID, e)
+		}
+		return nil, err
+	}
+	if err = ps.commentRepo.RemoveComment(ctx, commentInfo.ID); err != nil {
+		return nil, err
+	}
+	answer.CreatedAt = commentInfo.CreatedAt
+
+	if err = ps.questionCommon.UpdateAnswerCount(ctx, questionInfo.ID); err != nil {
+		log.Error("UpdateAnswerCount error", err.Error())
+	}
+	if err = ps.questionCommon.UpdateLastAnswer(ctx, questionInfo.ID, answer.ID); err != nil {
+		log.Error("UpdateLastAnswer error", err.Error())
+	}
+	if err = ps.questionCommon.UpdatePostTime(ctx, questionInfo.ID); err != nil {
+		log.Error("UpdatePostTime error", err.Error())
+	}
+	ps.updateUserAnswerCount(ctx, answer.UserID)
+
+	infoJSON, _ := json.Marshal(answer)
+	revisionID, err := ps.revisionService.AddRevision(ctx, &schema.AddRevisionDTO{
+		UserID:   answer.UserID,
+		ObjectID: answer.ID,
+		Content:  string(infoJSON),
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.answerService.notificationAnswerTheQuestion(ctx, questionInfo.UserID, questionInfo.ID, answer.ID, answer.UserID,
+		questionInfo.Title, htmltext.FetchExcerpt(answer.ParsedText, "...", 240))
+	ps.notificationPostConverted(ctx, req.UserID, answer.UserID, answer.ID,
+		constant.AnswerObjectType, constant.NotificationYourCommentWasConverted)
+
+	ps.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           answer.UserID,
+		ObjectID:         answer.ID,
+		OriginalObjectID: answer.ID,
+		ActivityTypeKey:  constant.ActAnswerAnswered,
+		RevisionID:       revisionID,
+	})
+	ps.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           answer.UserID,
+		ObjectID:         answer.ID,
+		OriginalObjectID: questionInfo.ID,
+		ActivityTypeKey:  constant.ActQuestionAnswered,
+	})
+	ps.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		TriggerUserID:    converter.StringToInt64(req.UserID),
+		ObjectID:         answer.ID,
+		OriginalObjectID: answer.ID,
+		ActivityTypeKey:  constant.ActAnswerConvertedFromComment,
+	})
+	ps.eventQueueService.Send(ctx, schema.NewEvent(constant.EventCommentDelete, req.UserID).
+		TID(commentInfo.ID).CID(commentInfo.ID, commentInfo.UserID))
+	ps.eventQueueService.Send(ctx, schema.NewEvent(constant.EventAnswerCreate, req.UserID).
+		TID(answer.ID).AID(answer.ID, answer.UserID))
+	ps.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionCommentConvertToAnswer,
+		ObjectType:     constant.CommentObjectType,
+		ObjectID:       commentInfo.ID,
+		Before:         map[string]string{"comment_id": commentInfo.ID},
+		After:          map[string]string{"answer_id": answer.ID},
+	})
+
+	resp = &schema.ConvertCommentToAnswerResp{
+		QuestionID: questionInfo.ID,
+		AnswerID:   answer.ID,
+	}
+	if handler.GetEnableShortID(ctx) {
+		resp.QuestionID = uid.EnShortID(resp.QuestionID)
+		resp.AnswerID = uid.EnShortID(resp.AnswerID)
+	}
+	return resp, nil
+}
+
+// ConvertAnswerToComment convert the answer into a comment on the question,
+// only the answer that is not accepted, has no comments and is short enough to be a comment can be converted.
+func (ps *PostConvertService) ConvertAnswerToComment(ctx context.Context, req *schema.ConvertAnswerToCommentReq) (
+	resp *schema.ConvertAnswerToCommentResp, err error) {
+	answerInfo, exist, err := ps.answerRepo.GetByID(ctx, req.AnswerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || answerInfo.Status != entity.AnswerStatusAvailable {
+		return nil, errors.BadRequest(reason.AnswerNotFound)
+	}
+	answerInfo.ID = uid.DeShortID(answerInfo.ID)
+	answerInfo.QuestionID = uid.DeShortID(answerInfo.QuestionID)
+	if answerInfo.Accepted == schema.AnswerAcceptedEnable ||
+		utf8.RuneCountInString(answerInfo.OriginalText) > schema.CommentMaxLength {
+		return nil, errors.BadRequest(reason.AnswerCannotConvert)
+	}
+	_, commentCount, err := ps.commentRepo.GetCommentPage(ctx, &comment.CommentQuery{
+		PageCond: pager.PageCond{Page: 1, PageSize: 1},
+		ObjectID: answerInfo.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if commentCount > 0 {
+		return nil, errors.BadRequest(reason.AnswerCannotConvert)
+	}
+
+	// the comment is added as the author of the answer, so the question author is notified as usual
+	commentInfo, err := ps.commentService.AddComment(ctx, &schema.AddCommentReq{
+		ObjectID:     answerInfo.QuestionID,
+		OriginalText: answerInfo.OriginalText,
+		ParsedText:   answerInfo.ParsedText,
+		UserID:       answerInfo.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = ps.postConvertRepo.ConvertAnswerToComment(ctx, answerInfo, commentInfo.CommentID); err != nil {
+		if e := ps.commentRepo.RemoveComment(ctx, commentInfo.CommentID); e != nil {
+			log.Errorf("remove the comment %s converted from answer failed: %v", commentInfo.CommentID, e)
+		}
+		return nil, err
+	}
+	if err = ps.answerRepo.RemoveAnswer(ctx, answerInfo.ID); err != nil {
+		return nil, err
+	}
+	if err = ps.questionCommon.UpdateAnswerCount(ctx, answerInfo.QuestionID); err != nil {
+		log.Error("UpdateAnswerCount error", err.Error())
+	}
+	ps.updateUserAnswerCount(ctx, answerInfo.UserID)
+
+	ps.notificationPostConverted(ctx, req.UserID, answerInfo.UserID, commentInfo.CommentID,
+		constant.CommentObjectType, constant.NotificationYourAnswerWasConverted)
+	ps.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		TriggerUserID:    converter.StringToInt64(req.UserID),
+		ObjectID:         answerInfo.ID,
+		OriginalObjectID: answerInfo.ID,
+		ActivityTypeKey:  constant.ActAnswerConvertedToComment,
+	})
+	ps.eventQueueService.Send(ctx, schema.NewEvent(constant.EventAnswerDelete, req.UserID).
+		TID(answerInfo.ID).AID(answerInfo.ID, answerInfo.UserID))
+	ps.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionAnswerConvertToComment,
+		ObjectType:     constant.AnswerObjectType,
+		ObjectID:       answerInfo.ID,
+		Before:         map[string]string{"answer_id": answerInfo.ID},
+		After:          map[string]string{"comment_id": commentInfo.CommentID},
+	})
+
+	resp = &schema.ConvertAnswerToCommentResp{
+		QuestionID: answerInfo.QuestionID,
+		CommentID:  commentInfo.CommentID,
+	}
+	if handler.GetEnableShortID(ctx) {
+		resp.QuestionID = uid.EnShortID(resp.QuestionID)
+	}
+	return resp, nil
+}
+
+func (ps *PostConvertService) updateUserAnswerCount(ctx context.Context, userID string) {
+	userAnswerCount, err := ps.answerRepo.GetCountByUserID(ctx, userID)
+	if err != nil {
+		log.Error("GetCountByUserID error", err.Error())
+		return
+	}
+	if err = ps.userCommon.UpdateAnswerCount(ctx, userID, int(userAnswerCount)); err != nil {
+		log.Error("user UpdateAnswerCount error", err.Error())
+	}
+}
+
+// notificationPostConverted tell the author that the post was converted by others
+func (ps *PostConvertService) notificationPostConverted(ctx context.Context,
+	operatorUserID, authorUserID, objectID, objectType, action string) {
+	if operatorUserID == authorUserID {
+		return
+	}
+	msg := &schema.NotificationMsg{
+		TriggerUserID:      operatorUserID,
+		ReceiverUserID:     authorUserID,
+		Type:               schema.NotificationTypeInbox,
+		ObjectID:           objectID,
+		ObjectType:         objectType,
+		NotificationAction: action,
+	}
+	ps.notificationQueueService.Send(ctx, msg)
+}
@@ -0,0 +1,365 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// QuestionCloseVoteRepo question close vote repository
+type QuestionCloseVoteRepo interface {
+	AddCloseVote(ctx context.Context, vote *entity.QuestionCloseVote) (err error)
+	GetPendingCloseVote(ctx context.Context, questionID, userID string, voteType int, since time.Time) (
+		vote *entity.QuestionCloseVote, exist bool, err error)
+	GetPendingCloseVotes(ctx context.Context, questionIDs []string, voteType int, since time.Time) (
+		votes []*entity.QuestionCloseVote, err error)
+	GetPendingCloseVoteQuestionPage(ctx context.Context, page, pageSize, voteType int, since time.Time) (
+		questionIDs []string, total int64, err error)
+	CountPendingCloseVoteQuestions(ctx context.Context, voteType int, since time.Time) (count int64, err error)
+	UpdateCloseVoteStatus(ctx context.Context, questionID string, voteTypes []int, status int) (err error)
+	ExpireCloseVotes(ctx context.Context, before time.Time) (err error)
+}
+
+// VoteToCloseQuestion close the question directly if the close voting is disabled or the vote is binding,
+// otherwise record the vote and close the question when enough votes are cast.
+func (qs *QuestionService) VoteToCloseQuestion(ctx context.Context, req *schema.CloseQuestionReq) (
+	resp *schema.QuestionCloseVoteResp, err error) {
+	siteWrite, err := qs.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !siteWrite.CloseVoteEnabled || req.BindingVote {
+		if err = qs.CloseQuestion(ctx, req); err != nil {
+			return nil, err
+		}
+		return &schema.QuestionCloseVoteResp{Done: true}, nil
+	}
+
+	questionInfo, exist, err := qs.questionRepo.GetQuestion(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	if questionInfo.Status != entity.QuestionStatusAvailable {
+		return nil, errors.BadRequest(reason.QuestionCloseVoteInvalid)
+	}
+	duplicateIDs, err := qs.checkCloseReason(ctx, questionInfo.ID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, resp, err := qs.addCloseVote(ctx, siteWrite, &entity.QuestionCloseVote{
+		QuestionID:   questionInfo.ID,
+		UserID:       req.UserID,
+		VoteType:     entity.QuestionCloseVoteTypeClose,
+		CloseType:    req.CloseType,
+		CloseMsg:     req.CloseMsg,
+		DuplicateIDs: strings.Join(duplicateIDs, ","),
+		Status:       entity.QuestionCloseVoteStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.VoteCount < resp.Threshold {
+		return resp, nil
+	}
+
+	closeReq := getCloseReqByVotes(votes)
+	closeReq.ID = questionInfo.ID
+	closeReq.UserID = req.UserID
+	if err = qs.CloseQuestion(ctx, closeReq); err != nil {
+		return nil, err
+	}
+	resp.Done = true
+	return resp, nil
+}
+
+// VoteToReopenQuestion reopen the question directly if the close voting is disabled or the vote is binding,
+// otherwise record the vote and reopen the question when enough votes are cast.
+func (qs *QuestionService) VoteToReopenQuestion(ctx context.Context, req *schema.ReopenQuestionReq) (
+	resp *schema.QuestionCloseVoteResp, err error) {
+	siteWrite, err := qs.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !siteWrite.CloseVoteEnabled || req.BindingVote {
+		if err = qs.ReopenQuestion(ctx, req); err != nil {
+			return nil, err
+		}
+		return &schema.QuestionCloseVoteResp{Done: true}, nil
+	}
+
+	questionInfo, exist, err := qs.questionRepo.GetQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	if questionInfo.Status != entity.QuestionStatusClosed {
+		return nil, errors.BadRequest(reason.QuestionCloseVoteInvalid)
+	}
+
+	_, resp, err = qs.addCloseVote(ctx, siteWrite, &entity.QuestionCloseVote{
+		QuestionID: questionInfo.ID,
+		UserID:     req.UserID,
+		VoteType:   entity.QuestionCloseVoteTypeReopen,
+		Status:     entity.QuestionCloseVoteStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.VoteCount < resp.Threshold {
+		return resp, nil
+	}
+	if err = qs.ReopenQuestion(ctx, req); err != nil {
+		return nil, err
+	}
+	resp.Done = true
+	return resp, nil
+}
+
+// addCloseVote add the vote and get all the valid votes of the question
+func (qs *QuestionService) addCloseVote(ctx context.Context, siteWrite *schema.SiteWriteResp,
+	vote *entity.QuestionCloseVote) (votes []*entity.QuestionCloseVote, resp *schema.QuestionCloseVoteResp, err error) {
+	since := getCloseVoteSince(siteWrite)
+	_, exist, err := qs.closeVoteRepo.GetPendingCloseVote(ctx, vote.QuestionID, vote.UserID, vote.VoteType, since)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exist {
+		return nil, nil, errors.BadRequest(reason.QuestionCloseVoteAlreadyCast)
+	}
+	if err = qs.closeVoteRepo.AddCloseVote(ctx, vote); err != nil {
+		return nil, nil, err
+	}
+
+	votes, err = qs.closeVoteRepo.GetPendingCloseVotes(ctx, []string{vote.QuestionID}, vote.VoteType, since)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp = &schema.QuestionCloseVoteResp{
+		VoteCount: len(votes),
+		Threshold: siteWrite.GetCloseVoteThreshold(),
+	}
+	return votes, resp, nil
+}
+
+// getCloseReqByVotes the question is closed for the reason chosen by most voters,
+// if there is a tie, the reason of the latest vote wins. The message of the latest vote with the reason is used.
+func getCloseReqByVotes(votes []*entity.QuestionCloseVote) *schema.CloseQuestionReq {
+	reasonCount := make(map[int]int)
+	var chosen *entity.QuestionCloseVote
+	for _, vote := range votes {
+		reasonCount[vote.CloseType]++
+		if chosen == nil || reasonCount[vote.CloseType] >= reasonCount[chosen.CloseType] {
+			chosen = vote
+		}
+	}
+	req := &schema.CloseQuestionReq{}
+	if chosen == nil {
+		return req
+	}
+	req.CloseType = chosen.CloseType
+	req.CloseMsg = chosen.CloseMsg
+	if len(chosen.DuplicateIDs) > 0 {
+		req.DuplicateIDs = strings.Split(chosen.DuplicateIDs, ",")
+	}
+	return req
+}
+
+// resolveCloseVotes the pending votes are completed or dismissed once the question status is changed
+func (qs *QuestionService) resolveCloseVotes(ctx context.Context, questionID string, voteTypes []int, status int) {
+	if err := qs.closeVoteRepo.UpdateCloseVoteStatus(ctx, questionID, voteTypes, status); err != nil {
+		log.Errorf("update close votes of question %s failed: %v", questionID, err)
+	}
+}
+
+// GetCloseVoteQueuePage get the questions with the pending votes to close or reopen, the latest voted first
+func (qs *QuestionService) GetCloseVoteQueuePage(ctx context.Context, req *schema.GetCloseVoteQueuePageReq) (
+	pageModel *pager.PageModel, err error) {
+	siteWrite, err := qs.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*schema.GetCloseVoteQueueResp, 0)
+	if !siteWrite.CloseVoteEnabled {
+		return pager.NewPageModel(0, resp), nil
+	}
+
+	voteType := schema.CloseVoteTypeMapping[req.VoteType]
+	since := getCloseVoteSince(siteWrite)
+	questionIDs, total, err := qs.closeVoteRepo.GetPendingCloseVoteQuestionPage(ctx, req.Page, req.PageSize,
+		voteType, since)
+	if err != nil {
+		return nil, err
+	}
+	votes, err := qs.closeVoteRepo.GetPendingCloseVotes(ctx, questionIDs, voteType, since)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := qs.questionRepo.FindByID(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	questionMapping := make(map[string]*entity.Question, len(questions))
+	for _, question := range questions {
+		questionMapping[question.ID] = question
+	}
+	userIDs := make([]string, 0, len(votes))
+	questionVotes := make(map[string][]*entity.QuestionCloseVote, len(questionIDs))
+	for _, vote := range votes {
+		userIDs = append(userIDs, vote.UserID)
+		questionVotes[vote.QuestionID] = append(questionVotes[vote.QuestionID], vote)
+	}
+	userInfoMapping, err := qs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	reasonMapping := make(map[int]*schema.ReasonItem)
+	for _, questionID := range questionIDs {
+		question, ok := questionMapping[questionID]
+		if !ok {
+			continue
+		}
+		// the status is already changed in other ways, such as deleted by the author
+		if (voteType == entity.QuestionCloseVoteTypeClose && question.Status != entity.QuestionStatusAvailable) ||
+			(voteType == entity.QuestionCloseVoteTypeReopen && question.Status != entity.QuestionStatusClosed) {
+			qs.resolveCloseVotes(ctx, question.ID, []int{voteType}, entity.QuestionCloseVoteStatusDismissed)
+			continue
+		}
+		item := &schema.GetCloseVoteQueueResp{
+			QuestionID: question.ID,
+			Title:      question.Title,
+			UrlTitle:   htmltext.UrlTitle(question.Title),
+			VoteType:   req.VoteType,
+			VoteCount:  len(questionVotes[questionID]),
+			Threshold:  siteWrite.GetCloseVoteThreshold(),
+			Votes:      make([]*schema.CloseVoteInfo, 0),
+		}
+		if handler.GetEnableShortID(ctx) {
+			item.QuestionID = uid.EnShortID(question.ID)
+		}
+		for _, vote := range questionVotes[questionID] {
+			if vote.UserID == req.UserID {
+				item.Voted = true
+			}
+			info := &schema.CloseVoteInfo{
+				User:      userInfoMapping[vote.UserID],
+				CloseMsg:  vote.CloseMsg,
+				CreatedAt: vote.CreatedAt.Unix(),
+			}
+			if vote.CloseType > 0 {
+				info.Reason = qs.getCloseVoteReason(ctx, reasonMapping, vote.CloseType)
+			}
+			item.Votes = append(item.Votes, info)
+		}
+		resp = append(resp, item)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+func (qs *QuestionService) getCloseVoteReason(ctx context.Context, reasonMapping map[int]*schema.ReasonItem,
+	closeType int) *schema.ReasonItem {
+	if reasonItem, ok := reasonMapping[closeType]; ok {
+		return reasonItem
+	}
+	reasonItem := &schema.ReasonItem{ReasonType: closeType}
+	cf, err := qs.configService.GetConfigByID(ctx, closeType)
+	if err != nil {
+		log.Error(err)
+	} else {
+		_ = json.Unmarshal(cf.GetByteValue(), reasonItem)
+		reasonItem.Translate(cf.Key, handler.GetLangByCtx(ctx))
+	}
+	reasonMapping[closeType] = reasonItem
+	return reasonItem
+}
+
+// GetCloseVoteQueueCount count the questions with the pending votes of the vote types
+func (qs *QuestionService) GetCloseVoteQueueCount(ctx context.Context, voteTypes []int) (count int64, err error) {
+	siteWrite, err := qs.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !siteWrite.CloseVoteEnabled {
+		return 0, nil
+	}
+	since := getCloseVoteSince(siteWrite)
+	for _, voteType := range voteTypes {
+		c, err := qs.closeVoteRepo.CountPendingCloseVoteQuestions(ctx, voteType, since)
+		if err != nil {
+			return 0, err
+		}
+		count += c
+	}
+	return count, nil
+}
+
+// DismissCloseVotes the moderator keeps the question status and dismisses the pending votes
+func (qs *QuestionService) DismissCloseVotes(ctx context.Context, req *schema.DismissCloseVotesReq) (err error) {
+	err = qs.closeVoteRepo.UpdateCloseVoteStatus(ctx, req.QuestionID,
+		[]int{schema.CloseVoteTypeMapping[req.VoteType]}, entity.QuestionCloseVoteStatusDismissed)
+	if err != nil {
+		return err
+	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionQuestionCloseVoteDismiss,
+		ObjectType:     constant.QuestionObjectType,
+		ObjectID:       uid.DeShortID(req.QuestionID),
+		After:          map[string]string{"vote_type": req.VoteType},
+	})
+	return nil
+}
+
+// ExpireCloseVotesCron expire the stale votes which are not completed in time
+func (qs *QuestionService) ExpireCloseVotesCron(ctx context.Context) {
+	siteWrite, err := qs.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if err = qs.closeVoteRepo.ExpireCloseVotes(ctx, getCloseVoteSince(siteWrite)); err != nil {
+		log.Errorf("expire close votes failed: %v", err)
+	}
+}
+
+// getCloseVoteSince the votes cast before the time are stale
+func getCloseVoteSince(siteWrite *schema.SiteWriteResp) time.Time {
+	return time.Now().AddDate(0, 0, -siteWrite.GetCloseVoteExpireDays())
+}
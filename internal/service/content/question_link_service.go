@@ -0,0 +1,202 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// QuestionMergeRepo question merge repository
+type QuestionMergeRepo interface {
+	MergeQuestion(ctx context.Context, sourceQuestionID, targetQuestionID, closeMeta, userID string) (err error)
+}
+
+// GetQuestionLinks get the duplicate and merged relations of the question
+func (qs *QuestionService) GetQuestionLinks(ctx context.Context, req *schema.GetQuestionLinksReq) (
+	resp []*schema.QuestionLinkInfo, err error) {
+	return qs.getLinkedQuestions(ctx, uid.DeShortID(req.QuestionID))
+}
+
+func (qs *QuestionService) getLinkedQuestions(ctx context.Context, questionID string) (
+	resp []*schema.QuestionLinkInfo, err error) {
+	resp = make([]*schema.QuestionLinkInfo, 0)
+	links, err := qs.questionLinkRepo.GetQuestionLinks(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return resp, nil
+	}
+
+	relations := make(map[string]string, len(links))
+	linkedIDs := make([]string, 0, len(links))
+	for _, link := range links {
+		linkedID, relation := link.ToQuestionID, schema.QuestionRelationDuplicateOf
+		switch {
+		case link.FromQuestionID == questionID && link.LinkType == entity.QuestionLinkTypeMerged:
+			relation = schema.QuestionRelationMergedInto
+		case link.ToQuestionID == questionID && link.LinkType == entity.QuestionLinkTypeMerged:
+			linkedID, relation = link.FromQuestionID, schema.QuestionRelationMergedFrom
+		case link.ToQuestionID == questionID:
+			linkedID, relation = link.FromQuestionID, schema.QuestionRelationDuplicatedBy
+		}
+		if _, ok := relations[linkedID]; !ok {
+			linkedIDs = append(linkedIDs, linkedID)
+		}
+		relations[linkedID] = relation
+	}
+
+	questions, err := qs.questionRepo.FindByID(ctx, linkedIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, question := range questions {
+		if question.Status == entity.QuestionStatusDeleted || question.Status == entity.QuestionStatusPending {
+			continue
+		}
+		resp = append(resp, &schema.QuestionLinkInfo{
+			ID:               question.ID,
+			Title:            question.Title,
+			UrlTitle:         htmltext.UrlTitle(question.Title),
+			Status:           question.Status,
+			AnswerCount:      question.AnswerCount,
+			AcceptedAnswerID: uid.EnShortID(question.AcceptedAnswerID),
+			Relation:         relations[uid.DeShortID(question.ID)],
+		})
+	}
+	return resp, nil
+}
+
+// checkDuplicateQuestions the duplicate questions must exist and be visible
+func (qs *QuestionService) checkDuplicateQuestions(ctx context.Context, questionID string, duplicateIDs []string) (
+	err error) {
+	questions, err := qs.questionRepo.FindByID(ctx, duplicateIDs)
+	if err != nil {
+		return err
+	}
+	if len(questions) != len(duplicateIDs) {
+		return errors.BadRequest(reason.QuestionDuplicateInvalid)
+	}
+	for _, question := range questions {
+		if uid.DeShortID(question.ID) == questionID ||
+			question.Status == entity.QuestionStatusDeleted || question.Status == entity.QuestionStatusPending {
+			return errors.BadRequest(reason.QuestionDuplicateInvalid)
+		}
+	}
+	return nil
+}
+
+// isMergedQuestion whether the question has been merged into another question
+func (qs *QuestionService) isMergedQuestion(ctx context.Context, questionID string) (merged bool, err error) {
+	links, err := qs.questionLinkRepo.GetQuestionLinks(ctx, questionID)
+	if err != nil {
+		return false, err
+	}
+	for _, link := range links {
+		if link.FromQuestionID == questionID && link.LinkType == entity.QuestionLinkTypeMerged {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MergeQuestion move answers, comments, votes, followers and collections from the source question to the target,
+// then the source question is closed as duplicate and linked to the target question.
+func (qs *QuestionService) MergeQuestion(ctx context.Context, req *schema.MergeQuestionReq) (err error) {
+	sourceID, targetID := uid.DeShortID(req.SourceID), uid.DeShortID(req.TargetID)
+	if sourceID == targetID {
+		return errors.BadRequest(reason.QuestionMergeInvalid)
+	}
+	source, exist, err := qs.questionRepo.GetQuestion(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	if !exist || source.Status == entity.QuestionStatusDeleted || source.Status == entity.QuestionStatusPending {
+		return errors.BadRequest(reason.QuestionNotFound)
+	}
+	target, exist, err := qs.questionRepo.GetQuestion(ctx, targetID)
+	if err != nil {
+		return err
+	}
+	if !exist || target.Status == entity.QuestionStatusDeleted || target.Status == entity.QuestionStatusPending {
+		return errors.BadRequest(reason.QuestionNotFound)
+	}
+	// the closed target can not accept the answers, and the merged target leads to a redirect chain
+	if target.Status == entity.QuestionStatusClosed {
+		return errors.BadRequest(reason.QuestionMergeInvalid)
+	}
+	for _, questionID := range []string{sourceID, targetID} {
+		merged, err := qs.isMergedQuestion(ctx, questionID)
+		if err != nil {
+			return err
+		}
+		if merged {
+			return errors.BadRequest(reason.QuestionMergeInvalid)
+		}
+	}
+	duplicateReason, err := qs.configService.GetConfigByKey(ctx, constant.ReasonADuplicate)
+	if err != nil {
+		return err
+	}
+
+	// the answers are moved to the target, so the bounty of the source can not be awarded any more
+	if err = qs.bountyService.CloseBounty(ctx, sourceID); err != nil {
+		return err
+	}
+	closeMeta, _ := json.Marshal(schema.CloseQuestionMeta{
+		CloseType:    duplicateReason.ID,
+		DuplicateIDs: []string{targetID},
+	})
+	err = qs.questionMergeRepo.MergeQuestion(ctx, sourceID, targetID, string(closeMeta), req.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, questionID := range []string{sourceID, targetID} {
+		_ = qs.questionRepo.UpdateSearch(ctx, questionID)
+		qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
+			UserID:           req.UserID,
+			ObjectID:         questionID,
+			OriginalObjectID: questionID,
+			ActivityTypeKey:  constant.ActQuestionMerged,
+		})
+	}
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionQuestionMerge,
+		ObjectType:     constant.QuestionObjectType,
+		ObjectID:       sourceID,
+		Before:         map[string]string{"status": entity.AdminQuestionSearchStatusIntToString[source.Status]},
+		After: map[string]string{
+			"status":    entity.AdminQuestionSearchStatusIntToString[entity.QuestionStatusClosed],
+			"merged_to": targetID,
+		},
+	})
+	return nil
+}
@@ -38,8 +38,11 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/apache/incubator-answer/internal/service/bounty"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/draft"
 	"github.com/apache/incubator-answer/internal/service/export"
 	metacommon "github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
@@ -90,6 +93,13 @@ type QuestionService struct {
 	reviewService                    *review.ReviewService
 	configService                    *config.ConfigService
 	eventQueueService                event_queue.EventQueueService
+	bountyRepo                       bounty.BountyRepo
+	bountyService                    *bounty.BountyService
+	questionLinkRepo                 questioncommon.QuestionLinkRepo
+	questionMergeRepo                QuestionMergeRepo
+	draftService                     *draft.DraftService
+	auditLogService                  *audit_log.AuditLogService
+	closeVoteRepo                    QuestionCloseVoteRepo
 }
 
 func NewQuestionService(
@@ -115,6 +125,13 @@ func NewQuestionService(
 	reviewService *review.ReviewService,
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
+	bountyRepo bounty.BountyRepo,
+	bountyService *bounty.BountyService,
+	questionLinkRepo questioncommon.QuestionLinkRepo,
+	questionMergeRepo QuestionMergeRepo,
+	draftService *draft.DraftService,
+	auditLogService *audit_log.AuditLogService,
+	closeVoteRepo QuestionCloseVoteRepo,
 ) *QuestionService {
 	return &QuestionService{
 		activityRepo:                     activityRepo,
@@ -139,6 +156,13 @@ func NewQuestionService(
 		reviewService:                    reviewService,
 		configService:                    configService,
 		eventQueueService:                eventQueueService,
+		bountyRepo:                       bountyRepo,
+		bountyService:                    bountyService,
+		questionLinkRepo:                 questionLinkRepo,
+		questionMergeRepo:                questionMergeRepo,
+		draftService:                     draftService,
+		auditLogService:                  auditLogService,
+		closeVoteRepo:                    closeVoteRepo,
 	}
 }
 
@@ -150,39 +174,91 @@ func (qs *QuestionService) CloseQuestion(ctx context.Context, req *schema.CloseQ
 	if !has {
 		return nil
 	}
-
-	cf, err := qs.configService.GetConfigByID(ctx, req.CloseType)
-	if err != nil || cf == nil {
-		return errors.BadRequest(reason.ReportNotFound)
-	}
-	if cf.Key == constant.ReasonADuplicate && !checker.IsURL(req.CloseMsg) {
-		return errors.BadRequest(reason.InvalidURLError)
+	duplicateIDs, err := qs.checkCloseReason(ctx, questionInfo.ID, req)
+	if err != nil {
+		return err
 	}
+	previousStatus := questionInfo.Status
 
 	questionInfo.Status = entity.QuestionStatusClosed
 	err = qs.questionRepo.UpdateQuestionStatus(ctx, questionInfo.ID, questionInfo.Status)
 	if err != nil {
 		return err
 	}
+	qs.resolveCloseVotes(ctx, questionInfo.ID, []int{entity.QuestionCloseVoteTypeClose},
+		entity.QuestionCloseVoteStatusCompleted)
 
 	closeMeta, _ := json.Marshal(schema.CloseQuestionMeta{
-		CloseType: req.CloseType,
-		CloseMsg:  req.CloseMsg,
+		CloseType:    req.CloseType,
+		CloseMsg:     req.CloseMsg,
+		DuplicateIDs: duplicateIDs,
 	})
 	err = qs.metaService.AddMeta(ctx, req.ID, entity.QuestionCloseReasonKey, string(closeMeta))
 	if err != nil {
 		return err
 	}
 
+	if len(duplicateIDs) > 0 {
+		links := make([]*entity.QuestionLink, 0, len(duplicateIDs))
+		for _, duplicateID := range duplicateIDs {
+			links = append(links, &entity.QuestionLink{
+				FromQuestionID: questionInfo.ID,
+				ToQuestionID:   duplicateID,
+				LinkType:       entity.QuestionLinkTypeDuplicate,
+				UserID:         req.UserID,
+			})
+		}
+		if err = qs.questionLinkRepo.AddQuestionLinks(ctx, links); err != nil {
+			return err
+		}
+	}
+
 	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 		UserID:           req.UserID,
 		ObjectID:         questionInfo.ID,
 		OriginalObjectID: questionInfo.ID,
 		ActivityTypeKey:  constant.ActQuestionClosed,
 	})
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionQuestionClose,
+		ObjectType:     constant.QuestionObjectType,
+		ObjectID:       questionInfo.ID,
+		Before:         map[string]any{"status": entity.AdminQuestionSearchStatusIntToString[previousStatus]},
+		After: map[string]any{
+			"status":        entity.AdminQuestionSearchStatusIntToString[questionInfo.Status],
+			"close_type":    req.CloseType,
+			"close_msg":     req.CloseMsg,
+			"duplicate_ids": duplicateIDs,
+		},
+	})
 	return nil
 }
 
+// checkCloseReason check the close reason and the duplicate questions, only the duplicate reason keeps the questions
+func (qs *QuestionService) checkCloseReason(ctx context.Context, questionID string, req *schema.CloseQuestionReq) (
+	duplicateIDs []string, err error) {
+	cf, err := qs.configService.GetConfigByID(ctx, req.CloseType)
+	if err != nil || cf == nil {
+		return nil, errors.BadRequest(reason.ReportNotFound)
+	}
+	if cf.Key != constant.ReasonADuplicate {
+		return nil, nil
+	}
+	duplicateIDs = make([]string, 0, len(req.DuplicateIDs))
+	for _, duplicateID := range req.DuplicateIDs {
+		duplicateIDs = append(duplicateIDs, uid.DeShortID(duplicateID))
+	}
+	if len(duplicateIDs) > 0 {
+		if err = qs.checkDuplicateQuestions(ctx, questionID, duplicateIDs); err != nil {
+			return nil, err
+		}
+	} else if !checker.IsURL(req.CloseMsg) {
+		return nil, errors.BadRequest(reason.InvalidURLError)
+	}
+	return duplicateIDs, nil
+}
+
 // ReopenQuestion reopen question
 func (qs *QuestionService) ReopenQuestion(ctx context.Context, req *schema.ReopenQuestionReq) error {
 	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, req.QuestionID)
@@ -192,18 +268,32 @@ func (qs *QuestionService) ReopenQuestion(ctx context.Context, req *schema.Reope
 	if !has {
 		return nil
 	}
+	previousStatus := questionInfo.Status
 
 	questionInfo.Status = entity.QuestionStatusAvailable
 	err = qs.questionRepo.UpdateQuestionStatus(ctx, questionInfo.ID, questionInfo.Status)
 	if err != nil {
 		return err
 	}
+	qs.resolveCloseVotes(ctx, questionInfo.ID, []int{entity.QuestionCloseVoteTypeReopen},
+		entity.QuestionCloseVoteStatusCompleted)
+	if err = qs.questionLinkRepo.RemoveDuplicateLinks(ctx, questionInfo.ID); err != nil {
+		return err
+	}
 	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 		UserID:           req.UserID,
 		ObjectID:         questionInfo.ID,
 		OriginalObjectID: questionInfo.ID,
 		ActivityTypeKey:  constant.ActQuestionReopened,
 	})
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionQuestionReopen,
+		ObjectType:     constant.QuestionObjectType,
+		ObjectID:       questionInfo.ID,
+		Before:         map[string]string{"status": entity.AdminQuestionSearchStatusIntToString[previousStatus]},
+		After:          map[string]string{"status": entity.AdminQuestionSearchStatusIntToString[questionInfo.Status]},
+	})
 	return nil
 }
 
@@ -399,6 +489,7 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	}
 	qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionCreate, req.UserID).TID(question.ID).
 		QID(question.ID, question.UserID))
+	qs.draftService.RemovePublishedDraft(ctx, req.UserID, req.DraftID)
 
 	questionInfo, err = qs.GetQuestion(ctx, question.ID, question.UserID, req.QuestionPermission)
 	return
@@ -421,6 +512,7 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	if questionInfo.Pin == entity.QuestionPin && req.Operation == schema.QuestionOperationHide {
 		return nil
 	}
+	before := questionOperationAuditSnapshot(questionInfo)
 
 	switch req.Operation {
 	case schema.QuestionOperationHide:
@@ -447,6 +539,10 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 		questionInfo.Pin = entity.QuestionPin
 	case schema.QuestionOperationUnPin:
 		questionInfo.Pin = entity.QuestionUnPin
+	case schema.QuestionOperationWiki:
+		questionInfo.Wiki = true
+	case schema.QuestionOperationUnWiki:
+		questionInfo.Wiki = false
 	}
 
 	err = qs.questionRepo.UpdateQuestionOperation(ctx, questionInfo)
@@ -459,6 +555,8 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	actMap[schema.QuestionOperationUnPin] = constant.ActQuestionUnPin
 	actMap[schema.QuestionOperationHide] = constant.ActQuestionHide
 	actMap[schema.QuestionOperationShow] = constant.ActQuestionShow
+	actMap[schema.QuestionOperationWiki] = constant.ActQuestionWiki
+	actMap[schema.QuestionOperationUnWiki] = constant.ActQuestionUnWiki
 	_, ok := actMap[req.Operation]
 	if ok {
 		qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
@@ -469,9 +567,36 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 		})
 	}
 
+	auditLogActions := map[string]string{
+		schema.QuestionOperationPin:    constant.AuditLogActionQuestionPin,
+		schema.QuestionOperationUnPin:  constant.AuditLogActionQuestionUnPin,
+		schema.QuestionOperationHide:   constant.AuditLogActionQuestionHide,
+		schema.QuestionOperationShow:   constant.AuditLogActionQuestionShow,
+		schema.QuestionOperationWiki:   constant.AuditLogActionQuestionWiki,
+		schema.QuestionOperationUnWiki: constant.AuditLogActionQuestionUnWiki,
+	}
+	if action, ok := auditLogActions[req.Operation]; ok {
+		qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+			OperatorUserID: req.UserID,
+			Action:         action,
+			ObjectType:     constant.QuestionObjectType,
+			ObjectID:       questionInfo.ID,
+			Before:         before,
+			After:          questionOperationAuditSnapshot(questionInfo),
+		})
+	}
 	return nil
 }
 
+// questionOperationAuditSnapshot the pin, show and wiki state of the question recorded in the audit log
+func questionOperationAuditSnapshot(questionInfo *entity.Question) map[string]any {
+	return map[string]any{
+		"pin":  questionInfo.Pin == entity.QuestionPin,
+		"show": questionInfo.Show == entity.QuestionShow,
+		"wiki": questionInfo.Wiki,
+	}
+}
+
 // RemoveQuestion delete question
 func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.RemoveQuestionReq) (err error) {
 	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, req.ID)
@@ -517,6 +642,9 @@ func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.Remov
 	if err != nil {
 		return err
 	}
+	if err = qs.bountyService.CloseBounty(ctx, questionInfo.ID); err != nil {
+		log.Errorf("close bounty of deleted question %s failed: %v", questionInfo.ID, err)
+	}
 
 	userQuestionCount, err := qs.questioncommon.GetUserQuestionCount(ctx, questionInfo.UserID)
 	if err != nil {
@@ -795,6 +923,30 @@ func (qs *QuestionService) notificationInviteUser(
 	}
 }
 
+// CheckEditConflict check whether the edit is based on the latest revision of the question
+func (qs *QuestionService) CheckEditConflict(ctx context.Context, req *schema.QuestionUpdate) (
+	resp *schema.RevisionConflictResp, err error) {
+	if len(req.RevisionID) == 0 {
+		return nil, nil
+	}
+	questionInfo, exist, err := qs.questionRepo.GetQuestion(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	return qs.revisionService.CheckEditConflict(ctx, &schema.EditConflictCheck{
+		ObjectID:         questionInfo.ID,
+		BaseRevisionID:   req.RevisionID,
+		LatestRevisionID: questionInfo.RevisionID,
+		Title:            req.Title,
+		Content:          req.Content,
+		LatestTitle:      questionInfo.Title,
+		LatestContent:    questionInfo.OriginalText,
+	})
+}
+
 // UpdateQuestion update question
 func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.QuestionUpdate) (questionInfo any, err error) {
 	var canUpdate bool
@@ -973,6 +1125,9 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		question.Status == entity.QuestionStatusPending) && !per.CanReopen && question.UserID != userID {
 		return nil, errors.NotFound(reason.QuestionNotFound)
 	}
+	if question.Wiki && per.CanWikiEdit {
+		per.CanEdit = true
+	}
 	if question.Status != entity.QuestionStatusClosed {
 		per.CanReopen = false
 	}
@@ -1007,11 +1162,18 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		question.Operation = operation
 	}
 
+	question.LinkedQuestions, err = qs.getLinkedQuestions(ctx, uid.DeShortID(question.ID))
+	if err != nil {
+		log.Error(err)
+	}
+
 	question.Description = htmltext.FetchExcerpt(question.HTML, "...", 240)
 	question.MemberActions = permission.GetQuestionPermission(ctx, userID, question.UserID, question.Status,
 		per.CanEdit, per.CanDelete,
 		per.CanClose, per.CanReopen, per.CanPin, per.CanHide, per.CanUnPin, per.CanShow,
 		per.CanRecover)
+	question.MemberActions = append(question.MemberActions,
+		permission.GetWikiPermission(ctx, userID, question.UserID, per.CanWiki, question.Wiki)...)
 	question.ExtendsActions = permission.GetQuestionExtendsPermission(ctx, per.CanInviteOtherToAnswer)
 	return question, nil
 }
@@ -1363,9 +1525,26 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 	if err != nil {
 		return nil, 0, err
 	}
+	qs.setBountyAmount(ctx, questions)
 	return questions, total, nil
 }
 
+// setBountyAmount set the amount of the active bounty for each question
+func (qs *QuestionService) setBountyAmount(ctx context.Context, questions []*schema.QuestionPageResp) {
+	questionIDs := make([]string, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, uid.DeShortID(question.ID))
+	}
+	amounts, err := qs.bountyRepo.GetActiveBountyAmounts(ctx, questionIDs)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, question := range questions {
+		question.BountyAmount = amounts[uid.DeShortID(question.ID)]
+	}
+}
+
 // GetRecommendQuestionPage retrieves recommended question page based on following tags and questions.
 func (qs *QuestionService) GetRecommendQuestionPage(ctx context.Context, req *schema.QuestionPageReq) (
 	questions []*schema.QuestionPageResp, total int64, err error) {
@@ -1423,9 +1602,24 @@ func (qs *QuestionService) AdminSetQuestionStatus(ctx context.Context, req *sche
 	if err != nil {
 		return err
 	}
+	// the pending votes are outdated once the status is set by the admin
+	qs.resolveCloseVotes(ctx, questionInfo.ID,
+		[]int{entity.QuestionCloseVoteTypeClose, entity.QuestionCloseVoteTypeReopen},
+		entity.QuestionCloseVoteStatusDismissed)
+	qs.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionQuestionStatusUpdate,
+		ObjectType:     constant.QuestionObjectType,
+		ObjectID:       questionInfo.ID,
+		Before:         map[string]string{"status": entity.AdminQuestionSearchStatusIntToString[questionInfo.Status]},
+		After:          map[string]string{"status": req.Status},
+	})
 
 	msg := &schema.NotificationMsg{}
 	if setStatus == entity.QuestionStatusDeleted {
+		if err = qs.bountyService.CloseBounty(ctx, questionInfo.ID); err != nil {
+			log.Errorf("close bounty of deleted question %s failed: %v", questionInfo.ID, err)
+		}
 		// #2372 In order to simplify the process and complexity, as well as to consider if it is in-house,
 		// facing the problem of recovery.
 		//err = qs.answerActivityService.DeleteQuestion(ctx, questionInfo.ID, questionInfo.CreatedAt, questionInfo.VoteCount)
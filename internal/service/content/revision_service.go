@@ -34,6 +34,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
@@ -52,6 +53,13 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
+// reviewTaskQueueLabels the reviewing type labels of the review task queues
+var reviewTaskQueueLabels = map[string]string{
+	schema.ReviewTaskQueueFirstPost:  constant.ReviewFirstPostLabel,
+	schema.ReviewTaskQueueLateAnswer: constant.ReviewLateAnswerLabel,
+	schema.ReviewTaskQueueLowQuality: constant.ReviewLowQualityPostLabel,
+}
+
 // RevisionService user service
 type RevisionService struct {
 	revisionRepo             revision.RevisionRepo
@@ -68,6 +76,8 @@ type RevisionService struct {
 	reportRepo               report_common.ReportRepo
 	reviewService            *review.ReviewService
 	reviewActivity           activity.ReviewActivityRepo
+	questionService          *QuestionService
+	auditLogService          *audit_log.AuditLogService
 }
 
 func NewRevisionService(
@@ -85,6 +95,8 @@ func NewRevisionService(
 	reportRepo report_common.ReportRepo,
 	reviewService *review.ReviewService,
 	reviewActivity activity.ReviewActivityRepo,
+	questionService *QuestionService,
+	auditLogService *audit_log.AuditLogService,
 ) *RevisionService {
 	return &RevisionService{
 		revisionRepo:             revisionRepo,
@@ -101,6 +113,8 @@ func NewRevisionService(
 		reportRepo:               reportRepo,
 		reviewService:            reviewService,
 		reviewActivity:           reviewActivity,
+		questionService:          questionService,
+		auditLogService:          auditLogService,
 	}
 }
 
@@ -117,7 +131,11 @@ func (rs *RevisionService) RevisionAudit(ctx context.Context, req *schema.Revisi
 	}
 	if req.Operation == schema.RevisionAuditReject {
 		err = rs.revisionRepo.UpdateStatus(ctx, req.ID, entity.RevisionReviewRejectStatus, req.UserID)
-		return
+		if err != nil {
+			return err
+		}
+		rs.addRevisionAuditLog(ctx, req, revisioninfo)
+		return nil
 	}
 	if req.Operation == schema.RevisionAuditApprove {
 		objectType, objectTypeerr := obj.GetObjectTypeStrByObjectID(revisioninfo.ObjectID)
@@ -174,12 +192,27 @@ func (rs *RevisionService) RevisionAudit(ctx context.Context, req *schema.Revisi
 			ObjectType:     objectType,
 		}
 		rs.notificationQueueService.Send(ctx, msg)
+		rs.addRevisionAuditLog(ctx, req, revisioninfo)
 		return
 	}
 
 	return nil
 }
 
+// addRevisionAuditLog record the reviewer approved or rejected the revision
+func (rs *RevisionService) addRevisionAuditLog(ctx context.Context, req *schema.RevisionAuditReq,
+	revisionInfo *entity.Revision) {
+	objectType, _ := obj.GetObjectTypeStrByObjectID(revisionInfo.ObjectID)
+	rs.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionRevisionReview,
+		ObjectType:     objectType,
+		ObjectID:       revisionInfo.ObjectID,
+		Before:         map[string]string{"revision_id": revisionInfo.ID, "status": "unreviewed"},
+		After:          map[string]string{"revision_id": revisionInfo.ID, "operation": req.Operation},
+	})
+}
+
 func (rs *RevisionService) revisionAuditQuestion(ctx context.Context, revisionitem *schema.GetRevisionResp) (err error) {
 	questioninfo, ok := revisionitem.ContentParsed.(*schema.QuestionInfoResp)
 	if ok {
@@ -416,6 +449,191 @@ func (rs *RevisionService) GetRevisionList(ctx context.Context, req *schema.GetR
 	return
 }
 
+// revisionDiffContent the fields of the revision content to be compared
+type revisionDiffContent struct {
+	Title    string
+	SlugName string
+	Tags     []string
+	Content  string
+}
+
+// GetRevisionDiff get the diff between two revisions of the same question, answer, tag or comment
+func (rs *RevisionService) GetRevisionDiff(ctx context.Context, req *schema.GetRevisionDiffReq) (
+	resp *schema.GetRevisionDiffResp, err error) {
+	source, err := rs.getComparableRevision(ctx, req.SourceID, req)
+	if err != nil {
+		return nil, err
+	}
+	target, err := rs.getComparableRevision(ctx, req.TargetID, req)
+	if err != nil {
+		return nil, err
+	}
+	if source.ObjectID != target.ObjectID {
+		return nil, errors.BadRequest(reason.RequestFormatError)
+	}
+	if err = rs.checkCanViewPost(ctx, source.ObjectID, req.UserID, req.CanViewDeleted); err != nil {
+		return nil, err
+	}
+
+	sourceContent, err := rs.parseRevisionDiffContent(source)
+	if err != nil {
+		return nil, err
+	}
+	targetContent, err := rs.parseRevisionDiffContent(target)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.GetRevisionDiffResp{
+		ObjectID:   source.ObjectID,
+		ObjectType: constant.ObjectTypeNumberMapping[source.ObjectType],
+		SourceID:   source.ID,
+		TargetID:   target.ID,
+		Title:      schema.NewRevisionTextDiff(sourceContent.Title, targetContent.Title),
+		Content:    schema.NewRevisionTextDiff(sourceContent.Content, targetContent.Content),
+	}
+	switch resp.ObjectType {
+	case constant.QuestionObjectType:
+		resp.Tags = schema.NewRevisionTagsDiff(sourceContent.Tags, targetContent.Tags)
+	case constant.TagObjectType:
+		resp.SlugName = schema.NewRevisionTextDiff(sourceContent.SlugName, targetContent.SlugName)
+	}
+	if (resp.ObjectType == constant.QuestionObjectType || resp.ObjectType == constant.AnswerObjectType) &&
+		handler.GetEnableShortID(ctx) {
+		resp.ObjectID = uid.EnShortID(resp.ObjectID)
+	}
+	return resp, nil
+}
+
+// getComparableRevision get the revision, the same as the revision list, only the approved revisions are visible,
+// except that the reviewers can compare the unreviewed and rejected revisions in the review queue
+func (rs *RevisionService) getComparableRevision(ctx context.Context, revisionID string, req *schema.GetRevisionDiffReq) (
+	rev *entity.Revision, err error) {
+	rev, exist, err := rs.revisionRepo.GetRevisionByID(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	if rev.Status != entity.RevisioNnormalStatus && rev.Status != entity.RevisionReviewPassStatus && !req.CanReview {
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	// the edit history of comments is only visible to the moderators
+	if rev.ObjectType == constant.ObjectTypeStrMapping[constant.CommentObjectType] && !req.CanReview {
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	return rev, nil
+}
+
+func (rs *RevisionService) parseRevisionDiffContent(rev *entity.Revision) (content *revisionDiffContent, err error) {
+	content = &revisionDiffContent{Tags: make([]string, 0)}
+	switch constant.ObjectTypeNumberMapping[rev.ObjectType] {
+	case constant.QuestionObjectType:
+		question := &entity.QuestionWithTagsRevision{}
+		err = json.Unmarshal([]byte(rev.Content), question)
+		content.Title, content.Content = question.Title, question.OriginalText
+		for _, tag := range question.Tags {
+			content.Tags = append(content.Tags, tag.SlugName)
+		}
+	case constant.AnswerObjectType:
+		answer := &entity.Answer{}
+		err = json.Unmarshal([]byte(rev.Content), answer)
+		content.Content = answer.OriginalText
+	case constant.TagObjectType:
+		tag := &entity.Tag{}
+		err = json.Unmarshal([]byte(rev.Content), tag)
+		content.Title, content.SlugName, content.Content = tag.DisplayName, tag.SlugName, tag.OriginalText
+	case constant.CommentObjectType:
+		comment := &entity.Comment{}
+		err = json.Unmarshal([]byte(rev.Content), comment)
+		content.Content = comment.OriginalText
+	default:
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return content, nil
+}
+
+// GetContributors get the contributors of the post, sorted by the time of their first revision
+func (rs *RevisionService) GetContributors(ctx context.Context, req *schema.GetContributorsReq) (
+	resp []*schema.GetContributorsResp, err error) {
+	resp = make([]*schema.GetContributorsResp, 0)
+	if err = rs.checkCanViewPost(ctx, req.ObjectID, req.UserID, req.CanViewDeleted); err != nil {
+		return nil, err
+	}
+	revs, err := rs.revisionRepo.GetRevisionList(ctx, &entity.Revision{ObjectID: req.ObjectID})
+	if err != nil {
+		return nil, err
+	}
+
+	contributors := make(map[string]*schema.GetContributorsResp)
+	userIDs := make([]string, 0)
+	// the revisions are sorted by created time desc
+	for i := len(revs) - 1; i >= 0; i-- {
+		r := revs[i]
+		if r.Status != entity.RevisioNnormalStatus && r.Status != entity.RevisionReviewPassStatus {
+			continue
+		}
+		contributor, ok := contributors[r.UserID]
+		if !ok {
+			contributor = &schema.GetContributorsResp{}
+			contributors[r.UserID] = contributor
+			userIDs = append(userIDs, r.UserID)
+		}
+		contributor.RevisionCount++
+		contributor.LastEditTime = r.CreatedAt.Unix()
+	}
+	if len(userIDs) == 0 {
+		return resp, nil
+	}
+
+	userInfoMapping, err := rs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, userID := range userIDs {
+		userInfo, ok := userInfoMapping[userID]
+		if !ok {
+			continue
+		}
+		contributor := contributors[userID]
+		contributor.UserInfo = userInfo
+		resp = append(resp, contributor)
+	}
+	return resp, nil
+}
+
+// checkCanViewPost the same as the post detail, if the question or answer is deleted or pending,
+// only the author and the user who can reopen the question can view it
+func (rs *RevisionService) checkCanViewPost(ctx context.Context, objectID, userID string, canViewDeleted bool) (err error) {
+	objInfo, err := rs.objectInfoService.GetInfo(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	if canViewDeleted {
+		return nil
+	}
+	switch objInfo.ObjectType {
+	case constant.QuestionObjectType:
+		if (objInfo.QuestionStatus == entity.QuestionStatusDeleted ||
+			objInfo.QuestionStatus == entity.QuestionStatusPending) && objInfo.ObjectCreatorUserID != userID {
+			return errors.NotFound(reason.QuestionNotFound)
+		}
+	case constant.AnswerObjectType:
+		if objInfo.QuestionStatus == entity.QuestionStatusDeleted || objInfo.QuestionStatus == entity.QuestionStatusPending {
+			return errors.NotFound(reason.QuestionNotFound)
+		}
+		if (objInfo.AnswerStatus == entity.AnswerStatusDeleted ||
+			objInfo.AnswerStatus == entity.AnswerStatusPending) && objInfo.ObjectCreatorUserID != userID {
+			return errors.NotFound(reason.AnswerNotFound)
+		}
+	}
+	return nil
+}
+
 func (rs *RevisionService) parseItem(ctx context.Context, item *schema.GetRevisionResp) {
 	var (
 		err          error
@@ -425,6 +643,8 @@ func (rs *RevisionService) parseItem(ctx context.Context, item *schema.GetRevisi
 		answerInfo   *schema.AnswerInfo
 		tag          entity.Tag
 		tagInfo      *schema.GetTagResp
+		comment      entity.Comment
+		commentInfo  *schema.GetCommentResp
 	)
 
 	shortID := handler.GetEnableShortID(ctx)
@@ -473,6 +693,17 @@ func (rs *RevisionService) parseItem(ctx context.Context, item *schema.GetRevisi
 		}
 		tagInfo.GetExcerpt()
 		item.ContentParsed = tagInfo
+	case constant.ObjectTypeStrMapping["comment"]:
+		err = json.Unmarshal([]byte(item.Content), &comment)
+		if err != nil {
+			break
+		}
+		commentInfo = &schema.GetCommentResp{}
+		commentInfo.SetFromComment(&comment)
+		if shortID {
+			commentInfo.ObjectID = uid.EnShortID(commentInfo.ObjectID)
+		}
+		item.ContentParsed = commentInfo
 	}
 
 	if err != nil {
@@ -510,6 +741,18 @@ func (rs *RevisionService) GetReviewingType(ctx context.Context, req *schema.Get
 				TodoAmount: reviewCount,
 			})
 		}
+	} else {
+		// the tag moderators review the queued posts with their tags
+		reviewCount, isTagModerator, err := rs.reviewService.GetTagModeratorReviewPendingCount(ctx, req.UserID)
+		if err != nil {
+			log.Errorf("get tag moderator review count failed: %v", err)
+		} else if isTagModerator {
+			resp = append(resp, &schema.GetReviewingTypeResp{
+				Name:       string(constant.QueuedPost),
+				Label:      translator.Tr(handler.GetLangByCtx(ctx), constant.ReviewQueuedPostLabel),
+				TodoAmount: reviewCount,
+			})
+		}
 	}
 
 	// get flag amount
@@ -537,5 +780,40 @@ func (rs *RevisionService) GetReviewingType(ctx context.Context, req *schema.Get
 			TodoAmount: countUnreviewedRevision,
 		})
 	}
+
+	// get close and reopen vote amount, the queue is empty if the close voting is disabled
+	voteTypes := make([]int, 0)
+	if req.CanCloseQuestion {
+		voteTypes = append(voteTypes, entity.QuestionCloseVoteTypeClose)
+	}
+	if req.CanReopenQuestion {
+		voteTypes = append(voteTypes, entity.QuestionCloseVoteTypeReopen)
+	}
+	if len(voteTypes) > 0 {
+		closeVoteCount, err := rs.questionService.GetCloseVoteQueueCount(ctx, voteTypes)
+		if err != nil {
+			log.Errorf("get close vote count failed: %v", err)
+		} else if closeVoteCount > 0 {
+			resp = append(resp, &schema.GetReviewingTypeResp{
+				Name:       string(constant.CloseReopenVote),
+				Label:      translator.Tr(handler.GetLangByCtx(ctx), constant.ReviewCloseReopenVoteLabel),
+				TodoAmount: closeVoteCount,
+			})
+		}
+	}
+
+	// get the review task amount of the first posts, late answers and low quality queues
+	for _, queue := range req.ReviewTaskQueues {
+		taskCount, err := rs.reviewService.GetReviewTaskPendingCount(ctx, queue, req.UserID)
+		if err != nil {
+			log.Errorf("get review task count failed: %v", err)
+		} else if taskCount > 0 {
+			resp = append(resp, &schema.GetReviewingTypeResp{
+				Name:       queue,
+				Label:      translator.Tr(handler.GetLangByCtx(ctx), reviewTaskQueueLabels[queue]),
+				TodoAmount: taskCount,
+			})
+		}
+	}
 	return resp, nil
 }
@@ -22,6 +22,7 @@ package content
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/search_common"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
@@ -70,19 +71,61 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 	if finder == nil {
 		if cond.SearchAll() {
 			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchContents(ctx, cond.Words, cond.Tags, cond.UserID, cond.VoteAmount, dto.Page, dto.Size, dto.Order)
+				ss.searchRepo.SearchContents(ctx, cond, dto.Page, dto.Size, dto.Order)
 		} else if cond.SearchQuestion() {
 			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchQuestions(ctx, cond.Words, cond.Tags, cond.NotAccepted, cond.Views, cond.AnswerAmount, dto.Page, dto.Size, dto.Order)
+				ss.searchRepo.SearchQuestions(ctx, cond, dto.Page, dto.Size, dto.Order)
 		} else if cond.SearchAnswer() {
 			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchAnswers(ctx, cond.Words, cond.Tags, cond.Accepted, cond.QuestionID, dto.Page, dto.Size, dto.Order)
+				ss.searchRepo.SearchAnswers(ctx, cond, dto.Page, dto.Size, dto.Order)
 		}
 		return
 	}
 	return ss.searchByPlugin(ctx, finder, cond, dto)
 }
 
+// SearchNewQuestions search the newest questions created in [createdFrom, createdTo) that match the query,
+// the answer conditions of the query will be ignored
+func (ss *SearchService) SearchNewQuestions(ctx context.Context, userID, query string, createdFrom, createdTo int64,
+	page, size int) (resp []*schema.SearchResult, err error) {
+	dto := &schema.SearchDTO{
+		Query:  query,
+		Page:   page,
+		Size:   size,
+		Order:  "newest",
+		UserID: userID,
+	}
+	if _, err = dto.Check(); err != nil {
+		return nil, err
+	}
+	cond := ss.searchParser.ParseStructure(ctx, dto)
+	if cond.SearchAnswer() {
+		return make([]*schema.SearchResult, 0), nil
+	}
+	cond.TargetType = constant.QuestionObjectType
+	if cond.CreatedFrom < createdFrom {
+		cond.CreatedFrom = createdFrom
+	}
+	if cond.CreatedTo == 0 || cond.CreatedTo > createdTo {
+		cond.CreatedTo = createdTo
+	}
+
+	var finder plugin.Search
+	_ = plugin.CallSearch(func(search plugin.Search) error {
+		finder = search
+		return nil
+	})
+	if finder == nil {
+		resp, _, err = ss.searchRepo.SearchQuestions(ctx, cond, dto.Page, dto.Size, dto.Order)
+		return resp, err
+	}
+	searchResp, err := ss.searchByPlugin(ctx, finder, cond, dto)
+	if err != nil {
+		return nil, err
+	}
+	return searchResp.SearchResults, nil
+}
+
 func (ss *SearchService) searchByPlugin(ctx context.Context, finder plugin.Search, cond *schema.SearchCondition, dto *schema.SearchDTO) (resp *schema.SearchResp, err error) {
 	var res []plugin.SearchResult
 	resp = &schema.SearchResp{}
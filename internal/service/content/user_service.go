@@ -42,6 +42,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/user_access_token"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
 	"github.com/apache/incubator-answer/pkg/checker"
@@ -67,6 +68,7 @@ type UserService struct {
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
 	questionService               *questioncommon.QuestionCommon
 	eventQueueService             event_queue.EventQueueService
+	userAccessTokenService        *user_access_token.UserAccessTokenService
 }
 
 func NewUserService(userRepo usercommon.UserRepo,
@@ -82,6 +84,7 @@ func NewUserService(userRepo usercommon.UserRepo,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
 	questionService *questioncommon.QuestionCommon,
 	eventQueueService event_queue.EventQueueService,
+	userAccessTokenService *user_access_token.UserAccessTokenService,
 ) *UserService {
 	return &UserService{
 		userCommonService:             userCommonService,
@@ -97,6 +100,7 @@ func NewUserService(userRepo usercommon.UserRepo,
 		userNotificationConfigService: userNotificationConfigService,
 		questionService:               questionService,
 		eventQueueService:             eventQueueService,
+		userAccessTokenService:        userAccessTokenService,
 	}
 }
 
@@ -260,6 +264,7 @@ func (us *UserService) UpdatePasswordWhenForgot(ctx context.Context, req *schema
 	}
 	// When the user changes the password, all the current user's tokens are invalid.
 	us.authService.RemoveUserAllTokens(ctx, userInfo.ID)
+	us.revokeUserAccessTokens(ctx, userInfo.ID)
 	return nil
 }
 
@@ -303,9 +308,17 @@ func (us *UserService) UserModifyPassword(ctx context.Context, req *schema.UserM
 	}
 
 	us.authService.RemoveTokensExceptCurrentUser(ctx, userInfo.ID, req.AccessToken)
+	us.revokeUserAccessTokens(ctx, userInfo.ID)
 	return nil
 }
 
+// revokeUserAccessTokens revoke all the personal access tokens of the user after the password is changed
+func (us *UserService) revokeUserAccessTokens(ctx context.Context, userID string) {
+	if err := us.userAccessTokenService.RevokeAllUserAccessTokens(ctx, userID); err != nil {
+		log.Errorf("revoke access tokens of user %s failed: %v", userID, err)
+	}
+}
+
 // UpdateInfo update user info
 func (us *UserService) UpdateInfo(ctx context.Context, req *schema.UpdateInfoRequest) (
 	errFields []*validator.FormErrorField, err error) {
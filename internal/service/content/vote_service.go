@@ -252,6 +252,7 @@ func (vs *VoteService) createVoteOperationInfo(ctx context.Context,
 		OperatingUserID:     userID,
 		VoteUp:              voteUp,
 		VoteDown:            !voteUp,
+		Wiki:                objectInfo.Wiki,
 	}
 	voteOperationInfo.Activities = vs.getActivities(ctx, voteOperationInfo)
 	return voteOperationInfo
@@ -291,6 +292,10 @@ func (vs *VoteService) getActivities(ctx context.Context, op *schema.VoteOperati
 		if strings.Contains(action, "voted") {
 			t.ActivityUserID = op.ObjectCreatorUserID
 			t.TriggerUserID = op.OperatingUserID
+			// community wiki posts don't earn or lose the reputation of the owner
+			if op.Wiki {
+				t.Rank = 0
+			}
 		} else {
 			t.ActivityUserID = op.OperatingUserID
 			t.TriggerUserID = "0"
@@ -0,0 +1,213 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package draft
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// DraftRepo draft repository
+type DraftRepo interface {
+	AddDraft(ctx context.Context, draft *entity.Draft) (err error)
+	UpdateDraft(ctx context.Context, draft *entity.Draft) (affected int64, err error)
+	GetDraft(ctx context.Context, userID string, id int) (draft *entity.Draft, exist bool, err error)
+	GetAnswerDraft(ctx context.Context, userID, questionID string) (draft *entity.Draft, exist bool, err error)
+	GetDraftList(ctx context.Context, userID, objectType string) (drafts []*entity.Draft, err error)
+	CountDraft(ctx context.Context, userID string) (count int64, err error)
+	RemoveDraft(ctx context.Context, userID string, id int) (affected int64, err error)
+	RemoveExpiredDrafts(ctx context.Context, expiredAt time.Time) (affected int64, err error)
+}
+
+// DraftService draft service
+type DraftService struct {
+	draftRepo DraftRepo
+}
+
+// NewDraftService new draft service
+func NewDraftService(draftRepo DraftRepo) *DraftService {
+	return &DraftService{
+		draftRepo: draftRepo,
+	}
+}
+
+// SaveDraft autosave the draft, create a new one if the id is empty.
+// Only one answer draft is kept for each question, so the existing one will be overwritten.
+func (ds *DraftService) SaveDraft(ctx context.Context, req *schema.SaveDraftReq) (
+	resp *schema.SaveDraftResp, err error) {
+	if req.ObjectType == entity.DraftObjectTypeAnswer && len(req.QuestionID) == 0 {
+		return nil, errors.BadRequest(reason.DraftQuestionRequired)
+	}
+	draft := &entity.Draft{
+		ID:            req.ID,
+		UserID:        req.UserID,
+		ObjectType:    req.ObjectType,
+		Title:         req.Title,
+		Content:       req.Content,
+		AnswerContent: req.AnswerContent,
+	}
+	if req.ObjectType == entity.DraftObjectTypeAnswer {
+		draft.QuestionID = req.QuestionID
+		draft.Title, draft.AnswerContent = "", ""
+	} else {
+		tags, _ := json.Marshal(req.Tags)
+		draft.Tags = string(tags)
+	}
+
+	if draft.ID == 0 && draft.ObjectType == entity.DraftObjectTypeAnswer {
+		existDraft, exist, err := ds.draftRepo.GetAnswerDraft(ctx, req.UserID, req.QuestionID)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			draft.ID = existDraft.ID
+		}
+	}
+
+	if draft.ID > 0 {
+		affected, err := ds.draftRepo.UpdateDraft(ctx, draft)
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			return nil, errors.BadRequest(reason.DraftNotFound)
+		}
+		return &schema.SaveDraftResp{ID: draft.ID, UpdatedAt: time.Now().Unix()}, nil
+	}
+
+	count, err := ds.draftRepo.CountDraft(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= schema.DraftMaxAmount {
+		return nil, errors.BadRequest(reason.DraftTooMany)
+	}
+	if err = ds.draftRepo.AddDraft(ctx, draft); err != nil {
+		return nil, err
+	}
+	return &schema.SaveDraftResp{ID: draft.ID, UpdatedAt: draft.UpdatedAt.Unix()}, nil
+}
+
+// GetDraftList get all drafts of the user
+func (ds *DraftService) GetDraftList(ctx context.Context, req *schema.GetDraftListReq) (
+	resp []*schema.DraftInfo, err error) {
+	drafts, err := ds.draftRepo.GetDraftList(ctx, req.UserID, req.ObjectType)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.DraftInfo, 0, len(drafts))
+	for _, draft := range drafts {
+		resp = append(resp, ds.formatDraft(ctx, draft))
+	}
+	return resp, nil
+}
+
+// GetDraft get the draft by id, or the answer draft of the question if the id is empty
+func (ds *DraftService) GetDraft(ctx context.Context, req *schema.GetDraftReq) (
+	resp *schema.DraftInfo, err error) {
+	var (
+		draft *entity.Draft
+		exist bool
+	)
+	switch {
+	case req.ID > 0:
+		draft, exist, err = ds.draftRepo.GetDraft(ctx, req.UserID, req.ID)
+	case len(req.QuestionID) > 0:
+		draft, exist, err = ds.draftRepo.GetAnswerDraft(ctx, req.UserID, req.QuestionID)
+	default:
+		return nil, errors.BadRequest(reason.RequestFormatError)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.DraftNotFound)
+	}
+	return ds.formatDraft(ctx, draft), nil
+}
+
+// RemoveDraft remove the draft
+func (ds *DraftService) RemoveDraft(ctx context.Context, req *schema.RemoveDraftReq) (err error) {
+	affected, err := ds.draftRepo.RemoveDraft(ctx, req.UserID, req.ID)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.BadRequest(reason.DraftNotFound)
+	}
+	return nil
+}
+
+// RemovePublishedDraft remove the draft after it has been published as a question or answer
+func (ds *DraftService) RemovePublishedDraft(ctx context.Context, userID string, draftID int) {
+	if draftID <= 0 {
+		return
+	}
+	if _, err := ds.draftRepo.RemoveDraft(ctx, userID, draftID); err != nil {
+		log.Errorf("remove published draft %d failed: %v", draftID, err)
+	}
+}
+
+// ClearExpiredDraftsCron remove the drafts which are not updated for a long time
+func (ds *DraftService) ClearExpiredDraftsCron(ctx context.Context) {
+	expiredAt := time.Now().AddDate(0, 0, -schema.DraftExpiredDays)
+	affected, err := ds.draftRepo.RemoveExpiredDrafts(ctx, expiredAt)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if affected > 0 {
+		log.Infof("cleared %d expired drafts", affected)
+	}
+}
+
+func (ds *DraftService) formatDraft(ctx context.Context, draft *entity.Draft) *schema.DraftInfo {
+	info := &schema.DraftInfo{
+		ID:            draft.ID,
+		ObjectType:    draft.ObjectType,
+		Title:         draft.Title,
+		Content:       draft.Content,
+		Tags:          make([]*schema.TagItem, 0),
+		AnswerContent: draft.AnswerContent,
+		CreatedAt:     draft.CreatedAt.Unix(),
+		UpdatedAt:     draft.UpdatedAt.Unix(),
+	}
+	if draft.QuestionID != "0" {
+		info.QuestionID = draft.QuestionID
+		if handler.GetEnableShortID(ctx) {
+			info.QuestionID = uid.EnShortID(draft.QuestionID)
+		}
+	}
+	if len(draft.Tags) > 0 {
+		if err := json.Unmarshal([]byte(draft.Tags), &info.Tags); err != nil {
+			log.Errorf("parse draft %d tags failed: %v", draft.ID, err)
+		}
+	}
+	return info
+}
@@ -21,49 +21,132 @@ package event_queue
 
 import (
 	"context"
+	"sync"
 
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/service_config"
 	"github.com/segmentfault/pacman/log"
 )
 
+// memoryQueueSize the number of events buffered by the memory queue
+const memoryQueueSize = 128
+
 type EventQueueService interface {
 	Send(ctx context.Context, msg *schema.EventMsg)
-	RegisterHandler(handler func(ctx context.Context, msg *schema.EventMsg) error)
+	RegisterHandler(name string, handler func(ctx context.Context, msg *schema.EventMsg) error)
 }
 
-type eventQueueService struct {
-	Queue   chan *schema.EventMsg
-	Handler func(ctx context.Context, msg *schema.EventMsg) error
+// eventHandler the handler with a unique name, the name is used to record whether it has handled the event
+type eventHandler struct {
+	name    string
+	handler func(ctx context.Context, msg *schema.EventMsg) error
 }
 
-func (ns *eventQueueService) Send(ctx context.Context, msg *schema.EventMsg) {
-	ns.Queue <- msg
+// handlerGroup keeps all registered handlers, every event will be sent to each of them
+type handlerGroup struct {
+	lock     sync.RWMutex
+	handlers []*eventHandler
+}
+
+func (hg *handlerGroup) RegisterHandler(name string,
+	handler func(ctx context.Context, msg *schema.EventMsg) error) {
+	hg.lock.Lock()
+	defer hg.lock.Unlock()
+	hg.handlers = append(hg.handlers, &eventHandler{name: name, handler: handler})
+}
+
+// handle call the handlers which are not in done with the event, it returns the names of the handlers
+// which succeeded in this call, and the first error will be returned after all handlers are called
+func (hg *handlerGroup) handle(ctx context.Context, msg *schema.EventMsg, done []string) (
+	succeeded []string, err error) {
+	hg.lock.RLock()
+	handlers := hg.handlers
+	hg.lock.RUnlock()
+	if len(handlers) == 0 {
+		log.Warnf("no handler for event %s", msg.EventType)
+		return nil, nil
+	}
+	doneMapping := make(map[string]bool, len(done))
+	for _, name := range done {
+		doneMapping[name] = true
+	}
+	for _, h := range handlers {
+		if doneMapping[h.name] {
+			continue
+		}
+		if e := h.handler(ctx, msg); e != nil {
+			if err == nil {
+				err = e
+			}
+			continue
+		}
+		succeeded = append(succeeded, h.name)
+	}
+	return succeeded, err
 }
 
-func (ns *eventQueueService) RegisterHandler(
+type eventQueueService struct {
+	handlerGroup
+	Queue chan *schema.EventMsg
+	// overflow keeps the events when the queue is full, so the sender is never blocked by slow handlers
+	overflow EventQueueService
+}
+
+func (ns *eventQueueService) RegisterHandler(name string,
 	handler func(ctx context.Context, msg *schema.EventMsg) error) {
-	ns.Handler = handler
+	ns.handlerGroup.RegisterHandler(name, handler)
+	if ns.overflow != nil {
+		ns.overflow.RegisterHandler(name, handler)
+	}
+}
+
+// Send put the event into the queue without blocking, if the queue is full, the event is sent to the overflow queue
+func (ns *eventQueueService) Send(ctx context.Context, msg *schema.EventMsg) {
+	select {
+	case ns.Queue <- msg:
+		return
+	default:
+	}
+	if ns.overflow == nil {
+		log.Errorf("event queue is full, drop event %s", msg.EventType)
+		return
+	}
+	log.Warnf("event queue is full, save event %s to outbox", msg.EventType)
+	ns.overflow.Send(ctx, msg)
 }
 
 func (ns *eventQueueService) working() {
 	go func() {
 		for msg := range ns.Queue {
-			log.Debugf("received badge %+v", msg)
-			if ns.Handler == nil {
-				log.Warnf("no handler for badge")
-				continue
-			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
+			log.Debugf("received event %+v", msg)
+			if _, err := ns.handle(context.Background(), msg, nil); err != nil {
 				log.Error(err)
 			}
 		}
 	}()
 }
 
-// NewEventQueueService create a new badge queue service
-func NewEventQueueService() EventQueueService {
-	ns := &eventQueueService{}
-	ns.Queue = make(chan *schema.EventMsg, 128)
+// NewEventQueueService create a new event queue service, the driver is selected by the service config
+func NewEventQueueService(
+	serviceConfig *service_config.ServiceConfig,
+	eventOutboxRepo EventOutboxRepo,
+) EventQueueService {
+	maxAttempts := 0
+	if serviceConfig.EventQueue != nil {
+		maxAttempts = serviceConfig.EventQueue.MaxAttempts
+	}
+	outbox := NewOutboxEventQueueService(eventOutboxRepo, maxAttempts)
+	if serviceConfig.GetEventQueueDriver() == service_config.EventQueueDriverDatabase {
+		return outbox
+	}
+	return NewMemoryEventQueueService(outbox)
+}
+
+// NewMemoryEventQueueService create a new in-memory event queue service,
+// the events are sent to the overflow queue when the memory queue is full
+func NewMemoryEventQueueService(overflow EventQueueService) EventQueueService {
+	ns := &eventQueueService{overflow: overflow}
+	ns.Queue = make(chan *schema.EventMsg, memoryQueueSize)
 	ns.working()
 	return ns
 }
@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordEventQueueService records the events and handlers it received
+type recordEventQueueService struct {
+	events   []*schema.EventMsg
+	handlers []string
+}
+
+func (r *recordEventQueueService) Send(ctx context.Context, msg *schema.EventMsg) {
+	r.events = append(r.events, msg)
+}
+
+func (r *recordEventQueueService) RegisterHandler(name string,
+	handler func(ctx context.Context, msg *schema.EventMsg) error) {
+	r.handlers = append(r.handlers, name)
+}
+
+// sendWithTimeout fails the test if Send is blocked
+func sendWithTimeout(t *testing.T, ns EventQueueService, msg *schema.EventMsg) {
+	sent := make(chan struct{})
+	go func() {
+		ns.Send(context.TODO(), msg)
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("send is blocked by the full queue")
+	}
+}
+
+func TestEventQueueService_SendFullQueue(t *testing.T) {
+	overflow := &recordEventQueueService{}
+	// the queue is not consumed, so it is full after the buffered events are sent
+	ns := &eventQueueService{Queue: make(chan *schema.EventMsg, memoryQueueSize), overflow: overflow}
+	ns.RegisterHandler("handler", func(ctx context.Context, msg *schema.EventMsg) error { return nil })
+	assert.Equal(t, []string{"handler"}, overflow.handlers)
+
+	for i := 0; i < memoryQueueSize; i++ {
+		sendWithTimeout(t, ns, &schema.EventMsg{EventType: constant.EventQuestionCreate})
+	}
+	assert.Empty(t, overflow.events)
+
+	msg := &schema.EventMsg{EventType: constant.EventQuestionUpdate}
+	sendWithTimeout(t, ns, msg)
+	assert.Equal(t, []*schema.EventMsg{msg}, overflow.events)
+	assert.Equal(t, memoryQueueSize, len(ns.Queue))
+}
+
+func TestEventQueueService_SendFullQueueWithoutOverflow(t *testing.T) {
+	ns := &eventQueueService{Queue: make(chan *schema.EventMsg, 1)}
+	sendWithTimeout(t, ns, &schema.EventMsg{EventType: constant.EventQuestionCreate})
+	// the event is dropped rather than blocking the sender
+	sendWithTimeout(t, ns, &schema.EventMsg{EventType: constant.EventQuestionUpdate})
+	assert.Equal(t, 1, len(ns.Queue))
+}
@@ -0,0 +1,207 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_queue
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	defaultOutboxMaxAttempts = 8
+	outboxPollInterval       = 10 * time.Second
+	outboxCleanInterval      = time.Hour
+	outboxBatchSize          = 50
+	// outboxLease is the time an event is locked by a worker, if the worker dies
+	// the event will be picked up again after the lease expires.
+	outboxLease          = 5 * time.Minute
+	outboxRetryBaseDelay = 10 * time.Second
+	outboxRetryMaxDelay  = time.Hour
+	outboxDoneRetention  = 7 * 24 * time.Hour
+)
+
+// EventOutboxRepo event outbox repository
+type EventOutboxRepo interface {
+	AddEvent(ctx context.Context, event *entity.EventOutbox) (err error)
+	GetAvailableEvents(ctx context.Context, now time.Time, limit int) (events []*entity.EventOutbox, err error)
+	ClaimEvent(ctx context.Context, event *entity.EventOutbox, leaseUntil time.Time) (claimed bool, err error)
+	UpdateEventResult(ctx context.Context, event *entity.EventOutbox) (err error)
+	RemoveEventsBefore(ctx context.Context, status int, before time.Time) (err error)
+}
+
+// outboxEventQueueService is a database backed event queue. Events are stored in the outbox table
+// before they are handled, so they will not be lost when the process restarts.
+// Every event will be delivered to each handler at least once, so the handlers should be idempotent.
+// When some of the handlers fail, only the failed handlers will be retried.
+type outboxEventQueueService struct {
+	handlerGroup
+	eventOutboxRepo EventOutboxRepo
+	maxAttempts     int
+	notify          chan struct{}
+}
+
+// NewOutboxEventQueueService create a new database backed event queue service
+func NewOutboxEventQueueService(eventOutboxRepo EventOutboxRepo, maxAttempts int) EventQueueService {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultOutboxMaxAttempts
+	}
+	ns := &outboxEventQueueService{
+		eventOutboxRepo: eventOutboxRepo,
+		maxAttempts:     maxAttempts,
+		notify:          make(chan struct{}, 1),
+	}
+	ns.working()
+	return ns
+}
+
+// Send save the event into outbox and wake up the worker, it never blocks on the worker.
+func (ns *outboxEventQueueService) Send(ctx context.Context, msg *schema.EventMsg) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		log.Errorf("marshal event %s failed: %v", msg.EventType, err)
+		return
+	}
+	event := &entity.EventOutbox{
+		EventType:   string(msg.EventType),
+		Payload:     string(payload),
+		Status:      entity.EventOutboxStatusPending,
+		NextRetryAt: time.Now(),
+	}
+	if err = ns.eventOutboxRepo.AddEvent(ctx, event); err != nil {
+		// the event can not be persisted, try to handle it directly rather than drop it
+		log.Errorf("save event %s to outbox failed, handle it directly: %v", msg.EventType, err)
+		go func() {
+			if _, err := ns.handle(context.Background(), msg, nil); err != nil {
+				log.Error(err)
+			}
+		}()
+		return
+	}
+	select {
+	case ns.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (ns *outboxEventQueueService) working() {
+	go func() {
+		pollTicker := time.NewTicker(outboxPollInterval)
+		cleanTicker := time.NewTicker(outboxCleanInterval)
+		defer pollTicker.Stop()
+		defer cleanTicker.Stop()
+		for {
+			select {
+			case <-ns.notify:
+			case <-pollTicker.C:
+			case <-cleanTicker.C:
+				ns.clean(context.Background())
+				continue
+			}
+			ns.consume(context.Background())
+		}
+	}()
+}
+
+// consume handle all available events in batches until there is nothing left
+func (ns *outboxEventQueueService) consume(ctx context.Context) {
+	for {
+		events, err := ns.eventOutboxRepo.GetAvailableEvents(ctx, time.Now(), outboxBatchSize)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		if len(events) == 0 {
+			return
+		}
+		handled := 0
+		for _, event := range events {
+			claimed, err := ns.eventOutboxRepo.ClaimEvent(ctx, event, time.Now().Add(outboxLease))
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+			// the event has been taken by another worker
+			if !claimed {
+				continue
+			}
+			ns.deliver(ctx, event)
+			handled++
+		}
+		if handled == 0 {
+			return
+		}
+	}
+}
+
+// deliver the claimed event to the handlers which have not handled it and record the result
+func (ns *outboxEventQueueService) deliver(ctx context.Context, event *entity.EventOutbox) {
+	msg := &schema.EventMsg{}
+	err := json.Unmarshal([]byte(event.Payload), msg)
+	if err == nil {
+		log.Debugf("received event %+v", msg)
+		done := event.GetDoneHandlers()
+		var succeeded []string
+		succeeded, err = ns.handle(ctx, msg, done)
+		event.SetDoneHandlers(append(done, succeeded...))
+	}
+
+	if err == nil {
+		event.Status = entity.EventOutboxStatusDone
+		event.LastError = ""
+	} else if event.Attempts >= ns.maxAttempts {
+		log.Errorf("event %d %s is dead after %d attempts: %v", event.ID, event.EventType, event.Attempts, err)
+		event.Status = entity.EventOutboxStatusDead
+		event.LastError = err.Error()
+	} else {
+		log.Warnf("event %d %s failed at attempt %d: %v", event.ID, event.EventType, event.Attempts, err)
+		event.Status = entity.EventOutboxStatusPending
+		event.NextRetryAt = time.Now().Add(outboxRetryBackoff(event.Attempts))
+		event.LastError = err.Error()
+	}
+	if err := ns.eventOutboxRepo.UpdateEventResult(ctx, event); err != nil {
+		log.Error(err)
+	}
+}
+
+// clean remove the events that have been handled successfully for a while
+func (ns *outboxEventQueueService) clean(ctx context.Context) {
+	err := ns.eventOutboxRepo.RemoveEventsBefore(ctx, entity.EventOutboxStatusDone,
+		time.Now().Add(-outboxDoneRetention))
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// outboxRetryBackoff returns the exponential delay before the next attempt
+func outboxRetryBackoff(attempts int) time.Duration {
+	delay := outboxRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= outboxRetryMaxDelay {
+			return outboxRetryMaxDelay
+		}
+	}
+	return delay
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// resultEventOutboxRepo only records the result of the event
+type resultEventOutboxRepo struct {
+	EventOutboxRepo
+	results []entity.EventOutbox
+}
+
+func (r *resultEventOutboxRepo) UpdateEventResult(ctx context.Context, event *entity.EventOutbox) error {
+	r.results = append(r.results, *event)
+	return nil
+}
+
+func TestOutboxEventQueueService_DeliverRetryFailedHandlers(t *testing.T) {
+	repo := &resultEventOutboxRepo{}
+	ns := &outboxEventQueueService{eventOutboxRepo: repo, maxAttempts: defaultOutboxMaxAttempts}
+
+	calls := make(map[string]int)
+	failing := true
+	ns.RegisterHandler("first", func(ctx context.Context, msg *schema.EventMsg) error {
+		calls["first"]++
+		return nil
+	})
+	ns.RegisterHandler("second", func(ctx context.Context, msg *schema.EventMsg) error {
+		calls["second"]++
+		if failing {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+	ns.RegisterHandler("third", func(ctx context.Context, msg *schema.EventMsg) error {
+		calls["third"]++
+		return nil
+	})
+
+	payload, _ := json.Marshal(&schema.EventMsg{EventType: constant.EventQuestionCreate})
+	event := &entity.EventOutbox{ID: 1, Payload: string(payload), Attempts: 1}
+
+	ns.deliver(context.TODO(), event)
+	assert.Equal(t, entity.EventOutboxStatusPending, event.Status)
+	assert.Equal(t, []string{"first", "third"}, event.GetDoneHandlers())
+	assert.Equal(t, map[string]int{"first": 1, "second": 1, "third": 1}, calls)
+
+	// only the failed handler is called again
+	event.Attempts++
+	ns.deliver(context.TODO(), event)
+	assert.Equal(t, entity.EventOutboxStatusPending, event.Status)
+	assert.Equal(t, map[string]int{"first": 1, "second": 2, "third": 1}, calls)
+
+	failing = false
+	event.Attempts++
+	ns.deliver(context.TODO(), event)
+	assert.Equal(t, entity.EventOutboxStatusDone, event.Status)
+	assert.Equal(t, []string{"first", "third", "second"}, event.GetDoneHandlers())
+	assert.Equal(t, map[string]int{"first": 1, "second": 3, "third": 1}, calls)
+	assert.Equal(t, 3, len(repo.results))
+	assert.Equal(t, "first,third", repo.results[0].DoneHandlers)
+}
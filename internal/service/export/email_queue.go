@@ -0,0 +1,217 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package export
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	defaultEmailMaxAttempts = 5
+	emailPollInterval       = 30 * time.Second
+	emailCleanInterval      = time.Hour
+	emailBatchSize          = 20
+	// emailLease is the time an email is locked by a worker, if the worker dies
+	// the email will be picked up again after the lease expires.
+	emailLease          = 5 * time.Minute
+	emailRetryBaseDelay = time.Minute
+	emailRetryMaxDelay  = 6 * time.Hour
+	emailLogRetention   = 30 * 24 * time.Hour
+)
+
+// EmailLogRepo email log repository
+type EmailLogRepo interface {
+	AddEmailLog(ctx context.Context, emailLog *entity.EmailLog) (err error)
+	GetAvailableEmailLogs(ctx context.Context, now time.Time, limit int) (emailLogs []*entity.EmailLog, err error)
+	ClaimEmailLog(ctx context.Context, emailLog *entity.EmailLog, leaseUntil time.Time) (claimed bool, err error)
+	UpdateEmailLogResult(ctx context.Context, emailLog *entity.EmailLog) (err error)
+	RetryEmailLog(ctx context.Context, id int64) (affected int64, err error)
+	GetEmailLogPage(ctx context.Context, page, pageSize, status int) (emailLogs []*entity.EmailLog, total int64, err error)
+	RemoveEmailLogsBefore(ctx context.Context, status int, before time.Time) (err error)
+}
+
+func (es *EmailService) working() {
+	go func() {
+		pollTicker := time.NewTicker(emailPollInterval)
+		cleanTicker := time.NewTicker(emailCleanInterval)
+		defer pollTicker.Stop()
+		defer cleanTicker.Stop()
+		for {
+			select {
+			case <-es.notify:
+			case <-pollTicker.C:
+			case <-cleanTicker.C:
+				es.clean(context.Background())
+				continue
+			}
+			es.consume(context.Background())
+		}
+	}()
+}
+
+// consume send all available emails in batches until there is nothing left
+func (es *EmailService) consume(ctx context.Context) {
+	for {
+		emailLogs, err := es.emailLogRepo.GetAvailableEmailLogs(ctx, time.Now(), emailBatchSize)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		if len(emailLogs) == 0 {
+			return
+		}
+		handled := 0
+		for _, emailLog := range emailLogs {
+			claimed, err := es.emailLogRepo.ClaimEmailLog(ctx, emailLog, time.Now().Add(emailLease))
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+			// the email has been taken by another worker
+			if !claimed {
+				continue
+			}
+			es.deliver(ctx, emailLog)
+			handled++
+		}
+		if handled == 0 {
+			return
+		}
+	}
+}
+
+// deliver the claimed email and record the result
+func (es *EmailService) deliver(ctx context.Context, emailLog *entity.EmailLog) {
+	err := es.sendEmailLog(ctx, emailLog)
+	if err == nil {
+		log.Infof("send email to %s success", emailLog.ToEmail)
+		emailLog.Status = entity.EmailLogStatusSent
+		emailLog.LastError = ""
+		// the body may contain the tokenized links such as password reset, so it is not kept after sent
+		emailLog.Body = ""
+	} else if emailLog.Attempts >= es.maxAttempts {
+		log.Errorf("send email to %s failed after %d attempts: %s", emailLog.ToEmail, emailLog.Attempts, err)
+		emailLog.Status = entity.EmailLogStatusFailed
+		emailLog.LastError = err.Error()
+	} else {
+		log.Warnf("send email to %s failed at attempt %d: %s", emailLog.ToEmail, emailLog.Attempts, err)
+		emailLog.Status = entity.EmailLogStatusPending
+		emailLog.NextRetryAt = time.Now().Add(emailRetryBackoff(emailLog.Attempts))
+		emailLog.LastError = err.Error()
+	}
+	if err := es.emailLogRepo.UpdateEmailLogResult(ctx, emailLog); err != nil {
+		log.Error(err)
+	}
+}
+
+// sendEmailLog send the email by the current transport
+func (es *EmailService) sendEmailLog(ctx context.Context, emailLog *entity.EmailLog) error {
+	ec, err := es.GetEmailConfig(ctx)
+	if err != nil {
+		return err
+	}
+	transport := es.getTransport(ec)
+	if transport == nil {
+		return fmt.Errorf("no email transport is available, smtp host is empty")
+	}
+	emailLog.Transport = transport.Name()
+	return transport.Send(ctx, &plugin.EmailMessage{
+		FromName:  ec.FromName,
+		FromEmail: ec.FromEmail,
+		To:        emailLog.ToEmail,
+		Subject:   emailLog.Subject,
+		Body:      emailLog.Body,
+	})
+}
+
+// clean remove the old email logs
+func (es *EmailService) clean(ctx context.Context) {
+	for _, status := range []int{entity.EmailLogStatusSent, entity.EmailLogStatusFailed} {
+		err := es.emailLogRepo.RemoveEmailLogsBefore(ctx, status, time.Now().Add(-emailLogRetention))
+		if err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// emailRetryBackoff returns the exponential delay before the next attempt
+func emailRetryBackoff(attempts int) time.Duration {
+	delay := emailRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= emailRetryMaxDelay {
+			return emailRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// GetEmailLogPage get email log page
+func (es *EmailService) GetEmailLogPage(ctx context.Context, req *schema.GetEmailLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	emailLogs, total, err := es.emailLogRepo.GetEmailLogPage(ctx, req.Page, req.PageSize, schema.EmailLogStatus[req.Status])
+	if err != nil {
+		return nil, err
+	}
+	statusMapping := make(map[int]string, len(schema.EmailLogStatus))
+	for name, status := range schema.EmailLogStatus {
+		statusMapping[status] = name
+	}
+	resp := make([]*schema.GetEmailLogResp, 0, len(emailLogs))
+	for _, emailLog := range emailLogs {
+		resp = append(resp, &schema.GetEmailLogResp{
+			ID:        emailLog.ID,
+			ToEmail:   emailLog.ToEmail,
+			Subject:   emailLog.Subject,
+			Transport: emailLog.Transport,
+			Status:    statusMapping[emailLog.Status],
+			Attempts:  emailLog.Attempts,
+			LastError: emailLog.LastError,
+			CreatedAt: emailLog.CreatedAt.Unix(),
+			UpdatedAt: emailLog.UpdatedAt.Unix(),
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// RetryEmailLog send the failed email again
+func (es *EmailService) RetryEmailLog(ctx context.Context, req *schema.RetryEmailLogReq) (err error) {
+	affected, err := es.emailLogRepo.RetryEmailLog(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.BadRequest(reason.EmailLogNotFound)
+	}
+	select {
+	case es.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryEmailLogRepo keeps the email logs in memory, the attempts is used as the version like the real one
+type memoryEmailLogRepo struct {
+	EmailLogRepo
+	emailLogs map[int64]*entity.EmailLog
+	// lostClaim simulates that the emails are always claimed by another worker
+	lostClaim bool
+}
+
+func (r *memoryEmailLogRepo) GetAvailableEmailLogs(ctx context.Context, now time.Time, limit int) (
+	emailLogs []*entity.EmailLog, err error) {
+	for _, emailLog := range r.emailLogs {
+		if emailLog.Status != entity.EmailLogStatusPending && emailLog.Status != entity.EmailLogStatusSending {
+			continue
+		}
+		if emailLog.NextRetryAt.After(now) {
+			continue
+		}
+		copied := *emailLog
+		emailLogs = append(emailLogs, &copied)
+	}
+	return emailLogs, nil
+}
+
+func (r *memoryEmailLogRepo) ClaimEmailLog(ctx context.Context, emailLog *entity.EmailLog, leaseUntil time.Time) (
+	claimed bool, err error) {
+	stored := r.emailLogs[emailLog.ID]
+	if r.lostClaim || stored.Attempts != emailLog.Attempts {
+		return false, nil
+	}
+	stored.Status = entity.EmailLogStatusSending
+	stored.Attempts++
+	stored.NextRetryAt = leaseUntil
+	*emailLog = *stored
+	return true, nil
+}
+
+func (r *memoryEmailLogRepo) UpdateEmailLogResult(ctx context.Context, emailLog *entity.EmailLog) (err error) {
+	stored := r.emailLogs[emailLog.ID]
+	stored.Status = emailLog.Status
+	stored.Transport = emailLog.Transport
+	stored.NextRetryAt = emailLog.NextRetryAt
+	stored.LastError = emailLog.LastError
+	stored.Body = emailLog.Body
+	return nil
+}
+
+type emailConfigRepo struct {
+	config.ConfigRepo
+}
+
+func (r *emailConfigRepo) GetConfigByKey(ctx context.Context, key string) (c *entity.Config, err error) {
+	if key != constant.EmailConfigKey {
+		return nil, fmt.Errorf("unexpected config key %s", key)
+	}
+	return &entity.Config{Key: key, Value: `{"from_email":"noreply@example.com","from_name":"Answer"}`}, nil
+}
+
+func newTestEmailService(spoolDir string, maxAttempts int, emailLogs ...*entity.EmailLog) (
+	*EmailService, *memoryEmailLogRepo) {
+	repo := &memoryEmailLogRepo{emailLogs: make(map[int64]*entity.EmailLog)}
+	for _, emailLog := range emailLogs {
+		repo.emailLogs[emailLog.ID] = emailLog
+	}
+	return &EmailService{
+		configService: config.NewConfigService(&emailConfigRepo{}),
+		emailLogRepo:  repo,
+		serviceConfig: &service_config.ServiceConfig{Email: &service_config.EmailConfig{SpoolDir: spoolDir}},
+		maxAttempts:   maxAttempts,
+		notify:        make(chan struct{}, 1),
+	}, repo
+}
+
+func newTestEmailLog(id int64) *entity.EmailLog {
+	return &entity.EmailLog{
+		ID:          id,
+		ToEmail:     "test@example.com",
+		Subject:     "reset password",
+		Body:        "<a href=\"https://example.com/users/password-reset?code=secret\">reset</a>",
+		Status:      entity.EmailLogStatusPending,
+		NextRetryAt: time.Now().Add(-time.Minute),
+	}
+}
+
+func TestEmailService_consumeSent(t *testing.T) {
+	spoolDir := t.TempDir()
+	es, repo := newTestEmailService(spoolDir, 3, newTestEmailLog(1))
+	es.consume(context.TODO())
+
+	emailLog := repo.emailLogs[1]
+	assert.Equal(t, entity.EmailLogStatusSent, emailLog.Status)
+	assert.Equal(t, 1, emailLog.Attempts)
+	assert.Equal(t, EmailTransportSpool, emailLog.Transport)
+	assert.Empty(t, emailLog.LastError)
+	// the tokenized link is not kept in the log
+	assert.Empty(t, emailLog.Body)
+
+	newFiles, err := os.ReadDir(filepath.Join(spoolDir, "new"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(newFiles))
+}
+
+func TestEmailService_consumeRetry(t *testing.T) {
+	// the spool directory can not be created under a regular file, so the delivery always fails
+	spoolDir := filepath.Join(t.TempDir(), "file")
+	assert.NoError(t, os.WriteFile(spoolDir, nil, os.ModePerm))
+	es, repo := newTestEmailService(spoolDir, 2, newTestEmailLog(1))
+
+	before := time.Now()
+	es.consume(context.TODO())
+	emailLog := repo.emailLogs[1]
+	assert.Equal(t, entity.EmailLogStatusPending, emailLog.Status)
+	assert.Equal(t, 1, emailLog.Attempts)
+	assert.NotEmpty(t, emailLog.LastError)
+	assert.NotEmpty(t, emailLog.Body)
+	assert.False(t, emailLog.NextRetryAt.Before(before.Add(emailRetryBaseDelay)))
+
+	// the email is not sent again before the next retry time
+	es.consume(context.TODO())
+	assert.Equal(t, 1, emailLog.Attempts)
+
+	// the email is failed after the max attempts, and the body is kept for retrying manually
+	emailLog.NextRetryAt = time.Now().Add(-time.Minute)
+	es.consume(context.TODO())
+	assert.Equal(t, entity.EmailLogStatusFailed, emailLog.Status)
+	assert.Equal(t, 2, emailLog.Attempts)
+	assert.NotEmpty(t, emailLog.LastError)
+	assert.NotEmpty(t, emailLog.Body)
+}
+
+func TestEmailService_consumeClaim(t *testing.T) {
+	// the lease of the sending email has expired, because the worker died, so it is claimed again
+	expired := newTestEmailLog(1)
+	expired.Status = entity.EmailLogStatusSending
+	expired.Attempts = 1
+	// the sending email is still locked by another worker
+	leased := newTestEmailLog(2)
+	leased.Status = entity.EmailLogStatusSending
+	leased.Attempts = 1
+	leased.NextRetryAt = time.Now().Add(emailLease)
+
+	es, repo := newTestEmailService(t.TempDir(), 3, expired, leased)
+	es.consume(context.TODO())
+	assert.Equal(t, entity.EmailLogStatusSent, repo.emailLogs[1].Status)
+	assert.Equal(t, 2, repo.emailLogs[1].Attempts)
+	assert.Equal(t, entity.EmailLogStatusSending, repo.emailLogs[2].Status)
+	assert.Equal(t, 1, repo.emailLogs[2].Attempts)
+
+	// the email claimed by another worker is skipped, and the consume does not loop forever
+	es, repo = newTestEmailService(t.TempDir(), 3, newTestEmailLog(3))
+	repo.lostClaim = true
+	es.consume(context.TODO())
+	assert.Equal(t, entity.EmailLogStatusPending, repo.emailLogs[3].Status)
+	assert.Equal(t, 0, repo.emailLogs[3].Attempts)
+}
+
+func Test_emailRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Minute},
+		{attempts: 2, want: 2 * time.Minute},
+		{attempts: 3, want: 4 * time.Minute},
+		{attempts: 9, want: 256 * time.Minute},
+		{attempts: 10, want: emailRetryMaxDelay},
+		{attempts: 100, want: emailRetryMaxDelay},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempts %d", tt.attempts), func(t *testing.T) {
+			assert.Equal(t, tt.want, emailRetryBackoff(tt.attempts))
+		})
+	}
+}
@@ -20,12 +20,11 @@
 package export
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-answer/pkg/display"
-	"mime"
-	"os"
+	"html"
+	"net/url"
 	"strings"
 	"time"
 
@@ -33,20 +32,25 @@ import (
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/service_config"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 	"golang.org/x/net/context"
-	"gopkg.in/gomail.v2"
 )
 
 // EmailService kit service
 type EmailService struct {
 	configService   *config.ConfigService
 	emailRepo       EmailRepo
+	emailLogRepo    EmailLogRepo
 	siteInfoService siteinfo_common.SiteInfoCommonService
+	serviceConfig   *service_config.ServiceConfig
+	maxAttempts     int
+	notify          chan struct{}
 }
 
 // EmailRepo email repository
@@ -59,13 +63,25 @@ type EmailRepo interface {
 func NewEmailService(
 	configService *config.ConfigService,
 	emailRepo EmailRepo,
+	emailLogRepo EmailLogRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	serviceConfig *service_config.ServiceConfig,
 ) *EmailService {
-	return &EmailService{
+	maxAttempts := serviceConfig.GetEmailMaxAttempts()
+	if maxAttempts <= 0 {
+		maxAttempts = defaultEmailMaxAttempts
+	}
+	es := &EmailService{
 		configService:   configService,
 		emailRepo:       emailRepo,
+		emailLogRepo:    emailLogRepo,
 		siteInfoService: siteInfoService,
+		serviceConfig:   serviceConfig,
+		maxAttempts:     maxAttempts,
+		notify:          make(chan struct{}, 1),
 	}
+	es.working()
+	return es
 }
 
 // EmailConfig email config
@@ -109,49 +125,58 @@ func (es *EmailService) SendAndSaveCode(ctx context.Context, userID, toEmailAddr
 // SendAndSaveCodeWithTime send email and save code
 func (es *EmailService) SendAndSaveCodeWithTime(
 	ctx context.Context, userID, toEmailAddr, subject, body, code, codeContent string, duration time.Duration) {
-	err := es.emailRepo.SetCode(ctx, userID, code, codeContent, duration)
+	err := es.SendAndSaveCodeWithResult(ctx, userID, toEmailAddr, subject, body, code, codeContent, duration)
 	if err != nil {
 		log.Error(err)
-		return
 	}
-	es.Send(ctx, toEmailAddr, subject, body)
 }
 
-// Send email send
+// SendAndSaveCodeWithResult the same as SendAndSaveCodeWithTime, but return the error
+// when the code can not be saved or the email can be neither saved nor sent
+func (es *EmailService) SendAndSaveCodeWithResult(
+	ctx context.Context, userID, toEmailAddr, subject, body, code, codeContent string, duration time.Duration) (err error) {
+	if err = es.emailRepo.SetCode(ctx, userID, code, codeContent, duration); err != nil {
+		return err
+	}
+	return es.SendWithResult(ctx, toEmailAddr, subject, body)
+}
+
+// Send save the email into email log and wake up the worker to deliver it
 func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body string) {
+	if err := es.SendWithResult(ctx, toEmailAddr, subject, body); err != nil {
+		log.Errorf("send email to %s failed: %s", toEmailAddr, err)
+	}
+}
+
+// SendWithResult the same as Send, but return the error when the email can be neither saved nor sent
+func (es *EmailService) SendWithResult(ctx context.Context, toEmailAddr, subject, body string) (err error) {
 	log.Infof("try to send email to %s", toEmailAddr)
 	ec, err := es.GetEmailConfig(ctx)
 	if err != nil {
-		log.Errorf("get email config failed: %s", err)
-		return
+		return err
 	}
-	if len(ec.SMTPHost) == 0 {
+	if es.getTransport(ec) == nil {
 		log.Warnf("smtp host is empty, skip send email")
-		return
+		return nil
 	}
 
-	m := gomail.NewMessage()
-	fromName := mime.QEncoding.Encode("utf-8", ec.FromName)
-	m.SetHeader("From", fmt.Sprintf("%s <%s>", fromName, ec.FromEmail))
-	m.SetHeader("To", toEmailAddr)
-	m.SetHeader("Subject", subject)
-	m.SetBody("text/html", body)
-
-	d := gomail.NewDialer(ec.SMTPHost, ec.SMTPPort, ec.SMTPUsername, ec.SMTPPassword)
-	if ec.IsSSL() {
-		d.SSL = true
+	emailLog := &entity.EmailLog{
+		ToEmail:     toEmailAddr,
+		Subject:     subject,
+		Body:        body,
+		Status:      entity.EmailLogStatusPending,
+		NextRetryAt: time.Now(),
 	}
-	if ec.IsTLS() {
-		d.SSL = false
+	if err = es.emailLogRepo.AddEmailLog(ctx, emailLog); err != nil {
+		// the email can not be persisted, try to send it directly rather than drop it
+		log.Errorf("save email to %s failed, send it directly: %v", toEmailAddr, err)
+		return es.sendEmailLog(ctx, emailLog)
 	}
-	if len(os.Getenv("SKIP_SMTP_TLS_VERIFY")) > 0 {
-		d.TLSConfig = &tls.Config{ServerName: d.Host, InsecureSkipVerify: true}
-	}
-	if err := d.DialAndSend(m); err != nil {
-		log.Errorf("send email to %s failed: %s", toEmailAddr, err)
-	} else {
-		log.Infof("send email to %s success", toEmailAddr)
+	select {
+	case es.notify <- struct{}{}:
+	default:
 	}
+	return nil
 }
 
 // VerifyUrlExpired email send
@@ -327,6 +352,150 @@ func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.New
 	return title, body, nil
 }
 
+// SavedSearchDigestTemplate saved search digest template
+func (es *EmailService) SavedSearchDigestTemplate(ctx context.Context, raw *schema.SavedSearchDigestTemplateRawData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
+	questionList := make([]string, 0, len(raw.Questions))
+	for _, question := range raw.Questions {
+		questionUrl := display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, question.QuestionID, question.QuestionTitle)
+		questionList = append(questionList,
+			fmt.Sprintf("<a href='%s'>%s</a>", questionUrl, html.EscapeString(question.QuestionTitle)))
+	}
+	templateData := &schema.SavedSearchDigestTemplateData{
+		SiteName:     siteInfo.Name,
+		SearchName:   raw.SearchName,
+		SearchUrl:    fmt.Sprintf("%s/search?q=%s", siteInfo.SiteUrl, url.QueryEscape(raw.Query)),
+		Count:        len(raw.Questions),
+		QuestionList: strings.Join(questionList, "<br>\n"),
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeySavedSearchDigestTitle, templateData)
+	// the title is plain text, but the search name is written by the user, so it is escaped in the html body
+	templateData.SearchName = html.EscapeString(raw.SearchName)
+	body = translator.TrWithData(lang, constant.EmailTplKeySavedSearchDigestBody, templateData)
+	return title, body, nil
+}
+
+// DigestTemplate notification digest template
+func (es *EmailService) DigestTemplate(ctx context.Context, raw *schema.DigestTemplateRawData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
+	lang := handler.GetLangByCtx(ctx)
+	nothingNew := translator.Tr(lang, constant.EmailTplKeyDigestNothingNew)
+
+	notificationList := make([]string, 0, len(raw.Notifications))
+	for _, notification := range raw.Notifications {
+		objectUrl := display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl,
+			notification.QuestionID, notification.QuestionTitle)
+		if len(notification.AnswerID) > 0 {
+			objectUrl = display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl,
+				notification.QuestionID, notification.QuestionTitle, notification.AnswerID)
+		}
+		line := fmt.Sprintf("%s: <a href='%s'>%s</a>", html.EscapeString(notification.Action),
+			objectUrl, html.EscapeString(notification.QuestionTitle))
+		if len(notification.DisplayName) > 0 {
+			line = html.EscapeString(notification.DisplayName) + " " + line
+		}
+		notificationList = append(notificationList, line)
+	}
+	if len(notificationList) == 0 {
+		notificationList = append(notificationList, nothingNew)
+	}
+
+	questionList := make([]string, 0, len(raw.Questions))
+	for _, question := range raw.Questions {
+		questionUrl := display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, question.QuestionID, question.QuestionTitle)
+		questionList = append(questionList,
+			fmt.Sprintf("<a href='%s'>%s</a>", questionUrl, html.EscapeString(question.QuestionTitle)))
+	}
+	if len(questionList) == 0 {
+		questionList = append(questionList, nothingNew)
+	}
+
+	templateData := &schema.DigestTemplateData{
+		SiteName:          siteInfo.Name,
+		NotificationCount: len(raw.Notifications),
+		NotificationList:  strings.Join(notificationList, "<br>\n"),
+		QuestionList:      strings.Join(questionList, "<br>\n"),
+		SettingsUrl:       fmt.Sprintf("%s/users/settings/notify", siteInfo.SiteUrl),
+		UnsubscribeUrl:    fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+	}
+	title = translator.TrWithData(lang, constant.EmailTplKeyDigestTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyDigestBody, templateData)
+	return title, body, nil
+}
+
+// UserSuspendedTemplate user suspended template
+func (es *EmailService) UserSuspendedTemplate(ctx context.Context, raw *schema.UserSuspendedTemplateRawData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	lang := handler.GetLangByCtx(ctx)
+	templateData := &schema.UserSuspendedTemplateData{
+		SiteName:          siteInfo.Name,
+		SiteUrl:           siteInfo.SiteUrl,
+		DisplayName:       raw.DisplayName,
+		ReasonName:        raw.ReasonName,
+		ReasonDescription: raw.ReasonDescription,
+		Message:           html.EscapeString(raw.Message),
+	}
+	if raw.Forever {
+		templateData.Duration = translator.Tr(lang, constant.EmailTplKeyUserSuspendedForever)
+	} else {
+		suspendedUntil := raw.SuspendedUntil
+		if interfaceInfo, _ := es.siteInfoService.GetSiteInterface(ctx); interfaceInfo != nil {
+			if location, err := time.LoadLocation(interfaceInfo.TimeZone); err == nil {
+				suspendedUntil = suspendedUntil.In(location)
+			}
+		}
+		templateData.Duration = translator.TrWithData(lang, constant.EmailTplKeyUserSuspendedUntil,
+			map[string]string{"SuspendedUntil": suspendedUntil.Format("2006-01-02 15:04 MST")})
+	}
+
+	title = translator.TrWithData(lang, constant.EmailTplKeyUserSuspendedTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyUserSuspendedBody, templateData)
+	return title, body, nil
+}
+
+// ModeratorMessageTemplate moderator message template
+func (es *EmailService) ModeratorMessageTemplate(ctx context.Context, raw *schema.ModeratorMessageTemplateRawData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.ModeratorMessageTemplateData{
+		SiteName:    siteInfo.Name,
+		SiteUrl:     siteInfo.SiteUrl,
+		DisplayName: raw.DisplayName,
+		Content:     html.EscapeString(raw.Content),
+		MessageUrl:  fmt.Sprintf("%s/users/moderator-messages", siteInfo.SiteUrl),
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyModeratorMessageTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyModeratorMessageBody, templateData)
+	return title, body, nil
+}
+
 func (es *EmailService) GetEmailConfig(ctx context.Context) (ec *EmailConfig, err error) {
 	emailConf, err := es.configService.GetStringValue(ctx, constant.EmailConfigKey)
 	if err != nil {
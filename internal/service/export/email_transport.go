@@ -0,0 +1,152 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package export
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-answer/plugin"
+	"gopkg.in/gomail.v2"
+)
+
+const (
+	EmailTransportSMTP  = "smtp"
+	EmailTransportSpool = "spool"
+)
+
+// emailTransport delivers the email message
+type emailTransport interface {
+	Name() string
+	Send(ctx context.Context, msg *plugin.EmailMessage) error
+}
+
+// getTransport choose the transport to deliver emails.
+// The email sender plugin is preferred, then the spool directory, then SMTP.
+// Return nil if there is no available transport.
+func (es *EmailService) getTransport(ec *EmailConfig) (transport emailTransport) {
+	_ = plugin.CallEmailSender(func(sender plugin.EmailSender) error {
+		if transport == nil {
+			transport = &pluginTransport{sender: sender}
+		}
+		return nil
+	})
+	if transport != nil {
+		return transport
+	}
+	if spoolDir := es.serviceConfig.GetEmailSpoolDir(); len(spoolDir) > 0 {
+		return &spoolTransport{dir: spoolDir}
+	}
+	if len(ec.SMTPHost) > 0 {
+		return &smtpTransport{ec: ec}
+	}
+	return nil
+}
+
+func newMailMessage(msg *plugin.EmailMessage) *gomail.Message {
+	m := gomail.NewMessage()
+	fromName := mime.QEncoding.Encode("utf-8", msg.FromName)
+	m.SetHeader("From", fmt.Sprintf("%s <%s>", fromName, msg.FromEmail))
+	m.SetHeader("To", msg.To)
+	m.SetHeader("Subject", msg.Subject)
+	m.SetDateHeader("Date", time.Now())
+	m.SetBody("text/html", msg.Body)
+	return m
+}
+
+// smtpTransport send email by the SMTP server in email config
+type smtpTransport struct {
+	ec *EmailConfig
+}
+
+func (t *smtpTransport) Name() string {
+	return EmailTransportSMTP
+}
+
+func (t *smtpTransport) Send(ctx context.Context, msg *plugin.EmailMessage) error {
+	d := gomail.NewDialer(t.ec.SMTPHost, t.ec.SMTPPort, t.ec.SMTPUsername, t.ec.SMTPPassword)
+	if t.ec.IsSSL() {
+		d.SSL = true
+	}
+	if t.ec.IsTLS() {
+		d.SSL = false
+	}
+	if len(os.Getenv("SKIP_SMTP_TLS_VERIFY")) > 0 {
+		d.TLSConfig = &tls.Config{ServerName: d.Host, InsecureSkipVerify: true}
+	}
+	return d.DialAndSend(newMailMessage(msg))
+}
+
+var spoolSequence uint64
+
+// spoolTransport write email into a local directory in maildir format, the email is never sent out.
+type spoolTransport struct {
+	dir string
+}
+
+func (t *spoolTransport) Name() string {
+	return EmailTransportSpool
+}
+
+// Send write the message into tmp directory first, then move it to new directory,
+// so the readers never see a partial message.
+func (t *spoolTransport) Send(ctx context.Context, msg *plugin.EmailMessage) (err error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err = os.MkdirAll(filepath.Join(t.dir, sub), os.ModePerm); err != nil {
+			return err
+		}
+	}
+	hostname, _ := os.Hostname()
+	filename := fmt.Sprintf("%d.%d_%d.%s", time.Now().UnixNano(), os.Getpid(),
+		atomic.AddUint64(&spoolSequence, 1), hostname)
+	tmpPath := filepath.Join(t.dir, "tmp", filename)
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	if _, err = newMailMessage(msg).WriteTo(file); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err = file.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, filepath.Join(t.dir, "new", filename))
+}
+
+// pluginTransport send email by the email sender plugin
+type pluginTransport struct {
+	sender plugin.EmailSender
+}
+
+func (t *pluginTransport) Name() string {
+	return t.sender.Info().SlugName
+}
+
+func (t *pluginTransport) Send(ctx context.Context, msg *plugin.EmailMessage) error {
+	return t.sender.SendEmail(ctx, msg)
+}
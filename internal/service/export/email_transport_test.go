@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpoolTransport_Send(t *testing.T) {
+	dir := t.TempDir()
+	transport := &spoolTransport{dir: dir}
+	err := transport.Send(context.TODO(), &plugin.EmailMessage{
+		FromName:  "Answer",
+		FromEmail: "noreply@example.com",
+		To:        "test@example.com",
+		Subject:   "Hello",
+		Body:      "<p>world</p>",
+	})
+	assert.NoError(t, err)
+
+	tmpFiles, err := os.ReadDir(filepath.Join(dir, "tmp"))
+	assert.NoError(t, err)
+	assert.Empty(t, tmpFiles)
+
+	newFiles, err := os.ReadDir(filepath.Join(dir, "new"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(newFiles))
+	content, err := os.ReadFile(filepath.Join(dir, "new", newFiles[0].Name()))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "To: test@example.com")
+	assert.Contains(t, string(content), "Subject: Hello")
+	assert.Contains(t, string(content), "<p>world</p>")
+}
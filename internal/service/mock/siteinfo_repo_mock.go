@@ -240,6 +240,21 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteLogin(ctx interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteLogin", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteLogin), ctx)
 }
 
+// GetSiteReview mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteReview(ctx context.Context) (*schema.SiteReviewResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteReview", ctx)
+	ret0, _ := ret[0].(*schema.SiteReviewResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteReview indicates an expected call of GetSiteReview.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteReview(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteReview", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteReview), ctx)
+}
+
 // GetSiteSeo mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteSeo(ctx context.Context) (*schema.SiteSeoResp, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,303 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package moderator_message
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/reason_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	messageTemplateObjectType = "user"
+	messageTemplateAction     = "moderator_message"
+	// notificationTitleLength the max length of the message summary shown in the notification
+	notificationTitleLength = 80
+)
+
+// ModeratorMessageRepo moderator message repository
+type ModeratorMessageRepo interface {
+	AddModeratorMessage(ctx context.Context, message *entity.ModeratorMessage) (err error)
+	GetModeratorMessage(ctx context.Context, id int) (message *entity.ModeratorMessage, exist bool, err error)
+	GetModeratorMessageList(ctx context.Context, userID string, unacknowledgedOnly bool) (
+		messages []*entity.ModeratorMessage, err error)
+	HasUnacknowledgedModeratorMessage(ctx context.Context, userID string) (exist bool, err error)
+	AcknowledgeModeratorMessage(ctx context.Context, id int, userID string) (err error)
+}
+
+// ModeratorMessageService the private messages from the staff to the users
+type ModeratorMessageService struct {
+	moderatorMessageRepo     ModeratorMessageRepo
+	userRepo                 usercommon.UserRepo
+	userCommon               *usercommon.UserCommon
+	reasonRepo               reason_common.ReasonRepo
+	objectInfoService        *object_info.ObjService
+	notificationQueueService notice_queue.NotificationQueueService
+	emailService             *export.EmailService
+	siteInfoService          siteinfo_common.SiteInfoCommonService
+	auditLogService          *audit_log.AuditLogService
+}
+
+// NewModeratorMessageService new moderator message service
+func NewModeratorMessageService(
+	moderatorMessageRepo ModeratorMessageRepo,
+	userRepo usercommon.UserRepo,
+	userCommon *usercommon.UserCommon,
+	reasonRepo reason_common.ReasonRepo,
+	objectInfoService *object_info.ObjService,
+	notificationQueueService notice_queue.NotificationQueueService,
+	emailService *export.EmailService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	auditLogService *audit_log.AuditLogService,
+) *ModeratorMessageService {
+	return &ModeratorMessageService{
+		moderatorMessageRepo:     moderatorMessageRepo,
+		userRepo:                 userRepo,
+		userCommon:               userCommon,
+		reasonRepo:               reasonRepo,
+		objectInfoService:        objectInfoService,
+		notificationQueueService: notificationQueueService,
+		emailService:             emailService,
+		siteInfoService:          siteInfoService,
+		auditLogService:          auditLogService,
+	}
+}
+
+// AddModeratorMessage send a private message to the user, the user will be notified in app and by email
+func (ms *ModeratorMessageService) AddModeratorMessage(ctx context.Context, req *schema.AddModeratorMessageReq) (
+	resp *schema.AddModeratorMessageResp, err error) {
+	userInfo, exist, err := ms.userRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	if len(req.TemplateKey) > 0 {
+		_, ok, err := ms.getMessageTemplate(ctx, req.TemplateKey)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errors.BadRequest(reason.ModeratorMessageTemplateInvalid)
+		}
+	}
+	message := &entity.ModeratorMessage{
+		UserID:         userInfo.ID,
+		OperatorUserID: req.LoginUserID,
+		TemplateKey:    req.TemplateKey,
+		Content:        req.Content,
+		Status:         entity.ModeratorMessageStatusUnacknowledged,
+	}
+	if len(req.ObjectID) > 0 {
+		objInfo, err := ms.objectInfoService.GetInfo(ctx, uid.DeShortID(req.ObjectID))
+		if err != nil || objInfo == nil {
+			return nil, errors.BadRequest(reason.ObjectNotFound)
+		}
+		message.ObjectID = objInfo.ObjectID
+	}
+	if err = ms.moderatorMessageRepo.AddModeratorMessage(ctx, message); err != nil {
+		return nil, err
+	}
+
+	ms.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:      message.OperatorUserID,
+		ReceiverUserID:     message.UserID,
+		Type:               schema.NotificationTypeInbox,
+		ObjectID:           fmt.Sprintf("%d", message.ID),
+		ObjectType:         constant.ModeratorMessageObjectType,
+		Title:              htmltext.FetchExcerpt(message.Content, "...", notificationTitleLength),
+		ExtraInfo:          map[string]string{"moderator_message_id": fmt.Sprintf("%d", message.ID)},
+		NotificationAction: constant.NotificationModeratorMessage,
+	})
+	ms.sendModeratorMessageEmail(ctx, userInfo, message)
+	ms.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: message.OperatorUserID,
+		Action:         constant.AuditLogActionModeratorMessageSend,
+		ObjectType:     constant.UserObjectType,
+		ObjectID:       message.UserID,
+		After: map[string]any{
+			"moderator_message_id": message.ID,
+			"template_key":         message.TemplateKey,
+			"object_id":            message.ObjectID,
+			"content":              message.Content,
+		},
+	})
+	return &schema.AddModeratorMessageResp{ID: message.ID}, nil
+}
+
+// AcknowledgeModeratorMessage the user acknowledges the message
+func (ms *ModeratorMessageService) AcknowledgeModeratorMessage(ctx context.Context,
+	req *schema.AcknowledgeModeratorMessageReq) (err error) {
+	message, exist, err := ms.moderatorMessageRepo.GetModeratorMessage(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist || message.UserID != req.UserID {
+		return errors.BadRequest(reason.ModeratorMessageNotFound)
+	}
+	if message.Status == entity.ModeratorMessageStatusAcknowledged {
+		return nil
+	}
+	return ms.moderatorMessageRepo.AcknowledgeModeratorMessage(ctx, message.ID, message.UserID)
+}
+
+// HasUnacknowledgedModeratorMessage check whether the user must acknowledge some messages before posting
+func (ms *ModeratorMessageService) HasUnacknowledgedModeratorMessage(ctx context.Context, userID string) (
+	exist bool, err error) {
+	return ms.moderatorMessageRepo.HasUnacknowledgedModeratorMessage(ctx, userID)
+}
+
+// GetModeratorMessageList get the message history of the user, for the staff
+func (ms *ModeratorMessageService) GetModeratorMessageList(ctx context.Context,
+	req *schema.GetModeratorMessageListReq) (resp []*schema.ModeratorMessageResp, err error) {
+	messages, err := ms.moderatorMessageRepo.GetModeratorMessageList(ctx, req.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+	operatorIDs := make([]string, 0, len(messages))
+	for _, message := range messages {
+		operatorIDs = append(operatorIDs, message.OperatorUserID)
+	}
+	operatorMapping, err := ms.userCommon.BatchUserBasicInfoByID(ctx, operatorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = make([]*schema.ModeratorMessageResp, 0, len(messages))
+	for _, message := range messages {
+		item := ms.formatModeratorMessage(ctx, message)
+		item.Operator = operatorMapping[message.OperatorUserID]
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// GetUserModeratorMessageList get the messages received by the login user
+func (ms *ModeratorMessageService) GetUserModeratorMessageList(ctx context.Context,
+	req *schema.GetUserModeratorMessageListReq) (resp []*schema.ModeratorMessageResp, err error) {
+	messages, err := ms.moderatorMessageRepo.GetModeratorMessageList(ctx, req.UserID, req.Unacknowledged)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.ModeratorMessageResp, 0, len(messages))
+	for _, message := range messages {
+		resp = append(resp, ms.formatModeratorMessage(ctx, message))
+	}
+	return resp, nil
+}
+
+func (ms *ModeratorMessageService) getMessageTemplate(ctx context.Context, templateKey string) (
+	item *schema.ReasonItem, ok bool, err error) {
+	templates, err := ms.reasonRepo.ListReasons(ctx, messageTemplateObjectType, messageTemplateAction)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, t := range templates {
+		if t.ReasonKey == templateKey {
+			return t, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (ms *ModeratorMessageService) formatModeratorMessage(ctx context.Context, message *entity.ModeratorMessage) (
+	resp *schema.ModeratorMessageResp) {
+	resp = &schema.ModeratorMessageResp{
+		ID:          message.ID,
+		TemplateKey: message.TemplateKey,
+		Content:     message.Content,
+		CreatedAt:   message.CreatedAt.Unix(),
+	}
+	switch message.Status {
+	case entity.ModeratorMessageStatusUnacknowledged:
+		resp.Status = schema.ModeratorMessageStatusUnacknowledged
+	case entity.ModeratorMessageStatusAcknowledged:
+		resp.Status = schema.ModeratorMessageStatusAcknowledged
+		resp.AcknowledgedAt = message.AcknowledgedAt.Unix()
+	}
+	if len(message.TemplateKey) > 0 {
+		templateItem, ok, err := ms.getMessageTemplate(ctx, message.TemplateKey)
+		if err != nil {
+			log.Error(err)
+		}
+		if ok {
+			resp.TemplateName = templateItem.Name
+		}
+	}
+	if len(message.ObjectID) > 0 && message.ObjectID != "0" {
+		objInfo, err := ms.objectInfoService.GetInfo(ctx, message.ObjectID)
+		if err != nil {
+			log.Error(err)
+		} else if objInfo != nil {
+			resp.ObjectID = objInfo.ObjectID
+			resp.ObjectType = objInfo.ObjectType
+			resp.ObjectTitle = objInfo.Title
+			resp.QuestionID = objInfo.QuestionID
+			if handler.GetEnableShortID(ctx) {
+				resp.ObjectID = uid.EnShortID(resp.ObjectID)
+				resp.QuestionID = uid.EnShortID(resp.QuestionID)
+			}
+		}
+	}
+	return resp
+}
+
+func (ms *ModeratorMessageService) sendModeratorMessageEmail(ctx context.Context, userInfo *entity.User,
+	message *entity.ModeratorMessage) {
+	if userInfo.MailStatus != entity.EmailStatusAvailable {
+		return
+	}
+	lang := userInfo.Language
+	if len(lang) == 0 || lang == translator.DefaultLangOption {
+		if interfaceInfo, _ := ms.siteInfoService.GetSiteInterface(ctx); interfaceInfo != nil {
+			lang = interfaceInfo.Language
+		}
+	}
+	ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(lang))
+
+	title, body, err := ms.emailService.ModeratorMessageTemplate(ctx, &schema.ModeratorMessageTemplateRawData{
+		DisplayName: userInfo.DisplayName,
+		Content:     message.Content,
+	})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	ms.emailService.Send(ctx, userInfo.EMail, title, body)
+}
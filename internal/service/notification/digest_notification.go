@@ -0,0 +1,205 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/token"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	digestMaxNotifications = 20
+	digestMaxQuestions     = 5
+)
+
+// SendDigestCron send the notification digest email to users who chose the given frequency
+func (ns *ExternalNotificationService) SendDigestCron(ctx context.Context, frequency constant.NotificationFrequency) {
+	configs, err := ns.userNotificationConfigRepo.GetBySource(ctx, constant.DigestSource)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	now := time.Now()
+	// the first digest of the user only contains the notifications in the last period
+	firstCreatedFrom := now.AddDate(0, 0, -1)
+	if frequency == constant.NotificationFrequencyWeekly {
+		firstCreatedFrom = now.AddDate(0, 0, -7)
+	}
+	for _, conf := range configs {
+		channel := schema.NewNotificationChannelConfigFormJson(conf.Channels)
+		if channel.Key != constant.EmailChannel || channel.DigestFrequency() != frequency {
+			continue
+		}
+		createdFrom := conf.DigestSentAt
+		if createdFrom.IsZero() {
+			createdFrom = firstCreatedFrom
+		}
+		// the notifications are digested again next time if the digest is not sent
+		if err = ns.sendDigestEmail(ctx, conf.UserID, createdFrom, now); err != nil {
+			log.Errorf("send digest to user %s failed: %v", conf.UserID, err)
+			continue
+		}
+		if err = ns.userNotificationConfigRepo.UpdateDigestSentAt(ctx, conf.ID, now); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// sendDigestEmail send the digest of the notifications created in [createdFrom, createdTo) to the user
+func (ns *ExternalNotificationService) sendDigestEmail(ctx context.Context, userID string,
+	createdFrom, createdTo time.Time) (err error) {
+	userInfo, exist, err := ns.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.Status != entity.UserStatusAvailable || userInfo.MailStatus != entity.EmailStatusAvailable {
+		return nil
+	}
+	lang := userInfo.Language
+	if len(lang) == 0 || lang == translator.DefaultLangOption {
+		if interfaceInfo, _ := ns.siteInfoService.GetSiteInterface(ctx); interfaceInfo != nil {
+			lang = interfaceInfo.Language
+		}
+	}
+	ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(lang))
+
+	rawData := &schema.DigestTemplateRawData{UnsubscribeCode: token.GenerateToken()}
+	rawData.Notifications, err = ns.getDigestNotifications(ctx, userID, lang, createdFrom, createdTo)
+	if err != nil {
+		return err
+	}
+	rawData.Questions, err = ns.getDigestQuestions(ctx, userID, createdFrom)
+	if err != nil {
+		return err
+	}
+	if len(rawData.Notifications) == 0 && len(rawData.Questions) == 0 {
+		log.Debugf("nothing to digest for user %s", userID)
+		return nil
+	}
+
+	title, body, err := ns.emailService.DigestTemplate(ctx, rawData)
+	if err != nil {
+		return err
+	}
+	codeContent := &schema.EmailCodeContent{
+		SourceType: schema.UnsubscribeSourceType,
+		Email:      userInfo.EMail,
+		UserID:     userID,
+		NotificationSources: []constant.NotificationSource{
+			constant.DigestSource,
+			constant.InboxSource,
+			constant.AllNewQuestionForFollowingTagsSource,
+		},
+		SkipValidationLatestCode: true,
+	}
+	return ns.emailService.SendAndSaveCodeWithResult(
+		ctx, userID, userInfo.EMail, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 7*24*time.Hour)
+}
+
+// getDigestNotifications get the unread inbox notifications related to questions since the last digest
+func (ns *ExternalNotificationService) getDigestNotifications(ctx context.Context, userID, lang string,
+	createdFrom, createdTo time.Time) (notifications []*schema.DigestNotification, err error) {
+	notificationList, err := ns.notificationRepo.GetUnreadInboxNotifications(
+		ctx, userID, createdFrom, createdTo, digestMaxNotifications)
+	if err != nil {
+		return nil, err
+	}
+	for _, notificationInfo := range notificationList {
+		item := &schema.NotificationContent{}
+		if err := json.Unmarshal([]byte(notificationInfo.Content), item); err != nil {
+			log.Error("NotificationContent Unmarshal Error", err.Error())
+			continue
+		}
+		questionID := item.ObjectInfo.ObjectMap["question"]
+		if len(questionID) == 0 {
+			continue
+		}
+		notification := &schema.DigestNotification{
+			Action:        translator.Tr(i18n.Language(lang), item.NotificationAction),
+			QuestionID:    questionID,
+			QuestionTitle: item.ObjectInfo.Title,
+			AnswerID:      item.ObjectInfo.ObjectMap["answer"],
+		}
+		// If notification is downvote, the user info is not needed.
+		if item.UserInfo != nil &&
+			item.NotificationAction != constant.NotificationDownVotedTheQuestion &&
+			item.NotificationAction != constant.NotificationDownVotedTheAnswer {
+			notification.DisplayName = item.UserInfo.DisplayName
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
+
+// getDigestQuestions get top questions in the user's following tags
+func (ns *ExternalNotificationService) getDigestQuestions(ctx context.Context, userID string, createdFrom time.Time) (
+	questions []*schema.DigestQuestion, err error) {
+	tagIDs, err := ns.followRepo.GetFollowIDs(ctx, userID, entity.Tag{}.TableName())
+	if err != nil {
+		return nil, err
+	}
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+	inDays := int(time.Since(createdFrom).Hours()/24 + 0.5)
+	// The question is listed once for each following tag it has, a question has at most five tags.
+	questionList, _, err := ns.questionRepo.GetQuestionPage(ctx, 1, digestMaxQuestions*5,
+		tagIDs, "", "score", inDays, false, false)
+	if err != nil {
+		return nil, err
+	}
+	questionMapping := make(map[string]bool)
+	for _, question := range questionList {
+		if question.UserID == userID || questionMapping[question.ID] {
+			continue
+		}
+		questionMapping[question.ID] = true
+		questions = append(questions, &schema.DigestQuestion{
+			QuestionID:    question.ID,
+			QuestionTitle: question.Title,
+		})
+		if len(questions) >= digestMaxQuestions {
+			break
+		}
+	}
+	return questions, nil
+}
+
+// isDigestEnabled whether the user receives email notifications in the digest instead of immediately
+func (ns *ExternalNotificationService) isDigestEnabled(ctx context.Context, userID string) bool {
+	conf, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(ctx, userID, constant.DigestSource)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	if !exist {
+		return false
+	}
+	channel := schema.NewNotificationChannelConfigFormJson(conf.Channels)
+	return channel.DigestFrequency() != constant.NotificationFrequencyImmediate
+}
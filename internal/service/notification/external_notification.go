@@ -28,6 +28,8 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	notficationcommon "github.com/apache/incubator-answer/internal/service/notification_common"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
@@ -44,6 +46,8 @@ type ExternalNotificationService struct {
 	notificationQueueService   notice_queue.ExternalNotificationQueueService
 	userExternalLoginRepo      user_external_login.UserExternalLoginRepo
 	siteInfoService            siteinfo_common.SiteInfoCommonService
+	notificationRepo           notficationcommon.NotificationRepo
+	questionRepo               questioncommon.QuestionRepo
 }
 
 func NewExternalNotificationService(
@@ -55,6 +59,8 @@ func NewExternalNotificationService(
 	notificationQueueService notice_queue.ExternalNotificationQueueService,
 	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	notificationRepo notficationcommon.NotificationRepo,
+	questionRepo questioncommon.QuestionRepo,
 ) *ExternalNotificationService {
 	n := &ExternalNotificationService{
 		data:                       data,
@@ -65,6 +71,8 @@ func NewExternalNotificationService(
 		notificationQueueService:   notificationQueueService,
 		userExternalLoginRepo:      userExternalLoginRepo,
 		siteInfoService:            siteInfoService,
+		notificationRepo:           notificationRepo,
+		questionRepo:               questionRepo,
 	}
 	notificationQueueService.RegisterHandler(n.Handler)
 	return n
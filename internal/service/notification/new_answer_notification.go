@@ -39,6 +39,10 @@ func (ns *ExternalNotificationService) handleNewAnswerNotification(ctx context.C
 	if !exist {
 		return nil
 	}
+	// The notification will be sent in the digest email.
+	if ns.isDigestEnabled(ctx, msg.ReceiverUserID) {
+		return nil
+	}
 	channels := schema.NewNotificationChannelsFormJson(notificationConfig.Channels)
 	for _, channel := range channels {
 		if !channel.Enable {
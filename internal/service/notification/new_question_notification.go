@@ -98,10 +98,26 @@ func (ns *ExternalNotificationService) getNewQuestionSubscribers(ctx context.Con
 	if err != nil {
 		return nil, err
 	}
+	// Users who enabled digest will receive the questions of following tags in the digest email.
+	digestConfigs, err := ns.userNotificationConfigRepo.GetByUsersAndSource(
+		ctx, tagsFollowerIDs, constant.DigestSource)
+	if err != nil {
+		return nil, err
+	}
+	digestUserMapping := make(map[string]bool)
+	for _, digestConfig := range digestConfigs {
+		channel := schema.NewNotificationChannelConfigFormJson(digestConfig.Channels)
+		if channel.DigestFrequency() != constant.NotificationFrequencyImmediate {
+			digestUserMapping[digestConfig.UserID] = true
+		}
+	}
 	for _, userNotificationConfig := range userNotificationConfigs {
 		if _, ok := subscribersMapping[userNotificationConfig.UserID]; ok {
 			continue
 		}
+		if digestUserMapping[userNotificationConfig.UserID] {
+			continue
+		}
 		subscribersMapping[userNotificationConfig.UserID] = &NewQuestionSubscriber{
 			UserID:             userNotificationConfig.UserID,
 			Channels:           schema.NewNotificationChannelsFormJson(userNotificationConfig.Channels),
@@ -257,6 +257,10 @@ func (ns *NotificationService) formatNotificationPage(ctx context.Context, notif
 			}{BadgeName: badgeName})
 			item.UserInfo = nil
 		}
+		// The moderator message is sent on behalf of the moderators team, so the sender is not shown.
+		if item.ObjectInfo.ObjectType == constant.ModeratorMessageObjectType {
+			item.UserInfo = nil
+		}
 
 		item.ID = notificationInfo.ID
 		item.NotificationAction = translator.Tr(lang, item.NotificationAction)
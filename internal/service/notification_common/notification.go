@@ -55,6 +55,8 @@ type NotificationRepo interface {
 	UpdateNotificationContent(ctx context.Context, notification *entity.Notification) (err error)
 	GetById(ctx context.Context, id string) (*entity.Notification, bool, error)
 	CountNotificationByUser(ctx context.Context, cond *entity.Notification) (int64, error)
+	GetUnreadInboxNotifications(ctx context.Context, userID string, createdFrom, createdTo time.Time, limit int) (
+		notificationList []*entity.Notification, err error)
 }
 
 type NotificationCommon struct {
@@ -121,12 +123,18 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 	}
 	var questionID string // just for notify all followers
 	var objInfo *schema.SimpleObjectInfo
-	if msg.ObjectType == constant.BadgeAwardObjectType {
+	switch msg.ObjectType {
+	case constant.BadgeAwardObjectType:
 		req.ObjectInfo.Title = msg.Title
 		objectMap := make(map[string]string)
 		objectMap["badge_id"] = msg.ExtraInfo["badge_id"]
 		req.ObjectInfo.ObjectMap = objectMap
-	} else {
+	case constant.ModeratorMessageObjectType:
+		req.ObjectInfo.Title = msg.Title
+		objectMap := make(map[string]string)
+		objectMap["moderator_message_id"] = msg.ExtraInfo["moderator_message_id"]
+		req.ObjectInfo.ObjectMap = objectMap
+	default:
 		objInfo, err = ns.objectInfoService.GetInfo(ctx, req.ObjectInfo.ObjectID)
 		if err != nil {
 			log.Error(err)
@@ -207,7 +215,7 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 
 	go ns.SendNotificationToAllFollower(ctx, msg, questionID)
 
-	if msg.Type == schema.NotificationTypeInbox {
+	if msg.Type == schema.NotificationTypeInbox && objInfo != nil {
 		ns.syncNotificationToPlugin(ctx, objInfo, msg)
 	}
 	return nil
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package permission
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/schema"
+)
+
+// GetConvertPermission get the permission of converting the comment into an answer
+// or converting the answer into a comment.
+func GetConvertPermission(ctx context.Context, objectType string, canConvert bool) (
+	actions []*schema.PermissionMemberAction) {
+	lang := handler.GetLangByCtx(ctx)
+	actions = make([]*schema.PermissionMemberAction, 0)
+	if !canConvert {
+		return actions
+	}
+	switch objectType {
+	case constant.CommentObjectType:
+		actions = append(actions, &schema.PermissionMemberAction{
+			Action: "convert_to_answer",
+			Name:   translator.Tr(lang, convertToAnswerActionName),
+			Type:   "confirm",
+		})
+	case constant.AnswerObjectType:
+		actions = append(actions, &schema.PermissionMemberAction{
+			Action: "convert_to_comment",
+			Name:   translator.Tr(lang, convertToCommentActionName),
+			Type:   "confirm",
+		})
+	}
+	return actions
+}
@@ -62,8 +62,39 @@ const (
 	AnswerUnDelete              = "answer.undeleted"
 	QuestionUnDelete            = "question.undeleted"
 	TagUnDelete                 = "tag.undeleted"
+	QuestionBounty              = "question.bounty"
+	QuestionMerge               = "question.merge"
+	QuestionWiki                = "question.wiki"
+	QuestionWikiEdit            = "question.wiki_edit"
+	AnswerWiki                  = "answer.wiki"
+	AnswerWikiEdit              = "answer.wiki_edit"
+	CommentConvert              = "comment.convert"
+	AnswerConvert               = "answer.convert"
+	UserModeratorMessage        = "user.moderator_message"
+	ReviewFirstPost             = "review.first_post"
+	ReviewLateAnswer            = "review.late_answer"
+	ReviewLowQuality            = "review.low_quality"
 )
 
+// tagModeratorPowers the powers that tag moderators have on the posts with their tags
+var tagModeratorPowers = map[string]bool{
+	QuestionEdit:              true,
+	QuestionEditWithoutReview: true,
+	QuestionClose:             true,
+	QuestionReopen:            true,
+	QuestionDelete:            true,
+	QuestionUnDelete:          true,
+	AnswerEdit:                true,
+	AnswerEditWithoutReview:   true,
+	AnswerDelete:              true,
+	AnswerUnDelete:            true,
+}
+
+// IsTagModeratorPower whether the tag moderators have the power of the action
+func IsTagModeratorPower(action string) bool {
+	return tagModeratorPowers[action]
+}
+
 const (
 	reportActionName                = "action.report"
 	editActionName                  = "action.edit"
@@ -76,4 +107,8 @@ const (
 	hideActionName                  = "action.hide"
 	showActionName                  = "action.show"
 	inviteSomeoneToAnswerActionName = "action.invite_someone_to_answer"
+	wikiActionName                  = "action.wiki"
+	unwikiActionName                = "action.unwiki"
+	convertToAnswerActionName       = "action.convert_to_answer"
+	convertToCommentActionName      = "action.convert_to_comment"
 )
@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package permission
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/schema"
+)
+
+// GetWikiPermission get the community wiki permission of question or answer,
+// the author can always change the wiki mode of the post.
+func GetWikiPermission(ctx context.Context, userID, creatorUserID string, canWiki, wiki bool) (
+	actions []*schema.PermissionMemberAction) {
+	lang := handler.GetLangByCtx(ctx)
+	actions = make([]*schema.PermissionMemberAction, 0)
+	if len(userID) == 0 || (!canWiki && userID != creatorUserID) {
+		return actions
+	}
+	if wiki {
+		actions = append(actions, &schema.PermissionMemberAction{
+			Action: "unwiki",
+			Name:   translator.Tr(lang, unwikiActionName),
+			Type:   "confirm",
+		})
+	} else {
+		actions = append(actions, &schema.PermissionMemberAction{
+			Action: "wiki",
+			Name:   translator.Tr(lang, wikiActionName),
+			Type:   "confirm",
+		})
+	}
+	return actions
+}
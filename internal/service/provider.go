@@ -25,8 +25,10 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/auth"
 	"github.com/apache/incubator-answer/internal/service/badge"
+	"github.com/apache/incubator-answer/internal/service/bounty"
 	"github.com/apache/incubator-answer/internal/service/collection"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	"github.com/apache/incubator-answer/internal/service/comment"
@@ -34,11 +36,13 @@ import (
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/content"
 	"github.com/apache/incubator-answer/internal/service/dashboard"
+	"github.com/apache/incubator-answer/internal/service/draft"
 	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/follow"
 	"github.com/apache/incubator-answer/internal/service/meta"
 	"github.com/apache/incubator-answer/internal/service/meta_common"
+	"github.com/apache/incubator-answer/internal/service/moderator_message"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
 	notficationcommon "github.com/apache/incubator-answer/internal/service/notification_common"
@@ -52,16 +56,21 @@ import (
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/saved_search"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/internal/service/tag"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
 	"github.com/apache/incubator-answer/internal/service/uploader"
+	"github.com/apache/incubator-answer/internal/service/user_access_token"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
+	"github.com/apache/incubator-answer/internal/service/webhook"
 	"github.com/google/wire"
 )
 
@@ -80,6 +89,7 @@ var ProviderSetService = wire.NewSet(
 	content.NewUserService,
 	content.NewQuestionService,
 	content.NewAnswerService,
+	content.NewPostConvertService,
 	export.NewEmailService,
 	tagcommon.NewTagCommonService,
 	usercommon.NewUserCommon,
@@ -124,4 +134,13 @@ var ProviderSetService = wire.NewSet(
 	badge.NewBadgeEventService,
 	badge.NewBadgeAwardService,
 	badge.NewBadgeGroupService,
+	webhook.NewWebhookService,
+	user_access_token.NewUserAccessTokenService,
+	saved_search.NewSavedSearchService,
+	bounty.NewBountyService,
+	draft.NewDraftService,
+	tag_moderator.NewTagModeratorService,
+	user_suspension.NewUserSuspensionService,
+	moderator_message.NewModeratorMessageService,
+	audit_log.NewAuditLogService,
 )
@@ -627,6 +627,7 @@ func (qs *QuestionCommon) ShowFormat(ctx context.Context, data *entity.Question)
 	if handler.GetEnableShortID(ctx) {
 		info.ID = uid.EnShortID(data.ID)
 	}
+	info.RevisionID = data.RevisionID
 	info.Title = data.Title
 	info.UrlTitle = htmltext.UrlTitle(data.Title)
 	info.Content = data.OriginalText
@@ -651,6 +652,7 @@ func (qs *QuestionCommon) ShowFormat(ctx context.Context, data *entity.Question)
 	}
 	info.Status = data.Status
 	info.Pin = data.Pin
+	info.Wiki = data.Wiki
 	info.Show = data.Show
 	info.UserID = data.UserID
 	info.LastEditUserID = data.LastEditUserID
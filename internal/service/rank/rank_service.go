@@ -34,6 +34,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -58,12 +59,13 @@ type UserRankRepo interface {
 
 // RankService rank service
 type RankService struct {
-	userCommon        *usercommon.UserCommon
-	configService     *config.ConfigService
-	userRankRepo      UserRankRepo
-	objectInfoService *object_info.ObjService
-	roleService       *role.UserRoleRelService
-	rolePowerService  *role.RolePowerRelService
+	userCommon          *usercommon.UserCommon
+	configService       *config.ConfigService
+	userRankRepo        UserRankRepo
+	objectInfoService   *object_info.ObjService
+	roleService         *role.UserRoleRelService
+	rolePowerService    *role.RolePowerRelService
+	tagModeratorService *tag_moderator.TagModeratorService
 }
 
 // NewRankService new rank service
@@ -73,14 +75,16 @@ func NewRankService(
 	objectInfoService *object_info.ObjService,
 	roleService *role.UserRoleRelService,
 	rolePowerService *role.RolePowerRelService,
-	configService *config.ConfigService) *RankService {
+	configService *config.ConfigService,
+	tagModeratorService *tag_moderator.TagModeratorService) *RankService {
 	return &RankService{
-		userCommon:        userCommon,
-		configService:     configService,
-		userRankRepo:      userRankRepo,
-		objectInfoService: objectInfoService,
-		roleService:       roleService,
-		rolePowerService:  rolePowerService,
+		userCommon:          userCommon,
+		configService:       configService,
+		userRankRepo:        userRankRepo,
+		objectInfoService:   objectInfoService,
+		roleService:         roleService,
+		rolePowerService:    rolePowerService,
+		tagModeratorService: tagModeratorService,
 	}
 }
 
@@ -114,6 +118,9 @@ func (rs *RankService) CheckOperationPermission(ctx context.Context, userID stri
 			objectInfo.ObjectCreatorUserID == userID {
 			return true, nil
 		}
+		if rs.CheckTagModeratorPermission(ctx, userID, action, objectID) {
+			return true, nil
+		}
 	}
 
 	can, _ = rs.checkUserRank(ctx, userInfo.ID, userInfo.Rank, PermissionPrefix+action)
@@ -158,6 +165,53 @@ func (rs *RankService) CheckOperationPermissions(ctx context.Context, userID str
 	return can, err
 }
 
+// CheckOperationPermissionsForObject verify that the user has permission to operate the object,
+// the tag moderators have the permission on the posts with their tags even if their rank is not enough.
+func (rs *RankService) CheckOperationPermissionsForObject(ctx context.Context, userID string, actions []string,
+	objectID string) (can []bool, err error) {
+	can, err = rs.CheckOperationPermissions(ctx, userID, actions)
+	if err != nil {
+		return can, err
+	}
+	checked, isTagModerator := false, false
+	for idx, action := range actions {
+		if can[idx] || !permission.IsTagModeratorPower(action) {
+			continue
+		}
+		if !checked {
+			isTagModerator = rs.CheckTagModeratorPermission(ctx, userID, action, objectID)
+			checked = true
+		}
+		can[idx] = isTagModerator
+	}
+	return can, nil
+}
+
+// CheckTagModeratorPermission check whether the user moderates the tags of the question that the object belongs to
+func (rs *RankService) CheckTagModeratorPermission(ctx context.Context, userID, action, objectID string) bool {
+	if !permission.IsTagModeratorPower(action) {
+		return false
+	}
+	ok, err := rs.tagModeratorService.IsTagModeratorOfObject(ctx, userID, objectID)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return ok
+}
+
+// CheckModeratorPermissionForObject check whether the user has the power by the role or by moderating the tags of
+// the object, the permission reached by the reputation is not included.
+func (rs *RankService) CheckModeratorPermissionForObject(ctx context.Context, userID, action, objectID string) bool {
+	if len(userID) == 0 {
+		return false
+	}
+	if rs.getUserPowerMapping(ctx, userID)[action] {
+		return true
+	}
+	return rs.CheckTagModeratorPermission(ctx, userID, action, objectID)
+}
+
 // CheckOperationObjectOwner check operation object owner
 func (rs *RankService) CheckOperationObjectOwner(ctx context.Context, userID, objectID string) bool {
 	objectID = uid.DeShortID(objectID)
@@ -174,6 +228,17 @@ func (rs *RankService) CheckOperationObjectOwner(ctx context.Context, userID, ob
 	return false
 }
 
+// CheckOperationObjectWiki check whether the object is community wiki
+func (rs *RankService) CheckOperationObjectWiki(ctx context.Context, objectID string) bool {
+	objectID = uid.DeShortID(objectID)
+	objectInfo, err := rs.objectInfoService.GetInfo(ctx, objectID)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return objectInfo != nil && objectInfo.Wiki
+}
+
 // CheckVotePermission verify that the user has vote permission
 func (rs *RankService) CheckVotePermission(ctx context.Context, userID, objectID string, voteUp bool) (
 	can bool, needRank int, err error) {
@@ -30,6 +30,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/object_info"
@@ -57,6 +58,7 @@ type ReportService struct {
 	reportHandle      *report_handle.ReportHandle
 	configService     *config.ConfigService
 	eventQueueService event_queue.EventQueueService
+	auditLogService   *audit_log.AuditLogService
 }
 
 // NewReportService new report service
@@ -70,6 +72,7 @@ func NewReportService(
 	reportHandle *report_handle.ReportHandle,
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
+	auditLogService *audit_log.AuditLogService,
 ) *ReportService {
 	return &ReportService{
 		reportRepo:        reportRepo,
@@ -81,6 +84,7 @@ func NewReportService(
 		reportHandle:      reportHandle,
 		configService:     configService,
 		eventQueueService: eventQueueService,
+		auditLogService:   auditLogService,
 	}
 }
 
@@ -217,15 +221,33 @@ func (rs *ReportService) ReviewReport(ctx context.Context, req *schema.ReviewRep
 	}
 
 	// ignore this report
-	if req.OperationType == constant.ReportOperationIgnoreReport {
-		return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusIgnore)
+	status := entity.ReportStatusIgnore
+	if req.OperationType != constant.ReportOperationIgnoreReport {
+		if err = rs.reportHandle.UpdateReportedObject(ctx, report, req); err != nil {
+			return
+		}
+		status = entity.ReportStatusCompleted
 	}
-
-	if err = rs.reportHandle.UpdateReportedObject(ctx, report, req); err != nil {
-		return
+	if err = rs.reportRepo.UpdateStatus(ctx, report.ID, status); err != nil {
+		return err
 	}
 
-	return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusCompleted)
+	rs.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionReportReview,
+		ObjectType:     constant.ReportObjectType,
+		ObjectID:       report.ID,
+		Before: map[string]string{
+			"object_type": constant.ObjectTypeNumberMapping[report.ObjectType],
+			"object_id":   report.ObjectID,
+		},
+		After: map[string]any{
+			"operation_type": req.OperationType,
+			"close_type":     req.CloseType,
+			"close_msg":      req.CloseMsg,
+		},
+	})
+	return nil
 }
 
 func (rs *ReportService) sendEvent(ctx context.Context,
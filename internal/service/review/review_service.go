@@ -28,12 +28,14 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/internal/service/tag_moderator"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/token"
@@ -51,6 +53,9 @@ type ReviewRepo interface {
 	GetReview(ctx context.Context, reviewID int) (review *entity.Review, exist bool, err error)
 	GetReviewCount(ctx context.Context, status int) (count int64, err error)
 	GetReviewPage(ctx context.Context, page, pageSize int, cond *entity.Review) (reviewList []*entity.Review, total int64, err error)
+	GetReviewCountByTagIDs(ctx context.Context, status int, tagIDs []string) (count int64, err error)
+	GetReviewPageByTagIDs(ctx context.Context, page, pageSize int, tagIDs []string, cond *entity.Review) (
+		reviewList []*entity.Review, total int64, err error)
 }
 
 // ReviewService user service
@@ -67,6 +72,9 @@ type ReviewService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	notificationQueueService         notice_queue.NotificationQueueService
 	siteInfoService                  siteinfo_common.SiteInfoCommonService
+	tagModeratorService              *tag_moderator.TagModeratorService
+	reviewTaskRepo                   ReviewTaskRepo
+	auditLogService                  *audit_log.AuditLogService
 }
 
 // NewReviewService new review service
@@ -83,6 +91,9 @@ func NewReviewService(
 	questionCommon *questioncommon.QuestionCommon,
 	notificationQueueService notice_queue.NotificationQueueService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	tagModeratorService *tag_moderator.TagModeratorService,
+	reviewTaskRepo ReviewTaskRepo,
+	auditLogService *audit_log.AuditLogService,
 ) *ReviewService {
 	return &ReviewService{
 		reviewRepo:                       reviewRepo,
@@ -97,6 +108,9 @@ func NewReviewService(
 		questionCommon:                   questionCommon,
 		notificationQueueService:         notificationQueueService,
 		siteInfoService:                  siteInfoService,
+		tagModeratorService:              tagModeratorService,
+		reviewTaskRepo:                   reviewTaskRepo,
+		auditLogService:                  auditLogService,
 	}
 }
 
@@ -125,6 +139,10 @@ func (cs *ReviewService) AddQuestionReview(ctx context.Context,
 	default:
 		questionStatus = entity.QuestionStatusAvailable
 	}
+	if questionStatus == entity.QuestionStatusAvailable {
+		cs.addReviewTasks(ctx, constant.QuestionObjectType, question.ID, question.UserID, question.ParsedText,
+			reviewContent.Author, question.CreatedAt)
+	}
 	return questionStatus
 }
 
@@ -149,6 +167,15 @@ func (cs *ReviewService) AddAnswerReview(ctx context.Context,
 	default:
 		answerStatus = entity.AnswerStatusAvailable
 	}
+	if answerStatus == entity.AnswerStatusAvailable {
+		questionInfo, exist, err := cs.questionRepo.GetQuestion(ctx, answer.QuestionID)
+		if err != nil {
+			log.Errorf("get question failed, err: %v", err)
+		} else if exist {
+			cs.addReviewTasks(ctx, constant.AnswerObjectType, answer.ID, answer.UserID, answer.ParsedText,
+				reviewContent.Author, questionInfo.CreatedAt)
+		}
+	}
 	return answerStatus
 }
 
@@ -221,6 +248,16 @@ func (cs *ReviewService) UpdateReview(ctx context.Context, req *schema.UpdateRev
 	if review.Status != entity.ReviewStatusPending {
 		return nil
 	}
+	// the tag moderators can only review the posts with their tags
+	if !req.IsAdmin {
+		isTagModerator, err := cs.tagModeratorService.IsTagModeratorOfObject(ctx, req.UserID, review.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !isTagModerator {
+			return errors.Forbidden(reason.ForbiddenError)
+		}
+	}
 
 	if err = cs.updateObjectStatus(ctx, review, req.IsApprove()); err != nil {
 		return err
@@ -231,7 +268,18 @@ func (cs *ReviewService) UpdateReview(ctx context.Context, req *schema.UpdateRev
 	} else {
 		err = cs.reviewRepo.UpdateReviewStatus(ctx, req.ReviewID, req.UserID, entity.ReviewStatusRejected)
 	}
-	return
+	if err != nil {
+		return err
+	}
+	cs.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionPendingPostReview,
+		ObjectType:     constant.ObjectTypeNumberMapping[review.ObjectType],
+		ObjectID:       review.ObjectID,
+		Before:         map[string]string{"status": "pending", "submitter": review.Submitter, "reason": review.Reason},
+		After:          map[string]string{"status": req.Status},
+	})
+	return nil
 }
 
 // update object status
@@ -246,7 +294,10 @@ func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.
 		if !exist {
 			return errors.BadRequest(reason.ObjectNotFound)
 		}
+		// the author info is got before approving, so the approved question is not counted as a previous post
+		var author plugin.ReviewContentAuthor
 		if isApprove {
+			author = cs.getReviewContentAuthorInfo(ctx, questionInfo.UserID)
 			questionInfo.Status = entity.QuestionStatusAvailable
 		} else {
 			questionInfo.Status = entity.QuestionStatusDeleted
@@ -255,6 +306,8 @@ func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.
 			return err
 		}
 		if isApprove {
+			cs.addReviewTasks(ctx, constant.QuestionObjectType, questionInfo.ID, questionInfo.UserID,
+				questionInfo.ParsedText, author, questionInfo.CreatedAt)
 			tags, err := cs.tagCommon.GetObjectEntityTag(ctx, questionInfo.ID)
 			if err != nil {
 				log.Errorf("get question tags failed, err: %v", err)
@@ -279,7 +332,9 @@ func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.
 		if !exist {
 			return errors.BadRequest(reason.ObjectNotFound)
 		}
+		var author plugin.ReviewContentAuthor
 		if isApprove {
+			author = cs.getReviewContentAuthorInfo(ctx, answerInfo.UserID)
 			answerInfo.Status = entity.AnswerStatusAvailable
 		} else {
 			answerInfo.Status = entity.AnswerStatusDeleted
@@ -295,6 +350,8 @@ func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.
 			return errors.BadRequest(reason.ObjectNotFound)
 		}
 		if isApprove {
+			cs.addReviewTasks(ctx, constant.AnswerObjectType, answerInfo.ID, answerInfo.UserID,
+				answerInfo.ParsedText, author, questionInfo.CreatedAt)
 			cs.notificationAnswerTheQuestion(ctx, questionInfo.UserID, questionInfo.ID, answerInfo.ID,
 				answerInfo.UserID, questionInfo.Title, answerInfo.OriginalText)
 		}
@@ -368,17 +425,45 @@ func (cs *ReviewService) GetReviewPendingCount(ctx context.Context) (count int64
 	return cs.reviewRepo.GetReviewCount(ctx, entity.ReviewStatusPending)
 }
 
+// GetTagModeratorReviewPendingCount get the pending review count of the posts with the tags moderated by the user
+func (cs *ReviewService) GetTagModeratorReviewPendingCount(ctx context.Context, userID string) (
+	count int64, isTagModerator bool, err error) {
+	tagIDs, err := cs.tagModeratorService.GetModeratedTagIDs(ctx, userID)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(tagIDs) == 0 {
+		return 0, false, nil
+	}
+	count, err = cs.reviewRepo.GetReviewCountByTagIDs(ctx, entity.ReviewStatusPending, tagIDs)
+	return count, true, err
+}
+
 // GetUnreviewedPostPage get review page
 func (cs *ReviewService) GetUnreviewedPostPage(ctx context.Context, req *schema.GetUnreviewedPostPageReq) (
 	pageModel *pager.PageModel, err error) {
+	var tagIDs []string
 	if !req.IsAdmin {
-		return pager.NewPageModel(0, make([]*schema.GetUnreviewedPostPageResp, 0)), nil
+		// the tag moderators can only see the posts with their tags
+		tagIDs, err = cs.tagModeratorService.GetModeratedTagIDs(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if len(tagIDs) == 0 {
+			return pager.NewPageModel(0, make([]*schema.GetUnreviewedPostPageResp, 0)), nil
+		}
 	}
 	cond := &entity.Review{
 		ObjectID: req.ObjectID,
 		Status:   entity.ReviewStatusPending,
 	}
-	reviewList, total, err := cs.reviewRepo.GetReviewPage(ctx, req.Page, 1, cond)
+	var reviewList []*entity.Review
+	var total int64
+	if req.IsAdmin {
+		reviewList, total, err = cs.reviewRepo.GetReviewPage(ctx, req.Page, 1, cond)
+	} else {
+		reviewList, total, err = cs.reviewRepo.GetReviewPageByTagIDs(ctx, req.Page, 1, tagIDs, cond)
+	}
 	if err != nil {
 		return
 	}
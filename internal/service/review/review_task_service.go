@@ -0,0 +1,316 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package review
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/jinzhu/copier"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ReviewTaskRepo review task repository
+type ReviewTaskRepo interface {
+	AddReviewTask(ctx context.Context, task *entity.ReviewTask) (err error)
+	GetReviewTask(ctx context.Context, taskID int) (task *entity.ReviewTask, exist bool, err error)
+	GetPendingReviewTaskPage(ctx context.Context, page, pageSize, queueType int, excludeUserID string) (
+		tasks []*entity.ReviewTask, total int64, err error)
+	GetPendingReviewTaskCount(ctx context.Context, queueType int, excludeUserID string) (count int64, err error)
+	CompleteReviewTask(ctx context.Context, taskID int, reviewerUserID, outcome string) (completed bool, err error)
+	InvalidateReviewTask(ctx context.Context, taskID int) (err error)
+	GetReviewTaskCounts(ctx context.Context, startTime, endTime time.Time) (counts []*ReviewTaskCount, err error)
+	GetReviewerTaskCounts(ctx context.Context, startTime, endTime time.Time, limit int) (
+		counts []*ReviewerTaskCount, err error)
+}
+
+// ReviewTaskCount the number of the review tasks grouped by the queue, status and outcome
+type ReviewTaskCount struct {
+	QueueType int    `xorm:"queue_type"`
+	Status    int    `xorm:"status"`
+	Outcome   string `xorm:"outcome"`
+	Count     int64  `xorm:"count"`
+}
+
+// ReviewerTaskCount the number of the review tasks completed by the reviewer
+type ReviewerTaskCount struct {
+	ReviewerUserID string `xorm:"reviewer_user_id"`
+	Count          int64  `xorm:"count"`
+}
+
+const reviewTaskStatsReviewerLimit = 20
+
+// addReviewTasks add the available post into the review queues whose entry criteria are met
+func (cs *ReviewService) addReviewTasks(ctx context.Context, objectType, objectID, userID, html string,
+	author plugin.ReviewContentAuthor, questionCreatedAt time.Time) {
+	siteReview, err := cs.siteInfoService.GetSiteReview(ctx)
+	if err != nil {
+		log.Errorf("get site review config failed, err: %v", err)
+		return
+	}
+	task := &entity.ReviewTask{
+		ObjectID:       uid.DeShortID(objectID),
+		ObjectType:     constant.ObjectTypeStrMapping[objectType],
+		UserID:         userID,
+		ReviewerUserID: "0",
+		Status:         entity.ReviewTaskStatusPending,
+	}
+	tasks := make([]*entity.ReviewTask, 0)
+
+	if siteReview.FirstPostEnabled {
+		// the new post is still pending when the author info is got, so only the previous posts are counted
+		previousPostCount := author.ApprovedQuestionAmount + author.ApprovedAnswerAmount
+		if previousPostCount < int64(siteReview.GetFirstPostCount()) {
+			t := *task
+			t.QueueType = entity.ReviewTaskQueueFirstPost
+			tasks = append(tasks, &t)
+		}
+	}
+
+	if siteReview.LateAnswerEnabled && objectType == constant.AnswerObjectType {
+		lateAnswerTime := time.Now().AddDate(0, 0, -siteReview.GetLateAnswerDays())
+		if questionCreatedAt.Before(lateAnswerTime) {
+			t := *task
+			t.QueueType = entity.ReviewTaskQueueLateAnswer
+			tasks = append(tasks, &t)
+		}
+	}
+
+	if siteReview.LowQualityEnabled {
+		if reasons := cs.getLowQualityReasons(html, siteReview); len(reasons) >= siteReview.GetLowQualityMinScore() {
+			t := *task
+			t.QueueType = entity.ReviewTaskQueueLowQuality
+			t.Reason = strings.Join(reasons, ",")
+			tasks = append(tasks, &t)
+		}
+	}
+
+	for _, t := range tasks {
+		if err := cs.reviewTaskRepo.AddReviewTask(ctx, t); err != nil {
+			log.Errorf("add review task failed, err: %v", err)
+		}
+	}
+}
+
+// getLowQualityReasons get the matched low quality heuristics of the post content
+func (cs *ReviewService) getLowQualityReasons(html string, siteReview *schema.SiteReviewResp) (reasons []string) {
+	stats := htmltext.GetTextStats(html)
+	reasons = make([]string, 0)
+	if stats.TextLength < siteReview.GetLowQualityMinLength() {
+		// the code with little explanation is regarded as code only rather than too short
+		if stats.CodeLength > 0 {
+			reasons = append(reasons, schema.LowQualityReasonCodeOnly)
+		} else {
+			reasons = append(reasons, schema.LowQualityReasonTooShort)
+		}
+	}
+	if stats.TextLength > 0 && stats.LinkLength*100 > stats.TextLength*siteReview.GetLowQualityMaxLinkRatio() {
+		reasons = append(reasons, schema.LowQualityReasonLinkHeavy)
+	}
+	return reasons
+}
+
+// GetReviewTaskPendingCount get the pending review task count of the queue, the posts of the user are excluded
+func (cs *ReviewService) GetReviewTaskPendingCount(ctx context.Context, queue, userID string) (count int64, err error) {
+	return cs.reviewTaskRepo.GetPendingReviewTaskCount(ctx, schema.ReviewTaskQueueMapping[queue], userID)
+}
+
+// GetReviewTaskPage get the pending review tasks of the queue, the posts of the reviewer are excluded
+func (cs *ReviewService) GetReviewTaskPage(ctx context.Context, req *schema.GetReviewTaskPageReq) (
+	pageModel *pager.PageModel, err error) {
+	tasks, total, err := cs.reviewTaskRepo.GetPendingReviewTaskPage(ctx, req.Page, req.PageSize,
+		schema.ReviewTaskQueueMapping[req.Queue], req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.GetReviewTaskPageResp, 0)
+	for _, task := range tasks {
+		info, err := cs.objectInfoService.GetUnreviewedRevisionInfo(ctx, task.ObjectID)
+		if err != nil {
+			log.Errorf("GetUnreviewedRevisionInfo failed, err: %v", err)
+			continue
+		}
+		// the post is deleted or hidden by others, so there is nothing left to review
+		if !cs.isReviewTaskObjectAvailable(info) {
+			if err := cs.reviewTaskRepo.InvalidateReviewTask(ctx, task.ID); err != nil {
+				log.Errorf("invalidate review task failed, err: %v", err)
+			}
+			continue
+		}
+
+		r := &schema.GetReviewTaskPageResp{
+			ReviewTaskID: task.ID,
+			Queue:        req.Queue,
+			ObjectID:     info.ObjectID,
+			QuestionID:   info.QuestionID,
+			AnswerID:     info.AnswerID,
+			ObjectType:   info.ObjectType,
+			Title:        info.Title,
+			UrlTitle:     htmltext.UrlTitle(info.Title),
+			OriginalText: info.Content,
+			ParsedText:   info.Html,
+			Tags:         info.Tags,
+			CreatedAt:    info.CreatedAt,
+			SubmitAt:     task.CreatedAt.Unix(),
+			Reasons:      make([]string, 0),
+		}
+		if len(task.Reason) > 0 {
+			r.Reasons = strings.Split(task.Reason, ",")
+		}
+
+		userInfo, exists, e := cs.userCommon.GetUserBasicInfoByID(ctx, task.UserID)
+		if e != nil {
+			log.Errorf("user not found by id: %s, err: %v", task.UserID, e)
+		}
+		if exists {
+			_ = copier.Copy(&r.AuthorUserInfo, userInfo)
+		}
+		resp = append(resp, r)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// isReviewTaskObjectAvailable whether the post is still visible to the reviewers
+func (cs *ReviewService) isReviewTaskObjectAvailable(info *schema.UnreviewedRevisionInfoInfo) bool {
+	if info == nil {
+		return false
+	}
+	switch info.ObjectType {
+	case constant.QuestionObjectType:
+		return info.Status == entity.QuestionStatusAvailable || info.Status == entity.QuestionStatusClosed
+	case constant.AnswerObjectType:
+		return info.Status == entity.AnswerStatusAvailable
+	}
+	return false
+}
+
+// UpdateReviewTask complete the review task with the outcome chosen by the reviewer
+func (cs *ReviewService) UpdateReviewTask(ctx context.Context, req *schema.UpdateReviewTaskReq) (err error) {
+	task, exist, err := cs.reviewTaskRepo.GetReviewTask(ctx, req.ReviewTaskID)
+	if err != nil {
+		return err
+	}
+	if !exist || task.QueueType != schema.ReviewTaskQueueMapping[req.Queue] {
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+	if task.UserID == req.UserID {
+		return errors.Forbidden(reason.ForbiddenError)
+	}
+	if task.Status != entity.ReviewTaskStatusPending {
+		return nil
+	}
+	if req.Outcome == constant.ReasonNeedsClose &&
+		constant.ObjectTypeNumberMapping[task.ObjectType] != constant.QuestionObjectType {
+		return errors.BadRequest(reason.RequestFormatError)
+	}
+	completed, err := cs.reviewTaskRepo.CompleteReviewTask(ctx, task.ID, req.UserID, req.Outcome)
+	if err != nil || !completed {
+		return err
+	}
+	cs.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.UserID,
+		Action:         constant.AuditLogActionReviewTaskComplete,
+		ObjectType:     constant.ObjectTypeNumberMapping[task.ObjectType],
+		ObjectID:       task.ObjectID,
+		Before:         map[string]string{"queue": req.Queue, "status": "pending"},
+		After:          map[string]string{"queue": req.Queue, "outcome": req.Outcome},
+	})
+	return nil
+}
+
+// GetReviewTaskStats get the statistics of the review queues and the most active reviewers
+func (cs *ReviewService) GetReviewTaskStats(ctx context.Context, req *schema.GetReviewTaskStatsReq) (
+	resp *schema.GetReviewTaskStatsResp, err error) {
+	var startTime, endTime time.Time
+	if req.StartTime > 0 {
+		startTime = time.Unix(req.StartTime, 0)
+	}
+	if req.EndTime > 0 {
+		endTime = time.Unix(req.EndTime, 0)
+	}
+
+	resp = &schema.GetReviewTaskStatsResp{
+		Queues:    make([]*schema.ReviewTaskQueueStat, 0),
+		Reviewers: make([]*schema.ReviewTaskReviewerStat, 0),
+	}
+	queueStats := make(map[int]*schema.ReviewTaskQueueStat)
+	for _, queueType := range []int{
+		entity.ReviewTaskQueueFirstPost,
+		entity.ReviewTaskQueueLateAnswer,
+		entity.ReviewTaskQueueLowQuality,
+	} {
+		queueStats[queueType] = &schema.ReviewTaskQueueStat{
+			Queue:    schema.ReviewTaskQueueNameMapping[queueType],
+			Outcomes: make(map[string]int64),
+		}
+		resp.Queues = append(resp.Queues, queueStats[queueType])
+	}
+
+	counts, err := cs.reviewTaskRepo.GetReviewTaskCounts(ctx, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	for _, count := range counts {
+		stat, ok := queueStats[count.QueueType]
+		if !ok {
+			continue
+		}
+		switch count.Status {
+		case entity.ReviewTaskStatusPending:
+			stat.PendingCount += count.Count
+		case entity.ReviewTaskStatusInvalid:
+			stat.InvalidCount += count.Count
+		case entity.ReviewTaskStatusCompleted:
+			stat.Outcomes[count.Outcome] += count.Count
+		}
+	}
+
+	reviewerCounts, err := cs.reviewTaskRepo.GetReviewerTaskCounts(ctx, startTime, endTime,
+		reviewTaskStatsReviewerLimit)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(reviewerCounts))
+	for _, count := range reviewerCounts {
+		userIDs = append(userIDs, count.ReviewerUserID)
+	}
+	userInfoMapping, err := cs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, count := range reviewerCounts {
+		resp.Reviewers = append(resp.Reviewers, &schema.ReviewTaskReviewerStat{
+			User:        userInfoMapping[count.ReviewerUserID],
+			ReviewCount: count.Count,
+		})
+	}
+	return resp, nil
+}
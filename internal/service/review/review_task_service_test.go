@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package review
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryReviewTaskRepo only records the added review tasks
+type memoryReviewTaskRepo struct {
+	ReviewTaskRepo
+	tasks []*entity.ReviewTask
+}
+
+func (r *memoryReviewTaskRepo) AddReviewTask(ctx context.Context, task *entity.ReviewTask) error {
+	r.tasks = append(r.tasks, task)
+	return nil
+}
+
+// reviewSiteInfoService only returns the site review config
+type reviewSiteInfoService struct {
+	siteinfo_common.SiteInfoCommonService
+	siteReview *schema.SiteReviewResp
+}
+
+func (s *reviewSiteInfoService) GetSiteReview(ctx context.Context) (*schema.SiteReviewResp, error) {
+	return s.siteReview, nil
+}
+
+func TestReviewService_addReviewTasksFirstPost(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectType string
+		author     plugin.ReviewContentAuthor
+		want       bool
+	}{
+		{"first question", constant.QuestionObjectType, plugin.ReviewContentAuthor{}, true},
+		{"first answer", constant.AnswerObjectType, plugin.ReviewContentAuthor{}, true},
+		{"last first question", constant.QuestionObjectType,
+			plugin.ReviewContentAuthor{ApprovedQuestionAmount: 1, ApprovedAnswerAmount: 1}, true},
+		{"question after the first posts", constant.QuestionObjectType,
+			plugin.ReviewContentAuthor{ApprovedQuestionAmount: 2, ApprovedAnswerAmount: 1}, false},
+		{"answer after the first posts", constant.AnswerObjectType,
+			plugin.ReviewContentAuthor{ApprovedAnswerAmount: 3}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryReviewTaskRepo{}
+			cs := &ReviewService{
+				reviewTaskRepo: repo,
+				siteInfoService: &reviewSiteInfoService{siteReview: &schema.SiteReviewResp{
+					FirstPostEnabled: true,
+					FirstPostCount:   3,
+				}},
+			}
+			cs.addReviewTasks(context.TODO(), tt.objectType, "10010000000000001", "1",
+				"<p>content</p>", tt.author, time.Now())
+			if !tt.want {
+				assert.Empty(t, repo.tasks)
+				return
+			}
+			if assert.Len(t, repo.tasks, 1) {
+				assert.Equal(t, entity.ReviewTaskQueueFirstPost, repo.tasks[0].QueueType)
+			}
+		})
+	}
+}
@@ -21,10 +21,12 @@ package revision_common
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/service/revision"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/diff"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
@@ -93,3 +95,59 @@ func (rs *RevisionService) ExistUnreviewedByObjectID(ctx context.Context, object
 	revision, exist, err = rs.revisionRepo.ExistUnreviewedByObjectID(ctx, objectID)
 	return revision, exist, err
 }
+
+// GetLastRevisionByObjectID get the last revision of the object
+func (rs *RevisionService) GetLastRevisionByObjectID(ctx context.Context, objectID string) (
+	revision *entity.Revision, exist bool, err error) {
+	return rs.revisionRepo.GetLastRevisionByObjectID(ctx, uid.DeShortID(objectID))
+}
+
+// CheckEditConflict check whether the edit is based on the latest revision of the object.
+// If not, the diff and the three-way merge result against the latest revision will be returned.
+func (rs *RevisionService) CheckEditConflict(ctx context.Context, req *schema.EditConflictCheck) (
+	resp *schema.RevisionConflictResp, err error) {
+	if len(req.BaseRevisionID) == 0 || req.BaseRevisionID == req.LatestRevisionID {
+		return nil, nil
+	}
+	if req.Title == req.LatestTitle && req.Content == req.LatestContent {
+		return nil, nil
+	}
+
+	// the revision content is the json of the object, title and original text are used to merge
+	base := &struct {
+		Title        string
+		OriginalText string
+	}{}
+	baseRevision, exist, err := rs.revisionRepo.GetRevisionByID(ctx, req.BaseRevisionID)
+	if err != nil {
+		return nil, err
+	}
+	if exist && baseRevision.ObjectID == uid.DeShortID(req.ObjectID) {
+		if err = json.Unmarshal([]byte(baseRevision.Content), base); err != nil {
+			log.Errorf("parse revision %s content failed: %v", baseRevision.ID, err)
+		}
+	}
+	// nothing changed by others since the base revision, e.g. only the tags are changed
+	if base.Title == req.LatestTitle && base.OriginalText == req.LatestContent {
+		return nil, nil
+	}
+
+	resp = &schema.RevisionConflictResp{
+		LatestRevisionID: req.LatestRevisionID,
+		LatestTitle:      req.LatestTitle,
+		LatestContent:    req.LatestContent,
+		Diff:             diff.Lines(req.LatestContent, req.Content),
+	}
+	var titleConflict bool
+	switch {
+	case req.Title == base.Title, req.Title == req.LatestTitle:
+		resp.MergedTitle = req.LatestTitle
+	case req.LatestTitle == base.Title:
+		resp.MergedTitle = req.Title
+	default:
+		resp.MergedTitle, titleConflict = req.Title, true
+	}
+	resp.MergedContent, resp.MergeConflict = diff.Merge(base.OriginalText, req.Content, req.LatestContent)
+	resp.MergeConflict = resp.MergeConflict || titleConflict
+	return resp, nil
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package revision_common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/revision"
+	"github.com/stretchr/testify/assert"
+)
+
+// baseRevisionRepo only returns the base revision of the edit
+type baseRevisionRepo struct {
+	revision.RevisionRepo
+	base *entity.Revision
+}
+
+func (r *baseRevisionRepo) GetRevisionByID(ctx context.Context, revisionID string) (
+	*entity.Revision, bool, error) {
+	return r.base, r.base.ID == revisionID, nil
+}
+
+func TestRevisionService_CheckEditConflict_LargeInput(t *testing.T) {
+	lines := func(prefix string) string {
+		b := make([]string, 0, 30000)
+		for i := 0; i < 30000; i++ {
+			b = append(b, fmt.Sprintf("%s line %d", prefix, i))
+		}
+		return strings.Join(b, "\n")
+	}
+	baseText, oursText, theirsText := lines("base"), lines("ours"), lines("theirs")
+	content, _ := json.Marshal(map[string]string{"Title": "title", "OriginalText": baseText})
+	rs := NewRevisionService(&baseRevisionRepo{
+		base: &entity.Revision{ID: "1", ObjectID: "10010000000000001", Content: string(content)},
+	}, nil)
+
+	resp, err := rs.CheckEditConflict(context.TODO(), &schema.EditConflictCheck{
+		ObjectID:         "10010000000000001",
+		BaseRevisionID:   "1",
+		LatestRevisionID: "2",
+		Title:            "title",
+		Content:          oursText,
+		LatestTitle:      "title",
+		LatestContent:    theirsText,
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.True(t, resp.MergeConflict)
+	assert.Equal(t, 60000, len(resp.Diff))
+	assert.True(t, strings.HasPrefix(resp.MergedContent, "<<<<<<< ours\n"+oursText[:10]))
+}
@@ -26,6 +26,7 @@ import (
 // RolePowerRelRepo rolePowerRel repository
 type RolePowerRelRepo interface {
 	GetRolePowerTypeList(ctx context.Context, roleID int) (powers []string, err error)
+	SaveRolePowerTypeList(ctx context.Context, roleID int, powerTypes []string) (err error)
 }
 
 // RolePowerRelService user service
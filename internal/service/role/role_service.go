@@ -21,18 +21,20 @@ package role
 
 import (
 	"context"
+	"strings"
 
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/jinzhu/copier"
+	"github.com/segmentfault/pacman/errors"
 )
 
 const (
-	// Since there is currently no need to edit roles to add roles and other operations,
-	// the current role information is translated directly.
-	// Later on, when the relevant ability is available, it can be adjusted by the user himself.
+	// The built-in roles are translated directly and can not be modified,
+	// the custom roles added by the admin are shown as they are.
 
 	RoleUserID      = 1
 	RoleAdminID     = 2
@@ -53,21 +55,41 @@ const (
 
 // RoleRepo role repository
 type RoleRepo interface {
+	AddRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error)
+	UpdateRole(ctx context.Context, role *entity.Role) (err error)
+	RemoveRole(ctx context.Context, roleID int) (err error)
+	GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error)
 	GetRoleAllList(ctx context.Context) (roles []*entity.Role, err error)
 	GetRoleAllMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error)
 }
 
 // RoleService user service
 type RoleService struct {
-	roleRepo RoleRepo
+	roleRepo         RoleRepo
+	powerRepo        PowerRepo
+	rolePowerRelRepo RolePowerRelRepo
+	userRoleRelRepo  UserRoleRelRepo
 }
 
-func NewRoleService(roleRepo RoleRepo) *RoleService {
+func NewRoleService(
+	roleRepo RoleRepo,
+	powerRepo PowerRepo,
+	rolePowerRelRepo RolePowerRelRepo,
+	userRoleRelRepo UserRoleRelRepo,
+) *RoleService {
 	return &RoleService{
-		roleRepo: roleRepo,
+		roleRepo:         roleRepo,
+		powerRepo:        powerRepo,
+		rolePowerRelRepo: rolePowerRelRepo,
+		userRoleRelRepo:  userRoleRelRepo,
 	}
 }
 
+// IsBuiltInRole whether the role is seeded by the installation
+func IsBuiltInRole(roleID int) bool {
+	return roleID == RoleUserID || roleID == RoleAdminID || roleID == RoleModeratorID
+}
+
 // GetRoleList get role list all
 func (rs *RoleService) GetRoleList(ctx context.Context) (resp []*schema.GetRoleResp, err error) {
 	roles, err := rs.roleRepo.GetRoleAllList(ctx)
@@ -81,9 +103,165 @@ func (rs *RoleService) GetRoleList(ctx context.Context) (resp []*schema.GetRoleR
 
 	resp = []*schema.GetRoleResp{}
 	_ = copier.Copy(&resp, roles)
+	for _, r := range resp {
+		r.BuiltIn = IsBuiltInRole(r.ID)
+	}
 	return
 }
 
+// GetRole get role by id
+func (rs *RoleService) GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error) {
+	return rs.roleRepo.GetRole(ctx, roleID)
+}
+
+// AddRole add custom role with powers
+func (rs *RoleService) AddRole(ctx context.Context, req *schema.AddRoleReq) (resp *schema.AddRoleResp, err error) {
+	if err = rs.checkRoleName(ctx, 0, req.Name); err != nil {
+		return nil, err
+	}
+	powerTypes, err := rs.checkPowerTypes(ctx, req.PowerTypes)
+	if err != nil {
+		return nil, err
+	}
+	role := &entity.Role{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+	if err = rs.roleRepo.AddRole(ctx, role, powerTypes); err != nil {
+		return nil, err
+	}
+	return &schema.AddRoleResp{ID: role.ID}, nil
+}
+
+// UpdateRole rename custom role
+func (rs *RoleService) UpdateRole(ctx context.Context, req *schema.UpdateRoleReq) (err error) {
+	role, err := rs.getCustomRole(ctx, req.RoleID)
+	if err != nil {
+		return err
+	}
+	if err = rs.checkRoleName(ctx, role.ID, req.Name); err != nil {
+		return err
+	}
+	role.Name = strings.TrimSpace(req.Name)
+	role.Description = req.Description
+	return rs.roleRepo.UpdateRole(ctx, role)
+}
+
+// RemoveRole remove custom role, the role can only be removed when no user is assigned to it
+func (rs *RoleService) RemoveRole(ctx context.Context, req *schema.RemoveRoleReq) (err error) {
+	if _, err = rs.getCustomRole(ctx, req.RoleID); err != nil {
+		return err
+	}
+	rels, err := rs.userRoleRelRepo.GetUserRoleRelListByRoleID(ctx, []int{req.RoleID})
+	if err != nil {
+		return err
+	}
+	if len(rels) > 0 {
+		return errors.BadRequest(reason.RoleInUse)
+	}
+	return rs.roleRepo.RemoveRole(ctx, req.RoleID)
+}
+
+// GetRolePowerList get the powers of the role
+func (rs *RoleService) GetRolePowerList(ctx context.Context, req *schema.GetRolePowerListReq) (
+	resp *schema.GetRolePowerListResp, err error) {
+	_, exist, err := rs.roleRepo.GetRole(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.RoleNotFound)
+	}
+	powerTypes, err := rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.GetRolePowerListResp{RoleID: req.RoleID, PowerTypes: powerTypes}, nil
+}
+
+// UpdateRolePowerList replace the powers of custom role
+func (rs *RoleService) UpdateRolePowerList(ctx context.Context, req *schema.UpdateRolePowerListReq) (err error) {
+	if _, err = rs.getCustomRole(ctx, req.RoleID); err != nil {
+		return err
+	}
+	powerTypes, err := rs.checkPowerTypes(ctx, req.PowerTypes)
+	if err != nil {
+		return err
+	}
+	return rs.rolePowerRelRepo.SaveRolePowerTypeList(ctx, req.RoleID, powerTypes)
+}
+
+// GetPowerList get all powers that can be assigned to roles
+func (rs *RoleService) GetPowerList(ctx context.Context) (resp []*schema.GetPowerResp, err error) {
+	powers, err := rs.powerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetPowerResp, 0, len(powers))
+	for _, power := range powers {
+		resp = append(resp, &schema.GetPowerResp{
+			Name:        power.Name,
+			PowerType:   power.PowerType,
+			Description: power.Description,
+		})
+	}
+	return resp, nil
+}
+
+func (rs *RoleService) getCustomRole(ctx context.Context, roleID int) (role *entity.Role, err error) {
+	if IsBuiltInRole(roleID) {
+		return nil, errors.BadRequest(reason.RoleBuiltInCannotModify)
+	}
+	role, exist, err := rs.roleRepo.GetRole(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.RoleNotFound)
+	}
+	return role, nil
+}
+
+// checkRoleName the role name should be unique regardless of case
+func (rs *RoleService) checkRoleName(ctx context.Context, roleID int, name string) (err error) {
+	roles, err := rs.roleRepo.GetRoleAllList(ctx)
+	if err != nil {
+		return err
+	}
+	name = strings.TrimSpace(name)
+	for _, role := range roles {
+		if role.ID != roleID && strings.EqualFold(role.Name, name) {
+			return errors.BadRequest(reason.RoleNameDuplicate)
+		}
+	}
+	return nil
+}
+
+// checkPowerTypes check all power types exist and remove the duplicate ones
+func (rs *RoleService) checkPowerTypes(ctx context.Context, powerTypes []string) (checked []string, err error) {
+	powers, err := rs.powerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return nil, err
+	}
+	powerMapping := make(map[string]bool, len(powers))
+	for _, power := range powers {
+		powerMapping[power.PowerType] = true
+	}
+	checked = make([]string, 0, len(powerTypes))
+	seen := make(map[string]bool, len(powerTypes))
+	for _, powerType := range powerTypes {
+		if !powerMapping[powerType] {
+			return nil, errors.BadRequest(reason.RolePowerNotFound)
+		}
+		if seen[powerType] {
+			continue
+		}
+		seen[powerType] = true
+		checked = append(checked, powerType)
+	}
+	return checked, nil
+}
+
 func (rs *RoleService) GetRoleMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error) {
 	return rs.roleRepo.GetRoleAllMapping(ctx)
 }
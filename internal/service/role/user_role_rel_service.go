@@ -22,12 +22,15 @@ package role
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/segmentfault/pacman/errors"
 )
 
 // UserRoleRelRepo userRoleRel repository
 type UserRoleRelRepo interface {
 	SaveUserRoleRel(ctx context.Context, userID string, roleID int) (err error)
+	SaveUserRoleRelList(ctx context.Context, userIDs []string, roleID int) (err error)
 	GetUserRoleRelList(ctx context.Context, userIDs []string) (userRoleRelList []*entity.UserRoleRel, err error)
 	GetUserRoleRelListByRoleID(ctx context.Context, roleIDs []int) (
 		userRoleRelList []*entity.UserRoleRel, err error)
@@ -50,9 +53,31 @@ func NewUserRoleRelService(userRoleRelRepo UserRoleRelRepo, roleService *RoleSer
 
 // SaveUserRole save user role
 func (us *UserRoleRelService) SaveUserRole(ctx context.Context, userID string, roleID int) (err error) {
+	if err = us.checkRoleExist(ctx, roleID); err != nil {
+		return err
+	}
 	return us.userRoleRelRepo.SaveUserRoleRel(ctx, userID, roleID)
 }
 
+// SaveUsersRole save the same role for users
+func (us *UserRoleRelService) SaveUsersRole(ctx context.Context, userIDs []string, roleID int) (err error) {
+	if err = us.checkRoleExist(ctx, roleID); err != nil {
+		return err
+	}
+	return us.userRoleRelRepo.SaveUserRoleRelList(ctx, userIDs, roleID)
+}
+
+func (us *UserRoleRelService) checkRoleExist(ctx context.Context, roleID int) (err error) {
+	_, exist, err := us.roleService.GetRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	return nil
+}
+
 // GetUserRoleMapping get user role mapping
 func (us *UserRoleRelService) GetUserRoleMapping(ctx context.Context, userIDs []string) (
 	userRoleMapping map[string]*entity.Role, err error) {
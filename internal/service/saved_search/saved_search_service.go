@@ -0,0 +1,275 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saved_search
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// savedSearchCheckPageSize the page size of saved searches checked in one batch
+	savedSearchCheckPageSize = 100
+	// savedSearchMatchPageSize the page size of new matched questions searched at a time
+	savedSearchMatchPageSize = 50
+	// savedSearchMaxInboxNotifications the max inbox notifications sent in one check
+	savedSearchMaxInboxNotifications = 3
+)
+
+// SavedSearchRepo saved search repository
+type SavedSearchRepo interface {
+	AddSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (err error)
+	UpdateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (affected int64, err error)
+	RemoveSavedSearch(ctx context.Context, userID string, id int) (affected int64, err error)
+	GetSavedSearchList(ctx context.Context, userID string) (savedSearches []*entity.SavedSearch, err error)
+	CountSavedSearch(ctx context.Context, userID string) (count int64, err error)
+	GetNotifiableSavedSearchPage(ctx context.Context, page, pageSize int) (savedSearches []*entity.SavedSearch, err error)
+	UpdateLastCheckedAt(ctx context.Context, id int, lastCheckedAt time.Time) (err error)
+}
+
+// SavedSearchService saved search service
+type SavedSearchService struct {
+	savedSearchRepo          SavedSearchRepo
+	searchService            *content.SearchService
+	userRepo                 usercommon.UserRepo
+	emailService             *export.EmailService
+	notificationQueueService notice_queue.NotificationQueueService
+}
+
+// NewSavedSearchService new saved search service
+func NewSavedSearchService(
+	savedSearchRepo SavedSearchRepo,
+	searchService *content.SearchService,
+	userRepo usercommon.UserRepo,
+	emailService *export.EmailService,
+	notificationQueueService notice_queue.NotificationQueueService,
+) *SavedSearchService {
+	return &SavedSearchService{
+		savedSearchRepo:          savedSearchRepo,
+		searchService:            searchService,
+		userRepo:                 userRepo,
+		emailService:             emailService,
+		notificationQueueService: notificationQueueService,
+	}
+}
+
+// GetSavedSearchList get all saved searches of the user
+func (ss *SavedSearchService) GetSavedSearchList(ctx context.Context, req *schema.GetSavedSearchListReq) (
+	resp []*schema.SavedSearchInfo, err error) {
+	savedSearches, err := ss.savedSearchRepo.GetSavedSearchList(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.SavedSearchInfo, 0, len(savedSearches))
+	for _, savedSearch := range savedSearches {
+		resp = append(resp, &schema.SavedSearchInfo{
+			ID:          savedSearch.ID,
+			Name:        savedSearch.Name,
+			Query:       savedSearch.Query,
+			NotifyInbox: savedSearch.NotifyInbox,
+			NotifyEmail: savedSearch.NotifyEmail,
+			CreatedAt:   savedSearch.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// AddSavedSearch save a search query, only the questions created after now will be notified
+func (ss *SavedSearchService) AddSavedSearch(ctx context.Context, req *schema.AddSavedSearchReq) (
+	resp *schema.SavedSearchInfo, err error) {
+	count, err := ss.savedSearchRepo.CountSavedSearch(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= schema.SavedSearchMaxAmount {
+		return nil, errors.BadRequest(reason.SavedSearchTooMany)
+	}
+	savedSearch := &entity.SavedSearch{
+		UserID:        req.UserID,
+		Name:          req.Name,
+		Query:         req.Query,
+		NotifyInbox:   req.NotifyInbox,
+		NotifyEmail:   req.NotifyEmail,
+		LastCheckedAt: time.Now(),
+	}
+	if err = ss.savedSearchRepo.AddSavedSearch(ctx, savedSearch); err != nil {
+		return nil, err
+	}
+	return &schema.SavedSearchInfo{
+		ID:          savedSearch.ID,
+		Name:        savedSearch.Name,
+		Query:       savedSearch.Query,
+		NotifyInbox: savedSearch.NotifyInbox,
+		NotifyEmail: savedSearch.NotifyEmail,
+		CreatedAt:   savedSearch.CreatedAt.Unix(),
+	}, nil
+}
+
+// UpdateSavedSearch update the saved search
+func (ss *SavedSearchService) UpdateSavedSearch(ctx context.Context, req *schema.UpdateSavedSearchReq) (err error) {
+	affected, err := ss.savedSearchRepo.UpdateSavedSearch(ctx, &entity.SavedSearch{
+		ID:          req.ID,
+		UserID:      req.UserID,
+		Name:        req.Name,
+		Query:       req.Query,
+		NotifyInbox: req.NotifyInbox,
+		NotifyEmail: req.NotifyEmail,
+	})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.BadRequest(reason.SavedSearchNotFound)
+	}
+	return nil
+}
+
+// RemoveSavedSearch remove the saved search
+func (ss *SavedSearchService) RemoveSavedSearch(ctx context.Context, req *schema.RemoveSavedSearchReq) (err error) {
+	affected, err := ss.savedSearchRepo.RemoveSavedSearch(ctx, req.UserID, req.ID)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.BadRequest(reason.SavedSearchNotFound)
+	}
+	return nil
+}
+
+// NotifyNewMatchesCron check all the saved searches which need to notify, and notify the new matched questions
+func (ss *SavedSearchService) NotifyNewMatchesCron(ctx context.Context) {
+	for page := 1; ; page++ {
+		savedSearches, err := ss.savedSearchRepo.GetNotifiableSavedSearchPage(ctx, page, savedSearchCheckPageSize)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		for _, savedSearch := range savedSearches {
+			if err := ss.notifyNewMatches(ctx, savedSearch); err != nil {
+				log.Errorf("notify saved search %d new matches failed: %v", savedSearch.ID, err)
+			}
+		}
+		if len(savedSearches) < savedSearchCheckPageSize {
+			return
+		}
+	}
+}
+
+func (ss *SavedSearchService) notifyNewMatches(ctx context.Context, savedSearch *entity.SavedSearch) (err error) {
+	now := time.Now()
+	lastCheckedAt := savedSearch.LastCheckedAt
+	if lastCheckedAt.IsZero() {
+		lastCheckedAt = savedSearch.CreatedAt
+	}
+	// the questions created after now are left to the next check
+	matches := make([]*schema.SearchResult, 0)
+	for page := 1; ; page++ {
+		results, err := ss.searchService.SearchNewQuestions(ctx, savedSearch.UserID, savedSearch.Query,
+			lastCheckedAt.Unix(), now.Unix(), page, savedSearchMatchPageSize)
+		if err != nil {
+			return err
+		}
+		// the questions asked by the user self are not notified
+		for _, result := range results {
+			if getResultAuthorID(result) != savedSearch.UserID {
+				matches = append(matches, result)
+			}
+		}
+		if len(results) < savedSearchMatchPageSize {
+			break
+		}
+	}
+	if len(matches) > 0 {
+		log.Debugf("saved search %d has %d new matches", savedSearch.ID, len(matches))
+		if savedSearch.NotifyEmail {
+			// the matches are checked again next time if the digest email is not sent
+			if err = ss.sendDigestEmail(ctx, savedSearch, matches); err != nil {
+				return err
+			}
+		}
+		if savedSearch.NotifyInbox {
+			for i, match := range matches {
+				if i >= savedSearchMaxInboxNotifications {
+					break
+				}
+				ss.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+					TriggerUserID:       getResultAuthorID(match),
+					ReceiverUserID:      savedSearch.UserID,
+					Type:                schema.NotificationTypeInbox,
+					ObjectID:            match.Object.ID,
+					ObjectType:          constant.QuestionObjectType,
+					NotificationAction:  constant.NotificationSavedSearchNewMatch,
+					NoNeedPushAllFollow: true,
+				})
+			}
+		}
+	}
+	return ss.savedSearchRepo.UpdateLastCheckedAt(ctx, savedSearch.ID, now)
+}
+
+// getResultAuthorID get the author id of the search result, the author info may be missing if the user is removed
+func getResultAuthorID(result *schema.SearchResult) string {
+	if result.Object.UserInfo == nil {
+		return ""
+	}
+	return result.Object.UserInfo.ID
+}
+
+func (ss *SavedSearchService) sendDigestEmail(ctx context.Context, savedSearch *entity.SavedSearch,
+	matches []*schema.SearchResult) (err error) {
+	userInfo, exist, err := ss.userRepo.GetByUserID(ctx, savedSearch.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.MailStatus != entity.EmailStatusAvailable {
+		return nil
+	}
+	// If receiver has set language, use it to send email.
+	if len(userInfo.Language) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(userInfo.Language))
+	}
+	rawData := &schema.SavedSearchDigestTemplateRawData{
+		SearchName: savedSearch.Name,
+		Query:      savedSearch.Query,
+	}
+	for _, match := range matches {
+		rawData.Questions = append(rawData.Questions, &schema.SavedSearchDigestQuestion{
+			QuestionID:    match.Object.ID,
+			QuestionTitle: match.Object.Title,
+		})
+	}
+	title, body, err := ss.emailService.SavedSearchDigestTemplate(ctx, rawData)
+	if err != nil {
+		return err
+	}
+	return ss.emailService.SendWithResult(ctx, userInfo.EMail, title, body)
+}
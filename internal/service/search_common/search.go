@@ -24,11 +24,17 @@ import (
 
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/plugin"
+	"xorm.io/xorm"
 )
 
 type SearchRepo interface {
-	SearchContents(ctx context.Context, words []string, tagIDs [][]string, userID string, votes, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
-	SearchQuestions(ctx context.Context, words []string, tagIDs [][]string, notAccepted bool, views, answers int, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
-	SearchAnswers(ctx context.Context, words []string, tagIDs [][]string, accepted bool, questionID string, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
+	SearchContents(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
+	SearchQuestions(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
+	SearchAnswers(ctx context.Context, cond *schema.SearchCondition, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
 	ParseSearchPluginResult(ctx context.Context, sres []plugin.SearchResult, words []string) (resp []*schema.SearchResult, err error)
 }
+
+// SearchIndexRepo native full-text search index, it is maintained when questions and answers are written
+type SearchIndexRepo interface {
+	UpdateSearchIndex(ctx context.Context, session *xorm.Session, objectID, title, content string) (err error)
+}
@@ -22,9 +22,11 @@ package search_parser
 import (
 	"context"
 	"fmt"
-	"github.com/apache/incubator-answer/internal/base/constant"
 	"regexp"
 	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
 
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/tag_common"
@@ -51,21 +53,37 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 	var (
 		query      = dto.Query
 		limitWords = 5
+		now        = time.Now()
 	)
 
-	// match tags
+	// match tags, the excluded tags must be parsed first
+	cond.ExcludeTags = sp.parseExcludeTags(ctx, &query)
 	cond.Tags = sp.parseTags(ctx, &query)
 
 	// match all
 	cond.UserID = sp.parseUserID(ctx, &query, dto.UserID)
 	cond.VoteAmount = sp.parseVotes(&query)
-	cond.Words = sp.parseWithin(&query)
+	cond.CommenterID = sp.parseCommenterID(ctx, &query, dto.UserID)
+	cond.CreatedFrom, cond.CreatedTo = sp.parseTimeRange(&query, "created", now)
+	cond.ActiveFrom, cond.ActiveTo = sp.parseTimeRange(&query, "active", now)
 
 	// match questions
 	cond.NotAccepted = sp.parseNotAccepted(&query)
 	if cond.NotAccepted {
 		cond.TargetType = constant.QuestionObjectType
 	}
+	cond.HasAccepted = sp.parseHasAccepted(&query)
+	if cond.HasAccepted {
+		cond.TargetType = constant.QuestionObjectType
+	}
+	cond.Closed, cond.NotClosed = sp.parseClosed(&query)
+	if cond.Closed || cond.NotClosed {
+		cond.TargetType = constant.QuestionObjectType
+	}
+	cond.CollectorID = sp.parseCollectorID(&query, dto.UserID)
+	if cond.CollectorID != "" {
+		cond.TargetType = constant.QuestionObjectType
+	}
 	cond.Views = sp.parseViews(&query)
 	if cond.Views != -1 {
 		cond.TargetType = constant.QuestionObjectType
@@ -92,14 +110,19 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 		cond.TargetType = constant.AnswerObjectType
 	}
 
-	if len(strings.TrimSpace(query)) > 0 {
-		words := strings.Split(strings.TrimSpace(query), " ")
-		cond.Words = append(cond.Words, words...)
-	}
+	// the rest are keywords, phrases and excluded keywords
+	cond.WordGroups, cond.ExcludeWords = sp.parseWords(&query)
 
 	// check limit words
-	if len(cond.Words) > limitWords {
-		cond.Words = cond.Words[:limitWords]
+	if len(cond.WordGroups) > limitWords {
+		cond.WordGroups = cond.WordGroups[:limitWords]
+	}
+	if len(cond.ExcludeWords) > limitWords {
+		cond.ExcludeWords = cond.ExcludeWords[:limitWords]
+	}
+	cond.Words = make([]string, 0)
+	for _, group := range cond.WordGroups {
+		cond.Words = append(cond.Words, group...)
 	}
 	return
 }
@@ -150,6 +173,45 @@ func (sp *SearchParser) parseTags(ctx context.Context, query *string) (tags [][]
 	return
 }
 
+// parseExcludeTags parse excluded search tags like: -[tag], return tag ids with the synonyms
+func (sp *SearchParser) parseExcludeTags(ctx context.Context, query *string) (tagIDs []string) {
+	var (
+		exprTag = `-\[(.*?)\]`
+		q       = *query
+		limit   = 5
+	)
+
+	re := regexp.MustCompile(exprTag)
+	res := re.FindAllStringSubmatch(q, -1)
+	if len(res) == 0 {
+		return
+	}
+
+	tagIDs = make([]string, 0)
+	for i, item := range res {
+		if i >= limit {
+			break
+		}
+		tag, exists, err := sp.tagCommonService.GetTagBySlugName(ctx, item[1])
+		if err != nil || !exists {
+			continue
+		}
+		tagIDs = append(tagIDs, tag.ID)
+		if tag.MainTagID > 0 {
+			tagIDs = append(tagIDs, fmt.Sprintf("%d", tag.MainTagID))
+		}
+		synIDs, err := sp.tagCommonService.GetTagIDsByMainTagID(ctx, tag.ID)
+		if err != nil {
+			continue
+		}
+		tagIDs = append(tagIDs, synIDs...)
+	}
+	tagIDs = converter.UniqueArray(tagIDs)
+
+	*query = strings.TrimSpace(re.ReplaceAllString(q, ""))
+	return
+}
+
 // parseUserID return user id or current login user id
 func (sp *SearchParser) parseUserID(ctx context.Context, query *string, currentUserID string) (userID string) {
 	var (
@@ -194,22 +256,209 @@ func (sp *SearchParser) parseVotes(query *string) (votes int) {
 	return
 }
 
-// parseWithin parse quotes within words like: "hello world"
-func (sp *SearchParser) parseWithin(query *string) (words []string) {
+// parseWords parse the keywords, the OR operator, the quoted phrases like: "hello world" and the excluded words like: -hello.
+// The words joined by OR are in the same group.
+func (sp *SearchParser) parseWords(query *string) (groups [][]string, excludes []string) {
 	var (
 		q    = *query
-		expr = `(?U)(".+")`
+		expr = `-?"[^"]*"|\S+`
+		or   bool
 	)
+	groups = make([][]string, 0)
+	excludes = make([]string, 0)
+
 	re := regexp.MustCompile(expr)
-	matches := re.FindAllStringSubmatch(q, -1)
-	words = []string{}
-	for _, match := range matches {
-		if len(match[1]) == 0 {
+	for _, token := range re.FindAllString(q, -1) {
+		if token == "OR" {
+			or = len(groups) > 0
+			continue
+		}
+		exclude := strings.HasPrefix(token, "-") && len(token) > 1
+		if exclude {
+			token = token[1:]
+		}
+		word := strings.Join(strings.Fields(strings.Trim(token, `"`)), " ")
+		if len(word) == 0 {
+			continue
+		}
+		if exclude {
+			excludes = append(excludes, word)
 			continue
 		}
-		words = append(words, match[1])
+		if or {
+			groups[len(groups)-1] = append(groups[len(groups)-1], word)
+		} else {
+			groups = append(groups, []string{word})
+		}
+		or = false
+	}
+	*query = ""
+	return
+}
+
+// parseCommenterID return the user id who commented, or current login user id if commenter:me
+func (sp *SearchParser) parseCommenterID(ctx context.Context, query *string, currentUserID string) (userID string) {
+	var (
+		exprUsername = `commenter:(\S+)`
+		exprMe       = "commenter:me"
+		q            = *query
+	)
+
+	re := regexp.MustCompile(exprUsername)
+	res := re.FindStringSubmatch(q)
+	if strings.Contains(q, exprMe) {
+		userID = currentUserID
+		q = strings.ReplaceAll(q, exprMe, "")
+	} else if len(res) > 1 {
+		user, has, err := sp.userCommon.GetUserBasicInfoByUserName(ctx, res[1])
+		if err == nil && has {
+			userID = user.ID
+			q = re.ReplaceAllString(q, "")
+		}
+	}
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseCollectorID return current login user id if search the questions collected by self
+func (sp *SearchParser) parseCollectorID(query *string, currentUserID string) (userID string) {
+	var (
+		q    = *query
+		expr = `collected:mine`
+	)
+
+	if strings.Contains(q, expr) {
+		q = strings.ReplaceAll(q, expr, "")
+		userID = currentUserID
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseTimeRange parse the time range of the key, return unix timestamp range [from, to), zero means unlimited.
+// Support formats: key:2024-01-02, key:2024-01..2024-06, key:2024.., key:..2024-06, key:<30d, key:>1y
+func (sp *SearchParser) parseTimeRange(query *string, key string, now time.Time) (from, to int64) {
+	var (
+		q    = *query
+		expr = key + `:(\S+)`
+	)
+
+	re := regexp.MustCompile(expr)
+	res := re.FindStringSubmatch(q)
+	if len(res) < 2 {
+		return
+	}
+
+	var ok bool
+	value := res[1]
+	switch {
+	case strings.HasPrefix(value, "<"):
+		// newer than the duration
+		var t time.Time
+		if t, ok = parseTimeAgo(value[1:], now); ok {
+			from = t.Unix()
+		}
+	case strings.HasPrefix(value, ">"):
+		// older than the duration
+		var t time.Time
+		if t, ok = parseTimeAgo(value[1:], now); ok {
+			to = t.Unix()
+		}
+	case strings.Contains(value, ".."):
+		begin, end, _ := strings.Cut(value, "..")
+		ok = len(begin) > 0 || len(end) > 0
+		if len(begin) > 0 {
+			start, _, valid := parseDatePeriod(begin)
+			from, ok = start.Unix(), ok && valid
+		}
+		if len(end) > 0 {
+			_, stop, valid := parseDatePeriod(end)
+			to, ok = stop.Unix(), ok && valid
+		}
+	default:
+		var start, stop time.Time
+		if start, stop, ok = parseDatePeriod(value); ok {
+			from, to = start.Unix(), stop.Unix()
+		}
+	}
+	if !ok {
+		return 0, 0
 	}
-	q = re.ReplaceAllString(q, "")
+
+	*query = strings.TrimSpace(re.ReplaceAllString(q, ""))
+	return
+}
+
+// parseTimeAgo parse the duration like: 30d, 2w, 6m, 1y, return the time before now
+func parseTimeAgo(value string, now time.Time) (t time.Time, ok bool) {
+	if len(value) < 2 {
+		return now, false
+	}
+	amount := converter.StringToInt(value[:len(value)-1])
+	if amount <= 0 {
+		return now, false
+	}
+	switch value[len(value)-1] {
+	case 'd':
+		return now.AddDate(0, 0, -amount), true
+	case 'w':
+		return now.AddDate(0, 0, -amount*7), true
+	case 'm':
+		return now.AddDate(0, -amount, 0), true
+	case 'y':
+		return now.AddDate(-amount, 0, 0), true
+	}
+	return now, false
+}
+
+// parseDatePeriod parse the date like: 2024, 2024-01, 2024-01-02, return the period [start, end)
+func parseDatePeriod(value string) (start, end time.Time, ok bool) {
+	var err error
+	if start, err = time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return start, start.AddDate(0, 0, 1), true
+	}
+	if start, err = time.ParseInLocation("2006-01", value, time.Local); err == nil {
+		return start, start.AddDate(0, 1, 0), true
+	}
+	if start, err = time.ParseInLocation("2006", value, time.Local); err == nil {
+		return start, start.AddDate(1, 0, 0), true
+	}
+	return start, end, false
+}
+
+// parseClosed check the search is limit closed or not closed question
+func (sp *SearchParser) parseClosed(query *string) (closed, notClosed bool) {
+	var (
+		q       = *query
+		exprYes = `closed:yes`
+		exprNo  = `closed:no`
+	)
+
+	if strings.Contains(q, exprYes) {
+		closed = true
+		q = strings.ReplaceAll(q, exprYes, "")
+	} else if strings.Contains(q, exprNo) {
+		notClosed = true
+		q = strings.ReplaceAll(q, exprNo, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseHasAccepted return the question has accepted the answer
+func (sp *SearchParser) parseHasAccepted(query *string) (hasAccepted bool) {
+	var (
+		q    = *query
+		expr = `hasaccepted:yes`
+	)
+
+	if strings.Contains(q, expr) {
+		q = strings.ReplaceAll(q, expr, "")
+		hasAccepted = true
+	}
+
 	*query = strings.TrimSpace(q)
 	return
 }
@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchParser_parseWords(t *testing.T) {
+	sp := &SearchParser{}
+
+	query := `nginx OR haproxy "load  balancer" -apache -"http server" config`
+	groups, excludes := sp.parseWords(&query)
+	assert.Equal(t, [][]string{{"nginx", "haproxy"}, {"load balancer"}, {"config"}}, groups)
+	assert.Equal(t, []string{"apache", "http server"}, excludes)
+	assert.Empty(t, query)
+
+	// OR without the previous word is a normal word
+	query = `OR nginx`
+	groups, excludes = sp.parseWords(&query)
+	assert.Equal(t, [][]string{{"nginx"}}, groups)
+	assert.Empty(t, excludes)
+}
+
+func TestSearchParser_parseTimeRange(t *testing.T) {
+	sp := &SearchParser{}
+	now := time.Date(2024, 7, 15, 10, 0, 0, 0, time.Local)
+
+	query := "created:2024-01..2024-06 nginx"
+	from, to := sp.parseTimeRange(&query, "created", now)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local).Unix(), from)
+	assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.Local).Unix(), to)
+	assert.Equal(t, "nginx", query)
+
+	query = "created:2024-03-02"
+	from, to = sp.parseTimeRange(&query, "created", now)
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.Local).Unix(), from)
+	assert.Equal(t, time.Date(2024, 3, 3, 0, 0, 0, 0, time.Local).Unix(), to)
+
+	query = "created:2023.."
+	from, to = sp.parseTimeRange(&query, "created", now)
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local).Unix(), from)
+	assert.Equal(t, int64(0), to)
+
+	query = "active:<30d"
+	from, to = sp.parseTimeRange(&query, "active", now)
+	assert.Equal(t, now.AddDate(0, 0, -30).Unix(), from)
+	assert.Equal(t, int64(0), to)
+
+	query = "active:>1y"
+	from, to = sp.parseTimeRange(&query, "active", now)
+	assert.Equal(t, int64(0), from)
+	assert.Equal(t, now.AddDate(-1, 0, 0).Unix(), to)
+
+	// invalid value is kept in the query
+	query = "active:soon"
+	from, to = sp.parseTimeRange(&query, "active", now)
+	assert.Equal(t, int64(0), from)
+	assert.Equal(t, int64(0), to)
+	assert.Equal(t, "active:soon", query)
+}
@@ -20,5 +20,53 @@
 package service_config
 
 type ServiceConfig struct {
-	UploadPath string `json:"upload_path" mapstructure:"upload_path" yaml:"upload_path"`
+	UploadPath string            `json:"upload_path" mapstructure:"upload_path" yaml:"upload_path"`
+	EventQueue *EventQueueConfig `json:"event_queue" mapstructure:"event_queue" yaml:"event_queue,omitempty"`
+	Email      *EmailConfig      `json:"email" mapstructure:"email" yaml:"email,omitempty"`
+}
+
+const (
+	// EventQueueDriverMemory keep events in memory, events in flight are lost when the process exits.
+	// When the memory queue is full, the events are saved into the event_outbox table instead.
+	EventQueueDriverMemory = "memory"
+	// EventQueueDriverDatabase keep events in the event_outbox table until they are handled
+	EventQueueDriverDatabase = "database"
+)
+
+// EventQueueConfig event queue config
+type EventQueueConfig struct {
+	Driver      string `json:"driver" mapstructure:"driver" yaml:"driver"`
+	MaxAttempts int    `json:"max_attempts" mapstructure:"max_attempts" yaml:"max_attempts"`
+}
+
+// GetEventQueueDriver get event queue driver, default is memory
+func (c *ServiceConfig) GetEventQueueDriver() string {
+	if c == nil || c.EventQueue == nil || len(c.EventQueue.Driver) == 0 {
+		return EventQueueDriverMemory
+	}
+	return c.EventQueue.Driver
+}
+
+// EmailConfig email delivery config
+type EmailConfig struct {
+	// SpoolDir if it is set, emails are written into the directory in maildir format instead of sent by SMTP.
+	// It is useful for development and testing.
+	SpoolDir    string `json:"spool_dir" mapstructure:"spool_dir" yaml:"spool_dir"`
+	MaxAttempts int    `json:"max_attempts" mapstructure:"max_attempts" yaml:"max_attempts"`
+}
+
+// GetEmailSpoolDir get the email spool directory, empty means emails are not spooled
+func (c *ServiceConfig) GetEmailSpoolDir() string {
+	if c == nil || c.Email == nil {
+		return ""
+	}
+	return c.Email.SpoolDir
+}
+
+// GetEmailMaxAttempts get the max attempts of delivering an email, zero means the default value
+func (c *ServiceConfig) GetEmailMaxAttempts() int {
+	if c == nil || c.Email == nil {
+		return 0
+	}
+	return c.Email.MaxAttempts
 }
@@ -27,6 +27,7 @@ import (
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/entity"
@@ -99,6 +100,11 @@ func (s *SiteInfoService) GetSiteUsers(ctx context.Context) (resp *schema.SiteUs
 	return s.siteInfoCommonService.GetSiteUsers(ctx)
 }
 
+// GetSiteReview get site info about review queues
+func (s *SiteInfoService) GetSiteReview(ctx context.Context) (resp *schema.SiteReviewResp, err error) {
+	return s.siteInfoCommonService.GetSiteReview(ctx)
+}
+
 // GetSiteWrite get site info write
 func (s *SiteInfoService) GetSiteWrite(ctx context.Context) (resp *schema.SiteWriteResp, err error) {
 	resp = &schema.SiteWriteResp{}
@@ -269,6 +275,17 @@ func (s *SiteInfoService) SaveSiteUsers(ctx context.Context, req *schema.SiteUse
 	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeUsers, data)
 }
 
+// SaveSiteReview save site review queues config
+func (s *SiteInfoService) SaveSiteReview(ctx context.Context, req *schema.SiteReviewReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeReview,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeReview, data)
+}
+
 // GetSMTPConfig get smtp config
 func (s *SiteInfoService) GetSMTPConfig(ctx context.Context) (resp *schema.GetSMTPConfigResp, err error) {
 	emailConfig, err := s.emailService.GetEmailConfig(ctx)
@@ -309,6 +326,17 @@ func (s *SiteInfoService) UpdateSMTPConfig(ctx context.Context, req *schema.Upda
 	return nil
 }
 
+// GetEmailLogPage get email log page
+func (s *SiteInfoService) GetEmailLogPage(ctx context.Context, req *schema.GetEmailLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	return s.emailService.GetEmailLogPage(ctx, req)
+}
+
+// RetryEmailLog retry failed email
+func (s *SiteInfoService) RetryEmailLog(ctx context.Context, req *schema.RetryEmailLogReq) (err error) {
+	return s.emailService.RetryEmailLog(ctx, req)
+}
+
 func (s *SiteInfoService) GetSeo(ctx context.Context) (resp *schema.SiteSeoReq, err error) {
 	resp = &schema.SiteSeoReq{}
 	if err = s.siteInfoCommonService.GetSiteInfoByType(ctx, constant.SiteTypeSeo, resp); err != nil {
@@ -47,6 +47,7 @@ type SiteInfoCommonService interface {
 	GetSiteInterface(ctx context.Context) (resp *schema.SiteInterfaceResp, err error)
 	GetSiteBranding(ctx context.Context) (resp *schema.SiteBrandingResp, err error)
 	GetSiteUsers(ctx context.Context) (resp *schema.SiteUsersResp, err error)
+	GetSiteReview(ctx context.Context) (resp *schema.SiteReviewResp, err error)
 	FormatAvatar(ctx context.Context, originalAvatarData, email string, userStatus int) *schema.AvatarInfo
 	FormatListAvatar(ctx context.Context, userList []*entity.User) (userID2AvatarMapping map[string]*schema.AvatarInfo)
 	GetSiteWrite(ctx context.Context) (resp *schema.SiteWriteResp, err error)
@@ -102,6 +103,15 @@ func (s *siteInfoCommonService) GetSiteUsers(ctx context.Context) (resp *schema.
 	return resp, nil
 }
 
+// GetSiteReview get site info about review queues
+func (s *siteInfoCommonService) GetSiteReview(ctx context.Context) (resp *schema.SiteReviewResp, err error) {
+	resp = &schema.SiteReviewResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeReview, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // FormatAvatar format avatar
 func (s *siteInfoCommonService) FormatAvatar(ctx context.Context, originalAvatarData, email string, userStatus int) *schema.AvatarInfo {
 	gravatarBaseURL, defaultAvatar := s.getAvatarDefaultConfig(ctx)
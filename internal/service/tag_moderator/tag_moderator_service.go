@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_moderator
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// TagModeratorRepo tag moderator repository
+type TagModeratorRepo interface {
+	AddTagModerator(ctx context.Context, tagModerator *entity.TagModerator) (err error)
+	RemoveTagModerator(ctx context.Context, tagID, userID string) (err error)
+	GetTagModeratorList(ctx context.Context, tagID string) (tagModerators []*entity.TagModerator, err error)
+	GetModeratedTagIDs(ctx context.Context, userID string) (tagIDs []string, err error)
+	IsTagModeratorOfQuestion(ctx context.Context, userID, questionID string, includeDeleted bool) (ok bool, err error)
+}
+
+// TagModeratorService the users who moderate the posts with specific tags
+type TagModeratorService struct {
+	tagModeratorRepo  TagModeratorRepo
+	objectInfoService *object_info.ObjService
+	tagCommonService  *tagcommon.TagCommonService
+	userCommon        *usercommon.UserCommon
+}
+
+// NewTagModeratorService new tag moderator service
+func NewTagModeratorService(
+	tagModeratorRepo TagModeratorRepo,
+	objectInfoService *object_info.ObjService,
+	tagCommonService *tagcommon.TagCommonService,
+	userCommon *usercommon.UserCommon,
+) *TagModeratorService {
+	return &TagModeratorService{
+		tagModeratorRepo:  tagModeratorRepo,
+		objectInfoService: objectInfoService,
+		tagCommonService:  tagCommonService,
+		userCommon:        userCommon,
+	}
+}
+
+// GetTagModeratorList get the moderators of the tag
+func (ts *TagModeratorService) GetTagModeratorList(ctx context.Context, req *schema.GetTagModeratorListReq) (
+	resp []*schema.GetTagModeratorResp, err error) {
+	tagModerators, err := ts.tagModeratorRepo.GetTagModeratorList(ctx, req.TagID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(tagModerators))
+	for _, tagModerator := range tagModerators {
+		userIDs = append(userIDs, tagModerator.UserID)
+	}
+	userInfoMapping, err := ts.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = make([]*schema.GetTagModeratorResp, 0, len(tagModerators))
+	for _, tagModerator := range tagModerators {
+		userInfo, ok := userInfoMapping[tagModerator.UserID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.GetTagModeratorResp{
+			TagID:     tagModerator.TagID,
+			User:      userInfo,
+			CreatedAt: tagModerator.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// AddTagModerator make the user moderate the posts with the tag
+func (ts *TagModeratorService) AddTagModerator(ctx context.Context, req *schema.AddTagModeratorReq) (err error) {
+	_, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	_, exist, err = ts.userCommon.GetUserBasicInfoByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	return ts.tagModeratorRepo.AddTagModerator(ctx, &entity.TagModerator{
+		TagID:  req.TagID,
+		UserID: req.UserID,
+	})
+}
+
+// RemoveTagModerator remove tag moderator
+func (ts *TagModeratorService) RemoveTagModerator(ctx context.Context, req *schema.RemoveTagModeratorReq) (err error) {
+	return ts.tagModeratorRepo.RemoveTagModerator(ctx, req.TagID, req.UserID)
+}
+
+// GetModeratedTagIDs get the tags moderated by the user
+func (ts *TagModeratorService) GetModeratedTagIDs(ctx context.Context, userID string) (tagIDs []string, err error) {
+	if len(userID) == 0 {
+		return make([]string, 0), nil
+	}
+	return ts.tagModeratorRepo.GetModeratedTagIDs(ctx, userID)
+}
+
+// IsTagModeratorOfObject whether the user moderates any tag of the question that the object belongs to
+func (ts *TagModeratorService) IsTagModeratorOfObject(ctx context.Context, userID, objectID string) (ok bool, err error) {
+	if len(userID) == 0 || len(objectID) == 0 {
+		return false, nil
+	}
+	objectInfo, err := ts.objectInfoService.GetInfo(ctx, uid.DeShortID(objectID))
+	if err != nil {
+		return false, err
+	}
+	if objectInfo == nil || len(objectInfo.QuestionID) == 0 {
+		return false, nil
+	}
+	return ts.tagModeratorRepo.IsTagModeratorOfQuestion(ctx, userID, objectInfo.QuestionID,
+		objectInfo.QuestionStatus == entity.QuestionStatusDeleted)
+}
@@ -0,0 +1,235 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_access_token
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/role"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/random"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// lastUsedUpdateInterval avoid updating the last used time on every request
+const lastUsedUpdateInterval = time.Minute
+
+// UserAccessTokenRepo user access token repository
+type UserAccessTokenRepo interface {
+	AddUserAccessToken(ctx context.Context, token *entity.UserAccessToken) (err error)
+	GetUserAccessTokenByHash(ctx context.Context, tokenHash string) (token *entity.UserAccessToken, exist bool, err error)
+	GetUserAccessTokenList(ctx context.Context, userID string) (tokens []*entity.UserAccessToken, err error)
+	RevokeUserAccessToken(ctx context.Context, userID string, id int) (affected int64, err error)
+	RevokeAllUserAccessTokens(ctx context.Context, userID string) (err error)
+	UpdateLastUsedAt(ctx context.Context, id int, lastUsedAt time.Time) (err error)
+}
+
+// UserAccessTokenService personal access token service
+type UserAccessTokenService struct {
+	userAccessTokenRepo UserAccessTokenRepo
+	userRepo            usercommon.UserRepo
+	userRoleService     *role.UserRoleRelService
+}
+
+// NewUserAccessTokenService new user access token service
+func NewUserAccessTokenService(
+	userAccessTokenRepo UserAccessTokenRepo,
+	userRepo usercommon.UserRepo,
+	userRoleService *role.UserRoleRelService,
+) *UserAccessTokenService {
+	return &UserAccessTokenService{
+		userAccessTokenRepo: userAccessTokenRepo,
+		userRepo:            userRepo,
+		userRoleService:     userRoleService,
+	}
+}
+
+// GetUserAccessTokenList get all tokens of the user
+func (us *UserAccessTokenService) GetUserAccessTokenList(ctx context.Context, req *schema.GetUserAccessTokenListReq) (
+	resp []*schema.UserAccessTokenInfo, err error) {
+	tokens, err := us.userAccessTokenRepo.GetUserAccessTokenList(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserAccessTokenInfo, 0, len(tokens))
+	for _, token := range tokens {
+		info := &schema.UserAccessTokenInfo{
+			ID:          token.ID,
+			Name:        token.Name,
+			TokenPrefix: token.TokenPrefix,
+			Scopes:      strings.Split(token.Scopes, ","),
+			Status:      "available",
+			CreatedAt:   token.CreatedAt.Unix(),
+		}
+		if !token.ExpiredAt.IsZero() {
+			info.ExpiredAt = token.ExpiredAt.Unix()
+		}
+		if !token.LastUsedAt.IsZero() {
+			info.LastUsedAt = token.LastUsedAt.Unix()
+		}
+		if token.Status == entity.UserAccessTokenStatusRevoked {
+			info.Status = "revoked"
+		} else if isExpired(token) {
+			info.Status = "expired"
+		}
+		resp = append(resp, info)
+	}
+	return resp, nil
+}
+
+// AddUserAccessToken create a new token, the plain token is only returned here
+func (us *UserAccessTokenService) AddUserAccessToken(ctx context.Context, req *schema.AddUserAccessTokenReq) (
+	resp *schema.AddUserAccessTokenResp, err error) {
+	scopes := make([]string, 0, len(req.Scopes))
+	for _, scope := range schema.AccessTokenScopes {
+		for _, s := range req.Scopes {
+			if s == scope {
+				scopes = append(scopes, scope)
+				break
+			}
+		}
+	}
+	if hasScope(scopes, schema.AccessTokenScopeAdmin) && !req.IsAdmin {
+		return nil, errors.Forbidden(reason.UserAccessTokenScopeNotAllowed)
+	}
+
+	plainToken := schema.UserAccessTokenPrefix + random.Secret(24)
+	token := &entity.UserAccessToken{
+		UserID:      req.UserID,
+		Name:        req.Name,
+		TokenHash:   hashToken(plainToken),
+		TokenPrefix: plainToken[:len(schema.UserAccessTokenPrefix)+6],
+		Scopes:      strings.Join(scopes, ","),
+		Status:      entity.UserAccessTokenStatusAvailable,
+	}
+	if req.ExpireDays > 0 {
+		token.ExpiredAt = time.Now().AddDate(0, 0, req.ExpireDays)
+	}
+	if err = us.userAccessTokenRepo.AddUserAccessToken(ctx, token); err != nil {
+		return nil, err
+	}
+	resp = &schema.AddUserAccessTokenResp{ID: token.ID, Token: plainToken}
+	if !token.ExpiredAt.IsZero() {
+		resp.ExpiredAt = token.ExpiredAt.Unix()
+	}
+	return resp, nil
+}
+
+// RevokeUserAccessToken revoke the token
+func (us *UserAccessTokenService) RevokeUserAccessToken(ctx context.Context, req *schema.RevokeUserAccessTokenReq) (err error) {
+	affected, err := us.userAccessTokenRepo.RevokeUserAccessToken(ctx, req.UserID, req.ID)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.BadRequest(reason.UserAccessTokenNotFound)
+	}
+	return nil
+}
+
+// RevokeAllUserAccessTokens revoke all tokens of the user
+func (us *UserAccessTokenService) RevokeAllUserAccessTokens(ctx context.Context, userID string) (err error) {
+	return us.userAccessTokenRepo.RevokeAllUserAccessTokens(ctx, userID)
+}
+
+// GetUserCacheInfo verify the personal access token, returns the owner info and the scopes of the token.
+// If the token is invalid, the userInfo will be nil.
+func (us *UserAccessTokenService) GetUserCacheInfo(ctx context.Context, accessToken string) (
+	userInfo *entity.UserCacheInfo, scopes []string, err error) {
+	token, exist, err := us.userAccessTokenRepo.GetUserAccessTokenByHash(ctx, hashToken(accessToken))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exist || token.Status != entity.UserAccessTokenStatusAvailable || isExpired(token) {
+		return nil, nil, nil
+	}
+	user, exist, err := us.userRepo.GetByUserID(ctx, token.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exist {
+		return nil, nil, nil
+	}
+	roleID, err := us.userRoleService.GetUserRole(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if now := time.Now(); now.Sub(token.LastUsedAt) > lastUsedUpdateInterval {
+		if err := us.userAccessTokenRepo.UpdateLastUsedAt(ctx, token.ID, now); err != nil {
+			log.Error(err)
+		}
+	}
+
+	userInfo = &entity.UserCacheInfo{
+		UserID:      user.ID,
+		UserStatus:  user.Status,
+		EmailStatus: user.MailStatus,
+		RoleID:      roleID,
+	}
+	return userInfo, strings.Split(token.Scopes, ","), nil
+}
+
+// IsUserAccessToken check if the token is a personal access token
+func IsUserAccessToken(accessToken string) bool {
+	return strings.HasPrefix(accessToken, schema.UserAccessTokenPrefix)
+}
+
+// ScopeAllowed check whether the scopes allow the request. The admin scope is required by admin api,
+// the write scope is required by the request that may change data, otherwise read or write scope is required.
+func ScopeAllowed(scopes []string, method string, adminAPI bool) bool {
+	if adminAPI {
+		return hasScope(scopes, schema.AccessTokenScopeAdmin)
+	}
+	if hasScope(scopes, schema.AccessTokenScopeWrite) {
+		return true
+	}
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return hasScope(scopes, schema.AccessTokenScopeRead)
+	}
+	return false
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func isExpired(token *entity.UserAccessToken) bool {
+	return !token.ExpiredAt.IsZero() && token.ExpiredAt.Before(time.Now())
+}
+
+func hashToken(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(sum[:])
+}
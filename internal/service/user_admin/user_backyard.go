@@ -41,10 +41,13 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity"
+	"github.com/apache/incubator-answer/internal/service/audit_log"
 	"github.com/apache/incubator-answer/internal/service/auth"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/user_access_token"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_suspension"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
@@ -66,16 +69,19 @@ type UserAdminRepo interface {
 
 // UserAdminService user service
 type UserAdminService struct {
-	userRepo              UserAdminRepo
-	userRoleRelService    *role.UserRoleRelService
-	authService           *auth.AuthService
-	userCommonService     *usercommon.UserCommon
-	userActivity          activity.UserActiveActivityRepo
-	siteInfoCommonService siteinfo_common.SiteInfoCommonService
-	emailService          *export.EmailService
-	questionCommonRepo    questioncommon.QuestionRepo
-	answerCommonRepo      answercommon.AnswerRepo
-	commentCommonRepo     comment_common.CommentCommonRepo
+	userRepo               UserAdminRepo
+	userRoleRelService     *role.UserRoleRelService
+	authService            *auth.AuthService
+	userCommonService      *usercommon.UserCommon
+	userActivity           activity.UserActiveActivityRepo
+	siteInfoCommonService  siteinfo_common.SiteInfoCommonService
+	emailService           *export.EmailService
+	questionCommonRepo     questioncommon.QuestionRepo
+	answerCommonRepo       answercommon.AnswerRepo
+	commentCommonRepo      comment_common.CommentCommonRepo
+	userSuspensionService  *user_suspension.UserSuspensionService
+	auditLogService        *audit_log.AuditLogService
+	userAccessTokenService *user_access_token.UserAccessTokenService
 }
 
 // NewUserAdminService new user admin service
@@ -90,18 +96,24 @@ func NewUserAdminService(
 	questionCommonRepo questioncommon.QuestionRepo,
 	answerCommonRepo answercommon.AnswerRepo,
 	commentCommonRepo comment_common.CommentCommonRepo,
+	userSuspensionService *user_suspension.UserSuspensionService,
+	auditLogService *audit_log.AuditLogService,
+	userAccessTokenService *user_access_token.UserAccessTokenService,
 ) *UserAdminService {
 	return &UserAdminService{
-		userRepo:              userRepo,
-		userRoleRelService:    userRoleRelService,
-		authService:           authService,
-		userCommonService:     userCommonService,
-		userActivity:          userActivity,
-		siteInfoCommonService: siteInfoCommonService,
-		emailService:          emailService,
-		questionCommonRepo:    questionCommonRepo,
-		answerCommonRepo:      answerCommonRepo,
-		commentCommonRepo:     commentCommonRepo,
+		userRepo:               userRepo,
+		userRoleRelService:     userRoleRelService,
+		authService:            authService,
+		userCommonService:      userCommonService,
+		userActivity:           userActivity,
+		siteInfoCommonService:  siteInfoCommonService,
+		emailService:           emailService,
+		questionCommonRepo:     questionCommonRepo,
+		answerCommonRepo:       answerCommonRepo,
+		commentCommonRepo:      commentCommonRepo,
+		userSuspensionService:  userSuspensionService,
+		auditLogService:        auditLogService,
+		userAccessTokenService: userAccessTokenService,
 	}
 }
 
@@ -122,6 +134,13 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	if userInfo.Status == entity.UserStatusDeleted {
 		return nil
 	}
+	if req.IsSuspended() && len(req.SuspendReason) > 0 {
+		if err = us.userSuspensionService.CheckSuspendReason(ctx, req.SuspendReason); err != nil {
+			return err
+		}
+	}
+	previousStatus := userInfo.Status
+	before := userAuditSnapshot(userInfo)
 
 	if req.IsInactive() {
 		userInfo.MailStatus = entity.EmailStatusToBeVerified
@@ -143,6 +162,38 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 		return err
 	}
 
+	// record the suspension history, the time-limited suspension will be lifted by cron automatically
+	if req.IsSuspended() {
+		if err = us.userSuspensionService.SuspendUser(ctx, userInfo, req); err != nil {
+			return err
+		}
+	} else if previousStatus == entity.UserStatusSuspended && userInfo.Status != entity.UserStatusSuspended {
+		if err = us.userSuspensionService.LiftUserSuspension(ctx, userInfo.ID); err != nil {
+			return err
+		}
+	}
+
+	after := userAuditSnapshot(userInfo)
+	if req.IsSuspended() {
+		after["suspend_duration"] = req.SuspendDuration
+		after["suspend_reason"] = req.SuspendReason
+		after["suspend_message"] = req.SuspendMessage
+	}
+	after["remove_all_content"] = req.RemoveAllContent
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.LoginUserID,
+		Action:         constant.AuditLogActionUserStatusUpdate,
+		ObjectType:     constant.UserObjectType,
+		ObjectID:       userInfo.ID,
+		Before:         before,
+		After:          after,
+	})
+
+	// the deleted or suspended user can not use the personal access tokens any more
+	if req.IsDeleted() || req.IsSuspended() {
+		us.removeUserAllTokens(ctx, userInfo.ID)
+	}
+
 	// remove all content that user created, such as question, answer, comment, etc.
 	if req.RemoveAllContent {
 		us.removeAllUserCreatedContent(ctx, userInfo.ID)
@@ -155,6 +206,14 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	return nil
 }
 
+// removeUserAllTokens log out the user and revoke all the personal access tokens of the user
+func (us *UserAdminService) removeUserAllTokens(ctx context.Context, userID string) {
+	us.authService.RemoveUserAllTokens(ctx, userID)
+	if err := us.userAccessTokenService.RevokeAllUserAccessTokens(ctx, userID); err != nil {
+		log.Errorf("revoke access tokens of user %s failed: %v", userID, err)
+	}
+}
+
 // removeAllUserCreatedContent remove all user created content
 func (us *UserAdminService) removeAllUserCreatedContent(ctx context.Context, userID string) {
 	if err := us.questionCommonRepo.RemoveAllUserQuestion(ctx, userID); err != nil {
@@ -174,13 +233,72 @@ func (us *UserAdminService) UpdateUserRole(ctx context.Context, req *schema.Upda
 	if req.UserID == req.LoginUserID {
 		return errors.BadRequest(reason.UserCannotUpdateYourRole)
 	}
+	previousRoleID, err := us.userRoleRelService.GetUserRole(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
 
 	err = us.userRoleRelService.SaveUserRole(ctx, req.UserID, req.RoleID)
 	if err != nil {
 		return err
 	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.LoginUserID,
+		Action:         constant.AuditLogActionUserRoleUpdate,
+		ObjectType:     constant.UserObjectType,
+		ObjectID:       req.UserID,
+		Before:         map[string]int{"role_id": previousRoleID},
+		After:          map[string]int{"role_id": req.RoleID},
+	})
 
-	us.authService.RemoveUserAllTokens(ctx, req.UserID)
+	us.removeUserAllTokens(ctx, req.UserID)
+	return
+}
+
+// UpdateUsersRole update the role of users in bulk
+func (us *UserAdminService) UpdateUsersRole(ctx context.Context, req *schema.UpdateUsersRoleReq) (err error) {
+	// Users cannot modify their roles
+	for _, userID := range req.UserIDs {
+		if userID == req.LoginUserID {
+			return errors.BadRequest(reason.UserCannotUpdateYourRole)
+		}
+	}
+	userMapping, err := us.userCommonService.BatchUserBasicInfoByID(ctx, req.UserIDs)
+	if err != nil {
+		return err
+	}
+	for _, userID := range req.UserIDs {
+		if _, ok := userMapping[userID]; !ok {
+			return errors.BadRequest(reason.UserNotFound)
+		}
+	}
+
+	previousRoleIDs := make(map[string]int, len(req.UserIDs))
+	for _, userID := range req.UserIDs {
+		previousRoleIDs[userID], err = us.userRoleRelService.GetUserRole(ctx, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = us.userRoleRelService.SaveUsersRole(ctx, req.UserIDs, req.RoleID)
+	if err != nil {
+		return err
+	}
+	for _, userID := range req.UserIDs {
+		us.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+			OperatorUserID: req.LoginUserID,
+			Action:         constant.AuditLogActionUserRoleUpdate,
+			ObjectType:     constant.UserObjectType,
+			ObjectID:       userID,
+			Before:         map[string]int{"role_id": previousRoleIDs[userID]},
+			After:          map[string]int{"role_id": req.RoleID},
+		})
+	}
+
+	for _, userID := range req.UserIDs {
+		us.removeUserAllTokens(ctx, userID)
+	}
 	return
 }
 
@@ -216,6 +334,13 @@ func (us *UserAdminService) AddUser(ctx context.Context, req *schema.AddUserReq)
 	if err != nil {
 		return err
 	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.LoginUserID,
+		Action:         constant.AuditLogActionUserAdd,
+		ObjectType:     constant.UserObjectType,
+		ObjectID:       userInfo.ID,
+		After:          userAuditSnapshot(userInfo),
+	})
 	return
 }
 
@@ -237,7 +362,21 @@ func (us *UserAdminService) AddUsers(ctx context.Context, req *schema.AddUsersRe
 	if errData != nil {
 		return errData.GetErrField(ctx), errors.BadRequest(reason.RequestFormatError)
 	}
-	return nil, us.userRepo.AddUsers(ctx, users)
+	if err = us.userRepo.AddUsers(ctx, users); err != nil {
+		return nil, err
+	}
+	// the ids of the users added in bulk are unknown, so record them in one log
+	snapshots := make([]map[string]any, 0, len(users))
+	for _, userInfo := range users {
+		snapshots = append(snapshots, userAuditSnapshot(userInfo))
+	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.LoginUserID,
+		Action:         constant.AuditLogActionUserAdd,
+		ObjectType:     constant.UserObjectType,
+		After:          snapshots,
+	})
+	return nil, nil
 }
 
 func (us *UserAdminService) checkUserDuplicateInner(ctx context.Context, users []*schema.AddUserReq) (
@@ -341,8 +480,15 @@ func (us *UserAdminService) UpdateUserPassword(ctx context.Context, req *schema.
 	if err != nil {
 		return err
 	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.LoginUserID,
+		Action:         constant.AuditLogActionUserPasswordUpdate,
+		ObjectType:     constant.UserObjectType,
+		ObjectID:       userInfo.ID,
+		After:          map[string]string{"password": schema.AuditLogRedactedValue},
+	})
 	// logout this user
-	us.authService.RemoveUserAllTokens(ctx, req.UserID)
+	us.removeUserAllTokens(ctx, req.UserID)
 	return
 }
 
@@ -359,6 +505,7 @@ func (us *UserAdminService) EditUserProfile(ctx context.Context, req *schema.Edi
 	if !exist {
 		return nil, errors.BadRequest(reason.UserNotFound)
 	}
+	before := userAuditSnapshot(userInfo)
 
 	if checker.IsInvalidUsername(req.Username) || checker.IsUsersIgnorePath(req.Username) {
 		return append(errFields, &validator.FormErrorField{
@@ -399,6 +546,18 @@ func (us *UserAdminService) EditUserProfile(ctx context.Context, req *schema.Edi
 	if err != nil {
 		return nil, err
 	}
+	us.auditLogService.AddAuditLog(ctx, &schema.AuditLogMsg{
+		OperatorUserID: req.LoginUserID,
+		Action:         constant.AuditLogActionUserProfileUpdate,
+		ObjectType:     constant.UserObjectType,
+		ObjectID:       req.UserID,
+		Before:         before,
+		After: map[string]any{
+			"username":     user.Username,
+			"display_name": user.DisplayName,
+			"email":        user.EMail,
+		},
+	})
 	return
 }
 
@@ -479,6 +638,9 @@ func (us *UserAdminService) GetUserPage(ctx context.Context, req *schema.GetUser
 		} else if u.Status == entity.UserStatusSuspended {
 			t.Status = constant.UserSuspended
 			t.SuspendedAt = u.SuspendedAt.Unix()
+			if !u.SuspendedUntil.IsZero() {
+				t.SuspendedUntil = u.SuspendedUntil.Unix()
+			}
 		} else if u.MailStatus == entity.EmailStatusToBeVerified {
 			t.Status = constant.UserInactive
 		} else {
@@ -568,3 +730,23 @@ func (us *UserAdminService) SendUserActivation(ctx context.Context, req *schema.
 	go us.emailService.SendAndSaveCode(ctx, userInfo.ID, userInfo.EMail, title, body, code, data.ToJSONString())
 	return nil
 }
+
+// userAuditSnapshot the user information recorded in the audit log
+func userAuditSnapshot(userInfo *entity.User) map[string]any {
+	snapshot := map[string]any{
+		"username":     userInfo.Username,
+		"display_name": userInfo.DisplayName,
+		"email":        userInfo.EMail,
+	}
+	switch {
+	case userInfo.Status == entity.UserStatusDeleted:
+		snapshot["status"] = constant.UserDeleted
+	case userInfo.Status == entity.UserStatusSuspended:
+		snapshot["status"] = constant.UserSuspended
+	case userInfo.MailStatus == entity.EmailStatusToBeVerified:
+		snapshot["status"] = constant.UserInactive
+	default:
+		snapshot["status"] = constant.UserNormal
+	}
+	return snapshot
+}
@@ -21,6 +21,8 @@ package user_notification_config
 
 import (
 	"context"
+	"time"
+
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
@@ -36,6 +38,7 @@ type UserNotificationConfigRepo interface {
 		conf *entity.UserNotificationConfig, exist bool, err error)
 	GetByUsersAndSource(ctx context.Context, userIDs []string, source constant.NotificationSource) (
 		[]*entity.UserNotificationConfig, error)
+	UpdateDigestSentAt(ctx context.Context, id string, sentAt time.Time) (err error)
 }
 
 type UserNotificationConfigService struct {
@@ -85,6 +88,11 @@ func (us *UserNotificationConfigService) UpdateUserNotificationConfig(
 	if err != nil {
 		return err
 	}
+	err = us.userNotificationConfigRepo.Save(ctx,
+		us.convertToEntity(ctx, req.UserID, constant.DigestSource, req.NotificationConfig.Digest))
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,307 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_suspension
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/reason_common"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	suspendReasonObjectType = "user"
+	suspendReasonAction     = "suspend"
+	// expiredSuspensionBatchSize the max number of expired suspensions lifted in one cron execution
+	expiredSuspensionBatchSize = 100
+)
+
+// UserSuspensionRepo user suspension repository
+type UserSuspensionRepo interface {
+	AddUserSuspension(ctx context.Context, suspension *entity.UserSuspension) (err error)
+	LiftUserSuspension(ctx context.Context, userID string) (err error)
+	ExpireUserSuspension(ctx context.Context, suspension *entity.UserSuspension) (reinstated bool, err error)
+	GetActiveUserSuspension(ctx context.Context, userID string) (suspension *entity.UserSuspension, exist bool, err error)
+	GetUserSuspensionList(ctx context.Context, userID string) (suspensions []*entity.UserSuspension, err error)
+	GetExpiredUserSuspensions(ctx context.Context, expiredAt time.Time, limit int) (
+		suspensions []*entity.UserSuspension, err error)
+}
+
+// UserSuspensionService the time-limited suspensions of users
+type UserSuspensionService struct {
+	userSuspensionRepo UserSuspensionRepo
+	userRepo           usercommon.UserRepo
+	reasonRepo         reason_common.ReasonRepo
+	authService        *auth.AuthService
+	userRoleRelService *role.UserRoleRelService
+	userCommon         *usercommon.UserCommon
+	emailService       *export.EmailService
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+}
+
+// NewUserSuspensionService new user suspension service
+func NewUserSuspensionService(
+	userSuspensionRepo UserSuspensionRepo,
+	userRepo usercommon.UserRepo,
+	reasonRepo reason_common.ReasonRepo,
+	authService *auth.AuthService,
+	userRoleRelService *role.UserRoleRelService,
+	userCommon *usercommon.UserCommon,
+	emailService *export.EmailService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *UserSuspensionService {
+	return &UserSuspensionService{
+		userSuspensionRepo: userSuspensionRepo,
+		userRepo:           userRepo,
+		reasonRepo:         reasonRepo,
+		authService:        authService,
+		userRoleRelService: userRoleRelService,
+		userCommon:         userCommon,
+		emailService:       emailService,
+		siteInfoService:    siteInfoService,
+	}
+}
+
+// CheckSuspendReason check the suspend reason is one of the configured user suspend reasons
+func (us *UserSuspensionService) CheckSuspendReason(ctx context.Context, reasonKey string) (err error) {
+	_, ok, err := us.getSuspendReason(ctx, reasonKey)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.BadRequest(reason.UserSuspendReasonInvalid)
+	}
+	return nil
+}
+
+func (us *UserSuspensionService) getSuspendReason(ctx context.Context, reasonKey string) (
+	item *schema.ReasonItem, ok bool, err error) {
+	reasons, err := us.reasonRepo.ListReasons(ctx, suspendReasonObjectType, suspendReasonAction)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, r := range reasons {
+		if r.ReasonKey == reasonKey {
+			return r, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// SuspendUser record the suspension of the user and email the reason to the user.
+// The previous active suspension of the user will be replaced.
+func (us *UserSuspensionService) SuspendUser(ctx context.Context, userInfo *entity.User,
+	req *schema.UpdateUserStatusReq) (err error) {
+	now := time.Now()
+	suspension := &entity.UserSuspension{
+		UserID:         userInfo.ID,
+		OperatorUserID: req.LoginUserID,
+		ReasonKey:      req.SuspendReason,
+		Message:        req.SuspendMessage,
+		Duration:       req.SuspendDuration,
+		Status:         entity.UserSuspensionStatusActive,
+	}
+	if !suspension.IsForever() {
+		suspension.SuspendedUntil = now.Add(time.Duration(suspension.Duration) * time.Hour)
+	}
+	if err = us.userSuspensionRepo.AddUserSuspension(ctx, suspension); err != nil {
+		return err
+	}
+	us.sendSuspendedEmail(ctx, userInfo, suspension)
+	return nil
+}
+
+// LiftUserSuspension lift the active suspension of the user when the user is unsuspended manually
+func (us *UserSuspensionService) LiftUserSuspension(ctx context.Context, userID string) (err error) {
+	return us.userSuspensionRepo.LiftUserSuspension(ctx, userID)
+}
+
+// GetUserSuspensionList get the suspension history of the user
+func (us *UserSuspensionService) GetUserSuspensionList(ctx context.Context, req *schema.GetUserSuspensionListReq) (
+	resp []*schema.UserSuspensionResp, err error) {
+	suspensions, err := us.userSuspensionRepo.GetUserSuspensionList(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	operatorIDs := make([]string, 0, len(suspensions))
+	for _, suspension := range suspensions {
+		operatorIDs = append(operatorIDs, suspension.OperatorUserID)
+	}
+	operatorMapping, err := us.userCommon.BatchUserBasicInfoByID(ctx, operatorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = make([]*schema.UserSuspensionResp, 0, len(suspensions))
+	for _, suspension := range suspensions {
+		item := us.formatUserSuspension(ctx, suspension)
+		item.Operator = operatorMapping[suspension.OperatorUserID]
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// GetCurrentUserSuspension get the active suspension of the login user, return nil if the user is not suspended
+func (us *UserSuspensionService) GetCurrentUserSuspension(ctx context.Context, userID string) (
+	resp *schema.UserSuspensionResp, err error) {
+	suspension, exist, err := us.userSuspensionRepo.GetActiveUserSuspension(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	return us.formatUserSuspension(ctx, suspension), nil
+}
+
+func (us *UserSuspensionService) formatUserSuspension(ctx context.Context, suspension *entity.UserSuspension) (
+	resp *schema.UserSuspensionResp) {
+	resp = &schema.UserSuspensionResp{
+		ID:          suspension.ID,
+		ReasonKey:   suspension.ReasonKey,
+		Message:     suspension.Message,
+		Duration:    suspension.Duration,
+		SuspendedAt: suspension.CreatedAt.Unix(),
+	}
+	if !suspension.IsForever() {
+		resp.SuspendedUntil = suspension.SuspendedUntil.Unix()
+	}
+	if !suspension.LiftedAt.IsZero() {
+		resp.LiftedAt = suspension.LiftedAt.Unix()
+	}
+	switch suspension.Status {
+	case entity.UserSuspensionStatusActive:
+		resp.Status = schema.UserSuspensionStatusActive
+	case entity.UserSuspensionStatusLifted:
+		resp.Status = schema.UserSuspensionStatusLifted
+	case entity.UserSuspensionStatusExpired:
+		resp.Status = schema.UserSuspensionStatusExpired
+	}
+	reasonItem, ok, err := us.getSuspendReason(ctx, suspension.ReasonKey)
+	if err != nil {
+		log.Error(err)
+	}
+	if ok {
+		resp.ReasonName = reasonItem.Name
+		resp.ReasonDescription = reasonItem.Description
+	}
+	return resp
+}
+
+func (us *UserSuspensionService) sendSuspendedEmail(ctx context.Context, userInfo *entity.User,
+	suspension *entity.UserSuspension) {
+	if userInfo.MailStatus != entity.EmailStatusAvailable {
+		return
+	}
+	lang := userInfo.Language
+	if len(lang) == 0 || lang == translator.DefaultLangOption {
+		if interfaceInfo, _ := us.siteInfoService.GetSiteInterface(ctx); interfaceInfo != nil {
+			lang = interfaceInfo.Language
+		}
+	}
+	ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(lang))
+
+	rawData := &schema.UserSuspendedTemplateRawData{
+		DisplayName:    userInfo.DisplayName,
+		Message:        suspension.Message,
+		SuspendedUntil: suspension.SuspendedUntil,
+		Forever:        suspension.IsForever(),
+	}
+	reasonItem, ok, err := us.getSuspendReason(ctx, suspension.ReasonKey)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if ok {
+		rawData.ReasonName = reasonItem.Name
+		rawData.ReasonDescription = reasonItem.Description
+	}
+	title, body, err := us.emailService.UserSuspendedTemplate(ctx, rawData)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	us.emailService.Send(ctx, userInfo.EMail, title, body)
+}
+
+// LiftExpiredSuspensionsCron reinstate the users whose suspensions are expired
+func (us *UserSuspensionService) LiftExpiredSuspensionsCron(ctx context.Context) {
+	for {
+		suspensions, err := us.userSuspensionRepo.GetExpiredUserSuspensions(ctx, time.Now(), expiredSuspensionBatchSize)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		failed := false
+		for _, suspension := range suspensions {
+			if err = us.expireUserSuspension(ctx, suspension); err != nil {
+				log.Errorf("expire user suspension %d failed: %v", suspension.ID, err)
+				failed = true
+			}
+		}
+		// the failed suspensions will be fetched again, so wait for the next execution
+		if failed || len(suspensions) < expiredSuspensionBatchSize {
+			return
+		}
+	}
+}
+
+func (us *UserSuspensionService) expireUserSuspension(ctx context.Context, suspension *entity.UserSuspension) (
+	err error) {
+	reinstated, err := us.userSuspensionRepo.ExpireUserSuspension(ctx, suspension)
+	if err != nil {
+		return err
+	}
+	if !reinstated {
+		return nil
+	}
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, suspension.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return nil
+	}
+	roleID, err := us.userRoleRelService.GetUserRole(ctx, userInfo.ID)
+	if err != nil {
+		return err
+	}
+	log.Infof("user %s is reinstated because the suspension %d is expired", userInfo.ID, suspension.ID)
+	// the login user cache still keeps the suspended status, so refresh it.
+	return us.authService.SetUserStatus(ctx, &entity.UserCacheInfo{
+		UserID:      userInfo.ID,
+		UserStatus:  userInfo.Status,
+		EmailStatus: userInfo.MailStatus,
+		RoleID:      roleID,
+	})
+}
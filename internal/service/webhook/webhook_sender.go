@@ -0,0 +1,275 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/token"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	webhookWorkerNum       = 4
+	webhookBatchSize       = 50
+	webhookPollInterval    = 10 * time.Second
+	webhookCleanInterval   = time.Hour
+	webhookRequestTimeout  = 10 * time.Second
+	webhookMaxAttempts     = 5
+	webhookRetryBaseDelay  = 30 * time.Second
+	webhookMaxResponseSize = 4 * 1024
+	webhookTaskRetention   = 7 * 24 * time.Hour
+	// webhookTaskLease is the time a task is locked by a worker, if the process dies
+	// the task will be picked up again after the lease expires.
+	webhookTaskLease = 5 * time.Minute
+)
+
+// webhookSender send the webhook requests by a fixed number of workers.
+// The deliveries are saved as tasks before they are sent, so the pending and retrying deliveries
+// will not be lost when the process restarts.
+type webhookSender struct {
+	webhookRepo WebhookRepo
+	httpClient  *http.Client
+	notify      chan struct{}
+}
+
+func newWebhookSender(webhookRepo WebhookRepo) *webhookSender {
+	s := &webhookSender{
+		webhookRepo: webhookRepo,
+		httpClient:  &http.Client{Timeout: webhookRequestTimeout},
+		notify:      make(chan struct{}, 1),
+	}
+	go s.working()
+	return s
+}
+
+// send save the deliveries of the event as tasks and wake up the worker, it never blocks on the worker.
+func (s *webhookSender) send(ctx context.Context, webhooks []*entity.Webhook, siteURL string, msg *schema.EventMsg) error {
+	if len(webhooks) == 0 {
+		return nil
+	}
+	tasks := make([]*entity.WebhookTask, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		deliveryID := token.GenerateToken()
+		body, err := json.Marshal(&schema.WebhookPayload{
+			DeliveryID: deliveryID,
+			EventType:  msg.EventType,
+			SiteURL:    siteURL,
+			Timestamp:  time.Now().Unix(),
+			Data:       msg,
+		})
+		if err != nil {
+			log.Errorf("marshal webhook payload failed: %v", err)
+			return nil
+		}
+		tasks = append(tasks, &entity.WebhookTask{
+			WebhookID:   webhook.ID,
+			DeliveryID:  deliveryID,
+			EventType:   string(msg.EventType),
+			Payload:     string(body),
+			Status:      entity.WebhookTaskStatusPending,
+			NextRetryAt: time.Now(),
+		})
+	}
+	if err := s.webhookRepo.AddWebhookTasks(ctx, tasks); err != nil {
+		return err
+	}
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (s *webhookSender) working() {
+	pollTicker := time.NewTicker(webhookPollInterval)
+	cleanTicker := time.NewTicker(webhookCleanInterval)
+	defer pollTicker.Stop()
+	defer cleanTicker.Stop()
+	for {
+		select {
+		case <-s.notify:
+		case <-pollTicker.C:
+		case <-cleanTicker.C:
+			s.clean(context.Background())
+			continue
+		}
+		s.consume(context.Background())
+	}
+}
+
+// consume send all available tasks in batches until there is nothing left
+func (s *webhookSender) consume(ctx context.Context) {
+	for {
+		tasks, err := s.webhookRepo.GetAvailableWebhookTasks(ctx, time.Now(), webhookBatchSize)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		if len(tasks) == 0 {
+			return
+		}
+		handled := 0
+		workers := make(chan struct{}, webhookWorkerNum)
+		wg := sync.WaitGroup{}
+		for _, task := range tasks {
+			claimed, err := s.webhookRepo.ClaimWebhookTask(ctx, task, time.Now().Add(webhookTaskLease))
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+			// the task has been taken by another worker
+			if !claimed {
+				continue
+			}
+			handled++
+			workers <- struct{}{}
+			wg.Add(1)
+			go func(task *entity.WebhookTask) {
+				defer func() {
+					<-workers
+					wg.Done()
+				}()
+				s.deliver(ctx, task)
+			}(task)
+		}
+		wg.Wait()
+		if handled == 0 {
+			return
+		}
+	}
+}
+
+// deliver send the claimed task, record the delivery and schedule the next attempt if it fails
+func (s *webhookSender) deliver(ctx context.Context, task *entity.WebhookTask) {
+	webhook, exist, err := s.webhookRepo.GetWebhook(ctx, task.WebhookID)
+	if err != nil {
+		// the task will be picked up again after the lease expires
+		log.Error(err)
+		return
+	}
+	if !exist || !webhook.Active {
+		task.Status = entity.WebhookTaskStatusDead
+	} else if s.request(ctx, webhook, task) {
+		task.Status = entity.WebhookTaskStatusDone
+	} else if task.Attempts >= webhookMaxAttempts {
+		log.Errorf("webhook %d delivery %s is dead after %d attempts", task.WebhookID, task.DeliveryID, task.Attempts)
+		task.Status = entity.WebhookTaskStatusDead
+	} else {
+		task.Status = entity.WebhookTaskStatusPending
+		task.NextRetryAt = time.Now().Add(webhookRetryBackoff(task.Attempts))
+	}
+	if err := s.webhookRepo.UpdateWebhookTaskResult(ctx, task); err != nil {
+		log.Error(err)
+	}
+}
+
+// request send the request and record the result
+func (s *webhookSender) request(ctx context.Context, webhook *entity.Webhook, task *entity.WebhookTask) (ok bool) {
+	delivery := &entity.WebhookDelivery{
+		WebhookID:   task.WebhookID,
+		DeliveryID:  task.DeliveryID,
+		EventType:   task.EventType,
+		Attempt:     task.Attempts,
+		RequestBody: task.Payload,
+	}
+	start := time.Now()
+	statusCode, respBody, err := s.post(ctx, webhook, task)
+	delivery.Duration = time.Since(start).Milliseconds()
+	delivery.StatusCode = statusCode
+	delivery.ResponseBody = respBody
+	if err != nil {
+		delivery.Error = err.Error()
+	} else if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		delivery.Error = fmt.Sprintf("unexpected status code %d", statusCode)
+	} else {
+		delivery.Success = true
+	}
+	if !delivery.Success {
+		log.Warnf("webhook %d delivery %s attempt %d failed: %s",
+			task.WebhookID, task.DeliveryID, task.Attempts, delivery.Error)
+	}
+	if err := s.webhookRepo.AddWebhookDelivery(ctx, delivery); err != nil {
+		log.Error(err)
+	}
+	return delivery.Success
+}
+
+// clean remove the tasks that have been finished for a while, the delivery records are kept
+func (s *webhookSender) clean(ctx context.Context) {
+	before := time.Now().Add(-webhookTaskRetention)
+	for _, status := range []int{entity.WebhookTaskStatusDone, entity.WebhookTaskStatusDead} {
+		err := s.webhookRepo.RemoveWebhookTasksBefore(ctx, status, before)
+		if err != nil {
+			log.Error(err)
+		}
+	}
+	// the deliveries keep the full request and response bodies, so they are removed in the same window
+	if err := s.webhookRepo.RemoveWebhookDeliveriesBefore(ctx, before); err != nil {
+		log.Error(err)
+	}
+}
+
+// webhookRetryBackoff returns the exponential delay before the next attempt
+func webhookRetryBackoff(attempts int) time.Duration {
+	return webhookRetryBaseDelay << (attempts - 1)
+}
+
+func (s *webhookSender) post(ctx context.Context, webhook *entity.Webhook, task *entity.WebhookTask) (
+	statusCode int, respBody string, err error) {
+	body := []byte(task.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Answer-Webhook/"+constant.Version)
+	req.Header.Set(schema.WebhookEventHeader, task.EventType)
+	req.Header.Set(schema.WebhookDeliveryHeader, task.DeliveryID)
+	req.Header.Set(schema.WebhookSignatureHeader, Sign(webhook.Secret, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	respBytes, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxResponseSize))
+	return resp.StatusCode, string(respBytes), nil
+}
+
+// Sign returns the signature of the body, the receiver should compute the same value to verify the request
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
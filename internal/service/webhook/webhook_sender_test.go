@@ -0,0 +1,213 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryWebhookRepo keeps the webhooks, tasks and deliveries in memory
+type memoryWebhookRepo struct {
+	WebhookRepo
+	lock       sync.Mutex
+	webhooks   map[int]*entity.Webhook
+	tasks      []*entity.WebhookTask
+	deliveries []*entity.WebhookDelivery
+}
+
+func (r *memoryWebhookRepo) GetWebhook(ctx context.Context, id int) (*entity.Webhook, bool, error) {
+	webhook, ok := r.webhooks[id]
+	return webhook, ok, nil
+}
+
+func (r *memoryWebhookRepo) AddWebhookDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+func (r *memoryWebhookRepo) AddWebhookTasks(ctx context.Context, tasks []*entity.WebhookTask) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, task := range tasks {
+		task.ID = int64(len(r.tasks) + 1)
+		stored := *task
+		r.tasks = append(r.tasks, &stored)
+	}
+	return nil
+}
+
+func (r *memoryWebhookRepo) GetAvailableWebhookTasks(ctx context.Context, now time.Time, limit int) (
+	[]*entity.WebhookTask, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	tasks := make([]*entity.WebhookTask, 0)
+	for _, task := range r.tasks {
+		if (task.Status == entity.WebhookTaskStatusPending || task.Status == entity.WebhookTaskStatusProcessing) &&
+			!task.NextRetryAt.After(now) && len(tasks) < limit {
+			found := *task
+			tasks = append(tasks, &found)
+		}
+	}
+	return tasks, nil
+}
+
+func (r *memoryWebhookRepo) ClaimWebhookTask(ctx context.Context, task *entity.WebhookTask, leaseUntil time.Time) (
+	bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	stored := r.tasks[task.ID-1]
+	if stored.Attempts != task.Attempts {
+		return false, nil
+	}
+	stored.Status, stored.Attempts, stored.NextRetryAt = entity.WebhookTaskStatusProcessing, stored.Attempts+1, leaseUntil
+	*task = *stored
+	return true, nil
+}
+
+func (r *memoryWebhookRepo) UpdateWebhookTaskResult(ctx context.Context, task *entity.WebhookTask) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	stored := r.tasks[task.ID-1]
+	stored.Status, stored.NextRetryAt = task.Status, task.NextRetryAt
+	return nil
+}
+
+func newTestWebhookSender(repo *memoryWebhookRepo) *webhookSender {
+	return &webhookSender{
+		webhookRepo: repo,
+		httpClient:  &http.Client{Timeout: webhookRequestTimeout},
+		notify:      make(chan struct{}, 1),
+	}
+}
+
+func TestWebhookSender_Send(t *testing.T) {
+	repo := &memoryWebhookRepo{}
+	sender := newTestWebhookSender(repo)
+	msg := &schema.EventMsg{EventType: constant.EventQuestionCreate}
+
+	webhooks := []*entity.Webhook{{ID: 1}, {ID: 2}}
+	assert.NoError(t, sender.send(context.TODO(), webhooks, "https://example.com", msg))
+	// the second event should not block even if the worker is not running
+	assert.NoError(t, sender.send(context.TODO(), webhooks, "https://example.com", msg))
+
+	assert.Equal(t, 4, len(repo.tasks))
+	assert.Equal(t, 1, len(sender.notify))
+	for i, task := range repo.tasks {
+		assert.Equal(t, webhooks[i%2].ID, task.WebhookID)
+		assert.Equal(t, entity.WebhookTaskStatusPending, task.Status)
+		assert.Equal(t, string(constant.EventQuestionCreate), task.EventType)
+		payload := &schema.WebhookPayload{}
+		assert.NoError(t, json.Unmarshal([]byte(task.Payload), payload))
+		assert.Equal(t, task.DeliveryID, payload.DeliveryID)
+		assert.Equal(t, "https://example.com", payload.SiteURL)
+	}
+}
+
+func TestWebhookSender_Deliver(t *testing.T) {
+	var received []*http.Request
+	var bodies [][]byte
+	lock := sync.Mutex{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lock.Lock()
+		received = append(received, r)
+		bodies = append(bodies, body)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	repo := &memoryWebhookRepo{webhooks: map[int]*entity.Webhook{
+		1: {ID: 1, URL: server.URL, Secret: "secret", Active: true},
+		2: {ID: 2, URL: server.URL, Secret: "secret", Active: false},
+	}}
+	sender := newTestWebhookSender(repo)
+	msg := &schema.EventMsg{EventType: constant.EventAnswerCreate}
+	assert.NoError(t, sender.send(context.TODO(), []*entity.Webhook{repo.webhooks[1], repo.webhooks[2]}, "", msg))
+
+	sender.consume(context.TODO())
+
+	// the task of the inactive webhook is dropped without request
+	assert.Equal(t, 1, len(received))
+	assert.Equal(t, Sign("secret", bodies[0]), received[0].Header.Get(schema.WebhookSignatureHeader))
+	assert.Equal(t, repo.tasks[0].DeliveryID, received[0].Header.Get(schema.WebhookDeliveryHeader))
+	assert.Equal(t, entity.WebhookTaskStatusDone, repo.tasks[0].Status)
+	assert.Equal(t, entity.WebhookTaskStatusDead, repo.tasks[1].Status)
+	assert.Equal(t, 1, len(repo.deliveries))
+	assert.True(t, repo.deliveries[0].Success)
+	assert.Equal(t, 1, repo.deliveries[0].Attempt)
+}
+
+func TestWebhookSender_Retry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	repo := &memoryWebhookRepo{webhooks: map[int]*entity.Webhook{
+		1: {ID: 1, URL: server.URL, Active: true},
+	}}
+	sender := newTestWebhookSender(repo)
+	msg := &schema.EventMsg{EventType: constant.EventAnswerCreate}
+	assert.NoError(t, sender.send(context.TODO(), []*entity.Webhook{repo.webhooks[1]}, "", msg))
+	task := repo.tasks[0]
+
+	for attempt := 1; attempt < webhookMaxAttempts; attempt++ {
+		start := time.Now()
+		sender.consume(context.TODO())
+		assert.Equal(t, entity.WebhookTaskStatusPending, task.Status)
+		assert.Equal(t, attempt, task.Attempts)
+		assert.False(t, task.NextRetryAt.Before(start.Add(webhookRetryBackoff(attempt))))
+
+		// the task is not available before the next retry time
+		sender.consume(context.TODO())
+		assert.Equal(t, attempt, task.Attempts)
+		task.NextRetryAt = time.Now()
+	}
+
+	sender.consume(context.TODO())
+	assert.Equal(t, entity.WebhookTaskStatusDead, task.Status)
+	assert.Equal(t, webhookMaxAttempts, task.Attempts)
+	assert.Equal(t, webhookMaxAttempts, len(repo.deliveries))
+	for i, delivery := range repo.deliveries {
+		assert.False(t, delivery.Success)
+		assert.Equal(t, i+1, delivery.Attempt)
+		assert.Equal(t, http.StatusInternalServerError, delivery.StatusCode)
+	}
+}
+
+func TestWebhookRetryBackoff(t *testing.T) {
+	assert.Equal(t, webhookRetryBaseDelay, webhookRetryBackoff(1))
+	assert.Equal(t, 2*webhookRetryBaseDelay, webhookRetryBackoff(2))
+	assert.Equal(t, 8*webhookRetryBaseDelay, webhookRetryBackoff(4))
+}
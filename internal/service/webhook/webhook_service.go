@@ -0,0 +1,261 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/random"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// WebhookRepo webhook repository
+type WebhookRepo interface {
+	AddWebhook(ctx context.Context, webhook *entity.Webhook) (err error)
+	UpdateWebhook(ctx context.Context, webhook *entity.Webhook) (err error)
+	RemoveWebhook(ctx context.Context, id int) (err error)
+	GetWebhook(ctx context.Context, id int) (webhook *entity.Webhook, exist bool, err error)
+	GetWebhookList(ctx context.Context) (webhooks []*entity.Webhook, err error)
+	GetActiveWebhookList(ctx context.Context) (webhooks []*entity.Webhook, err error)
+	AddWebhookDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (err error)
+	GetWebhookDeliveryPage(ctx context.Context, page, pageSize int, webhookID int) (
+		deliveries []*entity.WebhookDelivery, total int64, err error)
+	AddWebhookTasks(ctx context.Context, tasks []*entity.WebhookTask) (err error)
+	GetAvailableWebhookTasks(ctx context.Context, now time.Time, limit int) (tasks []*entity.WebhookTask, err error)
+	ClaimWebhookTask(ctx context.Context, task *entity.WebhookTask, leaseUntil time.Time) (claimed bool, err error)
+	UpdateWebhookTaskResult(ctx context.Context, task *entity.WebhookTask) (err error)
+	RemoveWebhookTasksBefore(ctx context.Context, status int, before time.Time) (err error)
+	RemoveWebhookDeliveriesBefore(ctx context.Context, before time.Time) (err error)
+}
+
+// WebhookService webhook service
+type WebhookService struct {
+	webhookRepo     WebhookRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	sender          *webhookSender
+}
+
+// NewWebhookService new webhook service
+func NewWebhookService(
+	webhookRepo WebhookRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	eventQueueService event_queue.EventQueueService,
+) *WebhookService {
+	ws := &WebhookService{
+		webhookRepo:     webhookRepo,
+		siteInfoService: siteInfoService,
+		sender:          newWebhookSender(webhookRepo),
+	}
+	eventQueueService.RegisterHandler("webhook", ws.Handler)
+	return ws
+}
+
+// GetWebhookList get all webhooks
+func (ws *WebhookService) GetWebhookList(ctx context.Context) (resp []*schema.WebhookInfo, err error) {
+	webhooks, err := ws.webhookRepo.GetWebhookList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.WebhookInfo, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		resp = append(resp, ws.formatWebhookInfo(webhook))
+	}
+	return resp, nil
+}
+
+// AddWebhook add webhook
+func (ws *WebhookService) AddWebhook(ctx context.Context, req *schema.AddWebhookReq) (
+	resp *schema.AddWebhookResp, err error) {
+	events, err := ws.checkEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Secret) == 0 {
+		req.Secret = random.Secret(20)
+	}
+	webhook := &entity.Webhook{
+		Name:        req.Name,
+		URL:         req.URL,
+		Secret:      req.Secret,
+		Events:      events,
+		Active:      req.Active,
+		Description: req.Description,
+	}
+	if err = ws.webhookRepo.AddWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return &schema.AddWebhookResp{ID: webhook.ID, Secret: webhook.Secret}, nil
+}
+
+// UpdateWebhook update webhook
+func (ws *WebhookService) UpdateWebhook(ctx context.Context, req *schema.UpdateWebhookReq) (err error) {
+	webhook, exist, err := ws.webhookRepo.GetWebhook(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.WebhookNotFound)
+	}
+	events, err := ws.checkEvents(req.Events)
+	if err != nil {
+		return err
+	}
+	webhook.Name = req.Name
+	webhook.URL = req.URL
+	webhook.Events = events
+	webhook.Active = req.Active
+	webhook.Description = req.Description
+	if len(req.Secret) > 0 {
+		webhook.Secret = req.Secret
+	}
+	return ws.webhookRepo.UpdateWebhook(ctx, webhook)
+}
+
+// DeleteWebhook delete webhook
+func (ws *WebhookService) DeleteWebhook(ctx context.Context, req *schema.DeleteWebhookReq) (err error) {
+	_, exist, err := ws.webhookRepo.GetWebhook(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.WebhookNotFound)
+	}
+	return ws.webhookRepo.RemoveWebhook(ctx, req.ID)
+}
+
+// GetWebhookDeliveryPage get recent deliveries of the webhook
+func (ws *WebhookService) GetWebhookDeliveryPage(ctx context.Context, req *schema.GetWebhookDeliveryPageReq) (
+	resp []*schema.GetWebhookDeliveryResp, total int64, err error) {
+	deliveries, total, err := ws.webhookRepo.GetWebhookDeliveryPage(ctx, req.Page, req.PageSize, req.WebhookID)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp = make([]*schema.GetWebhookDeliveryResp, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		resp = append(resp, &schema.GetWebhookDeliveryResp{
+			ID:           delivery.ID,
+			DeliveryID:   delivery.DeliveryID,
+			EventType:    delivery.EventType,
+			Attempt:      delivery.Attempt,
+			Success:      delivery.Success,
+			StatusCode:   delivery.StatusCode,
+			Duration:     delivery.Duration,
+			RequestBody:  delivery.RequestBody,
+			ResponseBody: delivery.ResponseBody,
+			Error:        delivery.Error,
+			CreatedAt:    delivery.CreatedAt.Unix(),
+		})
+	}
+	return resp, total, nil
+}
+
+// GetWebhookEventTypes get all event types that can be subscribed
+func (ws *WebhookService) GetWebhookEventTypes(ctx context.Context) (resp []string) {
+	resp = make([]string, 0, len(constant.EventTypeList))
+	for _, eventType := range constant.EventTypeList {
+		resp = append(resp, string(eventType))
+	}
+	return resp
+}
+
+// Handler send the event to all active webhooks which subscribed it.
+// The deliveries are saved as tasks and sent asynchronously, so the failure of webhooks will not block other event handlers.
+func (ws *WebhookService) Handler(ctx context.Context, msg *schema.EventMsg) error {
+	webhooks, err := ws.webhookRepo.GetActiveWebhookList(ctx)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	siteURL := ""
+	siteGeneral, err := ws.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Errorf("get site general failed: %v", err)
+	} else {
+		siteURL = siteGeneral.SiteUrl
+	}
+
+	subscribed := make([]*entity.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if isSubscribed(webhook, msg.EventType) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	return ws.sender.send(ctx, subscribed, siteURL, msg)
+}
+
+func (ws *WebhookService) checkEvents(events []string) (string, error) {
+	for _, event := range events {
+		if !constant.IsValidEventType(event) {
+			return "", errors.BadRequest(reason.WebhookEventTypeInvalid)
+		}
+	}
+	data, _ := json.Marshal(events)
+	return string(data), nil
+}
+
+func (ws *WebhookService) formatWebhookInfo(webhook *entity.Webhook) *schema.WebhookInfo {
+	return &schema.WebhookInfo{
+		ID:          webhook.ID,
+		Name:        webhook.Name,
+		URL:         webhook.URL,
+		Secret:      maskSecret(webhook.Secret),
+		Events:      parseEvents(webhook),
+		Active:      webhook.Active,
+		Description: webhook.Description,
+		CreatedAt:   webhook.CreatedAt.Unix(),
+		UpdatedAt:   webhook.UpdatedAt.Unix(),
+	}
+}
+
+func parseEvents(webhook *entity.Webhook) (events []string) {
+	events = make([]string, 0)
+	if err := json.Unmarshal([]byte(webhook.Events), &events); err != nil {
+		log.Errorf("parse webhook %d events failed: %v", webhook.ID, err)
+	}
+	return events
+}
+
+func isSubscribed(webhook *entity.Webhook, eventType constant.EventType) bool {
+	for _, event := range parseEvents(webhook) {
+		if event == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+func maskSecret(secret string) string {
+	if len(secret) <= 4 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
@@ -0,0 +1,311 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package diff
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// diff operation types
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+)
+
+// conflict markers of the three-way merge result
+const (
+	markerOurs   = "<<<<<<< ours"
+	markerSep    = "======="
+	markerTheirs = ">>>>>>> theirs"
+)
+
+// Op the operation of diff result
+type Op struct {
+	// operation type, equal, insert or delete
+	Type string `json:"type"`
+	// the text of the line or word
+	Text string `json:"text"`
+}
+
+// Lines line based diff from a to b
+func Lines(a, b string) []*Op {
+	return diffTokens(splitLines(a), splitLines(b))
+}
+
+// Words word based diff from a to b, the continuous words of the same operation are joined
+func Words(a, b string) []*Op {
+	ops := diffTokens(splitWords(a), splitWords(b))
+	joined := make([]*Op, 0, len(ops))
+	for start := 0; start < len(ops); {
+		end := start + 1
+		for end < len(ops) && ops[end].Type == ops[start].Type {
+			end++
+		}
+		var text strings.Builder
+		for _, op := range ops[start:end] {
+			text.WriteString(op.Text)
+		}
+		joined = append(joined, &Op{Type: ops[start].Type, Text: text.String()})
+		start = end
+	}
+	return joined
+}
+
+// HTML render the diff operations as html, the inserted and deleted text are wrapped by ins and del,
+// sep is appended to the text of each operation, e.g. "\n" for the line based diff.
+func HTML(ops []*Op, sep string) string {
+	var b strings.Builder
+	for _, op := range ops {
+		text := html.EscapeString(op.Text + sep)
+		switch op.Type {
+		case OpInsert:
+			b.WriteString("<ins>" + text + "</ins>")
+		case OpDelete:
+			b.WriteString("<del>" + text + "</del>")
+		default:
+			b.WriteString(text)
+		}
+	}
+	return b.String()
+}
+
+func diffTokens(a, b []string) (ops []*Op) {
+	ops = make([]*Op, 0, len(a)+len(b))
+	i, j := 0, 0
+	for _, p := range lcs(a, b) {
+		for ; i < p[0]; i++ {
+			ops = append(ops, &Op{Type: OpDelete, Text: a[i]})
+		}
+		for ; j < p[1]; j++ {
+			ops = append(ops, &Op{Type: OpInsert, Text: b[j]})
+		}
+		ops = append(ops, &Op{Type: OpEqual, Text: a[i]})
+		i, j = i+1, j+1
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, &Op{Type: OpDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, &Op{Type: OpInsert, Text: b[j]})
+	}
+	return ops
+}
+
+// Merge three-way merge the changes of ours and theirs which are both based on base.
+// The lines changed by both sides differently are kept with conflict markers, and conflict will be true.
+func Merge(base, ours, theirs string) (merged string, conflict bool) {
+	bl, ol, tl := splitLines(base), splitLines(ours), splitLines(theirs)
+	oursMatch, theirsMatch := matchLines(bl, ol), matchLines(bl, tl)
+
+	result := make([]string, 0, len(ol)+len(tl))
+	i, o, t := 0, 0, 0
+	for i < len(bl) || o < len(ol) || t < len(tl) {
+		// stable line, unchanged by both sides
+		if i < len(bl) && oursMatch[i] == o && theirsMatch[i] == t {
+			result = append(result, bl[i])
+			i, o, t = i+1, o+1, t+1
+			continue
+		}
+
+		// find the next line that both sides kept
+		ni, no, nt := len(bl), len(ol), len(tl)
+		for k := i; k < len(bl); k++ {
+			if oursMatch[k] >= 0 && theirsMatch[k] >= 0 {
+				ni, no, nt = k, oursMatch[k], theirsMatch[k]
+				break
+			}
+		}
+		baseChunk, oursChunk, theirsChunk := bl[i:ni], ol[o:no], tl[t:nt]
+		switch {
+		case equalLines(oursChunk, baseChunk):
+			result = append(result, theirsChunk...)
+		case equalLines(theirsChunk, baseChunk), equalLines(oursChunk, theirsChunk):
+			result = append(result, oursChunk...)
+		default:
+			conflict = true
+			result = append(result, markerOurs)
+			result = append(result, oursChunk...)
+			result = append(result, markerSep)
+			result = append(result, theirsChunk...)
+			result = append(result, markerTheirs)
+		}
+		i, o, t = ni, no, nt
+	}
+	return strings.Join(result, "\n"), conflict
+}
+
+// matchLines returns the index of b which each line of a matched, -1 means deleted
+func matchLines(a, b []string) []int {
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+	for _, p := range lcs(a, b) {
+		match[p[0]] = p[1]
+	}
+	return match
+}
+
+// maxDiffTokens the maximum number of the changed tokens to be compared one by one, the changed part is
+// regarded as replaced as a whole if there are more tokens, to limit the time of comparing
+const maxDiffTokens = 10000
+
+// lcs returns the index pairs of the longest common subsequence of a and b
+func lcs(a, b []string) (pairs [][2]int) {
+	// the common prefix and suffix are matched directly
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	for k := 0; k < prefix; k++ {
+		pairs = append(pairs, [2]int{k, k})
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(ma)+len(mb) <= maxDiffTokens {
+		pairs = hirschberg(ma, mb, prefix, prefix, pairs)
+	}
+
+	for k := suffix; k > 0; k-- {
+		pairs = append(pairs, [2]int{len(a) - k, len(b) - k})
+	}
+	return pairs
+}
+
+// hirschberg appends the index pairs of the lcs of a and b in linear space, the offsets are added to the indexes
+func hirschberg(a, b []string, aOffset, bOffset int, pairs [][2]int) [][2]int {
+	if len(a) == 0 || len(b) == 0 {
+		return pairs
+	}
+	if len(a) == 1 {
+		for j := range b {
+			if b[j] == a[0] {
+				return append(pairs, [2]int{aOffset, bOffset + j})
+			}
+		}
+		return pairs
+	}
+
+	// split a in half, and find the split of b where the lcs lengths of both halves sum up to the most
+	mid := len(a) / 2
+	forward := lcsLengthsForward(a[:mid], b)
+	backward := lcsLengthsBackward(a[mid:], b)
+	split, best := 0, -1
+	for j := 0; j <= len(b); j++ {
+		if forward[j]+backward[j] > best {
+			split, best = j, forward[j]+backward[j]
+		}
+	}
+	pairs = hirschberg(a[:mid], b[:split], aOffset, bOffset, pairs)
+	return hirschberg(a[mid:], b[split:], aOffset+mid, bOffset+split, pairs)
+}
+
+// lcsLengthsForward returns l where l[j] is the lcs length of a and b[:j]
+func lcsLengthsForward(a, b []string) []int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = maxInt(prev[j+1], cur[j])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
+
+// lcsLengthsBackward returns l where l[j] is the lcs length of a and b[j:]
+func lcsLengthsBackward(a, b []string) []int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				cur[j] = prev[j+1] + 1
+			} else {
+				cur[j] = maxInt(prev[j], cur[j+1])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// splitWords split the text into words and whitespaces
+func splitWords(s string) (words []string) {
+	words = make([]string, 0)
+	start := 0
+	for i, r := range s {
+		if i == start {
+			continue
+		}
+		prev, _ := utf8.DecodeLastRuneInString(s[:i])
+		if unicode.IsSpace(prev) != unicode.IsSpace(r) || isSplitRune(r) || isSplitRune(prev) {
+			words = append(words, s[start:i])
+			start = i
+		}
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+// isSplitRune punctuations and CJK characters are compared separately
+func isSplitRune(r rune) bool {
+	return unicode.IsPunct(r) || unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+func splitLines(s string) []string {
+	if len(s) == 0 {
+		return []string{}
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
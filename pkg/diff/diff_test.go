@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	lines := Lines("a\nb\nc", "a\nc\nd")
+	assert.Equal(t, []*Op{
+		{Type: OpEqual, Text: "a"},
+		{Type: OpDelete, Text: "b"},
+		{Type: OpEqual, Text: "c"},
+		{Type: OpInsert, Text: "d"},
+	}, lines)
+
+	lines = Lines("", "a")
+	assert.Equal(t, []*Op{{Type: OpInsert, Text: "a"}}, lines)
+}
+
+func TestMerge(t *testing.T) {
+	base := "title\n\nfirst\nsecond\nthird"
+
+	// both sides changed different lines
+	merged, conflict := Merge(base, "title\n\nfirst changed\nsecond\nthird", "title\n\nfirst\nsecond\nthird changed")
+	assert.False(t, conflict)
+	assert.Equal(t, "title\n\nfirst changed\nsecond\nthird changed", merged)
+
+	// both sides changed the same line in the same way
+	merged, conflict = Merge(base, "title\n\nfirst\nsecond!\nthird", "title\n\nfirst\nsecond!\nthird")
+	assert.False(t, conflict)
+	assert.Equal(t, "title\n\nfirst\nsecond!\nthird", merged)
+
+	// only one side appended lines
+	merged, conflict = Merge(base, base+"\nfourth", base)
+	assert.False(t, conflict)
+	assert.Equal(t, base+"\nfourth", merged)
+
+	// both sides changed the same line differently
+	merged, conflict = Merge(base, "title\n\nfirst\nours\nthird", "title\n\nfirst\ntheirs\nthird")
+	assert.True(t, conflict)
+	assert.Equal(t, "title\n\nfirst\n<<<<<<< ours\nours\n=======\ntheirs\n>>>>>>> theirs\nthird", merged)
+}
+
+func TestWords(t *testing.T) {
+	ops := Words("hello world!", "hello, new world!")
+	assert.Equal(t, []*Op{
+		{Type: OpEqual, Text: "hello"},
+		{Type: OpInsert, Text: ", new"},
+		{Type: OpEqual, Text: " world!"},
+	}, ops)
+	assert.Equal(t, "hello<ins>, new</ins> world!", HTML(ops, ""))
+
+	ops = Words("你好世界", "你好新世界")
+	assert.Equal(t, []*Op{
+		{Type: OpEqual, Text: "你好"},
+		{Type: OpInsert, Text: "新"},
+		{Type: OpEqual, Text: "世界"},
+	}, ops)
+
+	ops = Lines("<a>\nb", "<a>\nc")
+	assert.Equal(t, "&lt;a&gt;\n<del>b\n</del><ins>c\n</ins>", HTML(ops, "\n"))
+}
+
+func TestLinesLargeInput(t *testing.T) {
+	a := make([]string, 0, 40000)
+	b := make([]string, 0, 40000)
+	for i := 0; i < 40000; i++ {
+		a = append(a, fmt.Sprintf("a%d", i))
+		b = append(b, fmt.Sprintf("b%d", i))
+	}
+
+	// too many changed lines are replaced as a whole
+	ops := Lines("same\n"+strings.Join(a, "\n"), "same\n"+strings.Join(b, "\n"))
+	assert.Equal(t, 80001, len(ops))
+	assert.Equal(t, &Op{Type: OpEqual, Text: "same"}, ops[0])
+	assert.Equal(t, OpDelete, ops[1].Type)
+	assert.Equal(t, OpInsert, ops[len(ops)-1].Type)
+
+	// the changed lines under the limit are still compared one by one
+	ops = Lines(strings.Join(a[:3000], "\n"), strings.Join(append(b[:2000], a[1000:3000]...), "\n"))
+	equal := 0
+	for _, op := range ops {
+		if op.Type == OpEqual {
+			equal++
+		}
+	}
+	assert.Equal(t, 2000, equal)
+}
+
+func TestWordsLargeInput(t *testing.T) {
+	a, b := strings.Repeat("x ", 30000), strings.Repeat("y ", 30000)
+	ops := Words(a, b)
+	assert.LessOrEqual(t, len(ops), 3)
+
+	var oldText, newText strings.Builder
+	for _, op := range ops {
+		if op.Type != OpInsert {
+			oldText.WriteString(op.Text)
+		}
+		if op.Type != OpDelete {
+			newText.WriteString(op.Text)
+		}
+	}
+	assert.True(t, oldText.String() == a)
+	assert.True(t, newText.String() == b)
+}
@@ -20,10 +20,12 @@
 package htmltext
 
 import (
+	"html"
 	"io"
 	"net/http"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 	"unicode/utf8"
 
@@ -207,6 +209,104 @@ func FetchMatchedExcerpt(html string, words []string, trimMarker string, trimLen
 	return FetchRangedExcerpt(html, trimMarker, runeOffset, runeLimit)
 }
 
+const (
+	// HighlightPreTag the tag before the highlighted word
+	HighlightPreTag = "<mark>"
+	// HighlightPostTag the tag after the highlighted word
+	HighlightPostTag = "</mark>"
+)
+
+// matchedWordsRegexp returns a case-insensitive regexp that matches any of the words, longer words first
+func matchedWordsRegexp(words []string) *regexp.Regexp {
+	words = converter.UniqueArray(words)
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		if len(word) == 0 {
+			continue
+		}
+		quoted = append(quoted, regexp.QuoteMeta(word))
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	sort.SliceStable(quoted, func(i, j int) bool {
+		return len(quoted[i]) > len(quoted[j])
+	})
+	return regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+}
+
+// highlightText escape the plain text and wrap all the matched parts with highlight tags
+func highlightText(text string, re *regexp.Regexp) string {
+	if re == nil {
+		return html.EscapeString(text)
+	}
+	var (
+		sb   strings.Builder
+		last int
+	)
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		sb.WriteString(html.EscapeString(text[last:loc[0]]))
+		sb.WriteString(HighlightPreTag)
+		sb.WriteString(html.EscapeString(text[loc[0]:loc[1]]))
+		sb.WriteString(HighlightPostTag)
+		last = loc[1]
+	}
+	sb.WriteString(html.EscapeString(text[last:]))
+	return sb.String()
+}
+
+// HighlightMatchedWords escape the plain text and wrap all the matched words with highlight tags
+func HighlightMatchedWords(text string, words []string) string {
+	return highlightText(text, matchedWordsRegexp(words))
+}
+
+// FetchMatchedFragments returns at most maxFragments highlighted fragments from the HTML string,
+// every fragment contains trimLength runes around the matched word. Returns empty if nothing matched.
+func FetchMatchedFragments(htmlText string, words []string, trimMarker string, trimLength, maxFragments int) []string {
+	fragments := make([]string, 0)
+	re := matchedWordsRegexp(words)
+	if re == nil || maxFragments <= 0 {
+		return fragments
+	}
+	text := html.UnescapeString(ClearText(htmlText))
+	runeText := []rune(text)
+	trimLength = max(0, trimLength)
+
+	lastEnd := -1
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		runeIndex := utf8.RuneCountInString(text[0:loc[0]])
+		if runeIndex < lastEnd {
+			// already contained in the previous fragment
+			continue
+		}
+		runeLimit := trimLength + trimLength + utf8.RuneCountInString(text[loc[0]:loc[1]])
+		begin, end := getRuneRange(runeText, max(lastEnd, runeIndex-trimLength), runeLimit)
+
+		fragment := highlightText(string(runeText[begin:end]), re)
+		if begin > 0 {
+			fragment = trimMarker + fragment
+		}
+		if end < len(runeText) {
+			fragment = fragment + trimMarker
+		}
+		fragments = append(fragments, fragment)
+		lastEnd = end
+		if len(fragments) >= maxFragments {
+			break
+		}
+	}
+	return fragments
+}
+
+// SanitizeHighlight escape the highlighted text from outside, only highlight tags are kept
+func SanitizeHighlight(text string) string {
+	return strings.NewReplacer(
+		html.EscapeString(HighlightPreTag), HighlightPreTag,
+		html.EscapeString(HighlightPostTag), HighlightPostTag,
+	).Replace(html.EscapeString(html.UnescapeString(text)))
+}
+
 func GetPicByUrl(Url string) string {
 	res, err := http.Get(Url)
 	if err != nil {
@@ -219,3 +319,38 @@ func GetPicByUrl(Url string) string {
 	}
 	return string(pix)
 }
+
+var (
+	codeBlockRegexp = regexp.MustCompile(`(?is)<pre[^>]*>.*?</pre>`)
+	linkTextRegexp  = regexp.MustCompile(`(?is)<a\s[^>]*>(.*?)</a>`)
+	spaceRegexp     = regexp.MustCompile(`\s+`)
+)
+
+// TextStats the length statistics of the plain text of the html, the lengths are counted in runes
+type TextStats struct {
+	// the length of the plain text outside the code blocks
+	TextLength int
+	// the length of the link texts, which is included in the text length
+	LinkLength int
+	// the length of the code blocks
+	CodeLength int
+}
+
+// GetTextStats get the length statistics of the plain text of the html
+func GetTextStats(htmlText string) (stats TextStats) {
+	for _, code := range codeBlockRegexp.FindAllString(htmlText, -1) {
+		stats.CodeLength += utf8.RuneCountInString(plainText(code))
+	}
+	htmlText = codeBlockRegexp.ReplaceAllString(htmlText, " ")
+	for _, link := range linkTextRegexp.FindAllStringSubmatch(htmlText, -1) {
+		stats.LinkLength += utf8.RuneCountInString(plainText(link[1]))
+	}
+	stats.TextLength = utf8.RuneCountInString(plainText(htmlText))
+	return stats
+}
+
+// plainText strip the tags and merge the spaces
+func plainText(htmlText string) string {
+	text := html.UnescapeString(strip.StripTags(htmlText))
+	return strings.TrimSpace(spaceRegexp.ReplaceAllString(text, " "))
+}
@@ -204,3 +204,53 @@ func TestFetchMatchedExcerpt(t *testing.T) {
 	actual = FetchMatchedExcerpt(html, []string{"中文", "😂"}, "...", 6)
 	assert.Equal(t, expected, actual)
 }
+
+func TestHighlightMatchedWords(t *testing.T) {
+	expected := "<mark>Go</mark> &amp; <mark>golang</mark> <mark>GO</mark>"
+	actual := HighlightMatchedWords("Go & golang GO", []string{"go", "golang"})
+	assert.Equal(t, expected, actual)
+
+	expected = "a &lt;b&gt;"
+	actual = HighlightMatchedWords("a <b>", nil)
+	assert.Equal(t, expected, actual)
+}
+
+func TestFetchMatchedFragments(t *testing.T) {
+	html := "<p>one apple, two apples and many bananas in the fruit basket, but only one cherry</p>"
+
+	// test find nothing
+	actual := FetchMatchedFragments(html, []string{"youcantfindme"}, "...", 5, 3)
+	assert.Empty(t, actual)
+
+	// test the matched words in the same fragment are all highlighted
+	actual = FetchMatchedFragments(html, []string{"apple"}, "...", 10, 3)
+	assert.Equal(t, []string{"one <mark>apple</mark>, two <mark>apple</mark>s and..."}, actual)
+
+	// test multiple fragments and the max fragment count
+	actual = FetchMatchedFragments(html, []string{"one", "banana"}, "...", 3, 2)
+	assert.Equal(t, []string{"<mark>one</mark> apple...", "...ny <mark>banana</mark>s i..."}, actual)
+
+	// test the html entities are not escaped twice
+	actual = FetchMatchedFragments("<p>a &amp; b</p>", []string{"b"}, "...", 5, 1)
+	assert.Equal(t, []string{"a &amp; <mark>b</mark>"}, actual)
+}
+
+func TestSanitizeHighlight(t *testing.T) {
+	expected := "&lt;script&gt;x&lt;/script&gt; <mark>word</mark>"
+	actual := SanitizeHighlight("<script>x</script> <mark>word</mark>")
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetTextStats(t *testing.T) {
+	stats := GetTextStats("<p>hello <a href=\"https://example.com/\">example.com</a></p>")
+	assert.Equal(t, TextStats{TextLength: 17, LinkLength: 11}, stats)
+
+	stats = GetTextStats("<p>try:</p>\n<pre><code>fmt.Println(&quot;hi&quot;)\n</code></pre>")
+	assert.Equal(t, TextStats{TextLength: 4, CodeLength: 17}, stats)
+
+	stats = GetTextStats("<pre><code>a := 1</code></pre>")
+	assert.Equal(t, TextStats{CodeLength: 6}, stats)
+
+	stats = GetTextStats("")
+	assert.Equal(t, TextStats{}, stats)
+}
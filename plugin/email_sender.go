@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import "context"
+
+// EmailMessage is the email to be delivered by the email sender
+type EmailMessage struct {
+	FromName  string `json:"from_name"`
+	FromEmail string `json:"from_email"`
+	To        string `json:"to"`
+	Subject   string `json:"subject"`
+	// Body is the html content of the email
+	Body string `json:"body"`
+}
+
+type EmailSender interface {
+	Base
+
+	// SendEmail delivers the email, if an error is returned, the email will be retried later.
+	SendEmail(ctx context.Context, msg *EmailMessage) error
+}
+
+var (
+	// CallEmailSender is a function that calls all registered email senders
+	CallEmailSender,
+	registerEmailSender = MakePlugin[EmailSender](false)
+)
@@ -28,6 +28,18 @@ type SearchResult struct {
 	ID string
 	// Type content type, example: "answer", "question"
 	Type string
+	// Highlight the matched fragments generated by the search engine. optional
+	// If it is nil, the fragments will be generated from the content by matching the search words.
+	Highlight *SearchHighlight
+}
+
+// SearchHighlight the highlighted title and content fragments, the matched terms are wrapped in <mark> tags.
+// Any other HTML tags will be escaped.
+type SearchHighlight struct {
+	// Title highlighted title, keep it empty if the title is not matched
+	Title string
+	// Fragments highlighted fragments of the content
+	Fragments []string
 }
 
 type SearchContent struct {
@@ -55,8 +67,15 @@ type SearchBasicCond struct {
 
 	// The keywords for search.
 	Words []string
+	// WordGroups is the keywords grouped by the OR operator. The content should match at least one word of
+	// every group. A word that contains spaces is an exact phrase. Words is the flattened list of WordGroups.
+	WordGroups [][]string
+	// ExcludeWords the content should not contain any of these words.
+	ExcludeWords []string
 	// TagIDs is a list of tag IDs.
 	TagIDs [][]string
+	// ExcludeTagIDs the question should not have any of these tags.
+	ExcludeTagIDs []string
 	// The object's owner user ID.
 	UserID string
 	// The order of the search result.
@@ -76,9 +95,23 @@ type SearchBasicCond struct {
 	ViewAmount int
 	// greater than or equal to the number of answers. Only support search question.
 	AnswerAmount int
+
+	// The range of the created time, unix timestamp in seconds, zero means unlimited. [CreatedFrom, CreatedTo)
+	CreatedFrom int64
+	CreatedTo   int64
+	// The range of the last active time, unix timestamp in seconds, zero means unlimited. [ActiveFrom, ActiveTo)
+	ActiveFrom int64
+	ActiveTo   int64
+	// Weathers the question is closed or not. Only support search question.
+	QuestionClosed SearchClosedCond
+	// The user ID who commented on the object.
+	CommenterID string
+	// The user ID who collected the question. Only support search question.
+	CollectorID string
 }
 
 type SearchAcceptedCond int
+type SearchClosedCond int
 type SearchContentStatus int
 type SearchOrderCond string
 
@@ -88,6 +121,12 @@ const (
 	AcceptedCondFalse
 )
 
+const (
+	ClosedCondAll SearchClosedCond = iota
+	ClosedCondTrue
+	ClosedCondFalse
+)
+
 const (
 	SearchContentStatusAvailable = 1
 	SearchContentStatusDeleted   = 10
@@ -636,6 +636,14 @@ export const TIMELINE_NORMAL_ACTIVITY_TYPE = [
   'unpin',
   'show',
   'hide',
+  'bounty_offer',
+  'bounty_refund',
+  'bounty_awarded',
+  'merged',
+  'wiki',
+  'unwiki',
+  'converted_from_comment',
+  'converted_to_comment',
 ];
 
 export const SYSTEM_AVATAR_OPTIONS = [
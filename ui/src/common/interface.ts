@@ -83,6 +83,8 @@ export interface QuestionParams extends ImgCodeReq {
   url_title?: string;
   content: string;
   tags: Tag[];
+  draft_id?: number;
+  revision_id?: string;
 }
 
 export interface QuestionWithAnswer extends QuestionParams {
@@ -100,6 +102,68 @@ export interface AnswerParams extends ImgCodeReq {
   question_id: string;
   id: string;
   edit_summary?: string;
+  revision_id?: string;
+}
+
+export interface DiffOp {
+  type: 'equal' | 'insert' | 'delete';
+  text: string;
+}
+
+export interface RevisionTextDiff {
+  changed: boolean;
+  word_ops: DiffOp[];
+  line_ops: DiffOp[];
+  word_html: string;
+  line_html: string;
+}
+
+export interface RevisionDiff {
+  object_id: string;
+  object_type: 'question' | 'answer' | 'tag' | 'comment';
+  source_id: string;
+  target_id: string;
+  title: RevisionTextDiff;
+  slug_name?: RevisionTextDiff;
+  tags?: {
+    changed: boolean;
+    added: string[];
+    removed: string[];
+    kept: string[];
+  };
+  content: RevisionTextDiff;
+}
+
+export interface CommentTreeItem {
+  comment_id: string;
+  created_at: number;
+  object_id: string;
+  vote_count: number;
+  is_vote: boolean;
+  original_text: string;
+  parsed_text: string;
+  user_id: string;
+  username: string;
+  user_display_name: string;
+  user_avatar: string;
+  user_status: string;
+  reply_user_id: string;
+  reply_username: string;
+  reply_user_display_name: string;
+  reply_comment_id: string;
+  reply_user_status: string;
+  member_actions: MemberActionItem[];
+  children?: CommentTreeItem[];
+}
+
+export interface RevisionConflict {
+  latest_revision_id: string;
+  latest_title: string;
+  latest_content: string;
+  diff: DiffOp[];
+  merged_title: string;
+  merged_content: string;
+  merge_conflict: boolean;
 }
 
 export interface LoginReqParams {
@@ -237,6 +301,7 @@ export interface NotificationStatus {
 
 export interface QuestionDetailRes {
   id: string;
+  revision_id: string;
   title: string;
   content: string;
   html: string;
@@ -254,10 +319,22 @@ export interface QuestionDetailRes {
   answered: boolean;
   collected: boolean;
   answer_ids: string[];
+  linked_questions?: QuestionLinkInfo[];
+  wiki?: boolean;
 
   [prop: string]: any;
 }
 
+export interface QuestionLinkInfo {
+  id: string;
+  title: string;
+  url_title: string;
+  status: number;
+  answer_count: number;
+  accepted_answer_id: string;
+  relation: 'duplicate_of' | 'duplicated_by' | 'merged_into' | 'merged_from';
+}
+
 export interface AnswersReq extends Paging {
   order?: 'default' | 'updated' | 'created';
   question_id: string;
@@ -266,11 +343,13 @@ export interface AnswersReq extends Paging {
 export interface AnswerItem {
   id: string;
   question_id: string;
+  revision_id: string;
   content: string;
   html: string;
   create_time: string;
   update_time: string;
   user_info: UserInfoBase;
+  wiki?: boolean;
   [prop: string]: any;
 }
 
@@ -278,6 +357,7 @@ export interface PostAnswerReq extends ImgCodeReq {
   content: string;
   html?: string;
   question_id: string;
+  draft_id?: number;
 }
 
 export interface PageUser {
@@ -301,7 +381,34 @@ export type QuestionOrderBy =
   | 'active'
   | 'hot'
   | 'score'
-  | 'unanswered';
+  | 'unanswered'
+  | 'bounty';
+
+export interface QuestionBounty {
+  question_id: string;
+  amount: number;
+  expires_at: number;
+  created_at: number;
+  user_info: UserInfoBase;
+}
+
+export type DraftObjectType = 'question' | 'answer';
+
+export interface DraftParams {
+  id?: number;
+  object_type: DraftObjectType;
+  question_id?: string;
+  title?: string;
+  content?: string;
+  tags?: Tag[];
+  answer_content?: string;
+}
+
+export interface DraftInfo extends DraftParams {
+  id: number;
+  created_at: number;
+  updated_at: number;
+}
 
 export interface QueryQuestionsReq extends Paging {
   order: QuestionOrderBy;
@@ -395,6 +502,20 @@ export interface AdminSettingsSmtp {
   test_email_recipient?: string;
 }
 
+export type EmailLogStatus = 'pending' | 'sending' | 'sent' | 'failed';
+
+export interface AdminEmailLog {
+  id: number;
+  to_email: string;
+  subject: string;
+  transport: string;
+  status: EmailLogStatus;
+  attempts: number;
+  last_error: string;
+  created_at: number;
+  updated_at: number;
+}
+
 export interface AdminSettingsUsers {
   allow_update_avatar: boolean;
   allow_update_bio: boolean;
@@ -406,6 +527,18 @@ export interface AdminSettingsUsers {
   gravatar_base_url: string;
 }
 
+export interface AdminSettingsReview {
+  first_post_enabled: boolean;
+  first_post_count: number;
+  late_answer_enabled: boolean;
+  late_answer_days: number;
+  low_quality_enabled: boolean;
+  low_quality_min_length: number;
+  /** percentage of the link text in the plain text */
+  low_quality_max_link_ratio: number;
+  low_quality_min_score: number;
+}
+
 export interface SiteSettings {
   branding: AdminSettingBranding;
   general: AdminSettingsGeneral;
@@ -515,6 +648,11 @@ export interface SearchResItem {
     accepted: boolean;
     tags: TagBase[];
     status?: string;
+    /** matched words are wrapped in <mark>, other html is escaped */
+    highlight?: {
+      title: string;
+      fragments: string[];
+    };
   };
 }
 export interface SearchRes extends ListResult<SearchResItem> {
@@ -657,10 +795,113 @@ export interface QueuedReviewResp {
   list: QueuedReviewItem[];
 }
 
+export interface QuestionCloseVoteResp {
+  /** whether the question is closed or reopened */
+  done: boolean;
+  vote_count: number;
+  threshold: number;
+}
+
+export interface CloseVoteInfo {
+  user: UserInfoBase;
+  reason?: ReasonItem;
+  close_msg: string;
+  created_at: number;
+}
+
+export interface CloseVoteQueueItem {
+  question_id: string;
+  title: string;
+  url_title: string;
+  vote_type: 'close' | 'reopen';
+  vote_count: number;
+  threshold: number;
+  /** whether the current user has voted */
+  voted: boolean;
+  votes: CloseVoteInfo[];
+}
+
+export interface CloseVoteQueueResp {
+  count: number;
+  list: CloseVoteQueueItem[];
+}
+
+export type ReviewTaskQueue = 'first_post' | 'late_answer' | 'low_quality';
+
+export interface ReviewTaskItem {
+  review_task_id: number;
+  queue: ReviewTaskQueue;
+  object_id: string;
+  question_id: string;
+  answer_id: string;
+  object_type: 'question' | 'answer';
+  title: string;
+  url_title: string;
+  original_text: string;
+  parsed_text: string;
+  tags: Tag[];
+  author_user_info: UserInfoBase;
+  created_at: number;
+  submit_at: number;
+  /** matched low quality heuristics */
+  reasons: string[];
+}
+
+export interface ReviewTaskResp {
+  count: number;
+  list: ReviewTaskItem[];
+}
+
+export interface ReviewTaskQueueStat {
+  queue: ReviewTaskQueue;
+  pending_count: number;
+  invalid_count: number;
+  /** completed task amount by the review reason key */
+  outcomes: Record<string, number>;
+}
+
+export interface ReviewTaskStats {
+  queues: ReviewTaskQueueStat[];
+  reviewers: {
+    user: UserInfoBase;
+    review_count: number;
+  }[];
+}
+
 export interface UserRoleItem {
   id: number;
   name: string;
   description: string;
+  built_in?: boolean;
+}
+
+export interface RoleReq {
+  role_id?: number;
+  name: string;
+  description?: string;
+  power_types?: string[];
+}
+
+export interface RolePowers {
+  role_id: number;
+  power_types: string[];
+}
+
+export interface PowerItem {
+  name: string;
+  power_type: string;
+  description: string;
+}
+
+export interface TagModeratorReq {
+  tag_id: string;
+  user_id: string;
+}
+
+export interface TagModeratorItem {
+  tag_id: string;
+  user: UserInfoBase;
+  created_at: number;
 }
 export interface MemberActionItem {
   action: string;
@@ -670,7 +911,28 @@ export interface MemberActionItem {
 
 export interface QuestionOperationReq {
   id: string;
-  operation: 'pin' | 'unpin' | 'hide' | 'show';
+  operation: 'pin' | 'unpin' | 'hide' | 'show' | 'wiki' | 'unwiki';
+}
+
+export interface AnswerOperationReq {
+  id: string;
+  operation: 'wiki' | 'unwiki';
+}
+
+export interface ConvertCommentToAnswerRes {
+  question_id: string;
+  answer_id: string;
+}
+
+export interface ConvertAnswerToCommentRes {
+  question_id: string;
+  comment_id: string;
+}
+
+export interface PostContributor {
+  user_info: UserInfoBase;
+  revision_count: number;
+  last_edit_time: number;
 }
 
 export interface OauthBindEmailReq {
@@ -690,11 +952,13 @@ export interface UserOauthConnectorItem {
 export interface NotificationConfigItem {
   enable: boolean;
   key: string;
+  frequency?: 'immediate' | 'daily' | 'weekly';
 }
 export interface NotificationConfig {
   all_new_question: NotificationConfigItem;
   all_new_question_for_following_tags: NotificationConfigItem;
   inbox: NotificationConfigItem;
+  digest: NotificationConfigItem;
 }
 
 export interface ActivatedPlugin {
@@ -794,3 +1058,65 @@ export interface BadgeDetailListRes {
   count: number;
   list: BadgeDetailListItem[];
 }
+
+export interface UserSuspensionItem {
+  id: number;
+  operator?: UserInfoBase;
+  reason_key: string;
+  reason_name: string;
+  reason_description: string;
+  message: string;
+  duration: number;
+  suspended_at: number;
+  /** 0 means suspended until manually unsuspended */
+  suspended_until: number;
+  status: 'active' | 'lifted' | 'expired';
+  lifted_at: number;
+}
+
+export interface ModeratorMessageItem {
+  id: number;
+  operator?: UserInfoBase;
+  template_key: string;
+  template_name: string;
+  content: string;
+  object_id: string;
+  object_type: string;
+  object_title: string;
+  question_id: string;
+  status: 'unacknowledged' | 'acknowledged';
+  created_at: number;
+  acknowledged_at: number;
+}
+
+export interface AddModeratorMessageReq {
+  user_id: string;
+  object_id?: string;
+  template_key?: string;
+  content: string;
+}
+
+export interface AuditLogItem {
+  id: number;
+  operator?: UserInfoBase;
+  action: string;
+  object_type: string;
+  object_id: string;
+  /** json snapshot of the object before the operation */
+  before_data: string;
+  /** json snapshot of the object after the operation */
+  after_data: string;
+  ip: string;
+  created_at: number;
+}
+
+export interface AuditLogCondition {
+  operator_user_id?: string;
+  action?: string;
+  object_type?: string;
+  object_id?: string;
+  /** unix timestamp */
+  start_time?: number;
+  /** unix timestamp */
+  end_time?: number;
+}
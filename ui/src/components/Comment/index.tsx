@@ -42,8 +42,9 @@ import {
   deleteComment,
   updateComment,
   postVote,
+  convertCommentToAnswer,
 } from '@/services';
-import { commentReplyStore } from '@/stores';
+import { commentReplyStore, toastStore } from '@/stores';
 import Reactions from '@/pages/Questions/Detail/components/Reactions';
 
 import { Form, ActionBar, Reply } from './components';
@@ -284,6 +285,30 @@ const Comment = ({ objectId, mode, commentId }) => {
     });
   };
 
+  const handleConvertToAnswer = (id) => {
+    Modal.confirm({
+      title: t('title', { keyPrefix: 'question_detail.convert_to_answer' }),
+      content: t('content', {
+        keyPrefix: 'question_detail.convert_to_answer',
+      }),
+      cancelBtnVariant: 'link',
+      confirmText: t('confirm_btn', {
+        keyPrefix: 'question_detail.convert_to_answer',
+      }),
+      onConfirm: () => {
+        convertCommentToAnswer(id).then(() => {
+          toastStore.getState().show({
+            msg: t('post_converted_to_answer', { keyPrefix: 'messages' }),
+            variant: 'success',
+          });
+          setTimeout(() => {
+            window.location.reload();
+          }, 100);
+        });
+      },
+    });
+  };
+
   const submitVoteComment = (id, is_cancel) => {
     const imgCode: Types.ImgCodeReq = {
       captcha_id: undefined,
@@ -348,6 +373,8 @@ const Comment = ({ objectId, mode, commentId }) => {
       handleDelete(item.comment_id);
     } else if (action === 'edit') {
       handleEdit(item.comment_id);
+    } else if (action === 'convert_to_answer') {
+      handleConvertToAnswer(item.comment_id);
     }
   };
 
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import { FC, memo } from 'react';
+import { useTranslation } from 'react-i18next';
+
+import classnames from 'classnames';
+
+import Modal from '@/components/Modal';
+import type { RevisionConflict } from '@/common/interface';
+
+interface Props {
+  data: RevisionConflict | null;
+  onCancel: () => void;
+  onAccept: (data: RevisionConflict) => void;
+}
+
+const Index: FC<Props> = ({ data, onCancel, onAccept }) => {
+  const { t } = useTranslation('translation', { keyPrefix: 'edit_conflict' });
+  if (!data) {
+    return null;
+  }
+  return (
+    <Modal
+      visible
+      scrollable
+      title={t('title')}
+      confirmText={t('btn_use_merged')}
+      confirmBtnVariant="primary"
+      cancelBtnVariant="link"
+      onCancel={onCancel}
+      onConfirm={() => onAccept(data)}>
+      <p>{t('desc')}</p>
+      <div className="pre-line text-break font-monospace small mb-3">
+        {data.diff.map((line, index) => {
+          return (
+            <div
+              // eslint-disable-next-line react/no-array-index-key
+              key={index}
+              className={classnames({
+                'review-text-add': line.type === 'insert',
+                'review-text-delete': line.type === 'delete',
+              })}>
+              {line.text || ' '}
+            </div>
+          );
+        })}
+      </div>
+      <p className="mb-0 small text-secondary">
+        {data.merge_conflict ? t('merged_conflict') : t('merged')}
+      </p>
+    </Modal>
+  );
+};
+
+export default memo(Index);
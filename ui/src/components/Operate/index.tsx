@@ -33,6 +33,8 @@ import {
   editCheck,
   reopenQuestion,
   questionOperation,
+  answerOperation,
+  convertAnswerToComment,
   unDeleteAnswer,
   unDeleteQuestion,
 } from '@/services';
@@ -259,6 +261,47 @@ const Index: FC<IProps> = ({
     }, 100);
   };
 
+  const handleWiki = async (action: 'wiki' | 'unwiki') => {
+    if (type === 'answer') {
+      await answerOperation({ id: aid, operation: action });
+    } else {
+      await questionOperation({ id: qid, operation: action });
+    }
+    toastStore.getState().show({
+      msg: t(action === 'wiki' ? 'post_wiki' : 'post_unwiki', {
+        keyPrefix: 'messages',
+      }),
+      variant: 'success',
+    });
+    setTimeout(() => {
+      refreshQuestion();
+    }, 100);
+  };
+
+  const handleConvertToComment = () => {
+    Modal.confirm({
+      title: t('title', { keyPrefix: 'question_detail.convert_to_comment' }),
+      content: t('content', {
+        keyPrefix: 'question_detail.convert_to_comment',
+      }),
+      cancelBtnVariant: 'link',
+      confirmText: t('confirm_btn', {
+        keyPrefix: 'question_detail.convert_to_comment',
+      }),
+      onConfirm: () => {
+        convertAnswerToComment(aid).then(() => {
+          toastStore.getState().show({
+            msg: t('post_converted_to_comment', { keyPrefix: 'messages' }),
+            variant: 'success',
+          });
+          setTimeout(() => {
+            window.location.reload();
+          }, 100);
+        });
+      },
+    });
+  };
+
   const handlOtherActions = (action) => {
     const params: QuestionOperationReq = {
       id: qid,
@@ -312,6 +355,14 @@ const Index: FC<IProps> = ({
     ) {
       handlOtherActions(action);
     }
+
+    if (action === 'wiki' || action === 'unwiki') {
+      handleWiki(action);
+    }
+
+    if (action === 'convert_to_comment') {
+      handleConvertToComment();
+    }
   };
 
   const firstAction =
@@ -330,7 +381,10 @@ const Index: FC<IProps> = ({
         v.action === 'pin' ||
         v.action === 'unpin' ||
         v.action === 'hide' ||
-        v.action === 'show',
+        v.action === 'show' ||
+        v.action === 'wiki' ||
+        v.action === 'unwiki' ||
+        v.action === 'convert_to_comment',
     ) || [];
 
   return (
@@ -43,6 +43,7 @@ export const QUESTION_ORDER_KEYS: Type.QuestionOrderBy[] = [
   'hot',
   'score',
   'unanswered',
+  'bounty',
   'recommend',
 ];
 interface Props {
@@ -108,6 +109,11 @@ const QuestionList: FC<Props> = ({
                     {li.title}
                     {li.status === 2 ? ` [${t('closed')}]` : ''}
                   </NavLink>
+                  {li.bounty_amount > 0 && (
+                    <span className="badge text-bg-info align-middle ms-2">
+                      {t('bounty_amount', { amount: li.bounty_amount })}
+                    </span>
+                  )}
                 </h5>
                 <div className="d-flex flex-wrap flex-column flex-md-row align-items-md-center small mb-2 text-secondary">
                   <div className="d-flex flex-wrap me-0 me-md-3">
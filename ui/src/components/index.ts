@@ -45,6 +45,7 @@ import QueryGroup from './QueryGroup';
 import BrandUpload from './BrandUpload';
 import SchemaForm, { JSONSchema, UISchema, initFormData } from './SchemaForm';
 import DiffContent from './DiffContent';
+import EditConflictModal from './EditConflictModal';
 import Customize from './Customize';
 import CustomizeTheme from './CustomizeTheme';
 import PageTags from './PageTags';
@@ -93,6 +94,7 @@ export {
   SchemaForm,
   initFormData,
   DiffContent,
+  EditConflictModal,
   Customize,
   CustomizeTheme,
   PageTags,
@@ -0,0 +1,142 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import { FC } from 'react';
+import { Table, Button } from 'react-bootstrap';
+import { useSearchParams } from 'react-router-dom';
+import { useTranslation } from 'react-i18next';
+
+import classNames from 'classnames';
+
+import { Pagination, FormatTime, Empty, QueryGroup } from '@/components';
+import type * as Type from '@/common/interface';
+import { useToast } from '@/hooks';
+import { useEmailLogs, retryEmailLog } from '@/services';
+
+const PAGE_SIZE = 10;
+const StatusFilterKeys = ['all', 'pending', 'sent', 'failed'];
+
+const bgMap: Record<Type.EmailLogStatus, string> = {
+  pending: 'text-bg-light',
+  sending: 'text-bg-light',
+  sent: 'text-bg-success',
+  failed: 'text-bg-danger',
+};
+
+const EmailLogs: FC = () => {
+  const { t } = useTranslation('translation', {
+    keyPrefix: 'admin.smtp.logs',
+  });
+  const Toast = useToast();
+  const [urlSearchParams] = useSearchParams();
+  const curFilter = urlSearchParams.get('status') || StatusFilterKeys[0];
+  const curPage = Number(urlSearchParams.get('page') || '1');
+  const { data, isLoading, mutate } = useEmailLogs({
+    page: curPage,
+    page_size: PAGE_SIZE,
+    ...(curFilter === 'all' ? {} : { status: curFilter }),
+  });
+
+  const handleRetry = (id: number) => {
+    retryEmailLog(id).then(() => {
+      Toast.onShow({
+        msg: t('retry_success'),
+        variant: 'success',
+      });
+      mutate();
+    });
+  };
+
+  return (
+    <>
+      <div className="d-flex flex-wrap justify-content-between align-items-center mt-5 mb-3">
+        <h5 className="mb-0">{t('title')}</h5>
+        <QueryGroup
+          data={StatusFilterKeys}
+          currentSort={curFilter}
+          sortKey="status"
+          i18nKeyPrefix="admin.smtp.logs"
+        />
+      </div>
+      <Table responsive="md">
+        <thead>
+          <tr>
+            <th>{t('to_email')}</th>
+            <th>{t('subject')}</th>
+            <th style={{ width: '10%' }}>{t('transport')}</th>
+            <th style={{ width: '10%' }}>{t('status')}</th>
+            <th className="text-nowrap" style={{ width: '15%' }}>
+              {t('updated_at')}
+            </th>
+            <th style={{ width: '8%' }} className="text-end">
+              {t('action')}
+            </th>
+          </tr>
+        </thead>
+        <tbody className="align-middle">
+          {data?.list.map((item) => {
+            return (
+              <tr key={item.id}>
+                <td className="text-break">{item.to_email}</td>
+                <td className="text-break">
+                  {item.subject}
+                  {item.last_error ? (
+                    <div className="small text-danger">{item.last_error}</div>
+                  ) : null}
+                </td>
+                <td>{item.transport}</td>
+                <td>
+                  <span className={classNames('badge', bgMap[item.status])}>
+                    {t(item.status)}
+                  </span>
+                  <div className="small text-secondary">
+                    {t('attempts', { num: item.attempts })}
+                  </div>
+                </td>
+                <td>
+                  <FormatTime time={item.updated_at} />
+                </td>
+                <td className="text-end">
+                  {item.status === 'failed' ? (
+                    <Button
+                      variant="link"
+                      className="p-0"
+                      onClick={() => handleRetry(item.id)}>
+                      {t('retry')}
+                    </Button>
+                  ) : null}
+                </td>
+              </tr>
+            );
+          })}
+        </tbody>
+      </Table>
+      {Number(data?.count) <= 0 && !isLoading && <Empty />}
+      <div className="mt-4 mb-2 d-flex justify-content-center">
+        <Pagination
+          currentPage={curPage}
+          totalSize={data?.count || 0}
+          pageSize={PAGE_SIZE}
+        />
+      </div>
+    </>
+  );
+};
+
+export default EmailLogs;
@@ -27,6 +27,8 @@ import pattern from '@/common/pattern';
 import { SchemaForm, JSONSchema, UISchema, initFormData } from '@/components';
 import { handleFormError, scrollToElementTop } from '@/utils';
 
+import EmailLogs from './components/EmailLogs';
+
 const Smtp: FC = () => {
   const { t } = useTranslation('translation', {
     keyPrefix: 'admin.smtp',
@@ -231,6 +233,7 @@ const Smtp: FC = () => {
         onChange={handleOnChange}
         onSubmit={onSubmit}
       />
+      <EmailLogs />
     </>
   );
 };
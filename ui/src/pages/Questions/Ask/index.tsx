@@ -29,7 +29,12 @@ import debounce from 'lodash/debounce';
 import fm from 'front-matter';
 
 import { usePageTags, usePromptWithUnload } from '@/hooks';
-import { Editor, EditorRef, TagSelector } from '@/components';
+import {
+  Editor,
+  EditorRef,
+  TagSelector,
+  EditConflictModal,
+} from '@/components';
 import type * as Type from '@/common/interface';
 import { DRAFT_QUESTION_STORAGE_KEY } from '@/common/constants';
 import {
@@ -40,8 +45,10 @@ import {
   queryQuestionByTitle,
   getTagsBySlugName,
   saveQuestionWithAnswer,
+  getDrafts,
 } from '@/services';
 import {
+  guard,
   handleFormError,
   SaveDraft,
   storageExpires,
@@ -95,6 +102,11 @@ const Ask = () => {
   const [immData, setImmData] = useState<FormDataItem>(initFormData);
   const [checked, setCheckState] = useState(false);
   const [blockState, setBlockState] = useState(false);
+  // the revision which the edit is based on
+  const [revisionId, setRevisionId] = useState('');
+  const [conflict, setConflict] = useState<Type.RevisionConflict | null>(
+    null,
+  );
   const [focusType, setForceType] = useState('');
   const [hasDraft, setHasDraft] = useState(false);
   const resetForm = () => {
@@ -126,12 +138,32 @@ const Ask = () => {
   const saveCaptcha = useCaptchaPlugin('question');
   const editCaptcha = useCaptchaPlugin('edit');
 
-  const removeDraft = () => {
+  const removeDraft = (localOnly = false) => {
     saveDraft.save.cancel();
-    saveDraft.remove();
+    saveDraft.remove(localOnly);
     setHasDraft(false);
   };
 
+  const restoreServerDraft = () => {
+    if (!guard.tryLoggedAndActivated().ok) {
+      return;
+    }
+    getDrafts('question').then((drafts) => {
+      const draft = drafts?.[0];
+      if (!draft) {
+        return;
+      }
+      saveDraft.restore(draft.id);
+      formData.title.value = draft.title || '';
+      formData.content.value = draft.content || '';
+      formData.tags.value = draft.tags || [];
+      formData.answer_content.value = draft.answer_content || '';
+      setCheckState(Boolean(draft.answer_content));
+      setHasDraft(true);
+      setFormData({ ...formData });
+    });
+  };
+
   useEffect(() => {
     if (!qid) {
       // order: 1. tags query. 2. prefill query. 3. draft
@@ -156,6 +188,7 @@ const Ask = () => {
             updateTags(filterTags);
           }
         } else if (draft) {
+          saveDraft.restore(draft.draftId);
           formData.title.value = draft.title;
           formData.content.value = draft.content;
           formData.tags.value = draft.tags;
@@ -166,6 +199,9 @@ const Ask = () => {
         setFormData({ ...formData });
       } else {
         resetForm();
+        if (!queryTags) {
+          restoreServerDraft();
+        }
       }
     }
 
@@ -229,6 +265,7 @@ const Ask = () => {
       return;
     }
     questionDetail(qid).then((res) => {
+      setRevisionId(res.revision_id);
       formData.title.value = res.title;
       formData.content.value = res.content;
       formData.tags.value = res.tags.map((item) => {
@@ -299,12 +336,24 @@ const Ask = () => {
     }
   };
 
+  const acceptMergedQuestion = (data: Type.RevisionConflict) => {
+    setRevisionId(data.latest_revision_id);
+    setConflict(null);
+    setBlockState(true);
+    setFormData({
+      ...formData,
+      title: { value: data.merged_title, errorMsg: '', isInvalid: false },
+      content: { value: data.merged_content, errorMsg: '', isInvalid: false },
+    });
+  };
+
   const submitModifyQuestion = (params) => {
     setBlockState(false);
     const ep = {
       ...params,
       id: qid,
       edit_summary: formData.edit_summary.value,
+      revision_id: revisionId,
     };
     const imgCode = editCaptcha?.getCaptcha();
     if (imgCode?.verify) {
@@ -319,6 +368,11 @@ const Ask = () => {
         });
       })
       .catch((err) => {
+        if (err?.code === 409 && err.data) {
+          // edited by others since the edit started
+          setConflict(err.data);
+          return;
+        }
         if (err.isError) {
           editCaptcha?.handleCaptchaError(err.list);
           const data = handleFormError(err, formData);
@@ -336,6 +390,10 @@ const Ask = () => {
       params.captcha_code = imgCode.captcha_code;
       params.captcha_id = imgCode.captcha_id;
     }
+    const draftId = saveDraft.getDraftId();
+    if (draftId) {
+      params.draft_id = draftId;
+    }
     let res;
     if (checked) {
       res = await saveQuestionWithAnswer({
@@ -375,7 +433,8 @@ const Ask = () => {
         navigate(pathFactory.questionLanding(id, res?.url_title));
       }
     }
-    removeDraft();
+    // the server draft is removed once published, keep it if failed
+    removeDraft(true);
   };
 
   const handleSubmit = async (event: React.FormEvent<HTMLFormElement>) => {
@@ -605,6 +664,11 @@ const Ask = () => {
           </Card>
         </Col>
       </Row>
+      <EditConflictModal
+        data={conflict}
+        onCancel={() => setConflict(null)}
+        onAccept={acceptMergedQuestion}
+      />
     </div>
   );
 };
@@ -23,11 +23,16 @@ import { useTranslation } from 'react-i18next';
 
 import dayjs from 'dayjs';
 
+import { pathFactory } from '@/router/pathFactory';
+import type { QuestionLinkInfo } from '@/common/interface';
+
 interface Props {
   data;
+  linked?: QuestionLinkInfo[];
 }
-const Index: FC<Props> = ({ data }) => {
+const Index: FC<Props> = ({ data, linked = [] }) => {
   const { t } = useTranslation();
+  const duplicates = linked.filter((item) => item.relation === 'duplicate_of');
   return (
     <Alert className="mb-4" variant={data.level}>
       {data.level === 'info' ? (
@@ -42,6 +47,17 @@ const Index: FC<Props> = ({ data }) => {
           ) : (
             <p>{data.msg ? data.msg : data.description}</p>
           )}
+          {duplicates.length > 0 && (
+            <ul className="mb-2">
+              {duplicates.map((item) => (
+                <li key={item.id}>
+                  <a href={pathFactory.questionLanding(item.id, item.url_title)}>
+                    {item.title}
+                  </a>
+                </li>
+              ))}
+            </ul>
+          )}
           <div className="small">
             {t('question_detail.closed_in')}{' '}
             <time
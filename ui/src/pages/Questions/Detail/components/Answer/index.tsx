@@ -37,6 +37,8 @@ import { AnswerItem } from '@/common/interface';
 import { acceptanceAnswer } from '@/services';
 import { useRenderHtmlPlugin } from '@/utils/pluginKit';
 
+import WikiContributors from '../WikiContributors';
+
 interface Props {
   data: AnswerItem;
   /** router answer id */
@@ -189,13 +191,21 @@ const Index: FC<Props> = ({
           )}
         </div>
         <div style={{ minWidth: '196px' }}>
-          <UserCard
-            data={data?.user_info}
-            time={Number(data.create_time)}
-            preFix={t('answered')}
-            isLogged={isLogged}
-            timelinePath={`/posts/${data.question_id}/${data.id}/timeline`}
-          />
+          {data.wiki ? (
+            <WikiContributors
+              objectId={data.id}
+              isLogged={isLogged}
+              timelinePath={`/posts/${data.question_id}/${data.id}/timeline`}
+            />
+          ) : (
+            <UserCard
+              data={data?.user_info}
+              time={Number(data.create_time)}
+              preFix={t('answered')}
+              isLogged={isLogged}
+              timelinePath={`/posts/${data.question_id}/${data.id}/timeline`}
+            />
+          )}
         </div>
       </div>
 
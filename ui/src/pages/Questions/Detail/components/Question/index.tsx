@@ -38,6 +38,8 @@ import { formatCount, guard } from '@/utils';
 import { following } from '@/services';
 import { pathFactory } from '@/router/pathFactory';
 
+import WikiContributors from '../WikiContributors';
+
 interface Props {
   data: any;
   hasAnswer: boolean;
@@ -202,13 +204,21 @@ const Index: FC<Props> = ({ data, initPage, hasAnswer, isLogged }) => {
           )}
         </div>
         <div style={{ minWidth: '196px' }}>
-          <UserCard
-            data={data?.user_info}
-            time={data.create_time}
-            preFix={t('asked')}
-            isLogged={isLogged}
-            timelinePath={`/posts/${data.id}/timeline`}
-          />
+          {data.wiki ? (
+            <WikiContributors
+              objectId={data.id}
+              isLogged={isLogged}
+              timelinePath={`/posts/${data.id}/timeline`}
+            />
+          ) : (
+            <UserCard
+              data={data?.user_info}
+              time={data.create_time}
+              preFix={t('asked')}
+              isLogged={isLogged}
+              timelinePath={`/posts/${data.id}/timeline`}
+            />
+          )}
         </div>
       </div>
 
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+
+import { memo, FC } from 'react';
+import { Link } from 'react-router-dom';
+import { useTranslation } from 'react-i18next';
+
+import { usePostContributors } from '@/services';
+
+interface Props {
+  objectId: string;
+  timelinePath: string;
+  isLogged: boolean;
+}
+
+const Index: FC<Props> = ({ objectId, timelinePath, isLogged }) => {
+  const { t } = useTranslation('translation', {
+    keyPrefix: 'question_detail.wiki',
+  });
+  const { data = [] } = usePostContributors(objectId);
+  const revisionCount = data.reduce((sum, item) => sum + item.revision_count, 0);
+
+  return (
+    <div className="small text-secondary">
+      <div className="fw-bold">
+        <i className="br bi-people-fill me-1" />
+        {t('title')}
+      </div>
+      <div>
+        {isLogged ? (
+          <Link to={timelinePath} className="link-secondary">
+            {t('revisions', { count: revisionCount })}
+          </Link>
+        ) : (
+          t('revisions', { count: revisionCount })
+        )}
+        {', '}
+        {t('contributors', { count: data.length })}
+      </div>
+      <div>
+        {data.map((item, index) => (
+          <span key={item.user_info?.username}>
+            {index > 0 && ', '}
+            <Link to={`/users/${item.user_info?.username}`}>
+              {item.user_info?.display_name}
+            </Link>
+          </span>
+        ))}
+      </div>
+    </div>
+  );
+};
+
+export default memo(Index);
@@ -29,7 +29,7 @@ import { usePromptWithUnload } from '@/hooks';
 import { useCaptchaPlugin } from '@/utils/pluginKit';
 import { Editor, Modal, TextArea } from '@/components';
 import { FormDataType, PostAnswerReq } from '@/common/interface';
-import { postAnswer } from '@/services';
+import { postAnswer, getDrafts } from '@/services';
 import { guard, handleFormError, SaveDraft, storageExpires } from '@/utils';
 import { DRAFT_ANSWER_STORAGE_KEY } from '@/common/constants';
 import { writeSettingStore } from '@/stores';
@@ -72,16 +72,39 @@ const Index: FC<Props> = ({ visible = false, data, callback }) => {
     when: Boolean(formData.content.value),
   });
 
-  const removeDraft = () => {
+  const removeDraft = (localOnly = false) => {
     // immediately remove debounced save
     saveDraft.save.cancel();
-    saveDraft.remove();
+    saveDraft.remove(localOnly);
     setHasDraft(false);
   };
 
+  const restoreServerDraft = () => {
+    if (!guard.tryLoggedAndActivated().ok) {
+      return;
+    }
+    getDrafts('answer').then((drafts) => {
+      const draft = drafts?.find((item) => item.question_id === data.qid);
+      if (!draft?.content) {
+        return;
+      }
+      saveDraft.restore(draft.id, data.qid);
+      setShowEditor(true);
+      setFormData({
+        content: {
+          value: draft.content,
+          isInvalid: false,
+          errorMsg: '',
+        },
+      });
+      setHasDraft(true);
+    });
+  };
+
   useEffect(() => {
     const draft = storageExpires.get(DRAFT_ANSWER_STORAGE_KEY);
     if (draft?.questionId === data.qid && draft?.content) {
+      saveDraft.restore(draft.draftId, data.qid);
       setShowEditor(true);
       setFormData({
         content: {
@@ -91,6 +114,8 @@ const Index: FC<Props> = ({ visible = false, data, callback }) => {
         },
       });
       setHasDraft(true);
+    } else {
+      restoreServerDraft();
     }
     setTimeout(() => {
       setEditorCanSave(true);
@@ -163,6 +188,10 @@ const Index: FC<Props> = ({ visible = false, data, callback }) => {
       content: formData.content.value,
       html: marked.parse(formData.content.value),
     };
+    const draftId = saveDraft.getDraftId(data?.qid);
+    if (draftId) {
+      params.draft_id = draftId;
+    }
     const imgCode = aCaptcha?.getCaptcha();
     if (imgCode?.verify) {
       params.captcha_code = imgCode.captcha_code;
@@ -179,7 +208,8 @@ const Index: FC<Props> = ({ visible = false, data, callback }) => {
             errorMsg: '',
           },
         });
-        removeDraft();
+        // the server draft has been removed once published
+        removeDraft(true);
         callback?.(res.info);
       })
       .catch((ex) => {
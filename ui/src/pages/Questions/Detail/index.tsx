@@ -243,7 +243,12 @@ const Index = () => {
   return (
     <Row className="questionDetailPage pt-4 mb-5">
       <Col className="page-main flex-auto">
-        {question?.operation?.level && <Alert data={question.operation} />}
+        {question?.operation?.level && (
+          <Alert
+            data={question.operation}
+            linked={question.linked_questions}
+          />
+        )}
         {isSkeletonShow ? (
           <ContentLoader />
         ) : (
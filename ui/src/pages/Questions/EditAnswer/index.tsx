@@ -29,7 +29,13 @@ import { handleFormError } from '@/utils';
 import { usePageTags, usePromptWithUnload } from '@/hooks';
 import { useCaptchaPlugin, useRenderHtmlPlugin } from '@/utils/pluginKit';
 import { pathFactory } from '@/router/pathFactory';
-import { Editor, EditorRef, Icon, htmlRender } from '@/components';
+import {
+  Editor,
+  EditorRef,
+  Icon,
+  htmlRender,
+  EditConflictModal,
+} from '@/components';
 import type * as Type from '@/common/interface';
 import {
   useQueryAnswerInfo,
@@ -68,10 +74,16 @@ const Index = () => {
   const [formData, setFormData] = useState<FormDataItem>(initFormData);
   const [immData, setImmData] = useState(initFormData);
   const [contentChanged, setContentChanged] = useState(false);
+  // the revision which the edit is based on
+  const [revisionId, setRevisionId] = useState('');
+  const [conflict, setConflict] = useState<Type.RevisionConflict | null>(
+    null,
+  );
   const editCaptcha = useCaptchaPlugin('edit');
 
   useEffect(() => {
     if (data?.info?.content) {
+      setRevisionId(data.info.revision_id);
       setFormData({
         ...formData,
         content: {
@@ -157,6 +169,7 @@ const Index = () => {
       question_id: qid,
       id: aid,
       edit_summary: formData.description.value,
+      revision_id: revisionId,
     };
     editCaptcha?.resolveCaptchaReq(params);
 
@@ -175,6 +188,11 @@ const Index = () => {
         );
       })
       .catch((ex) => {
+        if (ex?.code === 409 && ex.data) {
+          // edited by others since the edit started
+          setConflict(ex.data);
+          return;
+        }
         if (ex.isError) {
           editCaptcha?.handleCaptchaError(ex.list);
           const stateData = handleFormError(ex, formData);
@@ -183,6 +201,15 @@ const Index = () => {
       });
   };
 
+  const acceptMergedAnswer = (merged: Type.RevisionConflict) => {
+    setRevisionId(merged.latest_revision_id);
+    setConflict(null);
+    setFormData({
+      ...formData,
+      content: { value: merged.merged_content, isInvalid: false, errorMsg: '' },
+    });
+  };
+
   const handleSubmit = async (event: React.FormEvent<HTMLFormElement>) => {
     setContentChanged(false);
 
@@ -331,6 +358,11 @@ const Index = () => {
           </Card>
         </Col>
       </Row>
+      <EditConflictModal
+        data={conflict}
+        onCancel={() => setConflict(null)}
+        onAccept={acceptMergedAnswer}
+      />
     </div>
   );
 };
@@ -71,7 +71,14 @@ const Index: FC<Props> = ({ data }) => {
           {data.object_type === 'question' ? 'Q' : 'A'}
         </span>
         <Link className="h5 mb-0 link-dark text-break" to={itemUrl}>
-          <HighlightText text={data.object.title} keywords={keywords} />
+          {data.object.highlight?.title ? (
+            <span
+              className="highlight-text"
+              dangerouslySetInnerHTML={{ __html: data.object.highlight.title }}
+            />
+          ) : (
+            <HighlightText text={data.object.title} keywords={keywords} />
+          )}
           {data.object.status === 'closed'
             ? ` [${t('closed', { keyPrefix: 'question' })}]`
             : null}
@@ -101,7 +108,16 @@ const Index: FC<Props> = ({ data }) => {
         />
       </div>
 
-      {data.object?.excerpt && (
+      {data.object?.highlight?.fragments?.length ? (
+        <p
+          className="small text-truncate-2 mb-2 last-p text-break highlight-text"
+          dangerouslySetInnerHTML={{
+            __html: data.object.highlight.fragments.join(' '),
+          }}
+        />
+      ) : null}
+
+      {!data.object?.highlight?.fragments?.length && data.object?.excerpt && (
         <p className="small text-truncate-2 mb-2 last-p text-break">
           <HighlightText
             text={escapeRemove(data.object.excerpt) || ''}
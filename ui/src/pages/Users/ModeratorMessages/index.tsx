@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import { useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import { Button, ListGroup } from 'react-bootstrap';
+import { Link } from 'react-router-dom';
+
+import { usePageTags } from '@/hooks';
+import { FormatTime, Empty } from '@/components';
+import { getModeratorMessages, acknowledgeModeratorMessage } from '@/services';
+import type { ModeratorMessageItem } from '@/common/interface';
+
+const ModeratorMessages = () => {
+  const { t } = useTranslation('translation', {
+    keyPrefix: 'moderator_messages',
+  });
+  const [messages, setMessages] = useState<ModeratorMessageItem[]>([]);
+  usePageTags({
+    title: t('moderator_messages', { keyPrefix: 'page_title' }),
+  });
+
+  const fetchMessages = () => {
+    getModeratorMessages().then((resp) => {
+      setMessages(resp || []);
+    });
+  };
+
+  useEffect(() => {
+    fetchMessages();
+  }, []);
+
+  const handleAcknowledge = (id: number) => {
+    acknowledgeModeratorMessage(id).then(() => {
+      fetchMessages();
+    });
+  };
+
+  return (
+    <div className="pt-4 mb-5">
+      <h3 className="mb-4">{t('title')}</h3>
+      {messages.length === 0 ? <Empty /> : null}
+      <ListGroup className="rounded-0">
+        {messages.map((item) => {
+          return (
+            <ListGroup.Item
+              key={item.id}
+              className="py-3 border-start-0 border-end-0">
+              {item.template_name ? (
+                <div className="fw-bold mb-2">{item.template_name}</div>
+              ) : null}
+              <div
+                className="text-break mb-2"
+                style={{ whiteSpace: 'pre-wrap' }}>
+                {item.content}
+              </div>
+              {item.object_id ? (
+                <div className="small mb-2">
+                  {t('related_post')}{' '}
+                  <Link
+                    to={
+                      item.object_type === 'question'
+                        ? `/questions/${item.object_id}`
+                        : `/questions/${item.question_id}/${item.object_id}`
+                    }>
+                    {item.object_title}
+                  </Link>
+                </div>
+              ) : null}
+              <div className="d-flex align-items-center small text-secondary">
+                <FormatTime time={item.created_at} />
+                {item.status === 'acknowledged' ? (
+                  <span className="ms-3">{t('acknowledged')}</span>
+                ) : (
+                  <Button
+                    variant="outline-primary"
+                    size="sm"
+                    className="ms-3"
+                    onClick={() => handleAcknowledge(item.id)}>
+                    {t('acknowledge')}
+                  </Button>
+                )}
+              </div>
+            </ListGroup.Item>
+          );
+        })}
+      </ListGroup>
+    </div>
+  );
+};
+
+export default ModeratorMessages;
@@ -50,6 +50,9 @@ const Inbox = ({ data, handleReadNotification }) => {
           case 'comment':
             url = `/questions/${question}/${answer}?commentId=${comment}`;
             break;
+          case 'moderator_message':
+            url = '/users/moderator-messages';
+            break;
           default:
             url = '';
         }
@@ -53,6 +53,18 @@ const Index = () => {
         description: t('all_new_question_for_following_tags.description'),
         default: configData?.all_new_question_for_following_tags.enable,
       },
+      digest: {
+        type: 'string',
+        title: t('digest.label'),
+        description: t('digest.description'),
+        enum: ['immediate', 'daily', 'weekly'],
+        enumNames: [
+          t('digest.immediate'),
+          t('digest.daily'),
+          t('digest.weekly'),
+        ],
+        default: configData?.digest?.frequency || 'immediate',
+      },
     },
   };
   const uiSchema: UISchema = {
@@ -75,6 +87,9 @@ const Index = () => {
         text: t('all_new_question_for_following_tags.description'),
       },
     },
+    digest: {
+      'ui:widget': 'select',
+    },
   };
   const [formData, setFormData] = useState<FormDataType>(initFormData(schema));
 
@@ -98,6 +113,11 @@ const Index = () => {
         enable: formData.all_new_question_for_following_tags.value,
         key: configData?.all_new_question_for_following_tags.key,
       },
+      digest: {
+        enable: formData.digest.value !== 'immediate',
+        key: configData?.digest?.key,
+        frequency: formData.digest.value,
+      },
     } as NotificationConfig;
 
     putNotificationConfig(params).then(() => {
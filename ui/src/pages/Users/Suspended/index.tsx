@@ -17,27 +17,54 @@
  * under the License.
  */
 
+import { useEffect, useState } from 'react';
 import { useTranslation } from 'react-i18next';
 import { Button } from 'react-bootstrap';
 
+import dayjs from 'dayjs';
+
 import { siteInfoStore } from '@/stores';
 import { usePageTags } from '@/hooks';
+import { getCurrentUserSuspension } from '@/services';
+import type { UserSuspensionItem } from '@/common/interface';
 
 const Suspended = () => {
   const { contact_email = '' } = siteInfoStore((state) => state.siteInfo);
   const { t } = useTranslation('translation', { keyPrefix: 'suspended' });
+  const { t: tCommon } = useTranslation();
+  const [suspension, setSuspension] = useState<UserSuspensionItem | null>();
   usePageTags({
     title: t('account_suspended', { keyPrefix: 'page_title' }),
   });
 
+  useEffect(() => {
+    getCurrentUserSuspension().then((resp) => {
+      setSuspension(resp);
+    });
+  }, []);
+
   return (
     <div className="d-flex flex-column align-items-center mt-5 pt-3">
       <h3 className="mb-5">{t('title')}</h3>
       <p className="text-center">
-        {t('forever')}
+        {suspension?.suspended_until
+          ? t('until_time', {
+              time: dayjs
+                .unix(suspension.suspended_until)
+                .tz()
+                .format(tCommon('dates.long_date_with_time')),
+            })
+          : t('forever')}
         <br />
-        {t('end')}
+        {suspension?.reason_name
+          ? `${suspension.reason_name}: ${suspension.reason_description}`
+          : t('end')}
       </p>
+      {suspension?.message ? (
+        <blockquote className="text-center text-secondary">
+          {suspension.message}
+        </blockquote>
+      ) : null}
       <Button href={`mailto:${contact_email}`} variant="link">
         {t('contact_us')}
       </Button>
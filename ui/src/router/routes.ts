@@ -191,6 +191,13 @@ const routes: RouteNode[] = [
             path: 'users/notifications/:type/:subType?',
             page: 'pages/Users/Notifications',
           },
+          {
+            path: 'users/moderator-messages',
+            page: 'pages/Users/ModeratorMessages',
+            guard: () => {
+              return guard.logged();
+            },
+          },
           {
             path: '/posts/:qid/timeline',
             page: 'pages/Timeline',
@@ -25,3 +25,5 @@ export * from './users';
 export * from './dashboard';
 export * from './plugins';
 export * from './badges';
+export * from './tags';
+export * from './audit_log';
@@ -18,6 +18,7 @@
  */
 
 import useSWR from 'swr';
+import qs from 'qs';
 
 import request from '@/utils/request';
 import type * as Type from '@/common/interface';
@@ -111,6 +112,29 @@ export const updateSmtpSetting = (params: Type.AdminSettingsSmtp) => {
   return request.put(apiUrl, params);
 };
 
+export const useEmailLogs = (params: {
+  page: number;
+  page_size: number;
+  status?: string;
+}) => {
+  const apiUrl = `/answer/admin/api/setting/smtp/logs?${qs.stringify(params)}`;
+  const { data, error, mutate } = useSWR<
+    Type.ListResult<Type.AdminEmailLog>,
+    Error
+  >(apiUrl, request.instance.get);
+  return {
+    data,
+    isLoading: !data && !error,
+    error,
+    mutate,
+  };
+};
+
+export const retryEmailLog = (id: number) => {
+  const apiUrl = `/answer/admin/api/setting/smtp/log/retry`;
+  return request.put(apiUrl, { id });
+};
+
 export const getAdminLanguageOptions = () => {
   const apiUrl = `/answer/admin/api/language/options`;
   return request.get<Type.LangsType[]>(apiUrl);
@@ -188,6 +212,24 @@ export const putUsersSetting = (params: AdminSettingsUsers) => {
   return request.put('/answer/admin/api/siteinfo/users', params);
 };
 
+export const getReviewSetting = () => {
+  return request.get<Type.AdminSettingsReview>(
+    '/answer/admin/api/siteinfo/review',
+  );
+};
+
+export const putReviewSetting = (params: Type.AdminSettingsReview) => {
+  return request.put('/answer/admin/api/siteinfo/review', params);
+};
+
+export const getReviewTaskStats = (params?: {
+  start_time?: number;
+  end_time?: number;
+}) => {
+  const apiUrl = `/answer/admin/api/review/tasks/stats?${qs.stringify(params)}`;
+  return request.get<Type.ReviewTaskStats>(apiUrl);
+};
+
 export const getPrivilegeSetting = () => {
   return request.get<AdminSettingsPrivilege>(
     '/answer/admin/api/setting/privileges',
@@ -27,6 +27,18 @@ export const changeUserStatus = (params) => {
   return request.put('/answer/admin/api/user/status', params);
 };
 
+export const getUserSuspensions = (user_id: string) => {
+  return request.get<Type.UserSuspensionItem[]>(
+    `/answer/admin/api/user/suspensions?${qs.stringify({ user_id })}`,
+  );
+};
+
+export const getUserModeratorMessages = (user_id: string) => {
+  return request.get<Type.ModeratorMessageItem[]>(
+    `/answer/admin/api/user/moderator-messages?${qs.stringify({ user_id })}`,
+  );
+};
+
 export const useQueryUsers = (params) => {
   const apiUrl = `/answer/admin/api/users/page?${qs.stringify(params)}`;
   const { data, error, mutate } = useSWR<Type.ListResult, Error>(
@@ -49,6 +61,39 @@ export const changeUserRole = (params) => {
   return request.put('/answer/admin/api/user/role', params);
 };
 
+export const changeUsersRole = (params: {
+  user_ids: string[];
+  role_id: number;
+}) => {
+  return request.put('/answer/admin/api/users/role', params);
+};
+
+export const addRole = (params: Type.RoleReq) => {
+  return request.post<{ id: number }>('/answer/admin/api/role', params);
+};
+
+export const updateRole = (params: Type.RoleReq) => {
+  return request.put('/answer/admin/api/role', params);
+};
+
+export const deleteRole = (role_id: number) => {
+  return request.delete('/answer/admin/api/role', { role_id });
+};
+
+export const getRolePowers = (role_id: number) => {
+  return request.get<Type.RolePowers>(
+    `/answer/admin/api/role/powers?${qs.stringify({ role_id })}`,
+  );
+};
+
+export const updateRolePowers = (params: Type.RolePowers) => {
+  return request.put('/answer/admin/api/role/powers', params);
+};
+
+export const getPowers = () => {
+  return request.get<Type.PowerItem[]>('/answer/admin/api/powers');
+};
+
 export const addUser = (params: {
   display_name: string;
   email: string;
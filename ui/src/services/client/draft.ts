@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import qs from 'qs';
+
+import request from '@/utils/request';
+import type * as Type from '@/common/interface';
+
+export const getDrafts = (object_type?: Type.DraftObjectType) => {
+  const apiUrl = `/answer/api/v1/user/drafts?${qs.stringify({ object_type })}`;
+  return request.get<Type.DraftInfo[]>(apiUrl);
+};
+
+export const saveServerDraft = (params: Type.DraftParams) => {
+  return request.put<{ id: number; updated_at: number }>(
+    '/answer/api/v1/user/draft',
+    params,
+  );
+};
+
+export const deleteServerDraft = (id: number) => {
+  return request.delete('/answer/api/v1/user/draft', { id });
+};
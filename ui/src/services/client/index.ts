@@ -31,3 +31,4 @@ export * from './user';
 export * from './Oauth';
 export * from './review';
 export * from './badges';
+export * from './draft';
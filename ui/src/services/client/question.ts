@@ -119,3 +119,33 @@ export const unDeleteQuestion = (qid) => {
     question_id: qid,
   });
 };
+
+export const useQuestionBounty = (question_id: string) => {
+  const apiUrl = question_id
+    ? `/answer/api/v1/question/bounty?${qs.stringify({ question_id })}`
+    : null;
+  const { data, error, mutate } = useSWR<Type.QuestionBounty | null, Error>(
+    apiUrl,
+    request.instance.get,
+  );
+  return {
+    data,
+    isLoading: !data && !error,
+    error,
+    mutate,
+  };
+};
+
+export const addQuestionBounty = (params: {
+  question_id: string;
+  amount: number;
+}) => {
+  return request.post('/answer/api/v1/question/bounty', params);
+};
+
+export const awardQuestionBounty = (params: {
+  question_id: string;
+  answer_id: string;
+}) => {
+  return request.put('/answer/api/v1/question/bounty/award', params);
+};
@@ -52,3 +52,34 @@ export const putPendingReviewAction = (params: {
 }) => {
   return request.put('/answer/api/v1/review/pending/post', params);
 };
+
+export const getCloseVoteQueueList = (
+  page: number,
+  voteType: 'close' | 'reopen',
+) => {
+  const apiUrl = `/answer/api/v1/question/close-votes/page?page=${page}&vote_type=${voteType}`;
+  return request.get<Type.CloseVoteQueueResp>(apiUrl);
+};
+
+export const putDismissCloseVotes = (params: {
+  question_id: string;
+  vote_type: 'close' | 'reopen';
+}) => {
+  return request.put('/answer/api/v1/question/close-votes/dismiss', params);
+};
+
+export const getReviewTaskList = (
+  page: number,
+  queue: Type.ReviewTaskQueue,
+) => {
+  const apiUrl = `/answer/api/v1/review/tasks/page?page=${page}&queue=${queue}`;
+  return request.get<Type.ReviewTaskResp>(apiUrl);
+};
+
+export const putReviewTask = (params: {
+  review_task_id: number;
+  queue: Type.ReviewTaskQueue;
+  outcome: string;
+}) => {
+  return request.put('/answer/api/v1/review/task', params);
+};
@@ -17,7 +17,11 @@
  * under the License.
  */
 
+import useSWR from 'swr';
+import qs from 'qs';
+
 import request from '@/utils/request';
+import type * as Type from '@/common/interface';
 
 export const editCheck = (id: string, passingError: boolean = false) => {
   const apiUrl = `/answer/api/v1/revisions/edit/check?id=${id}`;
@@ -33,3 +37,28 @@ export const revisionAudit = (id: string, operation: 'approve' | 'reject') => {
     operation,
   });
 };
+
+export const usePostContributors = (object_id: string, enabled = true) => {
+  const apiUrl =
+    object_id && enabled
+      ? `/answer/api/v1/revisions/contributors?${qs.stringify({ object_id })}`
+      : null;
+  const { data, error, mutate } = useSWR<Type.PostContributor[], Error>(
+    apiUrl,
+    request.instance.get,
+  );
+  return {
+    data,
+    isLoading: !data && !error,
+    error,
+    mutate,
+  };
+};
+
+export const getRevisionDiff = (source_id: string, target_id: string) => {
+  const apiUrl = `/answer/api/v1/revisions/diff?${qs.stringify({
+    source_id,
+    target_id,
+  })}`;
+  return request.get<Type.RevisionDiff>(apiUrl);
+};
@@ -18,6 +18,7 @@
  */
 
 import useSWR from 'swr';
+import qs from 'qs';
 
 import request from '@/utils/request';
 import type * as Type from '@/common/interface';
@@ -103,3 +104,30 @@ export const useSearchUserStaff = (name: string) => {
     : null;
   return useSWR<Type.User[]>(apiUrl, request.instance.get);
 };
+
+export const getCurrentUserSuspension = () => {
+  return request.get<Type.UserSuspensionItem | null>(
+    '/answer/api/v1/user/suspension',
+  );
+};
+
+export const getModeratorMessages = (unacknowledged = false) => {
+  return request.get<Type.ModeratorMessageItem[]>(
+    `/answer/api/v1/user/moderator-messages?${qs.stringify({
+      unacknowledged,
+    })}`,
+  );
+};
+
+export const acknowledgeModeratorMessage = (id: number) => {
+  return request.put('/answer/api/v1/user/moderator-message/acknowledge', {
+    id,
+  });
+};
+
+export const addModeratorMessage = (params: Type.AddModeratorMessageReq) => {
+  return request.post<{ id: number }>(
+    '/answer/api/v1/moderator-message',
+    params,
+  );
+};
@@ -73,6 +73,25 @@ export const useQueryComments = (params) => {
   );
 };
 
+export const useQueryCommentTree = (params: {
+  object_id: string;
+  page?: number;
+  page_size?: number;
+  query_cond?: 'vote' | 'created_at' | '';
+  max_depth?: number;
+  comment_id?: string;
+}) => {
+  return useSWR<Type.ListResult<Type.CommentTreeItem>>(
+    `/answer/api/v1/comment/page?${qs.stringify(
+      { ...params, mode: 'tree' },
+      {
+        skipNulls: true,
+      },
+    )}`,
+    request.instance.get,
+  );
+};
+
 export const updateComment = (params) => {
   return request.put('/answer/api/v1/comment', params);
 };
@@ -283,10 +302,18 @@ export const closeQuestion = (params: {
   id: string;
   close_msg?: string;
   close_type: number;
+  duplicate_ids?: string[];
 }) => {
   return request.put('/answer/api/v1/question/status', params);
 };
 
+export const mergeQuestion = (params: {
+  source_id: string;
+  target_id: string;
+}) => {
+  return request.post('/answer/api/v1/question/merge', params);
+};
+
 export const changeEmail = (params: { e_mail: string; pass?: string }) => {
   return request.post('/answer/api/v1/user/email/change/code', params);
 };
@@ -321,6 +348,24 @@ export const questionOperation = (params: Type.QuestionOperationReq) => {
   return request.put('/answer/api/v1/question/operation', params);
 };
 
+export const answerOperation = (params: Type.AnswerOperationReq) => {
+  return request.put('/answer/api/v1/answer/operation', params);
+};
+
+export const convertCommentToAnswer = (comment_id: string) => {
+  return request.post<Type.ConvertCommentToAnswerRes>(
+    '/answer/api/v1/comment/convert',
+    { comment_id },
+  );
+};
+
+export const convertAnswerToComment = (answer_id: string) => {
+  return request.post<Type.ConvertAnswerToCommentRes>(
+    '/answer/api/v1/answer/convert',
+    { answer_id },
+  );
+};
+
 export const getPluginsStatus = () => {
   return request.get<Type.ActivatedPlugin[]>('/answer/api/v1/plugin/status');
 };
@@ -24,6 +24,10 @@ import {
   DRAFT_ANSWER_STORAGE_KEY,
 } from '@/common/constants';
 import { storageExpires as storage } from '@/utils';
+import { saveServerDraft, deleteServerDraft } from '@/services';
+import type { DraftParams as ServerDraftParams } from '@/common/interface';
+
+import { tryLoggedAndActivated } from './guard';
 
 export type QuestionDraft = {
   params: {
@@ -52,9 +56,43 @@ class SaveDraft {
 
   status: 'save' | 'remove';
 
+  // the id of the draft saved on the server, so that it can be continued on other devices
+  draftId: number;
+
+  // the question which the answer draft belongs to
+  private draftQuestionId: string;
+
+  // stop syncing to the server once it fails, e.g. too many drafts
+  private syncable: boolean;
+
+  // increased on every remove, to drop the responses of the previous drafts
+  private version: number;
+
   constructor({ type = 'question' }: DraftType) {
     this.type = type;
     this.status = 'save';
+    this.draftId = 0;
+    this.draftQuestionId = '';
+    this.syncable = true;
+    this.version = 0;
+  }
+
+  /**
+   * continue the draft saved on the server
+   */
+  restore(draftId: number, questionId = '') {
+    this.draftId = draftId || 0;
+    this.draftQuestionId = questionId;
+  }
+
+  /**
+   * the id of the draft which should be published with the question or answer
+   */
+  getDraftId(questionId = '') {
+    if (this.type === 'answer' && questionId !== this.draftQuestionId) {
+      return 0;
+    }
+    return this.draftId;
   }
 
   save = debounce((data: DraftParams) => {
@@ -78,8 +116,16 @@ class SaveDraft {
     }
   }, 3000);
 
-  remove() {
+  /**
+   * @param localOnly the draft has been removed by the server after published
+   */
+  remove(localOnly = false) {
     this.status = 'remove';
+    this.version += 1;
+    if (this.draftId && !localOnly) {
+      deleteServerDraft(this.draftId);
+    }
+    this.draftId = 0;
     const that = this;
     if (this.type === 'question') {
       storage.remove(DRAFT_QUESTION_STORAGE_KEY, () => {
@@ -98,8 +144,46 @@ class SaveDraft {
       this.type === 'question'
         ? DRAFT_QUESTION_STORAGE_KEY
         : DRAFT_ANSWER_STORAGE_KEY;
-    storage.set(key, params);
+    storage.set(key, { ...params, draftId: this.draftId });
     callback?.();
+    this.syncDraft(key, params);
+  };
+
+  private syncDraft = (key: string, params: any) => {
+    if (!this.syncable || !tryLoggedAndActivated().ok) {
+      return;
+    }
+    const req: ServerDraftParams =
+      this.type === 'question'
+        ? {
+            id: this.draftId || undefined,
+            object_type: 'question',
+            title: params.title,
+            content: params.content,
+            tags: params.tags,
+            answer_content: params.answer_content,
+          }
+        : {
+            // only one answer draft is kept for each question by the server
+            object_type: 'answer',
+            question_id: params.questionId,
+            content: params.content,
+          };
+    const { version } = this;
+    saveServerDraft(req)
+      .then((res) => {
+        if (version !== this.version) {
+          // removed while saving
+          deleteServerDraft(res.id);
+          return;
+        }
+        this.draftId = res.id;
+        this.draftQuestionId = params.questionId || '';
+        storage.set(key, { ...params, draftId: res.id });
+      })
+      .catch(() => {
+        this.syncable = false;
+      });
   };
 }
 